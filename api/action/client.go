@@ -84,8 +84,8 @@ func (c *Client) ListCompleted(arg params.Entities) (params.ActionsByReceivers,
 	return results, err
 }
 
-// Cancel attempts to cancel a queued up Action from running.
-func (c *Client) Cancel(arg params.Actions) (params.ActionResults, error) {
+// Cancel attempts to cancel queued up or running Actions.
+func (c *Client) Cancel(arg params.Entities) (params.ActionResults, error) {
 	results := params.ActionResults{}
 	err := c.facade.FacadeCall("Cancel", arg, &results)
 	return results, err
@@ -120,3 +120,33 @@ func (c *Client) ServiceCharmActions(arg params.Entity) (*charm.Actions, error)
 	}
 	return result.Actions, nil
 }
+
+// AddSchedules adds schedules on which actions will be enqueued on
+// units, returning the added schedules.
+func (c *Client) AddSchedules(arg params.ActionSchedules) (params.ActionScheduleResults, error) {
+	results := params.ActionScheduleResults{}
+	err := c.facade.FacadeCall("AddSchedules", arg, &results)
+	return results, err
+}
+
+// ListSchedules returns all of the model's action schedules.
+func (c *Client) ListSchedules() (params.ActionSchedules, error) {
+	results := params.ActionSchedules{}
+	err := c.facade.FacadeCall("ListSchedules", nil, &results)
+	return results, err
+}
+
+// RemoveSchedules removes the action schedules with the given ids.
+func (c *Client) RemoveSchedules(arg params.ActionScheduleIds) (params.ErrorResults, error) {
+	results := params.ErrorResults{}
+	err := c.facade.FacadeCall("RemoveSchedules", arg, &results)
+	return results, err
+}
+
+// ServicesActionReceivers returns the tags of the units of each of the
+// given services, along with the tag of each service's current leader.
+func (c *Client) ServicesActionReceivers(arg params.Entities) (params.ServicesActionReceiversResults, error) {
+	results := params.ServicesActionReceiversResults{}
+	err := c.facade.FacadeCall("ServicesActionReceivers", arg, &results)
+	return results, err
+}
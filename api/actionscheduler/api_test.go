@@ -0,0 +1,153 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/actionscheduler"
+	"github.com/juju/juju/api/base"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+type APISuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&APISuite{})
+
+func (s *APISuite) TestWatchError(c *gc.C) {
+	var called bool
+	caller := apiCaller(c, func(request string, _, _ interface{}) error {
+		called = true
+		c.Check(request, gc.Equals, "Watch")
+		return errors.New("blam pow")
+	})
+	api := actionscheduler.NewAPI(caller, nil)
+
+	watcher, err := api.Watch()
+	c.Check(watcher, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, "blam pow")
+	c.Check(called, jc.IsTrue)
+}
+
+func (s *APISuite) TestWatchSuccess(c *gc.C) {
+	expectResult := params.StringsWatchResult{
+		StringsWatcherId: "123",
+		Changes:          []string{"1", "2", "3"},
+	}
+	caller := apiCaller(c, func(_ string, _, result interface{}) error {
+		resultPtr, ok := result.(*params.StringsWatchResult)
+		c.Assert(ok, jc.IsTrue)
+		*resultPtr = expectResult
+		return nil
+	})
+	expectWatcher := &stubWatcher{}
+	newWatcher := func(gotCaller base.APICaller, gotResult params.StringsWatchResult) watcher.StringsWatcher {
+		c.Check(gotCaller, gc.NotNil) // uncomparable
+		c.Check(gotResult, jc.DeepEquals, expectResult)
+		return expectWatcher
+	}
+	api := actionscheduler.NewAPI(caller, newWatcher)
+
+	watcher, err := api.Watch()
+	c.Check(watcher, gc.Equals, expectWatcher)
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *APISuite) TestNextRunsError(c *gc.C) {
+	caller := apiCaller(c, func(request string, _, _ interface{}) error {
+		c.Check(request, gc.Equals, "NextRuns")
+		return errors.New("snorble flip")
+	})
+	api := actionscheduler.NewAPI(caller, nil)
+
+	nextRuns, err := api.NextRuns()
+	c.Check(err, gc.ErrorMatches, "snorble flip")
+	c.Check(nextRuns, gc.IsNil)
+}
+
+func (s *APISuite) TestNextRunsSuccess(c *gc.C) {
+	t0 := time.Date(2016, time.June, 15, 11, 0, 0, 0, time.UTC)
+	caller := apiCaller(c, func(request string, _, result interface{}) error {
+		c.Check(request, gc.Equals, "NextRuns")
+		resultPtr, ok := result.(*params.ActionScheduleRuns)
+		c.Assert(ok, jc.IsTrue)
+		*resultPtr = params.ActionScheduleRuns{
+			Runs: []params.ActionScheduleRun{{Id: "1", NextRun: t0}},
+		}
+		return nil
+	})
+	api := actionscheduler.NewAPI(caller, nil)
+
+	nextRuns, err := api.NextRuns()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(nextRuns, jc.DeepEquals, map[string]time.Time{"1": t0})
+}
+
+func (s *APISuite) TestRunConvertArgs(c *gc.C) {
+	var called bool
+	caller := apiCaller(c, func(request string, arg, _ interface{}) error {
+		called = true
+		c.Check(request, gc.Equals, "Run")
+		c.Check(arg, gc.DeepEquals, params.ActionScheduleIds{
+			Ids: []string{"1", "2"},
+		})
+		return nil
+	})
+	api := actionscheduler.NewAPI(caller, nil)
+
+	err := api.Run([]string{"1", "2"})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(called, jc.IsTrue)
+}
+
+func (s *APISuite) TestRunCallError(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, _ interface{}) error {
+		return errors.New("snorble flip")
+	})
+	api := actionscheduler.NewAPI(caller, nil)
+
+	err := api.Run(nil)
+	c.Check(err, gc.ErrorMatches, "snorble flip")
+}
+
+func (s *APISuite) TestRunFirstError(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, result interface{}) error {
+		resultPtr, ok := result.(*params.ErrorResults)
+		c.Assert(ok, jc.IsTrue)
+		*resultPtr = params.ErrorResults{Results: []params.ErrorResult{{
+			nil,
+		}, {
+			&params.Error{Message: "expect this error"},
+		}, {
+			&params.Error{Message: "not this one"},
+		}}}
+		return nil
+	})
+	api := actionscheduler.NewAPI(caller, nil)
+
+	err := api.Run([]string{"1", "2", "3"})
+	c.Check(err, gc.ErrorMatches, "expect this error")
+}
+
+func apiCaller(c *gc.C, check func(request string, arg, result interface{}) error) base.APICaller {
+	return apitesting.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "ActionScheduler")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		return check(request, arg, result)
+	})
+}
+
+type stubWatcher struct {
+	watcher.StringsWatcher
+}
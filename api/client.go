@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -209,6 +210,22 @@ func (c *Client) ModelInfo() (params.ModelInfo, error) {
 	return info, err
 }
 
+// ExportBundle returns the YAML for a bundle that reproduces the
+// current model.
+func (c *Client) ExportBundle() (string, error) {
+	if c.BestAPIVersion() < 2 {
+		return "", errors.NotSupportedf("ExportBundle")
+	}
+	var result params.StringResult
+	if err := c.facade.FacadeCall("ExportBundle", nil, &result); err != nil {
+		return "", err
+	}
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Result, nil
+}
+
 // ModelUUID returns the model UUID from the client connection.
 func (c *Client) ModelUUID() (string, error) {
 	tag, err := c.st.ModelTag()
@@ -610,6 +627,21 @@ type DebugLogParams struct {
 	// NoTail tells the server to only return the logs it has now, and not
 	// to wait for new logs to arrive.
 	NoTail bool
+	// StartTime, if set, tells the server to only return log messages
+	// recorded at or after this time. Backlog is then ignored.
+	StartTime time.Time
+	// EndTime, if set, tells the server to only return log messages
+	// recorded at or before this time. The server does not wait for new
+	// logs to arrive.
+	EndTime time.Time
+	// MessageRegex, if set, is a regular expression that the message of
+	// each returned log line must match. The server evaluates it as PCRE,
+	// and only accepts patterns that are also valid Go regular
+	// expressions.
+	MessageRegex string
+	// JSON tells the server to send each log line as a JSON-encoded
+	// params.DebugLogRecord rather than as formatted text.
+	JSON bool
 }
 
 // WatchDebugLog returns a ReadCloser that the caller can read the log
@@ -647,6 +679,18 @@ func (c *Client) WatchDebugLog(args DebugLogParams) (io.ReadCloser, error) {
 	if args.Level != loggo.UNSPECIFIED {
 		attrs.Set("level", fmt.Sprint(args.Level))
 	}
+	if !args.StartTime.IsZero() {
+		attrs.Set("startTime", args.StartTime.UTC().Format(time.RFC3339Nano))
+	}
+	if !args.EndTime.IsZero() {
+		attrs.Set("endTime", args.EndTime.UTC().Format(time.RFC3339Nano))
+	}
+	if args.MessageRegex != "" {
+		attrs.Set("messageRegex", args.MessageRegex)
+	}
+	if args.JSON {
+		attrs.Set("format", "json")
+	}
 
 	connection, err := c.st.ConnectStream("/log", attrs)
 	if err != nil {
@@ -14,6 +14,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/httprequest"
@@ -330,6 +331,37 @@ func (s *clientSuite) TestClientEnvironmentUsers(c *gc.C) {
 	})
 }
 
+func (s *clientSuite) TestExportBundle(c *gc.C) {
+	client := s.APIState.Client()
+	cleanup := api.PatchClientFacadeCall(client,
+		func(req string, args interface{}, resp interface{}) error {
+			c.Assert(req, gc.Equals, "ExportBundle")
+			c.Assert(args, gc.IsNil)
+			resp.(*params.StringResult).Result = "services: {}\n"
+			return nil
+		})
+	defer cleanup()
+
+	bundle, err := client.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bundle, gc.Equals, "services: {}\n")
+}
+
+func (s *clientSuite) TestExportBundleNotSupported(c *gc.C) {
+	s.PatchValue(api.FacadeVersions, map[string]int{"Client": 1})
+	client := s.APIState.Client()
+	cleanup := api.PatchClientFacadeCall(client,
+		func(req string, args interface{}, resp interface{}) error {
+			c.Fatalf("unexpected call to %s", req)
+			return nil
+		})
+	defer cleanup()
+
+	_, err := client.ExportBundle()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "ExportBundle not supported")
+}
+
 func (s *clientSuite) TestDestroyEnvironment(c *gc.C) {
 	client := s.APIState.Client()
 	var called bool
@@ -434,6 +466,30 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 	})
 }
 
+func (s *clientSuite) TestWatchDebugLogQueryParamsEncoded(c *gc.C) {
+	s.PatchValue(api.WebsocketDialConfig, echoURL(c))
+
+	params := api.DebugLogParams{
+		StartTime:    time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC),
+		EndTime:      time.Date(2016, 6, 1, 13, 30, 0, 500, time.UTC),
+		MessageRegex: "hook fail(ed|ure)",
+		JSON:         true,
+	}
+
+	client := s.APIState.Client()
+	reader, err := client.WatchDebugLog(params)
+	c.Assert(err, jc.ErrorIsNil)
+
+	connectURL := connectURLFromReader(c, reader)
+	values := connectURL.Query()
+	c.Assert(values, jc.DeepEquals, url.Values{
+		"startTime":    {"2016-06-01T12:00:00Z"},
+		"endTime":      {"2016-06-01T13:30:00.0000005Z"},
+		"messageRegex": {"hook fail(ed|ure)"},
+		"format":       {"json"},
+	})
+}
+
 func (s *clientSuite) TestConnectStreamAtUUIDPath(c *gc.C) {
 	s.PatchValue(api.WebsocketDialConfig, echoURL(c))
 	// If the server supports it, we should log at "/model/UUID/log"
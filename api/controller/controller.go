@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/juju/permission"
 )
 
 var logger = loggo.GetLogger("juju.api.controller")
@@ -203,3 +204,67 @@ func (c *Client) InitiateModelMigration(spec ModelMigrationSpec) (string, error)
 	}
 	return result.Id, nil
 }
+
+// AbortModelMigration requests that the active migration of the
+// specified model is aborted. It is not an error to abort a migration
+// that has already been aborted.
+func (c *Client) AbortModelMigration(modelUUID string) error {
+	if c.facade.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("aborting model migrations on this controller")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewModelTag(modelUUID).String()}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("AbortModelMigration", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// AuditLog returns the entries in the controller's audit log that match
+// the filter, oldest first.
+func (c *Client) AuditLog(filter params.AuditLogFilter) ([]params.AuditLogEntry, error) {
+	if c.facade.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("audit log on this controller")
+	}
+	var results params.AuditLogResults
+	if err := c.facade.FacadeCall("AuditLog", filter, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Entries, nil
+}
+
+// GrantController grants a user the specified level of access to the
+// controller.
+func (c *Client) GrantController(user, access string) error {
+	return c.modifyControllerUser(params.GrantControllerAccess, user, access)
+}
+
+// RevokeController revokes the specified level of access to the controller
+// from a user.
+func (c *Client) RevokeController(user, access string) error {
+	return c.modifyControllerUser(params.RevokeControllerAccess, user, access)
+}
+
+func (c *Client) modifyControllerUser(action params.ControllerAction, user, access string) error {
+	if !names.IsValidUser(user) {
+		return errors.Errorf("invalid username: %q", user)
+	}
+	if _, err := permission.ParseControllerAccess(access); err != nil {
+		return errors.Trace(err)
+	}
+	args := params.ModifyControllerAccessRequest{
+		Changes: []params.ModifyControllerAccess{{
+			UserTag: names.NewUserTag(user).String(),
+			Action:  action,
+			Access:  params.ControllerAccessPermission(access),
+		}},
+	}
+	var result params.ErrorResults
+	err := c.facade.FacadeCall("ModifyControllerAccess", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
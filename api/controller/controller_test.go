@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/api/controller"
 	commontesting "github.com/juju/juju/apiserver/common/testing"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/migration"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
@@ -209,6 +210,105 @@ func (s *controllerSuite) TestInitiateModelMigrationError(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "unable to read model: .+")
 }
 
+func (s *controllerSuite) TestAbortModelMigration(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	spec := controller.ModelMigrationSpec{
+		ModelUUID:            st.ModelUUID(),
+		TargetControllerUUID: randomUUID(),
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "someone",
+		TargetPassword:       "secret",
+	}
+
+	controller := s.OpenAPI(c)
+	_, err := controller.InitiateModelMigration(spec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = controller.AbortModelMigration(st.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Check database.
+	mig, err := st.GetModelMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	phase, err := mig.Phase()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(phase, gc.Equals, migration.ABORT)
+}
+
+func (s *controllerSuite) TestAbortModelMigrationError(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	controller := s.OpenAPI(c)
+	err := controller.AbortModelMigration(st.ModelUUID())
+	c.Check(err, gc.ErrorMatches, "no migration in progress")
+}
+
+func (s *controllerSuite) TestAbortModelMigrationNotSupported(c *gc.C) {
+	client := s.OpenAPI(c)
+	controller.PatchBestAPIVersion(s, client, 2)
+	err := client.AbortModelMigration(s.State.ModelUUID())
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+
+	_, err = client.AuditLog(params.AuditLogFilter{})
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *controllerSuite) TestAuditLog(c *gc.C) {
+	t0 := time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC)
+	err := s.State.AddAuditEntry(state.AuditEntry{
+		Time:      t0,
+		ModelUUID: s.State.ModelUUID(),
+		User:      "bob@local",
+		Facade:    "Client",
+		Version:   1,
+		Method:    "FullStatus",
+		Duration:  time.Second,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	controller := s.OpenAPI(c)
+	entries, err := controller.AuditLog(params.AuditLogFilter{User: "bob"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, []params.AuditLogEntry{{
+		Time:     t0,
+		ModelTag: s.State.ModelTag().String(),
+		User:     "bob@local",
+		Facade:   "Client",
+		Version:  1,
+		Method:   "FullStatus",
+		Duration: time.Second,
+	}})
+}
+
+func (s *controllerSuite) TestGrantRevokeController(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true})
+
+	controller := s.OpenAPI(c)
+	err := controller.GrantController("bob", "add-model")
+	c.Assert(err, jc.ErrorIsNil)
+
+	controllerUser, err := s.State.ControllerUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(controllerUser.Access(), gc.Equals, state.ControllerAddModelAccess)
+
+	err = controller.RevokeController("bob", "add-model")
+	c.Assert(err, jc.ErrorIsNil)
+
+	controllerUser, err = s.State.ControllerUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(controllerUser.Access(), gc.Equals, state.ControllerLoginAccess)
+}
+
+func (s *controllerSuite) TestGrantControllerInvalidAccess(c *gc.C) {
+	controller := s.OpenAPI(c)
+	err := controller.GrantController("bob", "write")
+	c.Assert(err, gc.ErrorMatches, `invalid controller access permission "write"`)
+}
+
 func randomUUID() string {
 	return utils.MustNewUUID().String()
 }
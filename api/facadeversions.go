@@ -15,7 +15,8 @@ import (
 // New facades should start at 1.
 // Facades that existed before versioning start at 0.
 var facadeVersions = map[string]int{
-	"Action":                       1,
+	"Action":                       2,
+	"ActionScheduler":              1,
 	"Addresser":                    2,
 	"Agent":                        2,
 	"AgentTools":                   1,
@@ -27,14 +28,14 @@ var facadeVersions = map[string]int{
 	"CharmRevisionUpdater":         1,
 	"Charms":                       2,
 	"Cleaner":                      2,
-	"Client":                       1,
-	"Controller":                   2,
+	"Client":                       2,
+	"Controller":                   3,
 	"Deployer":                     1,
 	"DiscoverSpaces":               2,
 	"DiskManager":                  2,
 	"EntityWatcher":                2,
 	"FilesystemAttachmentsWatcher": 2,
-	"Firewaller":                   2,
+	"Firewaller":                   3,
 	"HighAvailability":             2,
 	"HostKeyReporter":              1,
 	"ImageManager":                 2,
@@ -57,7 +58,8 @@ var facadeVersions = map[string]int{
 	"MigrationMinion":              1,
 	"MigrationStatusWatcher":       1,
 	"MigrationTarget":              1,
-	"ModelManager":                 2,
+	"ModelArchive":                 1,
+	"ModelManager":                 3,
 	"NotifyWatcher":                1,
 	"Pinger":                       1,
 	"Provisioner":                  2,
@@ -66,7 +68,7 @@ var facadeVersions = map[string]int{
 	"RelationUnitsWatcher":         1,
 	"Resumer":                      2,
 	"RetryStrategy":                1,
-	"Service":                      3,
+	"Service":                      4,
 	"ServiceScaler":                1,
 	"Singular":                     1,
 	"Spaces":                       2,
@@ -0,0 +1,14 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewaller
+
+import (
+	"github.com/juju/names"
+)
+
+// NewService returns a Service for the given service tag, without
+// checking that it exists.
+func NewService(st *State, tag names.ServiceTag) *Service {
+	return &Service{st: st, tag: tag}
+}
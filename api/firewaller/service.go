@@ -6,10 +6,12 @@ package firewaller
 import (
 	"fmt"
 
+	"github.com/juju/errors"
 	"github.com/juju/names"
 
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/watcher"
 )
 
@@ -75,3 +77,38 @@ func (s *Service) IsExposed() (bool, error) {
 	}
 	return result.Result, nil
 }
+
+// ExposeSourceCIDRs returns the networks from which the opened ports of
+// this service may be reached while it is exposed.
+func (s *Service) ExposeSourceCIDRs() (network.ExposeSourceCIDRs, error) {
+	if s.st.BestAPIVersion() < 3 {
+		return network.ExposeSourceCIDRs{}, errors.NotSupportedf("expose source CIDRs")
+	}
+	var results params.ExposeSourceCIDRsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("GetExposeSourceCIDRs", args, &results)
+	if err != nil {
+		return network.ExposeSourceCIDRs{}, err
+	}
+	if len(results.Results) != 1 {
+		return network.ExposeSourceCIDRs{}, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return network.ExposeSourceCIDRs{}, result.Error
+	}
+	sources := network.ExposeSourceCIDRs{Default: result.Default}
+	for key, sourceCIDRs := range result.Ports {
+		portRange, err := network.ParsePortRange(key)
+		if err != nil {
+			return network.ExposeSourceCIDRs{}, err
+		}
+		if sources.Ports == nil {
+			sources.Ports = make(map[network.PortRange][]string)
+		}
+		sources.Ports[portRange] = sourceCIDRs
+	}
+	return sources, nil
+}
@@ -4,12 +4,15 @@
 package firewaller_test
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/firewaller"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/watcher/watchertest"
 )
 
@@ -91,3 +94,36 @@ func (s *serviceSuite) TestIsExposed(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(isExposed, jc.IsFalse)
 }
+
+func (s *serviceSuite) TestExposeSourceCIDRs(c *gc.C) {
+	sources, err := s.apiService.ExposeSourceCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sources, jc.DeepEquals, network.ExposeSourceCIDRs{})
+
+	err = s.service.SetExposeSourceCIDRs([]string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.service.SetExposePortSourceCIDRs(network.MustParsePortRange("8443/tcp"), []string{"192.168.0.0/16"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	sources, err = s.apiService.ExposeSourceCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sources, jc.DeepEquals, network.ExposeSourceCIDRs{
+		Default: []string{"10.0.0.0/8"},
+		Ports: map[network.PortRange][]string{
+			network.MustParsePortRange("8443/tcp"): {"192.168.0.0/16"},
+		},
+	})
+}
+
+func (s *serviceSuite) TestExposeSourceCIDRsNotSupported(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, args, result interface{}) error {
+			c.Fatalf("unexpected call to %s.%s", objType, request)
+			return nil
+		},
+	)
+	service := firewaller.NewService(firewaller.NewState(apiCaller), s.service.ServiceTag())
+	_, err := service.ExposeSourceCIDRs()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "expose source CIDRs not supported")
+}
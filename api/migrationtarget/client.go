@@ -18,6 +18,12 @@ type Client interface {
 	// controller.
 	Import([]byte) error
 
+	// ValidateImport checks whether a serialized model could be
+	// imported into the target controller, without importing it. It
+	// returns the problems that would prevent the import and the
+	// charms and tools the target controller is missing.
+	ValidateImport([]byte) (problems, missing []string, err error)
+
 	// Abort removes all data relating to a previously imported
 	// model.
 	Abort(string) error
@@ -42,6 +48,16 @@ func (c *client) Import(bytes []byte) error {
 	return c.caller.FacadeCall("Import", serialized, nil)
 }
 
+// ValidateImport implements Client.
+func (c *client) ValidateImport(bytes []byte) ([]string, []string, error) {
+	serialized := params.SerializedModel{Bytes: bytes}
+	var result params.ImportValidationResult
+	if err := c.caller.FacadeCall("ValidateImport", serialized, &result); err != nil {
+		return nil, nil, err
+	}
+	return result.Problems, result.Missing, nil
+}
+
 // Abort implements Client.
 func (c *client) Abort(modelUUID string) error {
 	args := params.ModelArgs{ModelTag: names.NewModelTag(modelUUID).String()}
@@ -42,6 +42,28 @@ func (s *ClientSuite) TestImport(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "boom")
 }
 
+func (s *ClientSuite) TestValidateImport(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		out := result.(*params.ImportValidationResult)
+		out.Problems = []string{"bad"}
+		out.Missing = []string{"charm cs:foo-1"}
+		return nil
+	})
+	client := migrationtarget.NewClient(apiCaller)
+
+	problems, missing, err := client.ValidateImport([]byte("foo"))
+	c.Assert(err, gc.IsNil)
+	c.Assert(problems, gc.DeepEquals, []string{"bad"})
+	c.Assert(missing, gc.DeepEquals, []string{"charm cs:foo-1"})
+
+	expectedArg := params.SerializedModel{Bytes: []byte("foo")}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.ValidateImport", []interface{}{"", expectedArg}},
+	})
+}
+
 func (s *ClientSuite) TestAbort(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
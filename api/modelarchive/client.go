@@ -0,0 +1,49 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelarchive
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the ModelArchive facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new Client based on an existing API connection.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "ModelArchive")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// ExportModel returns the serialized description of the model with
+// the given UUID.
+func (c *Client) ExportModel(modelUUID string) ([]byte, error) {
+	args := params.ModelArgs{ModelTag: names.NewModelTag(modelUUID).String()}
+	var result params.SerializedModel
+	if err := c.facade.FacadeCall("Export", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Bytes, nil
+}
+
+// ImportModel recreates the serialized model in the controller.
+func (c *Client) ImportModel(bytes []byte) error {
+	serialized := params.SerializedModel{Bytes: bytes}
+	return c.facade.FacadeCall("Import", serialized, nil)
+}
+
+// ActivateModel marks the imported model with the given UUID as
+// active. It fails while any of the charms or tools the model uses
+// are missing.
+func (c *Client) ActivateModel(modelUUID string) error {
+	args := params.ModelArgs{ModelTag: names.NewModelTag(modelUUID).String()}
+	return c.facade.FacadeCall("Activate", args, nil)
+}
@@ -0,0 +1,86 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelarchive_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelarchive"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type ClientSuite struct {
+	jujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) TestExportModel(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		out := result.(*params.SerializedModel)
+		out.Bytes = []byte("foo")
+		return nil
+	})
+	client := modelarchive.NewClient(apiCaller)
+
+	bytes, err := client.ExportModel("fake")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(bytes), gc.Equals, "foo")
+
+	expectedArg := params.ModelArgs{ModelTag: names.NewModelTag("fake").String()}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"ModelArchive.Export", []interface{}{"", expectedArg}},
+	})
+}
+
+func (s *ClientSuite) TestExportModelError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("boom")
+	})
+	client := modelarchive.NewClient(apiCaller)
+
+	_, err := client.ExportModel("fake")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ClientSuite) TestImportModel(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		return errors.New("boom")
+	})
+	client := modelarchive.NewClient(apiCaller)
+
+	err := client.ImportModel([]byte("foo"))
+	c.Assert(err, gc.ErrorMatches, "boom")
+
+	expectedArg := params.SerializedModel{Bytes: []byte("foo")}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"ModelArchive.Import", []interface{}{"", expectedArg}},
+	})
+}
+
+func (s *ClientSuite) TestActivateModel(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		return errors.New("boom")
+	})
+	client := modelarchive.NewClient(apiCaller)
+
+	err := client.ActivateModel("fake")
+	c.Assert(err, gc.ErrorMatches, "boom")
+
+	expectedArg := params.ModelArgs{ModelTag: names.NewModelTag("fake").String()}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"ModelArchive.Activate", []interface{}{"", expectedArg}},
+	})
+}
@@ -0,0 +1,6 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelarchive defines the client side API facade used to
+// export a model to a file and import it back in to a controller.
+package modelarchive
@@ -146,21 +146,52 @@ type SetCharmConfig struct {
 	// ResourceIDs is a map of resource names to resource IDs to activate during
 	// the upgrade.
 	ResourceIDs map[string]string
+	// RollingUpgrade, if set, upgrades the service's units in batches
+	// rather than all at once.
+	RollingUpgrade *params.CharmUpgradeStrategy
 }
 
-// SetCharm sets the charm for a given service.
+// SetCharm sets the charm for a given service. Older controllers would
+// ignore a rolling upgrade strategy and upgrade every unit at once, so
+// rolling upgrades are refused.
 func (c *Client) SetCharm(cfg SetCharmConfig) error {
+	if cfg.RollingUpgrade != nil && c.facade.BestAPIVersion() < 4 {
+		return errors.NotSupportedf("rolling charm upgrades on this controller")
+	}
 	args := params.ServiceSetCharm{
-		ServiceName: cfg.ServiceName,
-		CharmUrl:    cfg.CharmID.URL.String(),
-		Channel:     string(cfg.CharmID.Channel),
-		ForceSeries: cfg.ForceSeries,
-		ForceUnits:  cfg.ForceUnits,
-		ResourceIDs: cfg.ResourceIDs,
+		ServiceName:    cfg.ServiceName,
+		CharmUrl:       cfg.CharmID.URL.String(),
+		Channel:        string(cfg.CharmID.Channel),
+		ForceSeries:    cfg.ForceSeries,
+		ForceUnits:     cfg.ForceUnits,
+		ResourceIDs:    cfg.ResourceIDs,
+		RollingUpgrade: cfg.RollingUpgrade,
 	}
 	return c.facade.FacadeCall("SetCharm", args, nil)
 }
 
+// CharmUpgradeStatus returns the progress of the rolling charm upgrade
+// in progress for the given service.
+func (c *Client) CharmUpgradeStatus(serviceName string) (params.CharmUpgradeStatusResult, error) {
+	var result params.CharmUpgradeStatusResult
+	if c.facade.BestAPIVersion() < 4 {
+		return result, errors.NotSupportedf("rolling charm upgrades on this controller")
+	}
+	args := params.ServiceGet{ServiceName: serviceName}
+	err := c.facade.FacadeCall("CharmUpgradeStatus", args, &result)
+	return result, err
+}
+
+// ResumeCharmUpgrade allows a halted rolling charm upgrade of the given
+// service to continue.
+func (c *Client) ResumeCharmUpgrade(serviceName string) error {
+	if c.facade.BestAPIVersion() < 4 {
+		return errors.NotSupportedf("rolling charm upgrades on this controller")
+	}
+	args := params.ServiceResumeCharmUpgrade{ServiceName: serviceName}
+	return c.facade.FacadeCall("ResumeCharmUpgrade", args, nil)
+}
+
 // Update updates the service attributes, including charm URL,
 // minimum number of units, settings and constraints.
 func (c *Client) Update(args params.ServiceUpdate) error {
@@ -217,6 +248,23 @@ func (c *Client) Expose(service string) error {
 	return c.facade.FacadeCall("Expose", params, nil)
 }
 
+// ExposeFromSources exposes the service as Expose does, but only allows
+// traffic from the given source CIDRs. If portRange is not empty, the
+// source CIDRs apply only to that port range. Older controllers would
+// ignore the source CIDRs and expose the service to everyone, so they
+// are refused.
+func (c *Client) ExposeFromSources(service, portRange string, sourceCIDRs []string) error {
+	if c.facade.BestAPIVersion() < 4 {
+		return errors.NotSupportedf("exposing to source CIDRs on this controller")
+	}
+	params := params.ServiceExpose{
+		ServiceName: service,
+		SourceCIDRs: sourceCIDRs,
+		PortRange:   portRange,
+	}
+	return c.facade.FacadeCall("Expose", params, nil)
+}
+
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Unexpose(service string) error {
@@ -4,6 +4,7 @@
 package service_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -163,3 +164,126 @@ func (s *serviceSuite) TestServiceSetCharm(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(called, jc.IsTrue)
 }
+
+func (s *serviceSuite) TestServiceSetCharmRollingUpgrade(c *gc.C) {
+	strategy := &params.CharmUpgradeStrategy{BatchSize: 2, HaltOnError: true}
+	var called bool
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetCharm")
+		args, ok := a.(params.ServiceSetCharm)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(args.RollingUpgrade, jc.DeepEquals, strategy)
+		return nil
+	})
+	err := s.client.SetCharm(service.SetCharmConfig{
+		ServiceName: "service",
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("trusty/service-1"),
+		},
+		RollingUpgrade: strategy,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestServiceCharmUpgradeStatus(c *gc.C) {
+	var called bool
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "CharmUpgradeStatus")
+		c.Assert(a, jc.DeepEquals, params.ServiceGet{ServiceName: "service"})
+		result := response.(*params.CharmUpgradeStatusResult)
+		result.ToCharmURL = "cs:trusty/service-2"
+		result.Pending = []string{"service/1"}
+		return nil
+	})
+	result, err := s.client.CharmUpgradeStatus("service")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.ToCharmURL, gc.Equals, "cs:trusty/service-2")
+	c.Assert(result.Pending, jc.DeepEquals, []string{"service/1"})
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestServiceExposeFromSources(c *gc.C) {
+	var called bool
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "Expose")
+		c.Assert(a, jc.DeepEquals, params.ServiceExpose{
+			ServiceName: "service",
+			SourceCIDRs: []string{"10.0.0.0/8"},
+			PortRange:   "8443/tcp",
+		})
+		return nil
+	})
+	err := s.client.ExposeFromSources("service", "8443/tcp", []string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestServiceExposeFromSourcesOldController(c *gc.C) {
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	service.PatchBestAPIVersion(s, s.client, 3)
+	err := s.client.ExposeFromSources("service", "", []string{"10.0.0.0/8"})
+	c.Assert(err, gc.ErrorMatches, "exposing to source CIDRs on this controller not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *serviceSuite) TestServiceResumeCharmUpgrade(c *gc.C) {
+	var called bool
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "ResumeCharmUpgrade")
+		c.Assert(a, jc.DeepEquals, params.ServiceResumeCharmUpgrade{ServiceName: "service"})
+		return nil
+	})
+	err := s.client.ResumeCharmUpgrade("service")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestRollingUpgradeOldController(c *gc.C) {
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	service.PatchBestAPIVersion(s, s.client, 3)
+
+	err := s.client.SetCharm(service.SetCharmConfig{
+		ServiceName: "service",
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("trusty/service-1"),
+		},
+		RollingUpgrade: &params.CharmUpgradeStrategy{BatchSize: 2},
+	})
+	c.Check(err, gc.ErrorMatches, "rolling charm upgrades on this controller not supported")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = s.client.CharmUpgradeStatus("service")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	err = s.client.ResumeCharmUpgrade("service")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *serviceSuite) TestSetCharmOldController(c *gc.C) {
+	var called bool
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetCharm")
+		return nil
+	})
+	service.PatchBestAPIVersion(s, s.client, 3)
+
+	// Upgrades that are not rolling work as they always have.
+	err := s.client.SetCharm(service.SetCharmConfig{
+		ServiceName: "service",
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("trusty/service-1"),
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
@@ -14,6 +14,7 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("Action", 1, NewActionAPI)
+	common.RegisterStandardFacade("Action", 2, NewActionAPIV2)
 }
 
 // ActionAPI implements the client API for interacting with Actions
@@ -24,6 +25,13 @@ type ActionAPI struct {
 	check      *common.BlockChecker
 }
 
+// ActionAPIV2 implements version 2 of the Action facade. It adds
+// ServicesActionReceivers and action schedules, and its Cancel stops
+// running actions instead of only recording them as cancelled.
+type ActionAPIV2 struct {
+	*ActionAPI
+}
+
 // NewActionAPI returns an initialized ActionAPI
 func NewActionAPI(st *state.State, resources *common.Resources, authorizer common.Authorizer) (*ActionAPI, error) {
 	if !authorizer.AuthClient() {
@@ -38,6 +46,15 @@ func NewActionAPI(st *state.State, resources *common.Resources, authorizer commo
 	}, nil
 }
 
+// NewActionAPIV2 returns an initialized ActionAPIV2.
+func NewActionAPIV2(st *state.State, resources *common.Resources, authorizer common.Authorizer) (*ActionAPIV2, error) {
+	api, err := NewActionAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ActionAPIV2{api}, nil
+}
+
 // Actions takes a list of ActionTags, and returns the full Action for
 // each ID.
 func (a *ActionAPI) Actions(arg params.Entities) (params.ActionResults, error) {
@@ -126,7 +143,14 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
-		enqueued, err := receiver.AddAction(action.Name, action.Parameters)
+		var enqueued state.Action
+		if action.Timeout == 0 {
+			enqueued, err = receiver.AddAction(action.Name, action.Parameters)
+		} else if unit, ok := receiver.(*state.Unit); ok {
+			enqueued, err = unit.AddActionWithTimeout(action.Name, action.Parameters, action.Timeout)
+		} else {
+			err = errors.NotSupportedf("action timeouts for %s", names.ReadableString(receiver.Tag()))
+		}
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
 			continue
@@ -165,8 +189,21 @@ func (a *ActionAPI) ListCompleted(arg params.Entities) (params.ActionsByReceiver
 	return a.internalList(arg, completedActions)
 }
 
-// Cancel attempts to cancel enqueued Actions from running.
+// Cancel attempts to cancel enqueued Actions from running. Actions
+// that are already running are recorded as cancelled but not stopped.
 func (a *ActionAPI) Cancel(arg params.Entities) (params.ActionResults, error) {
+	return a.cancel(arg, func(action state.Action) (state.Action, error) {
+		return action.Finish(state.ActionResults{Status: state.ActionCancelled, Message: "action cancelled via the API"})
+	})
+}
+
+// Cancel takes enqueued Actions off the queue and asks the units
+// running Actions to stop them.
+func (a *ActionAPIV2) Cancel(arg params.Entities) (params.ActionResults, error) {
+	return a.cancel(arg, state.Action.Cancel)
+}
+
+func (a *ActionAPI) cancel(arg params.Entities, cancelAction func(state.Action) (state.Action, error)) (params.ActionResults, error) {
 	if err := a.check.ChangeAllowed(); err != nil {
 		return params.ActionResults{}, errors.Trace(err)
 	}
@@ -189,7 +226,7 @@ func (a *ActionAPI) Cancel(arg params.Entities) (params.ActionResults, error) {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
-		result, err := action.Finish(state.ActionResults{Status: state.ActionCancelled, Message: "action cancelled via the API"})
+		result, err := cancelAction(action)
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
 			continue
@@ -232,6 +269,138 @@ func (a *ActionAPI) ServicesCharmActions(args params.Entities) (params.ServicesC
 	return result, nil
 }
 
+// ServicesActionReceivers returns the tags of the units of each of the
+// given services, along with the tag of each service's current leader,
+// so that an action may be run across a service.
+func (a *ActionAPIV2) ServicesActionReceivers(args params.Entities) (params.ServicesActionReceiversResults, error) {
+	result := params.ServicesActionReceiversResults{Results: make([]params.ServiceActionReceiversResult, len(args.Entities))}
+	if len(args.Entities) == 0 {
+		return result, nil
+	}
+	leaders, err := a.state.ServiceLeaders()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		currentResult := &result.Results[i]
+		svcTag, err := names.ParseServiceTag(entity.Tag)
+		if err != nil {
+			currentResult.Error = common.ServerError(common.ErrBadId)
+			continue
+		}
+		currentResult.ServiceTag = svcTag.String()
+		svc, err := a.state.Service(svcTag.Id())
+		if err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+		units, err := svc.AllUnits()
+		if err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+		currentResult.Units = make([]string, len(units))
+		for j, unit := range units {
+			currentResult.Units[j] = unit.Tag().String()
+		}
+		if leader, ok := leaders[svc.Name()]; ok {
+			currentResult.Leader = names.NewUnitTag(leader).String()
+		}
+	}
+	return result, nil
+}
+
+// AddSchedules adds schedules on which actions will be enqueued on
+// units, and returns the added schedules.
+func (a *ActionAPIV2) AddSchedules(args params.ActionSchedules) (params.ActionScheduleResults, error) {
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+	results := params.ActionScheduleResults{
+		Results: make([]params.ActionScheduleResult, len(args.Schedules)),
+	}
+	for i, arg := range args.Schedules {
+		schedule, err := a.addSchedule(arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Schedule = &schedule
+	}
+	return results, nil
+}
+
+func (a *ActionAPIV2) addSchedule(arg params.ActionSchedule) (params.ActionSchedule, error) {
+	unitTag, err := names.ParseUnitTag(arg.Receiver)
+	if err != nil {
+		return params.ActionSchedule{}, errors.Trace(err)
+	}
+	unit, err := a.state.Unit(unitTag.Id())
+	if err != nil {
+		return params.ActionSchedule{}, errors.Trace(err)
+	}
+	schedule, err := unit.AddActionSchedule(arg.Name, arg.Parameters, arg.Schedule, arg.Timeout)
+	if err != nil {
+		return params.ActionSchedule{}, errors.Trace(err)
+	}
+	return makeActionSchedule(schedule), nil
+}
+
+// ListSchedules returns all of the model's action schedules.
+func (a *ActionAPIV2) ListSchedules() (params.ActionSchedules, error) {
+	schedules, err := a.state.AllActionSchedules()
+	if err != nil {
+		return params.ActionSchedules{}, errors.Trace(err)
+	}
+	result := params.ActionSchedules{
+		Schedules: make([]params.ActionSchedule, len(schedules)),
+	}
+	for i, schedule := range schedules {
+		result.Schedules[i] = makeActionSchedule(schedule)
+	}
+	return result, nil
+}
+
+// RemoveSchedules removes the action schedules with the given ids.
+// Actions they have already enqueued are not affected.
+func (a *ActionAPIV2) RemoveSchedules(args params.ActionScheduleIds) (params.ErrorResults, error) {
+	if err := a.check.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	for i, id := range args.Ids {
+		schedule, err := a.state.ActionSchedule(id)
+		if err == nil {
+			err = schedule.Remove()
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// makeActionSchedule converts a state.ActionSchedule to a
+// params.ActionSchedule.
+func makeActionSchedule(schedule *state.ActionSchedule) params.ActionSchedule {
+	result := params.ActionSchedule{
+		Id:         schedule.Id(),
+		Receiver:   names.NewUnitTag(schedule.Receiver()).String(),
+		Name:       schedule.Name(),
+		Parameters: schedule.Parameters(),
+		Timeout:    schedule.Timeout(),
+		Schedule:   schedule.Schedule(),
+		Created:    schedule.Created(),
+		NextRun:    schedule.NextRun(),
+		LastRun:    schedule.LastRun(),
+		LastError:  schedule.LastError(),
+	}
+	if schedule.LastAction() != "" {
+		result.LastAction = names.NewActionTag(schedule.LastAction()).String()
+	}
+	return result
+}
+
 // internalList takes a list of Entities representing ActionReceivers
 // and returns all of the Actions the extractorFn can get out of the
 // ActionReceiver.
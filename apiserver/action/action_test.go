@@ -6,6 +6,7 @@ package action_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names"
@@ -32,7 +33,7 @@ type actionSuite struct {
 	jujutesting.JujuConnSuite
 	commontesting.BlockHelper
 
-	action     *action.ActionAPI
+	action     *action.ActionAPIV2
 	authorizer apiservertesting.FakeAuthorizer
 	resources  *common.Resources
 
@@ -57,7 +58,7 @@ func (s *actionSuite) SetUpTest(c *gc.C) {
 		Tag: s.AdminUserTag(c),
 	}
 	var err error
-	s.action, err = action.NewActionAPI(s.State, nil, s.authorizer)
+	s.action, err = action.NewActionAPIV2(s.State, nil, s.authorizer)
 	c.Assert(err, jc.ErrorIsNil)
 
 	factory := jujuFactory.NewFactory(s.State)
@@ -277,6 +278,27 @@ func (s *actionSuite) TestEnqueue(c *gc.C) {
 	c.Assert(actions, gc.HasLen, 0)
 }
 
+func (s *actionSuite) TestEnqueueWithTimeout(c *gc.C) {
+	arg := params.Actions{
+		Actions: []params.Action{
+			{Receiver: s.wordpressUnit.Tag().String(), Name: "fakeaction", Timeout: time.Minute},
+			{Receiver: s.machine0.Tag().String(), Name: "juju-run", Timeout: time.Minute},
+		},
+	}
+	res, err := s.action.Enqueue(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 2)
+
+	c.Assert(res.Results[0].Error, gc.IsNil)
+	c.Assert(res.Results[0].Action.Timeout, gc.Equals, time.Minute)
+	actions, err := s.wordpressUnit.Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 1)
+	c.Assert(actions[0].Timeout(), gc.Equals, time.Minute)
+
+	c.Assert(res.Results[1].Error, gc.ErrorMatches, "action timeouts for machine 0 not supported")
+}
+
 type testCaseAction struct {
 	Name       string
 	Parameters map[string]interface{}
@@ -634,6 +656,49 @@ func (s *actionSuite) TestCancel(c *gc.C) {
 	c.Assert(myActions[1].Status, gc.Equals, params.ActionCancelled)
 }
 
+func (s *actionSuite) TestCancelRunning(c *gc.C) {
+	a, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	arg := params.Entities{Entities: []params.Entity{{Tag: a.Tag().String()}}}
+	results, err := s.action.Cancel(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Status, gc.Equals, params.ActionAborting)
+
+	// Cancelling again while the unit stops the action is fine.
+	results, err = s.action.Cancel(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Status, gc.Equals, params.ActionAborting)
+
+	_, err = a.Finish(state.ActionResults{Status: state.ActionCancelled})
+	c.Assert(err, jc.ErrorIsNil)
+	results, err = s.action.Cancel(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `cannot cancel action ".*": action ".*" already cancelled`)
+}
+
+func (s *actionSuite) TestCancelRunningV1(c *gc.C) {
+	v1, err := action.NewActionAPI(s.State, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Version 1 records the action as cancelled without stopping it.
+	arg := params.Entities{Entities: []params.Entity{{Tag: a.Tag().String()}}}
+	results, err := v1.Cancel(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Status, gc.Equals, params.ActionCancelled)
+}
+
 func (s *actionSuite) TestServicesCharmActions(c *gc.C) {
 	actionSchemas := map[string]map[string]interface{}{
 		"snapshot": {
@@ -725,6 +790,103 @@ func (s *actionSuite) TestServicesCharmActions(c *gc.C) {
 	}
 }
 
+func (s *actionSuite) TestServicesActionReceivers(c *gc.C) {
+	err := s.State.LeadershipClaimer().ClaimLeadership("wordpress", s.wordpressUnit.Name(), time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.action.ServicesActionReceivers(params.Entities{
+		Entities: []params.Entity{
+			{Tag: s.wordpress.Tag().String()},
+			{Tag: s.mysql.Tag().String()},
+			{Tag: s.dummy.Tag().String()},
+			{Tag: "service-nonsense"},
+			{Tag: s.mysqlUnit.Tag().String()},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results.Results, jc.DeepEquals, []params.ServiceActionReceiversResult{{
+		ServiceTag: s.wordpress.Tag().String(),
+		Units:      []string{s.wordpressUnit.Tag().String()},
+		Leader:     s.wordpressUnit.Tag().String(),
+	}, {
+		ServiceTag: s.mysql.Tag().String(),
+		Units:      []string{s.mysqlUnit.Tag().String()},
+	}, {
+		ServiceTag: s.dummy.Tag().String(),
+		Units:      []string{},
+	}, {
+		ServiceTag: "service-nonsense",
+		Error: &params.Error{
+			Message: `service "nonsense" not found`,
+			Code:    "not found",
+		},
+	}, {
+		Error: &params.Error{
+			Message: "id not found",
+			Code:    "not found",
+		},
+	}})
+}
+
+func (s *actionSuite) TestSchedules(c *gc.C) {
+	unitTag := s.wordpressUnit.Tag().String()
+	added, err := s.action.AddSchedules(params.ActionSchedules{
+		Schedules: []params.ActionSchedule{{
+			Receiver: unitTag,
+			Name:     "fakeaction",
+			Schedule: "0 3 * * *",
+			Timeout:  time.Minute,
+		}, {
+			Receiver: unitTag,
+			Name:     "fakeaction",
+			Schedule: "0 3 * *",
+		}, {
+			Receiver: unitTag,
+			Name:     "nonsense",
+			Schedule: "@daily",
+		}, {
+			Receiver: s.machine0.Tag().String(),
+			Name:     "fakeaction",
+			Schedule: "@daily",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(added.Results, gc.HasLen, 4)
+	c.Assert(added.Results[0].Error, gc.IsNil)
+	schedule := added.Results[0].Schedule
+	c.Assert(schedule, gc.NotNil)
+	c.Check(schedule.Receiver, gc.Equals, unitTag)
+	c.Check(schedule.Name, gc.Equals, "fakeaction")
+	c.Check(schedule.Schedule, gc.Equals, "0 3 * * *")
+	c.Check(schedule.Timeout, gc.Equals, time.Minute)
+	c.Check(schedule.NextRun.After(time.Now()), jc.IsTrue)
+	c.Check(schedule.NextRun.UTC().Hour(), gc.Equals, 3)
+	c.Check(added.Results[1].Error, gc.ErrorMatches, `schedule "0 3 \* \*": expected 5 fields, got 4`)
+	c.Check(added.Results[2].Error, gc.ErrorMatches, `action "nonsense" not defined on unit "wordpress/0"`)
+	c.Check(added.Results[3].Error, gc.ErrorMatches, `"machine-0" is not a valid unit tag`)
+
+	listed, err := s.action.ListSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(listed.Schedules, gc.HasLen, 1)
+	c.Check(listed.Schedules[0].Id, gc.Equals, schedule.Id)
+	c.Check(listed.Schedules[0].Receiver, gc.Equals, unitTag)
+	c.Check(listed.Schedules[0].Schedule, gc.Equals, "0 3 * * *")
+	c.Check(listed.Schedules[0].NextRun.Equal(schedule.NextRun), jc.IsTrue)
+	c.Check(listed.Schedules[0].LastRun.IsZero(), jc.IsTrue)
+
+	removed, err := s.action.RemoveSchedules(params.ActionScheduleIds{
+		Ids: []string{schedule.Id, "999"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(removed.Results, gc.HasLen, 2)
+	c.Check(removed.Results[0].Error, gc.IsNil)
+	c.Check(removed.Results[1].Error, gc.ErrorMatches, `action schedule "999" not found`)
+
+	listed, err = s.action.ListSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(listed.Schedules, gc.HasLen, 0)
+}
+
 func assertReadyToTest(c *gc.C, receiver state.ActionReceiver) {
 	// make sure there are no actions on the receiver already.
 	actions, err := receiver.Actions()
@@ -0,0 +1,100 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Backend exposes functionality required by Facade.
+type Backend interface {
+
+	// WatchActionSchedules returns a watcher that sends the ids of
+	// action schedules that have been added, changed or removed.
+	WatchActionSchedules() state.StringsWatcher
+
+	// ActionScheduleNextRuns returns the time at which each action
+	// schedule next falls due, keyed on schedule id.
+	ActionScheduleNextRuns() (map[string]time.Time, error)
+
+	// RunActionSchedule enqueues the identified schedule's action,
+	// if the schedule is due.
+	RunActionSchedule(id string) error
+}
+
+// Facade allows model-manager clients to watch and run action schedules.
+type Facade struct {
+	backend   Backend
+	resources *common.Resources
+}
+
+// NewFacade creates a new authorized Facade.
+func NewFacade(backend Backend, res *common.Resources, auth common.Authorizer) (*Facade, error) {
+	if !auth.AuthModelManager() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		backend:   backend,
+		resources: res,
+	}, nil
+}
+
+// Watch returns a watcher that sends the ids of action schedules
+// whose next run time may have changed.
+func (facade *Facade) Watch() (params.StringsWatchResult, error) {
+	watch := facade.backend.WatchActionSchedules()
+	if changes, ok := <-watch.Changes(); ok {
+		id := facade.resources.Register(watch)
+		return params.StringsWatchResult{
+			StringsWatcherId: id,
+			Changes:          changes,
+		}, nil
+	}
+	return params.StringsWatchResult{}, watcher.EnsureErr(watch)
+}
+
+// NextRuns returns the time at which each of the model's action
+// schedules next falls due.
+func (facade *Facade) NextRuns() (params.ActionScheduleRuns, error) {
+	nextRuns, err := facade.backend.ActionScheduleNextRuns()
+	if err != nil {
+		return params.ActionScheduleRuns{}, errors.Trace(err)
+	}
+	ids := make([]string, 0, len(nextRuns))
+	for id := range nextRuns {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	result := params.ActionScheduleRuns{
+		Runs: make([]params.ActionScheduleRun, len(ids)),
+	}
+	for i, id := range ids {
+		result.Runs[i] = params.ActionScheduleRun{
+			Id:      id,
+			NextRun: nextRuns[id],
+		}
+	}
+	return result, nil
+}
+
+// Run enqueues the actions of any supplied schedules that are due.
+// Schedules that are not yet due are left alone.
+func (facade *Facade) Run(args params.ActionScheduleIds) params.ErrorResults {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	for i, id := range args.Ids {
+		err := facade.backend.RunActionSchedule(id)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result
+}
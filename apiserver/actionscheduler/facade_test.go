@@ -0,0 +1,87 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/actionscheduler"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type FacadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FacadeSuite{})
+
+func (s *FacadeSuite) TestModelManager(c *gc.C) {
+	facade, err := actionscheduler.NewFacade(nil, nil, auth(true))
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(facade, gc.NotNil)
+}
+
+func (s *FacadeSuite) TestNotModelManager(c *gc.C) {
+	facade, err := actionscheduler.NewFacade(nil, nil, auth(false))
+	c.Check(err, gc.Equals, common.ErrPerm)
+	c.Check(facade, gc.IsNil)
+}
+
+func (s *FacadeSuite) TestWatchError(c *gc.C) {
+	fix := newFixture(c, &mockBackend{})
+	result, err := fix.Facade.Watch()
+	c.Check(err, gc.ErrorMatches, "blammo")
+	c.Check(result, gc.DeepEquals, params.StringsWatchResult{})
+	c.Check(fix.Resources.Count(), gc.Equals, 0)
+}
+
+func (s *FacadeSuite) TestWatchSuccess(c *gc.C) {
+	fix := newFixture(c, &mockBackend{watching: true})
+	result, err := fix.Facade.Watch()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(result.Changes, jc.DeepEquals, []string{"1", "2", "3"})
+	c.Check(fix.Resources.Count(), gc.Equals, 1)
+	resource := fix.Resources.Get(result.StringsWatcherId)
+	c.Check(resource, gc.NotNil)
+}
+
+func (s *FacadeSuite) TestNextRunsError(c *gc.C) {
+	fix := newFixture(c, &mockBackend{runsErr: errors.New("blammo")})
+	result, err := fix.Facade.NextRuns()
+	c.Check(err, gc.ErrorMatches, "blammo")
+	c.Check(result, gc.DeepEquals, params.ActionScheduleRuns{})
+}
+
+func (s *FacadeSuite) TestNextRunsSuccess(c *gc.C) {
+	t0 := time.Date(2016, time.June, 15, 11, 0, 0, 0, time.UTC)
+	t1 := time.Date(2016, time.June, 16, 3, 0, 0, 0, time.UTC)
+	fix := newFixture(c, &mockBackend{nextRuns: map[string]time.Time{
+		"2": t1,
+		"1": t0,
+	}})
+	result, err := fix.Facade.NextRuns()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.ActionScheduleRuns{
+		Runs: []params.ActionScheduleRun{{Id: "1", NextRun: t0}, {Id: "2", NextRun: t1}},
+	})
+}
+
+func (s *FacadeSuite) TestRun(c *gc.C) {
+	fix := newFixture(c, &mockBackend{})
+	result := fix.Facade.Run(params.ActionScheduleIds{
+		Ids: []string{"1", "missing", "error"},
+	})
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[1].Error, gc.ErrorMatches, `action schedule "missing" not found`)
+	c.Check(result.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Check(result.Results[2].Error, gc.ErrorMatches, "blammo")
+	c.Check(fix.Backend.run, jc.DeepEquals, []string{"1", "missing", "error"})
+}
@@ -0,0 +1,57 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/state"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb. If you were
+// to change any part of it so that it were no longer *obviously* and
+// *trivially* correct, you would be Doing It Wrong.
+
+func init() {
+	common.RegisterStandardFacade("ActionScheduler", 1, newFacade)
+}
+
+// newFacade wraps the supplied *state.State for the use of the Facade.
+func newFacade(st *state.State, res *common.Resources, auth common.Authorizer) (*Facade, error) {
+	return NewFacade(backendShim{st}, res, auth)
+}
+
+// backendShim wraps a *State to implement Backend without pulling in
+// direct mongodb dependencies.
+type backendShim struct {
+	st *state.State
+}
+
+// WatchActionSchedules is part of the Backend interface.
+func (shim backendShim) WatchActionSchedules() state.StringsWatcher {
+	return shim.st.WatchActionSchedules()
+}
+
+// ActionScheduleNextRuns is part of the Backend interface.
+func (shim backendShim) ActionScheduleNextRuns() (map[string]time.Time, error) {
+	schedules, err := shim.st.AllActionSchedules()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]time.Time, len(schedules))
+	for _, schedule := range schedules {
+		result[schedule.Id()] = schedule.NextRun()
+	}
+	return result, nil
+}
+
+// RunActionSchedule is part of the Backend interface.
+func (shim backendShim) RunActionSchedule(id string) error {
+	_, err := shim.st.RunActionSchedule(id)
+	return errors.Trace(err)
+}
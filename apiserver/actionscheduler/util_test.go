@@ -0,0 +1,93 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/actionscheduler"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/state"
+)
+
+// mockAuth implements common.Authorizer for the tests' convenience.
+type mockAuth struct {
+	common.Authorizer
+	modelManager bool
+}
+
+func (mock mockAuth) AuthModelManager() bool {
+	return mock.modelManager
+}
+
+// auth is a convenience constructor for a mockAuth.
+func auth(modelManager bool) common.Authorizer {
+	return mockAuth{modelManager: modelManager}
+}
+
+// mockWatcher implements state.StringsWatcher for the tests' convenience.
+type mockWatcher struct {
+	state.StringsWatcher
+	working bool
+}
+
+func (mock *mockWatcher) Changes() <-chan []string {
+	ch := make(chan []string, 1)
+	if mock.working {
+		ch <- []string{"1", "2", "3"}
+	} else {
+		close(ch)
+	}
+	return ch
+}
+
+func (mock *mockWatcher) Err() error {
+	return errors.New("blammo")
+}
+
+// mockBackend implements actionscheduler.Backend for the tests'
+// convenience.
+type mockBackend struct {
+	watching bool
+	nextRuns map[string]time.Time
+	runsErr  error
+	run      []string
+}
+
+func (mock *mockBackend) WatchActionSchedules() state.StringsWatcher {
+	return &mockWatcher{working: mock.watching}
+}
+
+func (mock *mockBackend) ActionScheduleNextRuns() (map[string]time.Time, error) {
+	return mock.nextRuns, mock.runsErr
+}
+
+func (mock *mockBackend) RunActionSchedule(id string) error {
+	mock.run = append(mock.run, id)
+	switch id {
+	case "missing":
+		return errors.NotFoundf("action schedule %q", id)
+	case "error":
+		return errors.New("blammo")
+	}
+	return nil
+}
+
+// fixture collects components needed to test the Facade.
+type fixture struct {
+	Backend   *mockBackend
+	Facade    *actionscheduler.Facade
+	Resources *common.Resources
+}
+
+func newFixture(c *gc.C, backend *mockBackend) *fixture {
+	resources := common.NewResources()
+	facade, err := actionscheduler.NewFacade(backend, resources, auth(true))
+	c.Assert(err, jc.ErrorIsNil)
+	return &fixture{backend, facade, resources}
+}
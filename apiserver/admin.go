@@ -40,6 +40,24 @@ var RestoreInProgressError = errors.New("restore in progress")
 var MaintenanceNoLoginError = errors.New("login failed - maintenance in progress")
 var errAlreadyLoggedIn = errors.New("already logged in")
 
+// checkControllerLoginAccess returns ErrPerm if the given local user
+// does not have login access to the controller. External users are
+// authorized by their identity provider and model access instead.
+func checkControllerLoginAccess(st *state.State, tag names.Tag) error {
+	userTag, ok := tag.(names.UserTag)
+	if !ok || !userTag.IsLocal() {
+		return nil
+	}
+	access, err := st.ControllerAccess(userTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !access.Includes(state.ControllerLoginAccess) {
+		return common.ErrPerm
+	}
+	return nil
+}
+
 func (a *admin) doLogin(req params.LoginRequest, loginVersion int) (params.LoginResultV1, error) {
 	var fail params.LoginResultV1
 
@@ -129,6 +147,11 @@ func (a *admin) doLogin(req params.LoginRequest, loginVersion int) (params.Login
 		// worker for the controller model.
 		agentPingerNeeded = false
 	}
+	if isUser {
+		if err := checkControllerLoginAccess(a.root.state, entity.Tag()); err != nil {
+			return fail, errors.Trace(err)
+		}
+	}
 	a.root.entity = entity
 
 	if a.reqNotifier != nil {
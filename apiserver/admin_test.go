@@ -480,6 +480,21 @@ func (s *loginSuite) TestNonEnvironUserLoginFails(c *gc.C) {
 	})
 }
 
+func (s *loginSuite) TestLoginWithoutControllerAccessFails(c *gc.C) {
+	info, cleanup := s.setupServerWithValidator(c, nil)
+	defer cleanup()
+	user := s.Factory.MakeUser(c, &factory.UserParams{Password: "dummy-password"})
+	err := s.State.RemoveControllerUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	info.Password = "dummy-password"
+	info.Tag = user.UserTag()
+	_, err = api.Open(info, fastDialOpts)
+	c.Assert(errors.Cause(err), gc.DeepEquals, &rpc.RequestError{
+		Message: "permission denied",
+		Code:    "unauthorized access",
+	})
+}
+
 func (s *loginSuite) TestLoginValidationSuccess(c *gc.C) {
 	validator := func(params.LoginRequest) error {
 		return nil
@@ -0,0 +1,234 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package apimetrics accumulates metrics describing the work done by
+// the API server, and renders them in the Prometheus text exposition
+// format.
+package apimetrics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ContentType is the content type of the text exposition format.
+const ContentType = "text/plain; version=0.0.4"
+
+// DurationBuckets holds the upper bounds, in seconds, of the histogram
+// buckets into which request durations are counted.
+var DurationBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Collector accumulates the API server's request and logsink metrics.
+// It is goroutine-safe.
+type Collector struct {
+	logRecords          int64
+	droppedAuditEntries int64
+
+	mu       sync.Mutex
+	requests map[requestKey]*requestStats
+}
+
+// requestKey identifies the API method a request was made to.
+type requestKey struct {
+	facade  string
+	version int
+	method  string
+}
+
+// requestStats accumulates the requests made to an API method.
+type requestStats struct {
+	count   uint64
+	errors  uint64
+	sum     float64
+	buckets []uint64
+}
+
+// NewCollector returns a Collector with no metrics recorded.
+func NewCollector() *Collector {
+	return &Collector{
+		requests: make(map[requestKey]*requestStats),
+	}
+}
+
+// RecordRequest records that a request to the given API method was
+// replied to after the given duration, and whether it failed.
+func (c *Collector) RecordRequest(facade string, version int, method string, failed bool, duration time.Duration) {
+	key := requestKey{facade, version, method}
+	seconds := duration.Seconds()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.requests[key]
+	if stats == nil {
+		stats = &requestStats{buckets: make([]uint64, len(DurationBuckets))}
+		c.requests[key] = stats
+	}
+	stats.count++
+	if failed {
+		stats.errors++
+	}
+	stats.sum += seconds
+	for i, bound := range DurationBuckets {
+		if seconds <= bound {
+			stats.buckets[i]++
+		}
+	}
+}
+
+// RecordLogRecord records that a log record was received by the
+// logsink.
+func (c *Collector) RecordLogRecord() {
+	atomic.AddInt64(&c.logRecords, 1)
+}
+
+// RecordDroppedAuditEntry records that an audit entry was dropped
+// because the audit log could not keep up, and returns the number of
+// entries dropped so far.
+func (c *Collector) RecordDroppedAuditEntry() int64 {
+	return atomic.AddInt64(&c.droppedAuditEntries, 1)
+}
+
+// Render writes the collected metrics to w, in the text exposition
+// format.
+func (c *Collector) Render(w io.Writer) error {
+	c.mu.Lock()
+	keys := make([]requestKey, 0, len(c.requests))
+	requests := make(map[requestKey]requestStats, len(c.requests))
+	for key, stats := range c.requests {
+		keys = append(keys, key)
+		copied := *stats
+		copied.buckets = append([]uint64(nil), stats.buckets...)
+		requests[key] = copied
+	}
+	c.mu.Unlock()
+	sort.Sort(requestKeys(keys))
+
+	ew := &errWriter{w: w}
+	writeHeader(ew, "juju_api_requests_total", "counter",
+		"Number of API requests replied to, by facade, version, method and whether they failed.")
+	for _, key := range keys {
+		stats := requests[key]
+		for _, failed := range []bool{false, true} {
+			value := stats.count - stats.errors
+			if failed {
+				value = stats.errors
+			}
+			writeSample(ew, "juju_api_requests_total", key.labels("error", strconv.FormatBool(failed)), float64(value))
+		}
+	}
+
+	writeHeader(ew, "juju_api_request_duration_seconds", "histogram",
+		"Time taken to reply to API requests, by facade, version and method.")
+	for _, key := range keys {
+		stats := requests[key]
+		for i, bound := range DurationBuckets {
+			labels := key.labels("le", formatFloat(bound))
+			writeSample(ew, "juju_api_request_duration_seconds_bucket", labels, float64(stats.buckets[i]))
+		}
+		writeSample(ew, "juju_api_request_duration_seconds_bucket", key.labels("le", "+Inf"), float64(stats.count))
+		writeSample(ew, "juju_api_request_duration_seconds_sum", key.labels(), stats.sum)
+		writeSample(ew, "juju_api_request_duration_seconds_count", key.labels(), float64(stats.count))
+	}
+
+	writeHeader(ew, "juju_logsink_records_total", "counter",
+		"Number of log records received from agents by the logsink.")
+	writeSample(ew, "juju_logsink_records_total", "", float64(atomic.LoadInt64(&c.logRecords)))
+
+	writeHeader(ew, "juju_api_audit_entries_dropped_total", "counter",
+		"Number of audit entries dropped because the audit log could not keep up.")
+	writeSample(ew, "juju_api_audit_entries_dropped_total", "", float64(atomic.LoadInt64(&c.droppedAuditEntries)))
+	return ew.err
+}
+
+// labels renders the key, and any further label names and values, as
+// a label set.
+func (key requestKey) labels(extra ...string) string {
+	pairs := append([]string{
+		"facade", key.facade,
+		"version", strconv.Itoa(key.version),
+		"method", key.method,
+	}, extra...)
+	return formatLabels(pairs)
+}
+
+type requestKeys []requestKey
+
+func (k requestKeys) Len() int      { return len(k) }
+func (k requestKeys) Swap(i, j int) { k[i], k[j] = k[j], k[i] }
+func (k requestKeys) Less(i, j int) bool {
+	if k[i].facade != k[j].facade {
+		return k[i].facade < k[j].facade
+	}
+	if k[i].version != k[j].version {
+		return k[i].version < k[j].version
+	}
+	return k[i].method < k[j].method
+}
+
+// Metric describes a metric with a single, unlabelled, value.
+type Metric struct {
+	Name  string
+	Type  string
+	Help  string
+	Value float64
+}
+
+// WriteMetrics writes the metrics to w, in the text exposition format.
+func WriteMetrics(w io.Writer, metrics []Metric) error {
+	ew := &errWriter{w: w}
+	for _, metric := range metrics {
+		writeHeader(ew, metric.Name, metric.Type, metric.Help)
+		writeSample(ew, metric.Name, "", metric.Value)
+	}
+	return ew.err
+}
+
+func writeHeader(w io.Writer, name, metricType, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, metricType)
+}
+
+func writeSample(w io.Writer, name, labels string, value float64) {
+	fmt.Fprintf(w, "%s%s %s\n", name, labels, formatFloat(value))
+}
+
+// formatLabels renders alternating label names and values as a label
+// set, such as {facade="Client",version="1"}.
+func formatLabels(pairs []string) string {
+	if len(pairs) == 0 {
+		return ""
+	}
+	result := "{"
+	for i := 0; i < len(pairs); i += 2 {
+		if i > 0 {
+			result += ","
+		}
+		result += pairs[i] + "=" + strconv.Quote(pairs[i+1])
+	}
+	return result + "}"
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// errWriter records the first error encountered by a sequence of
+// writes, and ignores the writes that follow it.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+// Write is part of the io.Writer interface.
+func (ew *errWriter) Write(p []byte) (int, error) {
+	if ew.err != nil {
+		return 0, ew.err
+	}
+	n, err := ew.w.Write(p)
+	ew.err = err
+	return n, err
+}
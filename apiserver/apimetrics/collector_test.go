@@ -0,0 +1,121 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apimetrics_test
+
+import (
+	"bytes"
+	"errors"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/apimetrics"
+)
+
+type CollectorSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&CollectorSuite{})
+
+func (s *CollectorSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.PatchValue(&apimetrics.DurationBuckets, []float64{0.1, 1})
+}
+
+func (*CollectorSuite) TestRenderEmpty(c *gc.C) {
+	var buf bytes.Buffer
+	err := apimetrics.NewCollector().Render(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(buf.String(), gc.Equals, `
+# HELP juju_api_requests_total Number of API requests replied to, by facade, version, method and whether they failed.
+# TYPE juju_api_requests_total counter
+# HELP juju_api_request_duration_seconds Time taken to reply to API requests, by facade, version and method.
+# TYPE juju_api_request_duration_seconds histogram
+# HELP juju_logsink_records_total Number of log records received from agents by the logsink.
+# TYPE juju_logsink_records_total counter
+juju_logsink_records_total 0
+# HELP juju_api_audit_entries_dropped_total Number of audit entries dropped because the audit log could not keep up.
+# TYPE juju_api_audit_entries_dropped_total counter
+juju_api_audit_entries_dropped_total 0
+`[1:])
+}
+
+func (*CollectorSuite) TestRender(c *gc.C) {
+	collector := apimetrics.NewCollector()
+	collector.RecordRequest("Client", 1, "FullStatus", false, 62500*time.Microsecond)
+	collector.RecordRequest("Client", 1, "FullStatus", true, 500*time.Millisecond)
+	collector.RecordRequest("Client", 1, "FullStatus", false, 2*time.Second)
+	collector.RecordRequest("Agent", 2, "GetEntities", false, 250*time.Millisecond)
+	collector.RecordLogRecord()
+	collector.RecordLogRecord()
+	c.Assert(collector.RecordDroppedAuditEntry(), gc.Equals, int64(1))
+
+	var buf bytes.Buffer
+	err := collector.Render(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(buf.String(), gc.Equals, `
+# HELP juju_api_requests_total Number of API requests replied to, by facade, version, method and whether they failed.
+# TYPE juju_api_requests_total counter
+juju_api_requests_total{facade="Agent",version="2",method="GetEntities",error="false"} 1
+juju_api_requests_total{facade="Agent",version="2",method="GetEntities",error="true"} 0
+juju_api_requests_total{facade="Client",version="1",method="FullStatus",error="false"} 2
+juju_api_requests_total{facade="Client",version="1",method="FullStatus",error="true"} 1
+# HELP juju_api_request_duration_seconds Time taken to reply to API requests, by facade, version and method.
+# TYPE juju_api_request_duration_seconds histogram
+juju_api_request_duration_seconds_bucket{facade="Agent",version="2",method="GetEntities",le="0.1"} 0
+juju_api_request_duration_seconds_bucket{facade="Agent",version="2",method="GetEntities",le="1"} 1
+juju_api_request_duration_seconds_bucket{facade="Agent",version="2",method="GetEntities",le="+Inf"} 1
+juju_api_request_duration_seconds_sum{facade="Agent",version="2",method="GetEntities"} 0.25
+juju_api_request_duration_seconds_count{facade="Agent",version="2",method="GetEntities"} 1
+juju_api_request_duration_seconds_bucket{facade="Client",version="1",method="FullStatus",le="0.1"} 1
+juju_api_request_duration_seconds_bucket{facade="Client",version="1",method="FullStatus",le="1"} 2
+juju_api_request_duration_seconds_bucket{facade="Client",version="1",method="FullStatus",le="+Inf"} 3
+juju_api_request_duration_seconds_sum{facade="Client",version="1",method="FullStatus"} 2.5625
+juju_api_request_duration_seconds_count{facade="Client",version="1",method="FullStatus"} 3
+# HELP juju_logsink_records_total Number of log records received from agents by the logsink.
+# TYPE juju_logsink_records_total counter
+juju_logsink_records_total 2
+# HELP juju_api_audit_entries_dropped_total Number of audit entries dropped because the audit log could not keep up.
+# TYPE juju_api_audit_entries_dropped_total counter
+juju_api_audit_entries_dropped_total 1
+`[1:])
+}
+
+func (*CollectorSuite) TestRenderWriteError(c *gc.C) {
+	err := apimetrics.NewCollector().Render(failingWriter{})
+	c.Check(err, gc.ErrorMatches, "bad write")
+}
+
+func (*CollectorSuite) TestWriteMetrics(c *gc.C) {
+	var buf bytes.Buffer
+	err := apimetrics.WriteMetrics(&buf, []apimetrics.Metric{{
+		Name:  "juju_api_connections",
+		Type:  "gauge",
+		Help:  "Number of active API connections.",
+		Value: 3,
+	}, {
+		Name:  "juju_txn_retries_total",
+		Type:  "counter",
+		Help:  "Number of transaction attempts retried.",
+		Value: 12,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(buf.String(), gc.Equals, `
+# HELP juju_api_connections Number of active API connections.
+# TYPE juju_api_connections gauge
+juju_api_connections 3
+# HELP juju_txn_retries_total Number of transaction attempts retried.
+# TYPE juju_txn_retries_total counter
+juju_txn_retries_total 12
+`[1:])
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("bad write")
+}
@@ -8,6 +8,7 @@ import (
 	"crypto/x509"
 	"net"
 	"net/http"
+	"reflect"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -21,11 +22,14 @@ import (
 	"golang.org/x/net/websocket"
 	"launchpad.net/tomb"
 
+	"github.com/juju/juju/apiserver/apimetrics"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/apihttp"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/jsoncodec"
+	"github.com/juju/juju/rpc/rpcreflect"
 	"github.com/juju/juju/state"
 )
 
@@ -35,6 +39,12 @@ var logger = loggo.GetLogger("juju.apiserver")
 // accept
 const loginRateLimit = 10
 
+// adminApiTypes holds the type of the Admin facade served for each
+// version in Server.adminApiFactories.
+var adminApiTypes = map[int]reflect.Type{
+	3: reflect.TypeOf((*adminApiV3)(nil)),
+}
+
 // Server holds the server side of the API.
 type Server struct {
 	tomb              tomb.Tomb
@@ -51,6 +61,8 @@ type Server struct {
 	modelUUID         string
 	authCtxt          *authContext
 	connections       int32 // count of active websocket connections
+	auditEntries      chan state.AuditEntry
+	metrics           *apimetrics.Collector
 }
 
 // LoginValidator functions are used to decide whether login requests
@@ -207,6 +219,8 @@ func newServer(s *state.State, lis *net.TCPListener, cfg ServerConfig) (_ *Serve
 		adminApiFactories: map[int]adminApiFactory{
 			3: newAdminApiV3,
 		},
+		auditEntries: make(chan state.AuditEntry, auditQueueSize),
+		metrics:      apimetrics.NewCollector(),
 	}
 	srv.authCtxt, err = newAuthContext(s)
 	if err != nil {
@@ -242,23 +256,47 @@ type requestNotifier struct {
 	id    int64
 	start time.Time
 
-	mu   sync.Mutex
-	tag_ string
+	mu         sync.Mutex
+	tag_       string
+	modelUUID_ string
 
 	// count is incremented by calls to join, and deincremented
 	// by calls to leave.
 	count *int32
+
+	// auditEntries receives an entry for each request made by a
+	// user, once the request has been replied to. Entries are
+	// dropped rather than holding up replies when it is full.
+	auditEntries chan<- state.AuditEntry
+
+	// metrics records the facade, version, method, outcome and
+	// duration of each request that is replied to.
+	metrics *apimetrics.Collector
+
+	// pending holds the requests that have not been replied to yet,
+	// keyed by request id.
+	pending map[uint64]pendingRequest
+}
+
+// pendingRequest holds the details of a request that are needed to
+// audit it once it has been replied to.
+type pendingRequest struct {
+	start time.Time
+	body  interface{}
 }
 
 var globalCounter int64
 
-func newRequestNotifier(count *int32) *requestNotifier {
+func newRequestNotifier(count *int32, auditEntries chan<- state.AuditEntry, metrics *apimetrics.Collector) *requestNotifier {
 	return &requestNotifier{
 		id:   atomic.AddInt64(&globalCounter, 1),
 		tag_: "<unknown>",
 		// TODO(fwereade): 2016-03-17 lp:1558657
-		start: time.Now(),
-		count: count,
+		start:        time.Now(),
+		count:        count,
+		auditEntries: auditEntries,
+		metrics:      metrics,
+		pending:      make(map[uint64]pendingRequest),
 	}
 }
 
@@ -268,6 +306,19 @@ func (n *requestNotifier) login(tag string) {
 	n.mu.Unlock()
 }
 
+func (n *requestNotifier) setModelUUID(modelUUID string) {
+	n.mu.Lock()
+	n.modelUUID_ = modelUUID
+	n.mu.Unlock()
+}
+
+func (n *requestNotifier) modelUUID() (modelUUID string) {
+	n.mu.Lock()
+	modelUUID = n.modelUUID_
+	n.mu.Unlock()
+	return
+}
+
 func (n *requestNotifier) tag() (tag string) {
 	n.mu.Lock()
 	tag = n.tag_
@@ -279,12 +330,20 @@ func (n *requestNotifier) ServerRequest(hdr *rpc.Header, body interface{}) {
 	if hdr.Request.Type == "Pinger" && hdr.Request.Action == "Ping" {
 		return
 	}
+	if n.auditEntries != nil && isAudited(hdr.Request) {
+		n.mu.Lock()
+		n.pending[hdr.RequestId] = pendingRequest{
+			start: time.Now(),
+			body:  body,
+		}
+		n.mu.Unlock()
+	}
 	// TODO(rog) 2013-10-11 remove secrets from some requests.
 	// Until secrets are removed, we only log the body of the requests at trace level
 	// which is below the default level of debug.
 	if logger.IsTraceEnabled() {
 		logger.Tracef("<- [%X] %s %s", n.id, n.tag(), jsoncodec.DumpRequest(hdr, body))
-	} else {
+	} else if logger.EffectiveLogLevel() <= loggo.DEBUG {
 		logger.Debugf("<- [%X] %s %s", n.id, n.tag(), jsoncodec.DumpRequest(hdr, "'params redacted'"))
 	}
 }
@@ -293,16 +352,85 @@ func (n *requestNotifier) ServerReply(req rpc.Request, hdr *rpc.Header, body int
 	if req.Type == "Pinger" && req.Action == "Ping" {
 		return
 	}
+	if n.metrics != nil {
+		facade, version, method := metricsMethod(req)
+		n.metrics.RecordRequest(facade, version, method, hdr.Error != "", timeSpent)
+	}
+	n.audit(req, hdr)
 	// TODO(rog) 2013-10-11 remove secrets from some responses.
 	// Until secrets are removed, we only log the body of the requests at trace level
 	// which is below the default level of debug.
 	if logger.IsTraceEnabled() {
 		logger.Tracef("-> [%X] %s %s", n.id, n.tag(), jsoncodec.DumpRequest(hdr, body))
-	} else {
+	} else if logger.EffectiveLogLevel() <= loggo.DEBUG {
 		logger.Debugf("-> [%X] %s %s %s %s[%q].%s", n.id, n.tag(), timeSpent, jsoncodec.DumpRequest(hdr, "'body redacted'"), req.Type, req.Id, req.Action)
 	}
 }
 
+// metricsMethod returns the facade, version and method under which
+// the request is recorded in the metrics. Requests that did not name
+// a method the server implements are all recorded as the "unknown"
+// facade, so that clients cannot create arbitrarily many metrics,
+// even before they have logged in.
+func metricsMethod(req rpc.Request) (string, int, string) {
+	if req.Type == "Admin" {
+		if adminType, ok := adminApiTypes[req.Version]; ok {
+			if _, err := rpcreflect.ObjTypeOf(adminType).Method(req.Action); err == nil {
+				return req.Type, req.Version, req.Action
+			}
+		}
+	} else if _, _, err := lookupMethod(req.Type, req.Version, req.Action); err == nil {
+		return req.Type, req.Version, req.Action
+	}
+	return "unknown", 0, ""
+}
+
+// audit sends an audit entry for the request being replied to, if it
+// was made by a user.
+func (n *requestNotifier) audit(req rpc.Request, hdr *rpc.Header) {
+	if n.auditEntries == nil {
+		return
+	}
+	n.mu.Lock()
+	pending, ok := n.pending[hdr.RequestId]
+	delete(n.pending, hdr.RequestId)
+	n.mu.Unlock()
+	if !ok {
+		return
+	}
+	var loginArgs interface{}
+	if req.Type == "Admin" && req.Action == "Login" {
+		loginArgs = pending.body
+	}
+	user, ok := auditUser(n.tag(), loginArgs)
+	if !ok {
+		return
+	}
+	entry := state.AuditEntry{
+		Time:      pending.start.UTC(),
+		ModelUUID: n.modelUUID(),
+		User:      user,
+		Facade:    req.Type,
+		Version:   req.Version,
+		Method:    req.Action,
+		Args:      audit.EncodeArgs(pending.body),
+		Error:     hdr.Error,
+		Duration:  time.Since(pending.start),
+	}
+	select {
+	case n.auditEntries <- entry:
+	default:
+		// Replies must not wait on a slow or unavailable database,
+		// so the entry is lost instead.
+		var dropped int64
+		if n.metrics != nil {
+			dropped = n.metrics.RecordDroppedAuditEntry()
+		}
+		logger.Warningf("audit queue full, dropped entry for %s calling %s.%s (%d dropped in total)",
+			entry.User, entry.Facade, entry.Method, dropped)
+	}
+}
+
 func (n *requestNotifier) join(req *http.Request) {
 	active := atomic.AddInt32(n.count, 1)
 	logger.Infof("[%X] API connection from %s, active connections: %d", n.id, req.RemoteAddr, active)
@@ -340,6 +468,12 @@ func (srv *Server) run() {
 		srv.tomb.Kill(srv.mongoPinger())
 	}()
 
+	srv.wg.Add(1)
+	go func() {
+		defer srv.wg.Done()
+		srv.auditWriter()
+	}()
+
 	// for pat based handlers, they are matched in-order of being
 	// registered, first match wins. So more specific ones have to be
 	// registered first.
@@ -450,6 +584,13 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 			ctxt: httpCtxt,
 		},
 	)
+	controllerCtxt := httpCtxt
+	controllerCtxt.controllerModelOnly = true
+	add("/metrics",
+		&metricsHandler{
+			ctxt: controllerCtxt,
+		},
+	)
 	add("/register",
 		&registerUserHandler{
 			httpCtxt,
@@ -533,7 +674,7 @@ func registerEndpoint(ep apihttp.Endpoint, mux *pat.PatternServeMux) {
 }
 
 func (srv *Server) apiHandler(w http.ResponseWriter, req *http.Request) {
-	reqNotifier := newRequestNotifier(&srv.connections)
+	reqNotifier := newRequestNotifier(&srv.connections, srv.auditEntries, srv.metrics)
 	reqNotifier.join(req)
 	defer reqNotifier.leave()
 	wsServer := websocket.Server{
@@ -553,13 +694,9 @@ func (srv *Server) serveConn(wsConn *websocket.Conn, reqNotifier *requestNotifie
 	if loggo.GetLogger("juju.rpc.jsoncodec").EffectiveLogLevel() <= loggo.TRACE {
 		codec.SetLogging(true)
 	}
-	var notifier rpc.RequestNotifier
-	if logger.EffectiveLogLevel() <= loggo.DEBUG {
-		// Incur request monitoring overhead only if we
-		// know we'll need it.
-		notifier = reqNotifier
-	}
-	conn := rpc.NewConn(codec, notifier)
+	// The notifier is always needed, so that user requests are
+	// audited; it only incurs logging overhead at debug level.
+	conn := rpc.NewConn(codec, reqNotifier)
 
 	h, err := srv.newAPIHandler(conn, reqNotifier, modelUUID)
 	if err != nil {
@@ -594,6 +731,7 @@ func (srv *Server) newAPIHandler(conn *rpc.Conn, reqNotifier *requestNotifier, m
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	reqNotifier.setModelUUID(resolvedModelUUID)
 	return newApiHandler(srv, st, conn, reqNotifier, modelUUID)
 }
 
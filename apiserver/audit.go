@@ -0,0 +1,80 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"strings"
+
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/state"
+)
+
+// auditQueueSize is the number of audit entries that may be waiting
+// to be written before further entries are dropped.
+const auditQueueSize = 1000
+
+// auditWriter records the audit entries sent by the request
+// notifiers until the server is stopped.
+func (srv *Server) auditWriter() {
+	for {
+		select {
+		case entry := <-srv.auditEntries:
+			srv.writeAuditEntry(entry)
+		case <-srv.tomb.Dying():
+			// Write out whatever is already queued.
+			for {
+				select {
+				case entry := <-srv.auditEntries:
+					srv.writeAuditEntry(entry)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (srv *Server) writeAuditEntry(entry state.AuditEntry) {
+	if err := srv.state.AddAuditEntry(entry); err != nil {
+		logger.Errorf("cannot record audit entry for %s calling %s.%s: %v",
+			entry.User, entry.Facade, entry.Method, err)
+	}
+}
+
+// isAudited reports whether the request should be recorded in the
+// audit log. Watcher Next and Stop calls are made continually by
+// long-polling clients and do not change anything, so they are left
+// out along with pings.
+func isAudited(req rpc.Request) bool {
+	if strings.HasSuffix(req.Type, "Watcher") {
+		return req.Action != "Next" && req.Action != "Stop"
+	}
+	return true
+}
+
+// auditUser returns the user to record in the audit log for a request
+// made on a connection authenticated as tag, or false if the request
+// should not be audited. Only requests made by users are audited;
+// agent traffic is not.
+func auditUser(tag string, loginArgs interface{}) (string, bool) {
+	if userTag, err := names.ParseUserTag(tag); err == nil {
+		return userTag.Canonical(), true
+	}
+	// A failed login leaves the connection unauthenticated, but the
+	// attempt is still recorded against the user named in it.
+	var authTag string
+	switch args := loginArgs.(type) {
+	case params.LoginRequest:
+		authTag = args.AuthTag
+	case *params.LoginRequest:
+		authTag = args.AuthTag
+	}
+	if userTag, err := names.ParseUserTag(authTag); err == nil {
+		return userTag.Canonical(), true
+	}
+	return "", false
+}
@@ -26,6 +26,7 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("Client", 1, NewClient)
+	common.RegisterStandardFacade("Client", 2, NewClientV2)
 }
 
 var logger = loggo.GetLogger("juju.apiserver.client")
@@ -54,6 +55,12 @@ type Client struct {
 	check *common.BlockChecker
 }
 
+// ClientV2 serves version 2 of the client-specific API methods, which
+// adds ExportBundle.
+type ClientV2 struct {
+	*Client
+}
+
 var getState = func(st *state.State) stateInterface {
 	return &stateShim{st}
 }
@@ -77,6 +84,15 @@ func NewClient(st *state.State, resources *common.Resources, authorizer common.A
 	return client, nil
 }
 
+// NewClientV2 creates a new instance of version 2 of the Client Facade.
+func NewClientV2(st *state.State, resources *common.Resources, authorizer common.Authorizer) (*ClientV2, error) {
+	client, err := NewClient(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientV2{client}, nil
+}
+
 func (c *Client) WatchAll() (params.AllWatcherId, error) {
 	w := c.api.stateAccessor.Watch()
 	return params.AllWatcherId{
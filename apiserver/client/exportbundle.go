@@ -0,0 +1,247 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/unitsort"
+	"github.com/juju/juju/state"
+)
+
+// ExportBundle returns the YAML for a bundle that reproduces the
+// services, machine placement and relations of the current model.
+func (c *ClientV2) ExportBundle() (params.StringResult, error) {
+	var result params.StringResult
+	data, err := c.exportBundleData()
+	if err != nil {
+		return result, errors.Annotate(err, "cannot export bundle")
+	}
+	out, err := goyaml.Marshal(data)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Result = string(out)
+	return result, nil
+}
+
+// exportBundleData builds the bundle data for the current model.
+func (c *Client) exportBundleData() (*charm.BundleData, error) {
+	st := c.api.stateAccessor
+	cfg, err := st.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	data := &charm.BundleData{
+		Services: make(map[string]*charm.ServiceSpec),
+		Machines: make(map[string]*charm.MachineSpec),
+	}
+	if series, ok := cfg.DefaultSeries(); ok {
+		data.Series = series
+	}
+
+	services, err := st.AllServices()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// machineIds holds the ids of the top level machines that host
+	// the model's principal units.
+	machineIds := make(map[string]bool)
+	for _, service := range services {
+		spec, placements, err := c.exportService(service)
+		if err != nil {
+			return nil, errors.Annotatef(err, "service %q", service.Name())
+		}
+		for _, id := range placements {
+			machineIds[state.TopParentId(id)] = true
+		}
+		data.Services[service.Name()] = spec
+	}
+
+	for id := range machineIds {
+		machine, err := st.Machine(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		spec, err := c.exportMachine(machine)
+		if err != nil {
+			return nil, errors.Annotatef(err, "machine %q", id)
+		}
+		data.Machines[id] = spec
+	}
+
+	relations, err := st.AllRelations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, relation := range relations {
+		endpoints := relation.Endpoints()
+		if len(endpoints) != 2 {
+			// Peer relations are established by the charms.
+			continue
+		}
+		pair := []string{endpoints[0].String(), endpoints[1].String()}
+		sort.Strings(pair)
+		data.Relations = append(data.Relations, pair)
+	}
+	sort.Sort(byEndpoints(data.Relations))
+	return data, nil
+}
+
+// exportService returns the bundle spec for the given service, along
+// with the ids of the machines its units are placed on.
+func (c *Client) exportService(service *state.Service) (*charm.ServiceSpec, []string, error) {
+	curl, _ := service.CharmURL()
+	spec := &charm.ServiceSpec{
+		Charm:  curl.String(),
+		Expose: service.IsExposed(),
+	}
+	if curl.Series == "" {
+		spec.Series = service.Series()
+	}
+
+	options, err := serviceOptions(service)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if len(options) > 0 {
+		spec.Options = options
+	}
+
+	annotations, err := c.api.stateAccessor.Annotations(service)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if len(annotations) > 0 {
+		spec.Annotations = annotations
+	}
+
+	bindings, err := service.EndpointBindings()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	for endpoint, space := range bindings {
+		if space == "" {
+			continue
+		}
+		if spec.EndpointBindings == nil {
+			spec.EndpointBindings = make(map[string]string)
+		}
+		spec.EndpointBindings[endpoint] = space
+	}
+
+	if !service.IsPrincipal() {
+		// Subordinate units follow their principals, and
+		// subordinate services have no constraints or storage.
+		return spec, nil, nil
+	}
+
+	cons, err := service.Constraints()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	spec.Constraints = cons.String()
+
+	storageCons, err := service.StorageConstraints()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	for name, sc := range storageCons {
+		if spec.Storage == nil {
+			spec.Storage = make(map[string]string)
+		}
+		spec.Storage[name] = fmt.Sprintf("%s,%d,%dM", sc.Pool, sc.Count, sc.Size)
+	}
+
+	units, err := service.AllUnits()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	byName := make(map[string]*state.Unit)
+	unitNames := make([]string, len(units))
+	for i, unit := range units {
+		byName[unit.Name()] = unit
+		unitNames[i] = unit.Name()
+	}
+	unitsort.Sort(unitNames)
+	spec.NumUnits = len(units)
+	var placements []string
+	for _, unitName := range unitNames {
+		unit := byName[unitName]
+		machineId, err := unit.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		placements = append(placements, machineId)
+		to := state.TopParentId(machineId)
+		if containerType := state.ContainerTypeFromId(machineId); containerType != "" {
+			to = fmt.Sprintf("%s:%s", containerType, to)
+		}
+		spec.To = append(spec.To, to)
+	}
+	return spec, placements, nil
+}
+
+// serviceOptions returns the service's config settings that differ from
+// the defaults of its charm.
+func serviceOptions(service *state.Service) (map[string]interface{}, error) {
+	ch, _, err := service.Charm()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	settings, err := service.ConfigSettings()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defaults := ch.Config().Options
+	options := make(map[string]interface{})
+	for name, value := range settings {
+		if option, ok := defaults[name]; ok && reflect.DeepEqual(option.Default, value) {
+			continue
+		}
+		options[name] = value
+	}
+	return options, nil
+}
+
+// exportMachine returns the bundle spec for the given machine.
+func (c *Client) exportMachine(machine *state.Machine) (*charm.MachineSpec, error) {
+	spec := &charm.MachineSpec{
+		Series: machine.Series(),
+	}
+	cons, err := machine.Constraints()
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	spec.Constraints = cons.String()
+	annotations, err := c.api.stateAccessor.Annotations(machine)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(annotations) > 0 {
+		spec.Annotations = annotations
+	}
+	return spec, nil
+}
+
+// byEndpoints sorts relations by their endpoints.
+type byEndpoints [][]string
+
+func (s byEndpoints) Len() int      { return len(s) }
+func (s byEndpoints) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byEndpoints) Less(i, j int) bool {
+	if s[i][0] != s[j][0] {
+		return s[i][0] < s[j][0]
+	}
+	return s[i][1] < s[j][1]
+}
@@ -0,0 +1,104 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client_test
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/client"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+func (s *serverSuite) TestExportBundle(c *gc.C) {
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err := wordpress.UpdateConfigSettings(charm.Settings{"blog-title": "Export"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = wordpress.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = wordpress.SetConstraints(constraints.MustParse("mem=4G"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetAnnotations(wordpress, map[string]string{"gui-x": "10"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Series:      "quantal",
+		Constraints: constraints.MustParse("mem=8G"),
+	})
+	err = s.State.SetAnnotations(machine, map[string]string{"owner": "ops"})
+	c.Assert(err, jc.ErrorIsNil)
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, machine.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, m := range []*state.Machine{machine, container} {
+		unit, err := wordpress.AddUnit()
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.AssignToMachine(m)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	unit, err := mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := (&client.ClientV2{Client: s.client}).ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := charm.ReadBundleData(strings.NewReader(result.Result))
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.State.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	series, _ := cfg.DefaultSeries()
+	c.Assert(data, jc.DeepEquals, &charm.BundleData{
+		Series: series,
+		Services: map[string]*charm.ServiceSpec{
+			"wordpress": {
+				Charm:       "local:quantal/wordpress-3",
+				NumUnits:    2,
+				To:          []string{machine.Id(), "lxd:" + machine.Id()},
+				Expose:      true,
+				Options:     map[string]interface{}{"blog-title": "Export"},
+				Annotations: map[string]string{"gui-x": "10"},
+				Constraints: "mem=4096M",
+			},
+			"mysql": {
+				Charm:    "local:quantal/mysql-1",
+				NumUnits: 1,
+				To:       []string{machine.Id()},
+			},
+		},
+		Machines: map[string]*charm.MachineSpec{
+			machine.Id(): {
+				Series:      "quantal",
+				Constraints: "mem=8192M",
+				Annotations: map[string]string{"owner": "ops"},
+			},
+		},
+		Relations: [][]string{{"mysql:server", "wordpress:db"}},
+	})
+}
+
+func (s *serverSuite) TestExportBundleEmptyModel(c *gc.C) {
+	result, err := (&client.ClientV2{Client: s.client}).ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := charm.ReadBundleData(strings.NewReader(result.Result))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data.Services, gc.HasLen, 0)
+	c.Assert(data.Relations, gc.HasLen, 0)
+}
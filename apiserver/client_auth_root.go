@@ -12,9 +12,9 @@ import (
 	"github.com/juju/juju/state"
 )
 
-// clientAuthRoot restricts API calls for users of a model. Initially the
-// authorisation checks are only for read only access to the model, but in the
-// near future, full ACL support is desirable.
+// clientAuthRoot restricts API calls for users of a model. Users with read
+// access may only make read only calls, and users with write access may not
+// make calls that require admin access to the model.
 type clientAuthRoot struct {
 	finder rpc.MethodFinder
 	user   *state.ModelUser
@@ -41,6 +41,9 @@ func (r *clientAuthRoot) FindMethod(rootName string, version int, methodName str
 			return nil, errors.Trace(common.ErrPerm)
 		}
 	}
+	if !r.user.IsAdmin() && isCallAdminOnly(rootName, methodName) {
+		return nil, errors.Trace(common.ErrPerm)
+	}
 
 	return caller, nil
 }
@@ -64,6 +64,24 @@ func (s *clientAuthRootSuite) TestReadOnlyUser(c *gc.C) {
 	s.AssertCallNotImplemented(c, client, "Unknown", 1, "Method")
 }
 
+func (s *clientAuthRootSuite) TestWriteUser(c *gc.C) {
+	envUser := s.Factory.MakeModelUser(c, &factory.ModelUserParams{Access: state.ModelWriteAccess})
+	client := newClientAuthRoot(&fakeFinder{}, envUser)
+	// deploys are fine
+	s.AssertCallGood(c, client, "Service", 3, "Deploy")
+	s.AssertCallGood(c, client, "Client", 1, "FullStatus")
+	// but destroying the model is not
+	s.AssertCallErrPerm(c, client, "Client", 1, "DestroyModel")
+	s.AssertCallNotImplemented(c, client, "Client", 1, "Unknown")
+}
+
+func (s *clientAuthRootSuite) TestAdminUser(c *gc.C) {
+	envUser := s.Factory.MakeModelUser(c, &factory.ModelUserParams{Access: state.ModelAdminAccess})
+	client := newClientAuthRoot(&fakeFinder{}, envUser)
+	s.AssertCallGood(c, client, "Service", 3, "Deploy")
+	s.AssertCallGood(c, client, "Client", 1, "DestroyModel")
+}
+
 func isCallNotImplementedError(err error) bool {
 	_, ok := err.(*rpcreflect.CallNotImplementedError)
 	return ok
@@ -52,8 +52,10 @@ func StateToParamsModelAccess(stateAccess state.ModelAccess) (params.ModelAccess
 	switch stateAccess {
 	case state.ModelReadAccess:
 		return params.ModelReadAccess, nil
-	case state.ModelAdminAccess:
+	case state.ModelWriteAccess:
 		return params.ModelWriteAccess, nil
+	case state.ModelAdminAccess:
+		return params.ModelAdminAccess, nil
 	}
 	return "", errors.Errorf("invalid model access permission %q", stateAccess)
 }
@@ -0,0 +1,94 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// ModifyControllerAccess changes the level of access users have to the
+// controller.
+func (c *ControllerAPI) ModifyControllerAccess(args params.ModifyControllerAccessRequest) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Changes)),
+	}
+	for i, arg := range args.Changes {
+		targetUserTag, err := names.ParseUserTag(arg.UserTag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(errors.Annotate(err, "could not modify controller access"))
+			continue
+		}
+		access, err := fromControllerAccessParam(arg.Access)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(errors.Annotate(err, "could not modify controller access"))
+			continue
+		}
+		result.Results[i].Error = common.ServerError(
+			changeControllerAccess(c.state, c.apiUser, targetUserTag, arg.Action, access))
+	}
+	return result, nil
+}
+
+// fromControllerAccessParam returns the state controller access type from
+// the API wireformat type.
+func fromControllerAccessParam(paramAccess params.ControllerAccessPermission) (state.ControllerAccess, error) {
+	switch paramAccess {
+	case params.ControllerLoginAccess:
+		return state.ControllerLoginAccess, nil
+	case params.ControllerAddModelAccess:
+		return state.ControllerAddModelAccess, nil
+	case params.ControllerSuperuserAccess:
+		return state.ControllerSuperuserAccess, nil
+	}
+	return "", errors.Errorf("invalid controller access permission %q", paramAccess)
+}
+
+// revokedControllerAccess maps the access being revoked to the access that
+// the user is left with. Revoking login access removes all access.
+var revokedControllerAccess = map[state.ControllerAccess]state.ControllerAccess{
+	state.ControllerAddModelAccess:  state.ControllerLoginAccess,
+	state.ControllerSuperuserAccess: state.ControllerAddModelAccess,
+}
+
+func changeControllerAccess(st *state.State, apiUser, targetUserTag names.UserTag, action params.ControllerAction, access state.ControllerAccess) error {
+	switch action {
+	case params.GrantControllerAccess:
+		_, err := st.AddControllerUser(state.ControllerUserSpec{User: targetUserTag, CreatedBy: apiUser, Access: access})
+		if !errors.IsAlreadyExists(err) {
+			return errors.Annotate(err, "could not grant controller access")
+		}
+		controllerUser, err := st.ControllerUser(targetUserTag)
+		if err != nil {
+			return errors.Annotate(err, "could not look up controller access for user")
+		}
+		if controllerUser.Access().Includes(access) {
+			return errors.Errorf("user already has %q access", controllerUser.Access())
+		}
+		err = controllerUser.SetAccess(access)
+		return errors.Annotate(err, "could not set controller access for user")
+
+	case params.RevokeControllerAccess:
+		if access == state.ControllerLoginAccess {
+			err := st.RemoveControllerUser(targetUserTag)
+			return errors.Annotate(err, "could not revoke controller access")
+		}
+		controllerUser, err := st.ControllerUser(targetUserTag)
+		if err != nil {
+			return errors.Annotate(err, "could not look up controller access for user")
+		}
+		if !controllerUser.Access().Includes(access) {
+			return errors.Errorf("user does not have %q access", access)
+		}
+		err = controllerUser.SetAccess(revokedControllerAccess[access])
+		return errors.Annotate(err, "could not revoke controller access")
+
+	default:
+		return errors.Errorf("unknown action %q", action)
+	}
+}
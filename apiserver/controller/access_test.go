@@ -0,0 +1,106 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+func (s *controllerSuite) modifyAccess(c *gc.C, user names.UserTag, action params.ControllerAction, access params.ControllerAccessPermission) error {
+	args := params.ModifyControllerAccessRequest{
+		Changes: []params.ModifyControllerAccess{{
+			UserTag: user.String(),
+			Action:  action,
+			Access:  access,
+		}}}
+	result, err := s.controller.ModifyControllerAccess(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	return result.OneError()
+}
+
+func (s *controllerSuite) TestGrantAddModel(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+
+	err := s.modifyAccess(c, user.UserTag(), params.GrantControllerAccess, params.ControllerAddModelAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	controllerUser, err := s.State.ControllerUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(controllerUser.Access(), gc.Equals, state.ControllerAddModelAccess)
+}
+
+func (s *controllerSuite) TestGrantAddModelRemoteUser(c *gc.C) {
+	user := names.NewUserTag("bob@remote")
+
+	err := s.modifyAccess(c, user, params.GrantControllerAccess, params.ControllerAddModelAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	controllerUser, err := s.State.ControllerUser(user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(controllerUser.Access(), gc.Equals, state.ControllerAddModelAccess)
+	c.Assert(controllerUser.CreatedBy(), gc.Equals, s.AdminUserTag(c).Canonical())
+}
+
+func (s *controllerSuite) TestGrantSuperuserMakesControllerAdministrator(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+
+	err := s.modifyAccess(c, user.UserTag(), params.GrantControllerAccess, params.ControllerSuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	isAdmin, err := s.State.IsControllerAdministrator(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(isAdmin, jc.IsTrue)
+}
+
+func (s *controllerSuite) TestGrantOnlyGreaterAccess(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+
+	err := s.modifyAccess(c, user.UserTag(), params.GrantControllerAccess, params.ControllerLoginAccess)
+	c.Assert(err, gc.ErrorMatches, `user already has "login" access`)
+}
+
+func (s *controllerSuite) TestRevokeAddModelLeavesLogin(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	err := s.modifyAccess(c, user.UserTag(), params.GrantControllerAccess, params.ControllerAddModelAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.modifyAccess(c, user.UserTag(), params.RevokeControllerAccess, params.ControllerAddModelAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	controllerUser, err := s.State.ControllerUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(controllerUser.Access(), gc.Equals, state.ControllerLoginAccess)
+}
+
+func (s *controllerSuite) TestRevokeAccessNotHeld(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+
+	err := s.modifyAccess(c, user.UserTag(), params.RevokeControllerAccess, params.ControllerSuperuserAccess)
+	c.Assert(err, gc.ErrorMatches, `user does not have "superuser" access`)
+}
+
+func (s *controllerSuite) TestRevokeLoginRemovesControllerUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+
+	err := s.modifyAccess(c, user.UserTag(), params.RevokeControllerAccess, params.ControllerLoginAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.ControllerUser(user.UserTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *controllerSuite) TestModifyControllerAccessInvalidAccess(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+
+	err := s.modifyAccess(c, user.UserTag(), params.GrantControllerAccess, params.ControllerAccessPermission("write"))
+	c.Assert(err, gc.ErrorMatches, `could not modify controller access: invalid controller access permission "write"`)
+}
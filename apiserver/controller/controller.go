@@ -23,6 +23,7 @@ var logger = loggo.GetLogger("juju.apiserver.controller")
 
 func init() {
 	common.RegisterStandardFacade("Controller", 2, NewControllerAPI)
+	common.RegisterStandardFacade("Controller", 3, NewControllerAPIV3)
 }
 
 // Controller defines the methods on the controller API end point.
@@ -35,6 +36,15 @@ type Controller interface {
 	WatchAllModels() (params.AllWatcherId, error)
 	ModelStatus(req params.Entities) (params.ModelStatusResults, error)
 	InitiateModelMigration(params.InitiateModelMigrationArgs) (params.InitiateModelMigrationResults, error)
+	ModifyControllerAccess(params.ModifyControllerAccessRequest) (params.ErrorResults, error)
+}
+
+// ControllerV3 defines the methods on version 3 of the controller API
+// end point.
+type ControllerV3 interface {
+	Controller
+	AbortModelMigration(params.Entities) (params.ErrorResults, error)
+	AuditLog(params.AuditLogFilter) (params.AuditLogResults, error)
 }
 
 // ControllerAPI implements the environment manager interface and is
@@ -48,6 +58,14 @@ type ControllerAPI struct {
 
 var _ Controller = (*ControllerAPI)(nil)
 
+// ControllerAPIV3 implements version 3 of the controller API end
+// point, which adds AbortModelMigration and AuditLog.
+type ControllerAPIV3 struct {
+	*ControllerAPI
+}
+
+var _ ControllerV3 = (*ControllerAPIV3)(nil)
+
 // NewControllerAPI creates a new api server endpoint for managing
 // environments.
 func NewControllerAPI(
@@ -79,6 +97,20 @@ func NewControllerAPI(
 	}, nil
 }
 
+// NewControllerAPIV3 creates a new api server endpoint for version 3
+// of the controller facade.
+func NewControllerAPIV3(
+	st *state.State,
+	resources *common.Resources,
+	authorizer common.Authorizer,
+) (*ControllerAPIV3, error) {
+	api, err := NewControllerAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIV3{api}, nil
+}
+
 // AllModels allows controller administrators to get the list of all the
 // environments in the controller.
 func (s *ControllerAPI) AllModels() (params.UserModelList, error) {
@@ -327,6 +359,107 @@ func (c *ControllerAPI) initiateOneModelMigration(spec params.ModelMigrationSpec
 	return mig.Id(), nil
 }
 
+// AbortModelMigration requests that the active migration of each of the
+// given models is aborted. The migration master for the model reverses
+// any import into the target controller and returns control of the
+// model to this controller. Aborting a migration that has already been
+// aborted is not an error.
+func (c *ControllerAPIV3) AbortModelMigration(args params.Entities) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		if err := c.abortOneModelMigration(entity.Tag); err != nil {
+			results.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return results, nil
+}
+
+func (c *ControllerAPI) abortOneModelMigration(tag string) error {
+	modelTag, err := names.ParseModelTag(tag)
+	if err != nil {
+		return errors.Annotate(err, "model tag")
+	}
+	if _, err := c.state.GetModel(modelTag); err != nil {
+		return errors.Annotate(err, "unable to read model")
+	}
+	hostedState, err := c.state.ForModel(modelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer hostedState.Close()
+
+	mig, err := hostedState.GetModelMigration()
+	if errors.IsNotFound(err) {
+		return errors.New("no migration in progress")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	phase, err := mig.Phase()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch {
+	case phase == migration.ABORT || phase == migration.ABORTDONE:
+		// Already aborted, so there is nothing more to do.
+		return nil
+	case phase.IsTerminal():
+		return errors.New("no migration in progress")
+	case !phase.CanTransitionTo(migration.ABORT):
+		return errors.Errorf("migration can not be aborted in phase %s", phase)
+	}
+	return errors.Trace(mig.SetPhase(migration.ABORT))
+}
+
+// AuditLog returns the entries in the controller's audit log that match
+// the filter, oldest first.
+func (c *ControllerAPIV3) AuditLog(filter params.AuditLogFilter) (params.AuditLogResults, error) {
+	stateFilter := state.AuditFilter{
+		From:  filter.From,
+		To:    filter.To,
+		Limit: filter.Limit,
+	}
+	if filter.User != "" {
+		if !names.IsValidUser(filter.User) {
+			return params.AuditLogResults{}, errors.NotValidf("user %q", filter.User)
+		}
+		stateFilter.User = names.NewUserTag(filter.User).Canonical()
+	}
+	if filter.ModelTag != "" {
+		modelTag, err := names.ParseModelTag(filter.ModelTag)
+		if err != nil {
+			return params.AuditLogResults{}, errors.Annotate(err, "model tag")
+		}
+		stateFilter.ModelUUID = modelTag.Id()
+	}
+	entries, err := c.state.AuditEntries(stateFilter)
+	if err != nil {
+		return params.AuditLogResults{}, errors.Trace(err)
+	}
+	results := params.AuditLogResults{
+		Entries: make([]params.AuditLogEntry, len(entries)),
+	}
+	for i, entry := range entries {
+		var modelTag string
+		if entry.ModelUUID != "" {
+			modelTag = names.NewModelTag(entry.ModelUUID).String()
+		}
+		results.Entries[i] = params.AuditLogEntry{
+			Time:     entry.Time,
+			ModelTag: modelTag,
+			User:     entry.User,
+			Facade:   entry.Facade,
+			Version:  entry.Version,
+			Method:   entry.Method,
+			Args:     entry.Args,
+			Error:    entry.Error,
+			Duration: entry.Duration,
+		}
+	}
+	return results, nil
+}
+
 func (c *ControllerAPI) environStatus(tag string) (params.ModelStatus, error) {
 	var status params.ModelStatus
 	modelTag, err := names.ParseModelTag(tag)
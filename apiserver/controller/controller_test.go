@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/apiserver/controller"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/migration"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
@@ -27,7 +28,7 @@ import (
 type controllerSuite struct {
 	jujutesting.JujuConnSuite
 
-	controller *controller.ControllerAPI
+	controller *controller.ControllerAPIV3
 	resources  *common.Resources
 	authorizer apiservertesting.FakeAuthorizer
 }
@@ -43,7 +44,7 @@ func (s *controllerSuite) SetUpTest(c *gc.C) {
 		Tag: s.AdminUserTag(c),
 	}
 
-	controller, err := controller.NewControllerAPI(s.State, s.resources, s.authorizer)
+	controller, err := controller.NewControllerAPIV3(s.State, s.resources, s.authorizer)
 	c.Assert(err, jc.ErrorIsNil)
 	s.controller = controller
 
@@ -69,6 +70,20 @@ func (s *controllerSuite) TestNewAPIRefusesNonAdmins(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 }
 
+func (s *controllerSuite) TestFacadeVersions(c *gc.C) {
+	factory, err := common.Facades.GetFactory("Controller", 2)
+	c.Assert(err, jc.ErrorIsNil)
+	api, err := factory(s.State, s.resources, s.authorizer, "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api, gc.FitsTypeOf, new(controller.ControllerAPI))
+
+	factory, err = common.Facades.GetFactory("Controller", 3)
+	c.Assert(err, jc.ErrorIsNil)
+	api, err = factory(s.State, s.resources, s.authorizer, "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api, gc.FitsTypeOf, new(controller.ControllerAPIV3))
+}
+
 func (s *controllerSuite) checkEnvironmentMatches(c *gc.C, env params.Model, expected *state.Model) {
 	c.Check(env.Name, gc.Equals, expected.Name())
 	c.Check(env.UUID, gc.Equals, expected.UUID())
@@ -382,7 +397,173 @@ func (s *controllerSuite) TestInitiateModelMigrationPartialFailure(c *gc.C) {
 	c.Check(out.Results[1].Error, gc.ErrorMatches, "unable to read model: .+")
 }
 
+func (s *controllerSuite) initiateMigration(c *gc.C, st *state.State) {
+	args := params.InitiateModelMigrationArgs{
+		Specs: []params.ModelMigrationSpec{{
+			ModelTag: st.ModelTag().String(),
+			TargetInfo: params.ModelMigrationTargetInfo{
+				ControllerTag: randomModelTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert",
+				AuthTag:       names.NewUserTag("admin").String(),
+				Password:      "secret",
+			},
+		}},
+	}
+	out, err := s.controller.InitiateModelMigration(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results[0].Error, gc.IsNil)
+}
+
+func (s *controllerSuite) abortMigration(c *gc.C, tag string) *params.Error {
+	out, err := s.controller.AbortModelMigration(params.Entities{
+		Entities: []params.Entity{{Tag: tag}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	return out.Results[0].Error
+}
+
+func (s *controllerSuite) TestAbortModelMigration(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	s.initiateMigration(c, st)
+
+	c.Assert(s.abortMigration(c, st.ModelTag().String()), gc.IsNil)
+
+	mig, err := st.GetModelMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	phase, err := mig.Phase()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(phase, gc.Equals, migration.ABORT)
+}
+
+func (s *controllerSuite) TestAbortModelMigrationRetry(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	s.initiateMigration(c, st)
+
+	c.Assert(s.abortMigration(c, st.ModelTag().String()), gc.IsNil)
+	c.Assert(s.abortMigration(c, st.ModelTag().String()), gc.IsNil)
+
+	// Once the abort has completed, retrying is still fine.
+	mig, err := st.GetModelMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(migration.ABORTDONE), jc.ErrorIsNil)
+	c.Assert(s.abortMigration(c, st.ModelTag().String()), gc.IsNil)
+}
+
+func (s *controllerSuite) TestAbortModelMigrationNoMigration(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	err := s.abortMigration(c, st.ModelTag().String())
+	c.Assert(err, gc.ErrorMatches, "no migration in progress")
+}
+
+func (s *controllerSuite) TestAbortModelMigrationAfterSuccess(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	s.initiateMigration(c, st)
+
+	mig, err := st.GetModelMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, phase := range []migration.Phase{
+		migration.READONLY,
+		migration.PRECHECK,
+		migration.IMPORT,
+		migration.VALIDATION,
+		migration.SUCCESS,
+	} {
+		c.Assert(mig.SetPhase(phase), jc.ErrorIsNil)
+	}
+
+	err = s.abortMigration(c, st.ModelTag().String())
+	c.Assert(err, gc.ErrorMatches, "migration can not be aborted in phase SUCCESS")
+}
+
+func (s *controllerSuite) TestAbortModelMigrationMissingModel(c *gc.C) {
+	err := s.abortMigration(c, randomModelTag())
+	c.Assert(err, gc.ErrorMatches, "unable to read model: .+")
+}
+
 func randomModelTag() string {
 	uuid := utils.MustNewUUID().String()
 	return names.NewModelTag(uuid).String()
 }
+
+func (s *controllerSuite) addAuditEntry(c *gc.C, t time.Time, user, modelUUID, method string) {
+	err := s.State.AddAuditEntry(state.AuditEntry{
+		Time:      t,
+		ModelUUID: modelUUID,
+		User:      user,
+		Facade:    "Client",
+		Version:   1,
+		Method:    method,
+		Args:      "{}",
+		Duration:  time.Millisecond,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *controllerSuite) TestAuditLog(c *gc.C) {
+	t0 := time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC)
+	modelUUID := s.State.ModelUUID()
+	s.addAuditEntry(c, t0, "admin@local", modelUUID, "FullStatus")
+	s.addAuditEntry(c, t0.Add(time.Minute), "bob@local", "", "ModelGet")
+
+	results, err := s.controller.AuditLog(params.AuditLogFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Entries, jc.DeepEquals, []params.AuditLogEntry{{
+		Time:     t0,
+		ModelTag: names.NewModelTag(modelUUID).String(),
+		User:     "admin@local",
+		Facade:   "Client",
+		Version:  1,
+		Method:   "FullStatus",
+		Args:     "{}",
+		Duration: time.Millisecond,
+	}, {
+		Time:     t0.Add(time.Minute),
+		User:     "bob@local",
+		Facade:   "Client",
+		Version:  1,
+		Method:   "ModelGet",
+		Args:     "{}",
+		Duration: time.Millisecond,
+	}})
+}
+
+func (s *controllerSuite) TestAuditLogFilter(c *gc.C) {
+	t0 := time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC)
+	modelUUID := s.State.ModelUUID()
+	s.addAuditEntry(c, t0, "admin@local", modelUUID, "FullStatus")
+	s.addAuditEntry(c, t0.Add(time.Minute), "bob@local", modelUUID, "ModelGet")
+	s.addAuditEntry(c, t0.Add(2*time.Minute), "bob@local", "", "ModelInfo")
+
+	methods := func(filter params.AuditLogFilter) []string {
+		results, err := s.controller.AuditLog(filter)
+		c.Assert(err, jc.ErrorIsNil)
+		var methods []string
+		for _, entry := range results.Entries {
+			methods = append(methods, entry.Method)
+		}
+		return methods
+	}
+	c.Check(methods(params.AuditLogFilter{User: "bob"}), jc.DeepEquals, []string{"ModelGet", "ModelInfo"})
+	c.Check(methods(params.AuditLogFilter{
+		ModelTag: names.NewModelTag(modelUUID).String(),
+	}), jc.DeepEquals, []string{"FullStatus", "ModelGet"})
+	c.Check(methods(params.AuditLogFilter{
+		From: t0.Add(30 * time.Second),
+		To:   t0.Add(90 * time.Second),
+	}), jc.DeepEquals, []string{"ModelGet"})
+	c.Check(methods(params.AuditLogFilter{Limit: 1}), jc.DeepEquals, []string{"ModelInfo"})
+}
+
+func (s *controllerSuite) TestAuditLogBadFilter(c *gc.C) {
+	_, err := s.controller.AuditLog(params.AuditLogFilter{User: "not a user!"})
+	c.Check(err, gc.ErrorMatches, `user "not a user!" not valid`)
+	_, err = s.controller.AuditLog(params.AuditLogFilter{ModelTag: "machine-0"})
+	c.Check(err, gc.ErrorMatches, `model tag: "machine-0" is not a valid model tag`)
+}
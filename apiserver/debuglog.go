@@ -8,8 +8,10 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strconv"
 	"syscall"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -67,6 +69,16 @@ func newDebugLogHandler(
 //   replay -> string - one of [true, false], if true, start the file from the start
 //   noTail -> string - one of [true, false], if true, existing logs are sent back,
 //      - but the command does not wait for new ones.
+//   startTime -> string - RFC3339 timestamp; only send lines logged at or after it
+//      - implies replay from that time; backlog is ignored
+//   endTime -> string - RFC3339 timestamp; only send lines logged at or before it
+//      - existing logs are sent back, but the command does not wait for new ones
+//   messageRegex -> string - only send lines whose message matches this
+//      regular expression. The pattern is evaluated by MongoDB, which
+//      uses PCRE; it must also be valid Go (RE2) syntax, which excludes
+//      PCRE-only features such as lookaround and backreferences
+//   format -> string - one of [text, json]; json sends each line as a
+//      JSON-encoded params.DebugLogRecord
 func (h *debugLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	server := websocket.Server{
 		Handler: func(conn *websocket.Conn) {
@@ -150,6 +162,10 @@ type debugLogParams struct {
 	excludeEntity []string
 	includeModule []string
 	excludeModule []string
+	startTime     time.Time
+	endTime       time.Time
+	messageRegex  string
+	jsonFormat    bool
 }
 
 func readDebugLogParams(queryMap url.Values) (*debugLogParams, error) {
@@ -197,6 +213,40 @@ func readDebugLogParams(queryMap url.Values) (*debugLogParams, error) {
 		params.filterLevel = level
 	}
 
+	if value := queryMap.Get("startTime"); value != "" {
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, errors.Errorf("startTime value %q is not a valid RFC3339 time", value)
+		}
+		params.startTime = t
+	}
+
+	if value := queryMap.Get("endTime"); value != "" {
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, errors.Errorf("endTime value %q is not a valid RFC3339 time", value)
+		}
+		params.endTime = t
+	}
+
+	if value := queryMap.Get("messageRegex"); value != "" {
+		// MongoDB evaluates the pattern as PCRE. RE2 syntax is
+		// essentially a subset of PCRE, so accepting only patterns
+		// that compile here keeps to what both understand.
+		if _, err := regexp.Compile(value); err != nil {
+			return nil, errors.Errorf("messageRegex value %q is not a valid regular expression", value)
+		}
+		params.messageRegex = value
+	}
+
+	switch value := queryMap.Get("format"); value {
+	case "", "text":
+	case "json":
+		params.jsonFormat = true
+	default:
+		return nil, errors.Errorf("format value %q is not one of %q, %q", value, "text", "json")
+	}
+
 	params.includeEntity = queryMap["includeEntity"]
 	params.excludeEntity = queryMap["excludeEntity"]
 	params.includeModule = queryMap["includeModule"]
@@ -4,12 +4,14 @@
 package apiserver
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state"
 )
 
@@ -43,8 +45,16 @@ func handleDebugLogDBRequest(
 				return errors.Annotate(tailer.Err(), "tailer stopped")
 			}
 
-			line := formatLogRecord(rec)
-			_, err := socket.Write([]byte(line))
+			var line []byte
+			if reqParams.jsonFormat {
+				line, err = formatLogRecordJSON(rec)
+				if err != nil {
+					return errors.Annotate(err, "cannot marshal log record")
+				}
+			} else {
+				line = []byte(formatLogRecord(rec))
+			}
+			_, err = socket.Write(line)
 			if err != nil {
 				return errors.Annotate(err, "sending failed")
 			}
@@ -59,6 +69,8 @@ func handleDebugLogDBRequest(
 
 func makeLogTailerParams(reqParams *debugLogParams) *state.LogTailerParams {
 	params := &state.LogTailerParams{
+		StartTime:     reqParams.startTime,
+		EndTime:       reqParams.endTime,
 		MinLevel:      reqParams.filterLevel,
 		NoTail:        reqParams.noTail,
 		InitialLines:  int(reqParams.backlog),
@@ -66,8 +78,9 @@ func makeLogTailerParams(reqParams *debugLogParams) *state.LogTailerParams {
 		ExcludeEntity: reqParams.excludeEntity,
 		IncludeModule: reqParams.includeModule,
 		ExcludeModule: reqParams.excludeModule,
+		MessageRegex:  reqParams.messageRegex,
 	}
-	if reqParams.fromTheStart {
+	if reqParams.fromTheStart || !reqParams.startTime.IsZero() {
 		params.InitialLines = 0
 	}
 	return params
@@ -84,6 +97,23 @@ func formatLogRecord(r *state.LogRecord) string {
 	)
 }
 
+// formatLogRecordJSON returns the log record as a line of JSON.
+func formatLogRecordJSON(r *state.LogRecord) ([]byte, error) {
+	data, err := json.Marshal(params.DebugLogRecord{
+		ModelUUID: r.ModelUUID,
+		Entity:    r.Entity,
+		Timestamp: r.Time.In(time.UTC),
+		Level:     r.Level.String(),
+		Module:    r.Module,
+		Location:  r.Location,
+		Message:   r.Message,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(data, '\n'), nil
+}
+
 func formatTime(t time.Time) string {
 	return t.In(time.UTC).Format("2006-01-02 15:04:05")
 }
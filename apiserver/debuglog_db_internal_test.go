@@ -43,9 +43,9 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 	s.PatchValue(&newLogTailer, func(_ state.LoggingState, params *state.LogTailerParams) (state.LogTailer, error) {
 		called = true
 
-		// Start time will be used once the client is extended to send
-		// time range arguments.
 		c.Assert(params.StartTime.IsZero(), jc.IsTrue)
+		c.Assert(params.EndTime.IsZero(), jc.IsTrue)
+		c.Assert(params.MessageRegex, gc.Equals, "")
 		c.Assert(params.NoTail, jc.IsTrue)
 		c.Assert(params.MinLevel, gc.Equals, loggo.INFO)
 		c.Assert(params.InitialLines, gc.Equals, 11)
@@ -87,6 +87,37 @@ func (s *debugLogDBIntSuite) TestParamConversionReplay(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *debugLogDBIntSuite) TestParamConversionTimeRange(c *gc.C) {
+	startTime := time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC)
+	endTime := time.Date(2016, 6, 1, 13, 0, 0, 0, time.UTC)
+	reqParams := &debugLogParams{
+		backlog:      123,
+		startTime:    startTime,
+		endTime:      endTime,
+		messageRegex: "^hook failed",
+	}
+
+	called := false
+	s.PatchValue(&newLogTailer, func(_ state.LoggingState, params *state.LogTailerParams) (state.LogTailer, error) {
+		called = true
+
+		// A start time replays from that time, so the backlog
+		// is ignored.
+		c.Assert(params.StartTime, gc.Equals, startTime)
+		c.Assert(params.EndTime, gc.Equals, endTime)
+		c.Assert(params.InitialLines, gc.Equals, 0)
+		c.Assert(params.MessageRegex, gc.Equals, "^hook failed")
+
+		return newFakeLogTailer(), nil
+	})
+
+	stop := make(chan struct{})
+	close(stop) // Stop the request immediately.
+	err := handleDebugLogDBRequest(nil, reqParams, s.sock, stop)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
 func (s *debugLogDBIntSuite) TestFullRequest(c *gc.C) {
 	// Set up a fake log tailer with a 2 log records ready to send.
 	tailer := newFakeLogTailer()
@@ -124,6 +155,34 @@ func (s *debugLogDBIntSuite) TestFullRequest(c *gc.C) {
 	s.assertStops(c, done, tailer)
 }
 
+func (s *debugLogDBIntSuite) TestFullRequestJSON(c *gc.C) {
+	tailer := newFakeLogTailer()
+	tailer.logsCh <- &state.LogRecord{
+		Time:      time.Date(2015, 6, 19, 15, 34, 37, 0, time.UTC),
+		Entity:    "machine-99",
+		Module:    "some.where",
+		Location:  "code.go:42",
+		Level:     loggo.INFO,
+		Message:   "stuff happened",
+		ModelUUID: "model-uuid",
+	}
+	s.PatchValue(&newLogTailer, func(_ state.LoggingState, params *state.LogTailerParams) (state.LogTailer, error) {
+		return tailer, nil
+	})
+
+	stop := make(chan struct{})
+	done := s.runRequest(&debugLogParams{jsonFormat: true}, stop)
+
+	s.assertOutput(c, []string{
+		"ok", // sendOk() call needs to happen first.
+		`{"model-uuid":"model-uuid","entity":"machine-99","timestamp":"2015-06-19T15:34:37Z",` +
+			`"level":"INFO","module":"some.where","location":"code.go:42","message":"stuff happened"}` + "\n",
+	})
+
+	close(stop)
+	s.assertStops(c, done, tailer)
+}
+
 func (s *debugLogDBIntSuite) TestRequestStopsWhenTailerStops(c *gc.C) {
 	tailer := newFakeLogTailer()
 	s.PatchValue(&newLogTailer, func(_ state.LoggingState, params *state.LogTailerParams) (state.LogTailer, error) {
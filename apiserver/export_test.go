@@ -29,6 +29,7 @@ var (
 	BZMimeType                   = bzMimeType
 	JSMimeType                   = jsMimeType
 	SpritePath                   = spritePath
+	IsAudited                    = isAudited
 )
 
 func ServerMacaroon(srv *Server) (*macaroon.Macaroon, error) {
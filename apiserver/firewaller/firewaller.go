@@ -17,6 +17,7 @@ import (
 func init() {
 	// Version 0 is no longer supported.
 	common.RegisterStandardFacade("Firewaller", 2, NewFirewallerAPI)
+	common.RegisterStandardFacade("Firewaller", 3, NewFirewallerAPIV3)
 }
 
 // FirewallerAPI provides access to the Firewaller API facade.
@@ -37,6 +38,12 @@ type FirewallerAPI struct {
 	accessEnviron common.GetAuthFunc
 }
 
+// FirewallerAPIV3 provides access to version 3 of the Firewaller API
+// facade, which adds GetExposeSourceCIDRs.
+type FirewallerAPIV3 struct {
+	*FirewallerAPI
+}
+
 // NewFirewallerAPI creates a new server-side FirewallerAPI facade.
 func NewFirewallerAPI(
 	st *state.State,
@@ -107,6 +114,19 @@ func NewFirewallerAPI(
 	}, nil
 }
 
+// NewFirewallerAPIV3 creates a new server-side FirewallerAPIV3 facade.
+func NewFirewallerAPIV3(
+	st *state.State,
+	resources *common.Resources,
+	authorizer common.Authorizer,
+) (*FirewallerAPIV3, error) {
+	api, err := NewFirewallerAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &FirewallerAPIV3{api}, nil
+}
+
 // WatchOpenedPorts returns a new StringsWatcher for each given
 // environment tag.
 func (f *FirewallerAPI) WatchOpenedPorts(args params.Entities) (params.StringsWatchResults, error) {
@@ -279,6 +299,41 @@ func (f *FirewallerAPI) GetExposed(args params.Entities) (params.BoolResults, er
 	return result, nil
 }
 
+// GetExposeSourceCIDRs returns the networks from which the opened ports
+// of each given service may be reached while it is exposed.
+func (f *FirewallerAPIV3) GetExposeSourceCIDRs(args params.Entities) (params.ExposeSourceCIDRsResults, error) {
+	result := params.ExposeSourceCIDRsResults{
+		Results: make([]params.ExposeSourceCIDRsResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessService()
+	if err != nil {
+		return params.ExposeSourceCIDRsResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseServiceTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		service, err := f.getService(canAccess, tag)
+		if err == nil {
+			var sources network.ExposeSourceCIDRs
+			sources, err = service.ExposeSourceCIDRs()
+			if err == nil {
+				result.Results[i].Default = sources.Default
+				for portRange, sourceCIDRs := range sources.Ports {
+					if result.Results[i].Ports == nil {
+						result.Results[i].Ports = make(map[string][]string)
+					}
+					result.Results[i].Ports[portRange.String()] = sourceCIDRs
+				}
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // GetAssignedMachine returns the assigned machine tag (if any) for
 // each given unit.
 func (f *FirewallerAPI) GetAssignedMachine(args params.Entities) (params.StringResults, error) {
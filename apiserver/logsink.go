@@ -85,6 +85,7 @@ func (h *logSinkHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 				case <-h.ctxt.stop():
 					return
 				case m := <-logCh:
+					h.ctxt.srv.metrics.RecordLogRecord()
 					fileErr := h.logToFile(filePrefix, m)
 					if fileErr != nil {
 						logger.Errorf("logging to logsink.log failed: %v", fileErr)
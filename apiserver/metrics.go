@@ -0,0 +1,108 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"gopkg.in/mgo.v2"
+
+	"github.com/juju/juju/apiserver/apimetrics"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/state"
+)
+
+// metricsHandler serves the API server's metrics in the Prometheus
+// text exposition format. Only users with access to the controller
+// may fetch them.
+type metricsHandler struct {
+	ctxt httpContext
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *metricsHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "GET" {
+		sendError(w, errors.MethodNotAllowedf("unsupported method: %q", req.Method))
+		return
+	}
+	st, entity, err := h.ctxt.stateForRequestAuthenticatedUser(req)
+	if err != nil {
+		sendError(w, errors.Trace(err))
+		return
+	}
+	if err := checkControllerUser(st, entity.Tag().(names.UserTag)); err != nil {
+		sendError(w, errors.Trace(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", apimetrics.ContentType)
+	if err := h.ctxt.srv.metrics.Render(w); err != nil {
+		logger.Errorf("cannot write API metrics: %v", err)
+		return
+	}
+	if err := apimetrics.WriteMetrics(w, h.ctxt.srv.serverMetrics()); err != nil {
+		logger.Errorf("cannot write API metrics: %v", err)
+	}
+}
+
+// checkControllerUser returns common.ErrPerm unless the user has been
+// granted access to the controller, or is a controller administrator.
+func checkControllerUser(st *state.State, user names.UserTag) error {
+	access, err := st.ControllerAccess(user)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if access.Includes(state.ControllerLoginAccess) {
+		return nil
+	}
+	isAdmin, err := st.IsControllerAdministrator(user)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isAdmin {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// serverMetrics returns the metrics describing the API server's
+// connections, and its use of the database.
+func (srv *Server) serverMetrics() []apimetrics.Metric {
+	txnRuns, txnRetries := state.TransactionStats()
+	mgoStats := mgo.GetStats()
+	return []apimetrics.Metric{{
+		Name:  "juju_api_connections",
+		Type:  "gauge",
+		Help:  "Number of active API connections.",
+		Value: float64(atomic.LoadInt32(&srv.connections)),
+	}, {
+		Name:  "juju_txn_runs_total",
+		Type:  "counter",
+		Help:  "Number of database transactions run.",
+		Value: float64(txnRuns),
+	}, {
+		Name:  "juju_txn_retries_total",
+		Type:  "counter",
+		Help:  "Number of database transaction attempts retried after their assertions failed.",
+		Value: float64(txnRetries),
+	}, {
+		Name:  "juju_mongo_sockets_alive",
+		Type:  "gauge",
+		Help:  "Number of open sockets to the database.",
+		Value: float64(mgoStats.SocketsAlive),
+	}, {
+		Name:  "juju_mongo_sockets_in_use",
+		Type:  "gauge",
+		Help:  "Number of sockets to the database in use by sessions.",
+		Value: float64(mgoStats.SocketsInUse),
+	}, {
+		Name:  "juju_mongo_socket_refs",
+		Type:  "gauge",
+		Help:  "Number of session references to sockets to the database.",
+		Value: float64(mgoStats.SocketRefs),
+	}}
+}
@@ -0,0 +1,95 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"net/http"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type metricsSuite struct {
+	authHttpSuite
+}
+
+var _ = gc.Suite(&metricsSuite{})
+
+func (s *metricsSuite) metricsURL(c *gc.C) string {
+	return s.makeURL(c, "https", "/metrics", nil).String()
+}
+
+func (s *metricsSuite) TestRequiresAuth(c *gc.C) {
+	resp := s.sendRequest(c, httpRequestParams{method: "GET", url: s.metricsURL(c)})
+	assertResponse(c, resp, http.StatusUnauthorized, params.ContentTypeJSON)
+}
+
+func (s *metricsSuite) TestRequiresGET(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{method: "POST", url: s.metricsURL(c)})
+	body := assertResponse(c, resp, http.StatusMethodNotAllowed, params.ContentTypeJSON)
+	c.Check(string(body), jc.Contains, `unsupported method: \"POST\"`)
+}
+
+func (s *metricsSuite) TestRequiresControllerUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Password: "hunter2",
+		Access:   state.ModelReadAccess,
+	})
+	request := httpRequestParams{
+		tag:      user.Tag().String(),
+		password: "hunter2",
+		method:   "GET",
+		url:      s.metricsURL(c),
+	}
+	resp := s.sendRequest(c, request)
+	assertResponse(c, resp, http.StatusOK, "text/plain; version=0.0.4")
+
+	// Once the user's access to the controller is revoked,
+	// they may no longer fetch metrics.
+	err := s.State.RemoveControllerUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	resp = s.sendRequest(c, request)
+	body := assertResponse(c, resp, http.StatusUnauthorized, params.ContentTypeJSON)
+	c.Check(string(body), jc.Contains, "permission denied")
+}
+
+func (s *metricsSuite) TestMetrics(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{method: "GET", url: s.metricsURL(c)})
+	body := assertResponse(c, resp, http.StatusOK, "text/plain; version=0.0.4")
+	c.Check(string(body), gc.Matches, `(?s).*
+juju_api_requests_total\{facade="Admin",version="3",method="Login",error="false"\} [1-9]\d*
+.*`)
+	c.Check(string(body), gc.Matches, `(?s).*
+juju_api_request_duration_seconds_count\{facade="Admin",version="3",method="Login"\} [1-9]\d*
+.*`)
+	for _, name := range []string{
+		"juju_logsink_records_total",
+		"juju_api_connections",
+		"juju_txn_runs_total",
+		"juju_txn_retries_total",
+		"juju_mongo_sockets_alive",
+		"juju_mongo_sockets_in_use",
+		"juju_mongo_socket_refs",
+	} {
+		c.Check(string(body), gc.Matches, `(?s).*\n`+name+` -?\d+\n.*`)
+	}
+}
+
+func (s *metricsSuite) TestMetricsUnknownRequests(c *gc.C) {
+	err := s.APIState.APICall("NoSuchFacade", 1, "", "NoSuchMethod", nil, nil)
+	c.Assert(err, gc.ErrorMatches, `unknown object type "NoSuchFacade".*`)
+	err = s.APIState.APICall("Client", 1, "", "NoSuchMethod", nil, nil)
+	c.Assert(err, gc.ErrorMatches, `no such request - method Client\(1\)\.NoSuchMethod is not implemented.*`)
+
+	resp := s.authRequest(c, httpRequestParams{method: "GET", url: s.metricsURL(c)})
+	body := assertResponse(c, resp, http.StatusOK, "text/plain; version=0.0.4")
+	c.Check(string(body), gc.Matches, `(?s).*
+juju_api_requests_total\{facade="unknown",version="0",method="",error="true"\} [1-9]\d*
+.*`)
+	c.Check(string(body), gc.Not(jc.Contains), "NoSuch")
+}
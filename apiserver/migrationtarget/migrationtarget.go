@@ -69,6 +69,19 @@ func (api *API) Import(serialized params.SerializedModel) error {
 	return err
 }
 
+// ValidateImport checks whether a serialized model could be imported
+// into this controller. Nothing is written to the controller.
+func (api *API) ValidateImport(serialized params.SerializedModel) (params.ImportValidationResult, error) {
+	validation, err := migration.ValidateImport(api.state, serialized.Bytes)
+	if err != nil {
+		return params.ImportValidationResult{}, errors.Trace(err)
+	}
+	return params.ImportValidationResult{
+		Problems: validation.Problems,
+		Missing:  validation.Missing,
+	}, nil
+}
+
 func (api *API) getModel(args params.ModelArgs) (*state.Model, error) {
 	tag, err := names.ParseModelTag(args.ModelTag)
 	if err != nil {
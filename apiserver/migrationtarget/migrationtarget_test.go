@@ -97,6 +97,33 @@ func (s *Suite) TestImport(c *gc.C) {
 	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeImporting)
 }
 
+func (s *Suite) TestValidateImport(c *gc.C) {
+	api := s.mustNewAPI(c)
+	uuid, bytes := s.makeExportedModel(c)
+	result, err := api.ValidateImport(params.SerializedModel{Bytes: bytes})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Problems, gc.HasLen, 0)
+
+	// The model should not have been left behind.
+	_, err = s.State.GetModel(names.NewModelTag(uuid))
+	c.Assert(errors.IsNotFound(err), jc.IsTrue)
+}
+
+func (s *Suite) TestValidateImportConflict(c *gc.C) {
+	api := s.mustNewAPI(c)
+	tag := s.importModel(c, api)
+	_, bytes := s.makeExportedModel(c)
+
+	result, err := api.ValidateImport(params.SerializedModel{Bytes: bytes})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Problems, gc.HasLen, 1)
+	c.Assert(result.Problems[0], gc.Matches, `model "some-model" for .* already exists`)
+
+	// The existing model is untouched.
+	_, err = s.State.GetModel(tag)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *Suite) TestAbort(c *gc.C) {
 	api := s.mustNewAPI(c)
 	tag := s.importModel(c, api)
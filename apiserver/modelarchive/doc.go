@@ -0,0 +1,7 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelarchive defines the API facade used by the export-model
+// and import-model commands to write a model out to a file and read it
+// back in to a controller.
+package modelarchive
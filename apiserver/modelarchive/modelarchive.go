@@ -0,0 +1,140 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelarchive
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("ModelArchive", 1, NewAPI)
+}
+
+// API implements the API used to export a model to a serialized
+// description and to import such a description into a controller.
+//
+// Only the model description is handled here. Charms, tools and
+// resource blobs are not part of the serialized model.
+type API struct {
+	state      *state.State
+	authorizer common.Authorizer
+	resources  *common.Resources
+}
+
+// NewAPI returns a new API.
+func NewAPI(
+	st *state.State,
+	resources *common.Resources,
+	authorizer common.Authorizer,
+) (*API, error) {
+	if err := checkAuth(authorizer, st); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &API{
+		state:      st,
+		authorizer: authorizer,
+		resources:  resources,
+	}, nil
+}
+
+func checkAuth(authorizer common.Authorizer, st *state.State) error {
+	if !authorizer.AuthClient() {
+		return errors.Trace(common.ErrPerm)
+	}
+
+	// Type assertion is fine because AuthClient is true.
+	apiUser := authorizer.GetAuthTag().(names.UserTag)
+	if isAdmin, err := st.IsControllerAdministrator(apiUser); err != nil {
+		return errors.Trace(err)
+	} else if !isAdmin {
+		// The entire facade is only accessible to controller administrators.
+		return errors.Trace(common.ErrPerm)
+	}
+	return nil
+}
+
+// Export serializes the specified model.
+func (api *API) Export(args params.ModelArgs) (params.SerializedModel, error) {
+	var result params.SerializedModel
+	tag, err := names.ParseModelTag(args.ModelTag)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	st, err := api.state.ForModel(tag)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	defer st.Close()
+
+	bytes, err := migration.ExportModel(st)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Bytes = bytes
+	return result, nil
+}
+
+// Import takes a serialized model and recreates it in the controller.
+// Unlike a migration, there is no source controller to hand over from,
+// so the model is activated as soon as the charms and tools it uses
+// are available to it. Until then it is left in the importing
+// migration mode and an error naming the missing binaries is
+// returned; once they have been uploaded, Activate completes the
+// import.
+func (api *API) Import(serialized params.SerializedModel) error {
+	_, st, err := migration.ImportModel(api.state, serialized.Bytes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer st.Close()
+	return errors.Trace(activate(st))
+}
+
+// Activate marks an imported model as active, once all the charms
+// and tools it uses are available to it.
+func (api *API) Activate(args params.ModelArgs) error {
+	tag, err := names.ParseModelTag(args.ModelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	st, err := api.state.ForModel(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer st.Close()
+	model, err := st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if model.MigrationMode() != state.MigrationModeImporting {
+		return errors.New("model is not being imported")
+	}
+	return errors.Trace(activate(st))
+}
+
+func activate(st *state.State) error {
+	missing, err := migration.MissingBinaries(st)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(missing) > 0 {
+		return errors.Errorf(
+			"model %s left importing, missing %s",
+			st.ModelUUID(), strings.Join(missing, ", "),
+		)
+	}
+	model, err := st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(model.SetMigrationMode(state.MigrationModeActive))
+}
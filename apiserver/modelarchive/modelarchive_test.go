@@ -0,0 +1,193 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelarchive_test
+
+import (
+	"regexp"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/modelarchive"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/provider/dummy"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type Suite struct {
+	statetesting.StateSuite
+	resources  *common.Resources
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&Suite{})
+
+func (s *Suite) SetUpTest(c *gc.C) {
+	// Set up InitialConfig with a dummy provider configuration. This
+	// is required to allow model import test to work.
+	env, err := environs.Prepare(
+		modelcmd.BootstrapContext(testing.Context(c)),
+		jujuclienttesting.NewMemStore(),
+		environs.PrepareParams{
+			ControllerName: "dummycontroller",
+			BaseConfig:     dummy.SampleConfig(),
+			CloudName:      "dummy",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.InitialConfig = testing.CustomModelConfig(c, env.Config().AllAttrs())
+
+	// The call up to StateSuite's SetUpTest uses s.InitialConfig so
+	// it has to happen here.
+	s.StateSuite.SetUpTest(c)
+
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: s.Owner,
+	}
+}
+
+func (s *Suite) TestFacadeRegistered(c *gc.C) {
+	factory, err := common.Facades.GetFactory("ModelArchive", 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err := factory(s.State, s.resources, s.authorizer, "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api, gc.FitsTypeOf, new(modelarchive.API))
+}
+
+func (s *Suite) TestNotUser(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := s.newAPI()
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
+}
+
+func (s *Suite) TestNotControllerAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("jrandomuser")
+	_, err := s.newAPI()
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
+}
+
+func (s *Suite) TestExport(c *gc.C) {
+	api := s.mustNewAPI(c)
+	result, err := api.Export(params.ModelArgs{ModelTag: s.State.ModelTag().String()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := description.Deserialize(result.Bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Tag(), gc.Equals, s.State.ModelTag())
+}
+
+func (s *Suite) TestExportOtherModel(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	api := s.mustNewAPI(c)
+	result, err := api.Export(params.ModelArgs{ModelTag: st.ModelTag().String()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := description.Deserialize(result.Bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Tag(), gc.Equals, st.ModelTag())
+}
+
+func (s *Suite) TestExportNotATag(c *gc.C) {
+	api := s.mustNewAPI(c)
+	_, err := api.Export(params.ModelArgs{ModelTag: "not-a-tag"})
+	c.Assert(err, gc.ErrorMatches, `"not-a-tag" is not a valid tag`)
+}
+
+func (s *Suite) TestImport(c *gc.C) {
+	api := s.mustNewAPI(c)
+	uuid, bytes := s.makeExportedModel(c)
+	err := api.Import(params.SerializedModel{Bytes: bytes})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.GetModel(names.NewModelTag(uuid))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Name(), gc.Equals, "some-model")
+	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeActive)
+}
+
+func (s *Suite) TestImportExisting(c *gc.C) {
+	api := s.mustNewAPI(c)
+	bytes, err := api.Export(params.ModelArgs{ModelTag: s.State.ModelTag().String()})
+	c.Assert(err, jc.ErrorIsNil)
+	err = api.Import(bytes)
+	c.Assert(err, gc.ErrorMatches, ".*already exists")
+}
+
+func (s *Suite) TestImportMissingCharm(c *gc.C) {
+	service := s.Factory.MakeService(c, nil)
+	curl, _ := service.CharmURL()
+	api := s.mustNewAPI(c)
+	uuid, bytes := s.makeExportedModel(c)
+	err := api.Import(params.SerializedModel{Bytes: bytes})
+	c.Assert(err, gc.ErrorMatches, `model .* left importing, missing charm `+regexp.QuoteMeta(curl.String()))
+
+	st, err := s.State.ForModel(names.NewModelTag(uuid))
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeImporting)
+
+	args := params.ModelArgs{ModelTag: model.ModelTag().String()}
+	err = api.Activate(args)
+	c.Assert(err, gc.ErrorMatches, `model .* left importing, missing charm .*`)
+
+	factory.NewFactory(st).MakeCharm(c, &factory.CharmParams{URL: curl.String()})
+	err = api.Activate(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err = st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeActive)
+}
+
+func (s *Suite) TestActivateNotImporting(c *gc.C) {
+	api := s.mustNewAPI(c)
+	err := api.Activate(params.ModelArgs{ModelTag: s.State.ModelTag().String()})
+	c.Assert(err, gc.ErrorMatches, "model is not being imported")
+}
+
+func (s *Suite) newAPI() (*modelarchive.API, error) {
+	return modelarchive.NewAPI(s.State, s.resources, s.authorizer)
+}
+
+func (s *Suite) mustNewAPI(c *gc.C) *modelarchive.API {
+	api, err := s.newAPI()
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *Suite) makeExportedModel(c *gc.C) (string, []byte) {
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	newUUID := utils.MustNewUUID().String()
+	model.UpdateConfig(map[string]interface{}{
+		"name":    "some-model",
+		"uuid":    newUUID,
+		"ca-cert": "not really a cert",
+	})
+
+	bytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+	return newUUID, bytes
+}
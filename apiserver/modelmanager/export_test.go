@@ -13,3 +13,7 @@ func AuthCheck(c *gc.C, mm *ModelManagerAPI, user names.UserTag) bool {
 	mm.authCheck(user)
 	return mm.isAdmin
 }
+
+func SetLegacyAccess(mm *ModelManagerAPI) {
+	mm.legacyAccess = true
+}
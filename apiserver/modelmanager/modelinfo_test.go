@@ -39,6 +39,10 @@ func (s *modelInfoSuite) SetUpTest(c *gc.C) {
 	}
 	s.st = &mockState{
 		uuid: coretesting.ModelTag.Id(),
+		logUsage: state.LogUsage{
+			Records: 1234,
+			Bytes:   567890,
+		},
 	}
 	s.st.model = &mockModel{
 		owner: names.NewUserTag("bob@local"),
@@ -93,7 +97,7 @@ func (s *modelInfoSuite) TestModelInfo(c *gc.C) {
 		Users: []params.ModelUserInfo{{
 			UserName:       "admin",
 			LastConnection: &time.Time{},
-			Access:         params.ModelWriteAccess,
+			Access:         params.ModelAdminAccess,
 		}, {
 			UserName:       "bob@local",
 			DisplayName:    "Bob",
@@ -105,12 +109,17 @@ func (s *modelInfoSuite) TestModelInfo(c *gc.C) {
 			LastConnection: &time.Time{},
 			Access:         params.ModelReadAccess,
 		}},
+		LogUsage: &params.ModelLogUsage{
+			Records: 1234,
+			Bytes:   567890,
+		},
 	})
 	s.st.CheckCalls(c, []gitjujutesting.StubCall{
 		{"IsControllerAdministrator", []interface{}{names.NewUserTag("admin@local")}},
 		{"ModelUUID", nil},
 		{"ForModel", []interface{}{names.NewModelTag(s.st.model.cfg.UUID())}},
 		{"Model", nil},
+		{"LogUsage", nil},
 		{"Close", nil},
 	})
 	s.st.model.CheckCalls(c, []gitjujutesting.StubCall{
@@ -122,6 +131,15 @@ func (s *modelInfoSuite) TestModelInfo(c *gc.C) {
 	})
 }
 
+func (s *modelInfoSuite) TestModelInfoLegacyAccess(c *gc.C) {
+	modelmanager.SetLegacyAccess(s.modelmanager)
+	info := s.getModelInfo(c)
+	c.Assert(info.Users, gc.HasLen, 3)
+	c.Assert(info.Users[0].UserName, gc.Equals, "admin")
+	c.Assert(info.Users[0].Access, gc.Equals, params.ModelWriteAccess)
+	c.Assert(info.Users[1].Access, gc.Equals, params.ModelReadAccess)
+}
+
 func (s *modelInfoSuite) TestModelInfoOwner(c *gc.C) {
 	s.setAPIUser(c, names.NewUserTag("bob@local"))
 	info := s.getModelInfo(c)
@@ -133,6 +151,7 @@ func (s *modelInfoSuite) TestModelInfoNonOwner(c *gc.C) {
 	info := s.getModelInfo(c)
 	c.Assert(info.Users, gc.HasLen, 1)
 	c.Assert(info.Users[0].UserName, gc.Equals, "charlotte@local")
+	c.Assert(info.LogUsage, gc.IsNil)
 }
 
 func (s *modelInfoSuite) getModelInfo(c *gc.C) params.ModelInfo {
@@ -167,6 +186,15 @@ func (s *modelInfoSuite) TestModelInfoErrorModelUsers(c *gc.C) {
 	s.testModelInfoError(c, coretesting.ModelTag.String(), `no users for you`)
 }
 
+func (s *modelInfoSuite) TestModelInfoErrorLogUsage(c *gc.C) {
+	s.st.SetErrors(
+		nil, // ForModel
+		nil, // Model
+		errors.New("no logs for you"),
+	)
+	s.testModelInfoError(c, coretesting.ModelTag.String(), `cannot get log usage: no logs for you`)
+}
+
 func (s *modelInfoSuite) TestModelInfoErrorNoModelUsers(c *gc.C) {
 	s.st.model.users = nil
 	s.testModelInfoError(c, coretesting.ModelTag.String(), `permission denied`)
@@ -194,10 +222,11 @@ type mockState struct {
 	common.ModelConfigGetter
 	common.ToolsStorageGetter
 
-	uuid  string
-	model *mockModel
-	owner names.UserTag
-	users []*state.ModelUser
+	uuid     string
+	model    *mockModel
+	owner    names.UserTag
+	users    []*state.ModelUser
+	logUsage state.LogUsage
 }
 
 func (st *mockState) ModelUUID() string {
@@ -215,6 +244,11 @@ func (st *mockState) IsControllerAdministrator(user names.UserTag) (bool, error)
 	return user.Canonical() == "admin@local", st.NextErr()
 }
 
+func (st *mockState) ControllerAccess(user names.UserTag) (state.ControllerAccess, error) {
+	st.MethodCall(st, "ControllerAccess", user)
+	return state.ControllerLoginAccess, st.NextErr()
+}
+
 func (st *mockState) NewModel(args state.ModelArgs) (*state.Model, *state.State, error) {
 	st.MethodCall(st, "NewModel", args)
 	return nil, nil, st.NextErr()
@@ -235,6 +269,11 @@ func (st *mockState) Model() (modelmanager.Model, error) {
 	return st.model, st.NextErr()
 }
 
+func (st *mockState) LogUsage() (state.LogUsage, error) {
+	st.MethodCall(st, "LogUsage")
+	return st.logUsage, st.NextErr()
+}
+
 func (st *mockState) Close() error {
 	st.MethodCall(st, "Close")
 	return st.NextErr()
@@ -28,7 +28,8 @@ import (
 var logger = loggo.GetLogger("juju.apiserver.modelmanager")
 
 func init() {
-	common.RegisterStandardFacade("ModelManager", 2, newFacade)
+	common.RegisterStandardFacade("ModelManager", 2, newFacadeV2)
+	common.RegisterStandardFacade("ModelManager", 3, newFacade)
 }
 
 // ModelManager defines the methods on the modelmanager API endpoint.
@@ -46,6 +47,12 @@ type ModelManagerAPI struct {
 	toolsFinder *common.ToolsFinder
 	apiUser     names.UserTag
 	isAdmin     bool
+
+	// legacyAccess is set for version 2 of the facade, which
+	// predates the separate "admin" access level: model admins are
+	// reported as "write", and granting or revoking "write" acts on
+	// admin access.
+	legacyAccess bool
 }
 
 var _ ModelManager = (*ModelManagerAPI)(nil)
@@ -54,6 +61,15 @@ func newFacade(st *state.State, resources *common.Resources, auth common.Authori
 	return NewModelManagerAPI(NewStateBackend(st), auth)
 }
 
+func newFacadeV2(st *state.State, resources *common.Resources, auth common.Authorizer) (*ModelManagerAPI, error) {
+	api, err := NewModelManagerAPI(NewStateBackend(st), auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	api.legacyAccess = true
+	return api, nil
+}
+
 // NewModelManagerAPI creates a new api server endpoint for managing
 // models.
 func NewModelManagerAPI(st Backend, authorizer common.Authorizer) (*ModelManagerAPI, error) {
@@ -96,6 +112,22 @@ func (m *ModelManagerAPI) authCheck(user names.UserTag) error {
 	return common.ErrPerm
 }
 
+// addModelCheck checks if the user is allowed to add models to the
+// controller.
+func (m *ModelManagerAPI) addModelCheck() error {
+	if m.isAdmin {
+		return nil
+	}
+	access, err := m.state.ControllerAccess(m.apiUser)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !access.Includes(state.ControllerAddModelAccess) {
+		return common.ErrPerm
+	}
+	return nil
+}
+
 // ConfigSource describes a type that is able to provide config.
 // Abstracted primarily for testing.
 type ConfigSource interface {
@@ -200,9 +232,12 @@ func (mm *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Mode
 		return result, errors.Trace(err)
 	}
 
-	// Any user is able to create themselves an model (until real fine
-	// grain permissions are available), and admins (the creator of the state
-	// server model) are able to create models for other people.
+	// Users with add-model access to the controller are able to create
+	// themselves a model, and admins (the creator of the controller model,
+	// or controller superusers) are able to create models for other people.
+	if err := mm.addModelCheck(); err != nil {
+		return result, errors.Trace(err)
+	}
 	err = mm.authCheck(ownerTag)
 	if err != nil {
 		return result, errors.Trace(err)
@@ -337,6 +372,9 @@ func (m *ModelManagerAPI) ModelInfo(args params.Entities) (params.ModelInfoResul
 			if err != nil {
 				return params.ModelInfo{}, errors.Trace(err)
 			}
+			if m.legacyAccess && userInfo.Access == params.ModelAdminAccess {
+				userInfo.Access = params.ModelWriteAccess
+			}
 			info.Users = append(info.Users, userInfo)
 		}
 
@@ -346,6 +384,17 @@ func (m *ModelManagerAPI) ModelInfo(args params.Entities) (params.ModelInfoResul
 			return params.ModelInfo{}, common.ErrPerm
 		}
 
+		if authorizedOwner {
+			usage, err := st.LogUsage()
+			if err != nil {
+				return params.ModelInfo{}, errors.Annotate(err, "cannot get log usage")
+			}
+			info.LogUsage = &params.ModelLogUsage{
+				Records: usage.Records,
+				Bytes:   usage.Bytes,
+			}
+		}
+
 		return info, nil
 	}
 
@@ -376,6 +425,17 @@ func (m *ModelManagerAPI) ModifyModelAccess(args params.ModifyModelAccessRequest
 			result.Results[i].Error = common.ServerError(err)
 			continue
 		}
+		if m.legacyAccess {
+			switch modelAccess {
+			case permission.ModelWriteAccess:
+				modelAccess = permission.ModelAdminAccess
+			case permission.ModelAdminAccess:
+				err := errors.Errorf("invalid model access permission %q", arg.Access)
+				err = errors.Annotate(err, "could not modify model access")
+				result.Results[i].Error = common.ServerError(err)
+				continue
+			}
+		}
 
 		targetUserTag, err := names.ParseUserTag(arg.UserTag)
 		if err != nil {
@@ -402,26 +462,26 @@ func resolveStateAccess(access permission.ModelAccess) (state.ModelAccess, error
 	case permission.ModelReadAccess:
 		return state.ModelReadAccess, nil
 	case permission.ModelWriteAccess:
-		// TODO: Initially, we'll map "write" access to admin-level access.
-		// Post Juju-2.0, support for more nuanced access will be added to the
-		// permission business logic and state model.
+		return state.ModelWriteAccess, nil
+	case permission.ModelAdminAccess:
 		return state.ModelAdminAccess, nil
 	}
 	logger.Errorf("invalid access permission: %+v", access)
 	return fail, errors.Errorf("invalid access permission")
 }
 
+// accessLevels orders the model access types from least to most
+// permissive.
+var accessLevels = map[state.ModelAccess]int{
+	state.ModelReadAccess:  1,
+	state.ModelWriteAccess: 2,
+	state.ModelAdminAccess: 3,
+}
+
 // isGreaterAccess returns whether the new access provides more permissions
 // than the current access.
-// TODO(cmars): If/when more access types are implemented in state,
-//   the implementation of this function will certainly need to change, and it
-//   should be abstracted away to juju/permission as pure business logic
-//   instead of operating on state values.
 func isGreaterAccess(currentAccess, newAccess state.ModelAccess) bool {
-	if currentAccess == state.ModelReadAccess && newAccess == state.ModelAdminAccess {
-		return true
-	}
-	return false
+	return accessLevels[newAccess] > accessLevels[currentAccess]
 }
 
 func userAuthorizedToChangeAccess(st Backend, userIsAdmin bool, userTag names.UserTag) error {
@@ -444,7 +504,7 @@ func userAuthorizedToChangeAccess(st Backend, userIsAdmin bool, userTag names.Us
 		}
 		return errors.Annotate(err, "could not retrieve user")
 	}
-	if currentUser.Access() != state.ModelAdminAccess {
+	if !currentUser.IsAdmin() {
 		return common.ErrPerm
 	}
 	return nil
@@ -495,21 +555,21 @@ func ChangeModelAccess(accessor Backend, modelTag names.ModelTag, apiUser, targe
 		return errors.Annotate(err, "could not grant model access")
 
 	case params.RevokeModelAccess:
-		if stateAccess == state.ModelReadAccess {
+		switch stateAccess {
+		case state.ModelReadAccess:
 			// Revoking read access removes all access.
 			err := st.RemoveModelUser(targetUserTag)
 			return errors.Annotate(err, "could not revoke model access")
 
-		} else if stateAccess == state.ModelAdminAccess {
-			// Revoking admin access sets read-only.
-			modelUser, err := st.ModelUser(targetUserTag)
-			if err != nil {
-				return errors.Annotate(err, "could not look up model access for user")
-			}
-			err = modelUser.SetAccess(state.ModelReadAccess)
-			return errors.Annotate(err, "could not set model access to read-only")
+		case state.ModelWriteAccess:
+			// Revoking write access sets read-only.
+			return setModelUserAccess(st, targetUserTag, stateAccess, state.ModelReadAccess)
 
-		} else {
+		case state.ModelAdminAccess:
+			// Revoking admin access leaves write access.
+			return setModelUserAccess(st, targetUserTag, stateAccess, state.ModelWriteAccess)
+
+		default:
 			return errors.Errorf("don't know how to revoke %q access", stateAccess)
 		}
 
@@ -518,6 +578,20 @@ func ChangeModelAccess(accessor Backend, modelTag names.ModelTag, apiUser, targe
 	}
 }
 
+// setModelUserAccess reduces the target user's access to the model to
+// newAccess, provided the user currently has at least the revoked access.
+func setModelUserAccess(st Backend, targetUserTag names.UserTag, revokedAccess, newAccess state.ModelAccess) error {
+	modelUser, err := st.ModelUser(targetUserTag)
+	if err != nil {
+		return errors.Annotate(err, "could not look up model access for user")
+	}
+	if isGreaterAccess(modelUser.Access(), revokedAccess) {
+		return errors.Errorf("user does not have %q access", revokedAccess)
+	}
+	err = modelUser.SetAccess(newAccess)
+	return errors.Annotatef(err, "could not set model access to %q", newAccess)
+}
+
 // FromModelAccessParam returns the logical model access type from the API wireformat type.
 func FromModelAccessParam(paramAccess params.ModelAccessPermission) (permission.ModelAccess, error) {
 	var fail permission.ModelAccess
@@ -526,6 +600,8 @@ func FromModelAccessParam(paramAccess params.ModelAccessPermission) (permission.
 		return permission.ModelReadAccess, nil
 	case params.ModelWriteAccess:
 		return permission.ModelWriteAccess, nil
+	case params.ModelAdminAccess:
+		return permission.ModelAdminAccess, nil
 	}
 	return fail, errors.Errorf("invalid model access permission %q", paramAccess)
 }
@@ -99,8 +99,18 @@ func (s *modelManagerSuite) createArgsForVersion(c *gc.C, owner names.UserTag, v
 	return params
 }
 
+func (s *modelManagerSuite) grantAddModel(c *gc.C, user names.UserTag) {
+	_, err := s.State.AddControllerUser(state.ControllerUserSpec{
+		User:      user,
+		CreatedBy: s.AdminUserTag(c),
+		Access:    state.ControllerAddModelAccess,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *modelManagerSuite) TestUserCanCreateModel(c *gc.C) {
 	owner := names.NewUserTag("external@remote")
+	s.grantAddModel(c, owner)
 	s.setAPIUser(c, owner)
 	model, err := s.modelmanager.CreateModel(s.createArgs(c, owner))
 	c.Assert(err, jc.ErrorIsNil)
@@ -108,6 +118,34 @@ func (s *modelManagerSuite) TestUserCanCreateModel(c *gc.C) {
 	c.Assert(model.Name, gc.Equals, "test-model")
 }
 
+func (s *modelManagerSuite) TestUserWithoutAddModelCannotCreateModel(c *gc.C) {
+	owner := names.NewUserTag("external@remote")
+	s.setAPIUser(c, owner)
+	_, err := s.modelmanager.CreateModel(s.createArgs(c, owner))
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelManagerSuite) TestLocalUserWithLoginCannotCreateModel(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "foobar", NoModelUser: true})
+	s.setAPIUser(c, user.UserTag())
+	_, err := s.modelmanager.CreateModel(s.createArgs(c, user.UserTag()))
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelManagerSuite) TestSuperuserCanCreateModelForSomeoneElse(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "foobar", NoModelUser: true})
+	controllerUser, err := s.State.ControllerUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = controllerUser.SetAccess(state.ControllerSuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.setAPIUser(c, user.UserTag())
+	owner := names.NewUserTag("external@remote")
+	model, err := s.modelmanager.CreateModel(s.createArgs(c, owner))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.OwnerTag, gc.Equals, owner.String())
+}
+
 func (s *modelManagerSuite) TestAdminCanCreateModelForSomeoneElse(c *gc.C) {
 	s.setAPIUser(c, s.AdminUserTag(c))
 	owner := names.NewUserTag("external@remote")
@@ -129,6 +167,7 @@ func (s *modelManagerSuite) TestAdminCanCreateModelForSomeoneElse(c *gc.C) {
 }
 
 func (s *modelManagerSuite) TestNonAdminCannotCreateModelForSomeoneElse(c *gc.C) {
+	s.grantAddModel(c, names.NewUserTag("non-admin@remote"))
 	s.setAPIUser(c, names.NewUserTag("non-admin@remote"))
 	owner := names.NewUserTag("external@remote")
 	_, err := s.modelmanager.CreateModel(s.createArgs(c, owner))
@@ -174,6 +213,7 @@ func (s *modelManagerSuite) TestCreateModelValidatesConfig(c *gc.C) {
 
 func (s *modelManagerSuite) TestCreateModelBadConfig(c *gc.C) {
 	owner := names.NewUserTag("external@remote")
+	s.grantAddModel(c, owner)
 	s.setAPIUser(c, owner)
 	for i, test := range []struct {
 		key      string
@@ -375,6 +415,42 @@ func (s *modelManagerSuite) TestRevokeAdminLeavesReadAccess(c *gc.C) {
 	c.Assert(modelUser.ReadOnly(), jc.IsTrue)
 }
 
+func (s *modelManagerSuite) TestRevokeAdminLeavesWriteAccess(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	user := s.Factory.MakeModelUser(c, &factory.ModelUserParams{Access: state.ModelAdminAccess})
+
+	err := s.revoke(c, user.UserTag(), params.ModelAdminAccess, user.ModelTag())
+	c.Assert(err, gc.IsNil)
+
+	modelUser, err := s.State.ModelUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Access(), gc.Equals, state.ModelWriteAccess)
+}
+
+func (s *modelManagerSuite) TestRevokeWriteLeavesReadAccess(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	user := s.Factory.MakeModelUser(c, &factory.ModelUserParams{Access: state.ModelWriteAccess})
+
+	err := s.revoke(c, user.UserTag(), params.ModelWriteAccess, user.ModelTag())
+	c.Assert(err, gc.IsNil)
+
+	modelUser, err := s.State.ModelUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Access(), gc.Equals, state.ModelReadAccess)
+}
+
+func (s *modelManagerSuite) TestRevokeAdminFromWriteUserFails(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	user := s.Factory.MakeModelUser(c, &factory.ModelUserParams{Access: state.ModelWriteAccess})
+
+	err := s.revoke(c, user.UserTag(), params.ModelAdminAccess, user.ModelTag())
+	c.Assert(err, gc.ErrorMatches, `user does not have "admin" access`)
+
+	modelUser, err := s.State.ModelUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Access(), gc.Equals, state.ModelWriteAccess)
+}
+
 func (s *modelManagerSuite) TestRevokeReadRemovesModelUser(c *gc.C) {
 	s.setAPIUser(c, s.AdminUserTag(c))
 	user := s.Factory.MakeModelUser(c, nil)
@@ -477,9 +553,46 @@ func (s *modelManagerSuite) TestGrantModelIncreaseAccess(c *gc.C) {
 	err := s.grant(c, user.UserTag(), params.ModelWriteAccess, st.ModelTag())
 	c.Assert(err, jc.ErrorIsNil)
 
+	modelUser, err := st.ModelUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Access(), gc.Equals, state.ModelWriteAccess)
+
+	err = s.grant(c, user.UserTag(), params.ModelAdminAccess, st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	modelUser, err = st.ModelUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Access(), gc.Equals, state.ModelAdminAccess)
+}
+
+func (s *modelManagerSuite) TestGrantModelWriteAccessLegacy(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	modelmanager.SetLegacyAccess(s.modelmanager)
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	stFactory := factory.NewFactory(st)
+	user := stFactory.MakeModelUser(c, &factory.ModelUserParams{Access: state.ModelReadAccess})
+
+	err := s.grant(c, user.UserTag(), params.ModelWriteAccess, st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+
 	modelUser, err := st.ModelUser(user.UserTag())
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(modelUser.Access(), gc.Equals, state.ModelAdminAccess)
+
+	err = s.grant(c, user.UserTag(), params.ModelAdminAccess, st.ModelTag())
+	c.Assert(err, gc.ErrorMatches, `could not modify model access: invalid model access permission "admin"`)
+}
+
+func (s *modelManagerSuite) TestGrantModelLesserAccessFails(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	stFactory := factory.NewFactory(st)
+	user := stFactory.MakeModelUser(c, &factory.ModelUserParams{Access: state.ModelAdminAccess})
+
+	err := s.grant(c, user.UserTag(), params.ModelWriteAccess, st.ModelTag())
+	c.Assert(err, gc.ErrorMatches, `user already has "admin" access`)
 }
 
 func (s *modelManagerSuite) TestGrantToModelNoAccess(c *gc.C) {
@@ -513,6 +626,21 @@ func (s *modelManagerSuite) TestGrantToModelWriteAccess(c *gc.C) {
 	apiUser := names.NewUserTag("bob@remote")
 	s.setAPIUser(c, apiUser)
 
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	stFactory := factory.NewFactory(st)
+	stFactory.MakeModelUser(c, &factory.ModelUserParams{
+		User: apiUser.Canonical(), Access: state.ModelWriteAccess})
+
+	other := names.NewUserTag("other@remote")
+	err := s.grant(c, other, params.ModelReadAccess, st.ModelTag())
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelManagerSuite) TestGrantToModelAdminAccess(c *gc.C) {
+	apiUser := names.NewUserTag("bob@remote")
+	s.setAPIUser(c, apiUser)
+
 	st := s.Factory.MakeModel(c, nil)
 	defer st.Close()
 	stFactory := factory.NewFactory(st)
@@ -20,6 +20,7 @@ type Backend interface {
 	ModelUUID() string
 	ModelsForUser(names.UserTag) ([]*state.UserModel, error)
 	IsControllerAdministrator(user names.UserTag) (bool, error)
+	ControllerAccess(user names.UserTag) (state.ControllerAccess, error)
 	NewModel(state.ModelArgs) (*state.Model, *state.State, error)
 	ControllerModel() (*state.Model, error)
 	ForModel(tag names.ModelTag) (Backend, error)
@@ -27,6 +28,7 @@ type Backend interface {
 	AddModelUser(state.ModelUserSpec) (*state.ModelUser, error)
 	RemoveModelUser(names.UserTag) error
 	ModelUser(names.UserTag) (*state.ModelUser, error)
+	LogUsage() (state.LogUsage, error)
 	Close() error
 }
 
@@ -62,6 +64,10 @@ func (st stateShim) Model() (Model, error) {
 	return modelShim{m}, nil
 }
 
+func (st stateShim) LogUsage() (state.LogUsage, error) {
+	return state.ModelLogUsage(st.State)
+}
+
 type modelShim struct {
 	*state.Model
 }
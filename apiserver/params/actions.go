@@ -30,6 +30,10 @@ const (
 	// ActionRunning is the status of an Action that has been started but
 	// not completed yet.
 	ActionRunning string = "running"
+
+	// ActionAborting is the status of a running Action that has been
+	// cancelled, but not yet stopped by its receiver.
+	ActionAborting string = "aborting"
 )
 
 // Actions is a slice of Action for bulk requests.
@@ -43,6 +47,15 @@ type Action struct {
 	Receiver   string                 `json:"receiver"`
 	Name       string                 `json:"name"`
 	Parameters map[string]interface{} `json:"parameters,omitempty"`
+
+	// Timeout is how long the action may run before it is stopped
+	// and marked as failed. When enqueueing, zero means the charm's
+	// default is used.
+	Timeout time.Duration `json:"timeout,omitempty"`
+
+	// Schedule is the id of the action schedule that enqueued the
+	// action, if any.
+	Schedule string `json:"schedule,omitempty"`
 }
 
 // ActionResults is a slice of ActionResult for bulk requests.
@@ -135,3 +148,77 @@ type ServiceCharmActionsResult struct {
 	Actions    *charm.Actions `json:"actions,omitempty"`
 	Error      *Error         `json:"error,omitempty"`
 }
+
+// ServicesActionReceiversResults holds a slice of
+// ServiceActionReceiversResult for a bulk request for the units of
+// services that actions may be run on.
+type ServicesActionReceiversResults struct {
+	Results []ServiceActionReceiversResult `json:"results,omitempty"`
+}
+
+// ServiceActionReceiversResult holds the tags of a service's units, and
+// of the unit that is currently its leader, if any.
+type ServiceActionReceiversResult struct {
+	ServiceTag string   `json:"servicetag,omitempty"`
+	Units      []string `json:"units,omitempty"`
+	Leader     string   `json:"leader,omitempty"`
+	Error      *Error   `json:"error,omitempty"`
+}
+
+// ActionSchedules holds a slice of ActionSchedule for bulk requests.
+type ActionSchedules struct {
+	Schedules []ActionSchedule `json:"schedules,omitempty"`
+}
+
+// ActionSchedule describes a schedule on which an action is enqueued
+// on a unit.
+type ActionSchedule struct {
+	Id         string                 `json:"id,omitempty"`
+	Receiver   string                 `json:"receiver"`
+	Name       string                 `json:"name"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Timeout    time.Duration          `json:"timeout,omitempty"`
+
+	// Schedule is a cron-style schedule, such as "0 3 * * *".
+	Schedule string `json:"schedule"`
+
+	Created time.Time `json:"created,omitempty"`
+	NextRun time.Time `json:"nextrun,omitempty"`
+
+	// LastRun is the time the schedule last fell due. LastAction is
+	// the tag of the action enqueued then, or LastError describes why
+	// it could not be enqueued.
+	LastRun    time.Time `json:"lastrun,omitempty"`
+	LastAction string    `json:"lastaction,omitempty"`
+	LastError  string    `json:"lasterror,omitempty"`
+}
+
+// ActionScheduleResults holds a slice of ActionScheduleResult for
+// bulk results.
+type ActionScheduleResults struct {
+	Results []ActionScheduleResult `json:"results,omitempty"`
+}
+
+// ActionScheduleResult holds an action schedule, or an error.
+type ActionScheduleResult struct {
+	Schedule *ActionSchedule `json:"schedule,omitempty"`
+	Error    *Error          `json:"error,omitempty"`
+}
+
+// ActionScheduleIds holds the ids of action schedules.
+type ActionScheduleIds struct {
+	Ids []string `json:"ids"`
+}
+
+// ActionScheduleRuns holds the times at which action schedules next
+// fall due.
+type ActionScheduleRuns struct {
+	Runs []ActionScheduleRun `json:"runs,omitempty"`
+}
+
+// ActionScheduleRun holds the time at which an action schedule next
+// falls due.
+type ActionScheduleRun struct {
+	Id      string    `json:"id"`
+	NextRun time.Time `json:"nextrun"`
+}
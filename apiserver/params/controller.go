@@ -3,6 +3,8 @@
 
 package params
 
+import "time"
+
 // DestroyControllerArgs holds the arguments for destroying a controller.
 type DestroyControllerArgs struct {
 	// DestroyModels specifies whether or not the hosted models
@@ -46,3 +48,69 @@ type ModelStatus struct {
 type ModelStatusResults struct {
 	Results []ModelStatus `json:"models"`
 }
+
+// ModifyControllerAccessRequest holds the parameters for making grant and
+// revoke controller calls.
+type ModifyControllerAccessRequest struct {
+	Changes []ModifyControllerAccess `json:"changes"`
+}
+
+// ModifyControllerAccess holds a single change to a user's access to the
+// controller.
+type ModifyControllerAccess struct {
+	UserTag string                     `json:"user-tag"`
+	Action  ControllerAction           `json:"action"`
+	Access  ControllerAccessPermission `json:"access"`
+}
+
+// ControllerAction is an action that can be performed on a controller.
+type ControllerAction string
+
+// Actions that can be performed on a controller.
+const (
+	GrantControllerAccess  ControllerAction = "grant"
+	RevokeControllerAccess ControllerAction = "revoke"
+)
+
+// ControllerAccessPermission is the type of permission that a user has to
+// access a controller.
+type ControllerAccessPermission string
+
+// Controller access permissions that may be set on a user.
+const (
+	ControllerLoginAccess     ControllerAccessPermission = "login"
+	ControllerAddModelAccess  ControllerAccessPermission = "add-model"
+	ControllerSuperuserAccess ControllerAccessPermission = "superuser"
+)
+
+// AuditLogFilter selects the audit log entries returned by the
+// controller. Zero valued fields match all entries.
+type AuditLogFilter struct {
+	From     time.Time `json:"from,omitempty"`
+	To       time.Time `json:"to,omitempty"`
+	User     string    `json:"user,omitempty"`
+	ModelTag string    `json:"model-tag,omitempty"`
+
+	// Limit bounds the number of entries returned, keeping the most
+	// recent ones.
+	Limit int `json:"limit,omitempty"`
+}
+
+// AuditLogEntry holds a single API request recorded in the audit log.
+type AuditLogEntry struct {
+	Time     time.Time     `json:"time"`
+	ModelTag string        `json:"model-tag,omitempty"`
+	User     string        `json:"user"`
+	Facade   string        `json:"facade"`
+	Version  int           `json:"version"`
+	Method   string        `json:"method"`
+	Args     string        `json:"args,omitempty"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// AuditLogResults holds the audit log entries matching a filter,
+// oldest first.
+type AuditLogResults struct {
+	Entries []AuditLogEntry `json:"entries"`
+}
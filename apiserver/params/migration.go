@@ -51,6 +51,17 @@ type SerializedModel struct {
 	Bytes []byte `json:"bytes"`
 }
 
+// ImportValidationResult reports the outcome of checking a serialized
+// model against a target controller without importing it.
+type ImportValidationResult struct {
+	// Problems holds the reasons the model could not be imported.
+	Problems []string `json:"problems"`
+
+	// Missing lists the charms and tools used by the model, which a
+	// migration uploads to the target controller.
+	Missing []string `json:"missing"`
+}
+
 // ModelArgs wraps a simple model tag.
 type ModelArgs struct {
 	ModelTag string `json:"model-tag"`
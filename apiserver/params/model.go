@@ -57,6 +57,19 @@ type ModelInfo struct {
 	// to the model. Owners and administrators can see all users
 	// that have access; other users can only see their own details.
 	Users []ModelUserInfo `json:"Users"`
+
+	// LogUsage describes the logs the controller holds for the
+	// model. It is only set for model administrators.
+	LogUsage *ModelLogUsage `json:"LogUsage,omitempty"`
+}
+
+// ModelLogUsage describes the logs the controller holds for a model.
+type ModelLogUsage struct {
+	// Records holds the number of log records held.
+	Records int `json:"records"`
+
+	// Bytes holds the estimated space used by the records.
+	Bytes int64 `json:"bytes"`
 }
 
 // ModelInfoResult holds the result of a ModelInfo call.
@@ -138,4 +151,5 @@ type ModelAccessPermission string
 const (
 	ModelReadAccess  ModelAccessPermission = "read"
 	ModelWriteAccess ModelAccessPermission = "write"
+	ModelAdminAccess ModelAccessPermission = "admin"
 )
@@ -466,6 +466,21 @@ type MachinePortsResults struct {
 	Results []MachinePortsResult `json:"Results"`
 }
 
+// ExposeSourceCIDRsResult holds a single result of the
+// FirewallerAPI.GetExposeSourceCIDRs() API call. Ports is keyed on
+// the port range string.
+type ExposeSourceCIDRsResult struct {
+	Error   *Error              `json:"Error"`
+	Default []string            `json:"Default"`
+	Ports   map[string][]string `json:"Ports"`
+}
+
+// ExposeSourceCIDRsResults holds all the results of the
+// FirewallerAPI.GetExposeSourceCIDRs() API call.
+type ExposeSourceCIDRsResults struct {
+	Results []ExposeSourceCIDRsResult `json:"Results"`
+}
+
 // APIHostPortsResult holds the result of an APIHostPorts
 // call. Each element in the top level slice holds
 // the addresses for one API server.
@@ -245,11 +245,52 @@ type ServiceSetCharm struct {
 	// ResourceIDs is a map of resource names to resource IDs to activate during
 	// the upgrade.
 	ResourceIDs map[string]string `json:"resourceids"`
+	// RollingUpgrade, if set, upgrades the service's units in batches
+	// rather than all at once.
+	RollingUpgrade *CharmUpgradeStrategy `json:"rollingupgrade,omitempty"`
+}
+
+// CharmUpgradeStrategy describes how a service's units are moved to a
+// new charm during a rolling upgrade.
+type CharmUpgradeStrategy struct {
+	// BatchSize is the number of units upgraded at once.
+	BatchSize int `json:"batchsize"`
+	// Pause is how long to wait after a batch of units has upgraded
+	// before upgrading the next batch.
+	Pause time.Duration `json:"pause"`
+	// HaltOnError stops the upgrade when an upgraded unit goes into
+	// an error state.
+	HaltOnError bool `json:"haltonerror"`
+}
+
+// CharmUpgradeStatusResult holds the result of the service
+// CharmUpgradeStatus call.
+type CharmUpgradeStatusResult struct {
+	FromCharmURL string               `json:"fromcharmurl"`
+	ToCharmURL   string               `json:"tocharmurl"`
+	Strategy     CharmUpgradeStrategy `json:"strategy"`
+	// Released holds the names of the units released to upgrade.
+	Released []string `json:"released"`
+	// Pending holds the names of the units still to be released.
+	Pending []string `json:"pending"`
+	// HaltReason, if not empty, explains why the upgrade is halted.
+	HaltReason string `json:"haltreason,omitempty"`
+}
+
+// ServiceResumeCharmUpgrade holds parameters for the service
+// ResumeCharmUpgrade call.
+type ServiceResumeCharmUpgrade struct {
+	ServiceName string
 }
 
 // ServiceExpose holds the parameters for making the service Expose call.
+// If SourceCIDRs is set, the opened ports of the service may only be
+// reached from those networks; if PortRange is also set, the source
+// CIDRs only apply to that port range.
 type ServiceExpose struct {
 	ServiceName string
+	SourceCIDRs []string `json:",omitempty"`
+	PortRange   string   `json:",omitempty"`
 }
 
 // ServiceSet holds the parameters for a service Set
@@ -267,8 +308,8 @@ type ServiceUnset struct {
 	Options     []string
 }
 
-// ServiceGet holds parameters for making the Get or
-// GetCharmURL calls.
+// ServiceGet holds parameters for making the Get,
+// GetCharmURL or CharmUpgradeStatus calls.
 type ServiceGet struct {
 	ServiceName string
 }
@@ -760,6 +801,18 @@ type LogRecord struct {
 	Message  string      `json:"x"`
 }
 
+// DebugLogRecord is a single log message sent by the debug-log API
+// endpoint when JSON output is requested.
+type DebugLogRecord struct {
+	ModelUUID string    `json:"model-uuid"`
+	Entity    string    `json:"entity"`
+	Timestamp time.Time `json:"timestamp"`
+	Level     string    `json:"level"`
+	Module    string    `json:"module"`
+	Location  string    `json:"location"`
+	Message   string    `json:"message"`
+}
+
 // GetBundleChangesParams holds parameters for making GetBundleChanges calls.
 type GetBundleChangesParams struct {
 	// BundleDataYAML is the YAML-encoded charm bundle data
@@ -17,6 +17,7 @@ var readOnlyCalls = set.NewStrings(
 	"Action.ListPending",
 	"Action.ListRunning",
 	"Action.ListCompleted",
+	"Action.ListSchedules",
 	"Action.ServicesCharmActions",
 	"Annotations.Get",
 	"Block.List",
@@ -26,6 +27,7 @@ var readOnlyCalls = set.NewStrings(
 	"Client.AgentVersion",
 	"Client.APIHostPorts",
 	"Client.CharmInfo",
+	"Client.ExportBundle",
 	"Client.ModelGet",
 	"Client.ModelInfo",
 	"Client.ModelUserInfo",
@@ -48,6 +50,7 @@ var readOnlyCalls = set.NewStrings(
 	"ModelManager.ModelInfo",
 	"Service.GetConstraints",
 	"Service.CharmRelations",
+	"Service.CharmUpgradeStatus",
 	"Service.Get",
 	"Spaces.ListSpaces",
 	"Storage.ListStorageDetails",
@@ -60,6 +63,21 @@ var readOnlyCalls = set.NewStrings(
 	"UserManager.UserInfo",
 )
 
+// adminOnlyCalls specify the API calls that may only be made by users
+// with admin access to the model. The format of the calls is the same as
+// for readOnlyCalls.
+var adminOnlyCalls = set.NewStrings(
+	"Client.AbortCurrentUpgrade",
+	"Client.DestroyModel",
+	"Client.SetModelAgentVersion",
+)
+
+// isCallAdminOnly returns whether or not the method on the facade
+// requires admin access to the model.
+func isCallAdminOnly(facade, method string) bool {
+	return adminOnlyCalls.Contains(facade + "." + method)
+}
+
 // isCallReadOnly returns whether or not the method on the facade
 // is known to not alter the database.
 func isCallReadOnly(facade, method string) bool {
@@ -43,13 +43,31 @@ func (*readOnlyCallsSuite) TestReadOnlyCallsExist(c *gc.C) {
 	}
 }
 
+func (*readOnlyCallsSuite) TestAdminOnlyCallsExist(c *gc.C) {
+	for _, name := range adminOnlyCalls.Values() {
+		parts := strings.Split(name, ".")
+		facade, method := parts[0], parts[1]
+		_, _, err := lookupMethod(facade, 1, method)
+		c.Check(err, jc.ErrorIsNil)
+	}
+}
+
+func (*readOnlyCallsSuite) TestAdminOnlyCall(c *gc.C) {
+	c.Check(isCallAdminOnly("Client", "DestroyModel"), jc.IsTrue)
+	c.Check(isCallAdminOnly("Client", "FullStatus"), jc.IsFalse)
+	c.Check(isCallAdminOnly("Service", "Deploy"), jc.IsFalse)
+}
+
 func (*readOnlyCallsSuite) TestReadOnlyCall(c *gc.C) {
 	for _, test := range []struct {
 		facade string
 		method string
 	}{
 		{"Action", "Actions"},
+		{"Action", "ListSchedules"},
+		{"Client", "ExportBundle"},
 		{"Client", "FullStatus"},
+		{"Service", "CharmUpgradeStatus"},
 		{"Service", "Get"},
 		{"Storage", "ListStorageDetails"},
 	} {
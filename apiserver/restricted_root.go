@@ -29,6 +29,7 @@ var restrictedRootNames = set.NewStrings(
 	"AllModelWatcher",
 	"Controller",
 	"MigrationTarget",
+	"ModelArchive",
 	"ModelManager",
 	"UserManager",
 )
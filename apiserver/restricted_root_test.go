@@ -47,6 +47,10 @@ func (r *restrictedRootSuite) TestFindAllowedMethod(c *gc.C) {
 	r.assertMethodAllowed(c, "Controller", 2, "DestroyController")
 	r.assertMethodAllowed(c, "Controller", 2, "ModelConfig")
 	r.assertMethodAllowed(c, "Controller", 2, "ListBlockedModels")
+
+	r.assertMethodAllowed(c, "ModelArchive", 1, "Export")
+	r.assertMethodAllowed(c, "ModelArchive", 1, "Import")
+	r.assertMethodAllowed(c, "ModelArchive", 1, "Activate")
 }
 
 func (r *restrictedRootSuite) TestFindDisallowedMethod(c *gc.C) {
@@ -461,3 +461,81 @@ func newServer(c *gc.C, st *state.State) *apiserver.Server {
 	c.Assert(err, jc.ErrorIsNil)
 	return srv
 }
+
+func (s *serverSuite) TestUserRequestsAreAudited(c *gc.C) {
+	_, err := s.APIState.Client().ModelGet()
+	c.Assert(err, jc.ErrorIsNil)
+
+	entries := s.waitForAuditEntry(c, "Client", "ModelGet")
+	c.Assert(entries, gc.HasLen, 1)
+	entry := entries[0]
+	c.Check(entry.User, gc.Equals, s.AdminUserTag(c).Canonical())
+	c.Check(entry.ModelUUID, gc.Equals, s.State.ModelUUID())
+	c.Check(entry.Version, gc.Equals, 1)
+	c.Check(entry.Error, gc.Equals, "")
+}
+
+func (s *serverSuite) TestFailedLoginIsAudited(c *gc.C) {
+	info := s.APIInfo(c)
+	info.Password = "wrong"
+	_, err := api.Open(info, fastDialOpts)
+	c.Assert(err, gc.ErrorMatches, `invalid entity name or password \(unauthorized access\)`)
+
+	entries := s.waitForAuditEntry(c, "Admin", "Login")
+	c.Assert(entries, gc.Not(gc.HasLen), 0)
+	entry := entries[len(entries)-1]
+	c.Check(entry.User, gc.Equals, s.AdminUserTag(c).Canonical())
+	c.Check(entry.Error, gc.Equals, "invalid entity name or password")
+	c.Check(entry.Args, gc.Not(jc.Contains), "wrong")
+}
+
+func (s *serverSuite) TestAgentRequestsAreNotAudited(c *gc.C) {
+	machine, password := s.Factory.MakeMachineReturningPassword(
+		c, &factory.MachineParams{Nonce: "fake_nonce"})
+	st := s.OpenAPIAsMachine(c, machine.Tag(), password, "fake_nonce")
+	err := st.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Audit entries are written in order, so once a later user request
+	// has been recorded the agent's login would have been too.
+	_, err = s.APIState.Client().ModelGet()
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitForAuditEntry(c, "Client", "ModelGet")
+
+	entries, err := s.State.AuditEntries(state.AuditFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	for _, entry := range entries {
+		c.Check(entry.User, gc.Not(gc.Equals), machine.Tag().String())
+		c.Check(entry.User, gc.Not(gc.Equals), "")
+	}
+}
+
+func (s *serverSuite) TestWatcherCallsAreNotAudited(c *gc.C) {
+	for _, req := range []rpc.Request{
+		{Type: "AllWatcher", Action: "Next"},
+		{Type: "AllWatcher", Action: "Stop"},
+		{Type: "NotifyWatcher", Action: "Next"},
+	} {
+		c.Check(apiserver.IsAudited(req), jc.IsFalse, gc.Commentf("%s.%s", req.Type, req.Action))
+	}
+	c.Check(apiserver.IsAudited(rpc.Request{Type: "Client", Action: "WatchAll"}), jc.IsTrue)
+	c.Check(apiserver.IsAudited(rpc.Request{Type: "Client", Action: "FullStatus"}), jc.IsTrue)
+}
+
+func (s *serverSuite) waitForAuditEntry(c *gc.C, facade, method string) []state.AuditEntry {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		entries, err := s.State.AuditEntries(state.AuditFilter{})
+		c.Assert(err, jc.ErrorIsNil)
+		var matching []state.AuditEntry
+		for _, entry := range entries {
+			if entry.Facade == facade && entry.Method == method {
+				matching = append(matching, entry)
+			}
+		}
+		if len(matching) > 0 {
+			return matching
+		}
+	}
+	c.Fatalf("no audit entry recorded for %s.%s", facade, method)
+	return nil
+}
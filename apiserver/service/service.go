@@ -9,6 +9,7 @@ package service
 import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/charm.v6-unstable"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
 	goyaml "gopkg.in/yaml.v2"
@@ -17,6 +18,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/instance"
 	jjj "github.com/juju/juju/juju"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	statestorage "github.com/juju/juju/state/storage"
 )
@@ -29,6 +31,13 @@ var (
 
 func init() {
 	common.RegisterStandardFacade("Service", 3, NewAPI)
+
+	// Version 4 adds CharmUpgradeStatus and ResumeCharmUpgrade. Its
+	// SetCharm honours the RollingUpgrade argument and its Expose the
+	// SourceCIDRs and PortRange arguments, which version 3 controllers
+	// silently ignore. Clients must require version 4 before passing
+	// them.
+	common.RegisterStandardFacade("Service", 4, NewAPI)
 }
 
 // Service defines the methods on the service API end point.
@@ -243,7 +252,7 @@ func (api *API) Update(args params.ServiceUpdate) error {
 		// For now we do not support changing the channel through Update().
 		// TODO(ericsnow) Support it?
 		channel := svc.Channel()
-		if err = api.serviceSetCharm(svc, args.CharmUrl, channel, args.ForceSeries, args.ForceCharmUrl, nil); err != nil {
+		if err = api.serviceSetCharm(svc, args.CharmUrl, channel, args.ForceSeries, args.ForceCharmUrl, nil, nil); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -283,11 +292,27 @@ func (api *API) SetCharm(args params.ServiceSetCharm) error {
 		return errors.Trace(err)
 	}
 	channel := csparams.Channel(args.Channel)
-	return api.serviceSetCharm(service, args.CharmUrl, channel, args.ForceSeries, args.ForceUnits, args.ResourceIDs)
+	var rolling *state.CharmUpgradeStrategy
+	if args.RollingUpgrade != nil {
+		rolling = &state.CharmUpgradeStrategy{
+			BatchSize:   args.RollingUpgrade.BatchSize,
+			Pause:       args.RollingUpgrade.Pause,
+			HaltOnError: args.RollingUpgrade.HaltOnError,
+		}
+	}
+	return api.serviceSetCharm(service, args.CharmUrl, channel, args.ForceSeries, args.ForceUnits, args.ResourceIDs, rolling)
 }
 
-// serviceSetCharm sets the charm for the given service.
-func (api *API) serviceSetCharm(service *state.Service, url string, channel csparams.Channel, forceSeries, forceUnits bool, resourceIDs map[string]string) error {
+// serviceSetCharm sets the charm for the given service. If rolling is
+// not nil, the service's units are upgraded in batches.
+func (api *API) serviceSetCharm(
+	service *state.Service,
+	url string,
+	channel csparams.Channel,
+	forceSeries, forceUnits bool,
+	resourceIDs map[string]string,
+	rolling *state.CharmUpgradeStrategy,
+) error {
 	curl, err := charm.ParseURL(url)
 	if err != nil {
 		return errors.Trace(err)
@@ -297,15 +322,65 @@ func (api *API) serviceSetCharm(service *state.Service, url string, channel cspa
 		return errors.Trace(err)
 	}
 	cfg := state.SetCharmConfig{
-		Charm:       sch,
-		Channel:     channel,
-		ForceSeries: forceSeries,
-		ForceUnits:  forceUnits,
-		ResourceIDs: resourceIDs,
+		Charm:          sch,
+		Channel:        channel,
+		ForceSeries:    forceSeries,
+		ForceUnits:     forceUnits,
+		ResourceIDs:    resourceIDs,
+		RollingUpgrade: rolling,
 	}
 	return service.SetCharm(cfg)
 }
 
+// CharmUpgradeStatus returns the progress of the rolling charm upgrade
+// in progress for the given service.
+func (api *API) CharmUpgradeStatus(args params.ServiceGet) (params.CharmUpgradeStatusResult, error) {
+	service, err := api.state.Service(args.ServiceName)
+	if err != nil {
+		return params.CharmUpgradeStatusResult{}, errors.Trace(err)
+	}
+	upgrade, err := service.CharmUpgrade()
+	if err != nil {
+		return params.CharmUpgradeStatusResult{}, errors.Trace(err)
+	}
+	units, err := service.AllUnits()
+	if err != nil {
+		return params.CharmUpgradeStatusResult{}, errors.Trace(err)
+	}
+	released := set.NewStrings(upgrade.Released...)
+	pending := []string{}
+	for _, unit := range units {
+		if !released.Contains(unit.Name()) {
+			pending = append(pending, unit.Name())
+		}
+	}
+	return params.CharmUpgradeStatusResult{
+		FromCharmURL: upgrade.FromCharmURL.String(),
+		ToCharmURL:   upgrade.ToCharmURL.String(),
+		Strategy: params.CharmUpgradeStrategy{
+			BatchSize:   upgrade.Strategy.BatchSize,
+			Pause:       upgrade.Strategy.Pause,
+			HaltOnError: upgrade.Strategy.HaltOnError,
+		},
+		Released:   released.SortedValues(),
+		Pending:    pending,
+		HaltReason: upgrade.HaltReason,
+	}, nil
+}
+
+// ResumeCharmUpgrade allows a halted rolling charm upgrade of the given
+// service to continue.
+func (api *API) ResumeCharmUpgrade(args params.ServiceResumeCharmUpgrade) error {
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	service, err := api.state.Service(args.ServiceName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return service.ResumeCharmUpgrade()
+}
+
 // settingsYamlFromGetYaml will parse a yaml produced by juju get and generate
 // charm.Settings from it that can then be sent to the service.
 func settingsFromGetYaml(yamlContents map[string]interface{}) (charm.Settings, error) {
@@ -434,7 +509,8 @@ func (api *API) CharmRelations(p params.ServiceCharmRelations) (params.ServiceCh
 }
 
 // Expose changes the juju-managed firewall to expose any ports that
-// were also explicitly marked by units as open.
+// were also explicitly marked by units as open. If source CIDRs are
+// given, the ports may only be reached from those networks.
 func (api *API) Expose(args params.ServiceExpose) error {
 	if err := api.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
@@ -443,6 +519,22 @@ func (api *API) Expose(args params.ServiceExpose) error {
 	if err != nil {
 		return err
 	}
+	if args.PortRange != "" {
+		if len(args.SourceCIDRs) == 0 {
+			return errors.New("source CIDRs must be specified with a port range")
+		}
+		portRange, err := network.ParsePortRange(args.PortRange)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := svc.SetExposePortSourceCIDRs(portRange, args.SourceCIDRs); err != nil {
+			return errors.Trace(err)
+		}
+	} else if len(args.SourceCIDRs) > 0 {
+		if err := svc.SetExposeSourceCIDRs(args.SourceCIDRs); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	return svc.SetExposed()
 }
 
@@ -29,6 +29,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	statestorage "github.com/juju/juju/state/storage"
 	"github.com/juju/juju/status"
@@ -681,6 +682,71 @@ func (s *serviceSuite) TestServiceSetCharm(c *gc.C) {
 	c.Assert(force, jc.IsFalse)
 }
 
+func (s *serviceSuite) TestServiceSetCharmRollingUpgrade(c *gc.C) {
+	curl, _ := s.UploadCharm(c, "precise/dummy-0", "dummy")
+	err := service.AddCharmWithAuthorization(s.State, params.AddCharmWithAuthorization{
+		URL: curl.String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := s.serviceApi.Deploy(params.ServicesDeploy{
+		Services: []params.ServiceDeploy{{
+			CharmUrl:    curl.String(),
+			ServiceName: "service",
+			NumUnits:    3,
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	newCurl, _ := s.UploadCharm(c, "precise/dummy-1", "dummy")
+	err = service.AddCharmWithAuthorization(s.State, params.AddCharmWithAuthorization{
+		URL: newCurl.String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.serviceApi.CharmUpgradeStatus(params.ServiceGet{"service"})
+	c.Assert(err, gc.ErrorMatches, `rolling charm upgrade for service "service" not found`)
+
+	err = s.serviceApi.SetCharm(params.ServiceSetCharm{
+		ServiceName: "service",
+		CharmUrl:    newCurl.String(),
+		RollingUpgrade: &params.CharmUpgradeStrategy{
+			BatchSize:   1,
+			Pause:       time.Minute,
+			HaltOnError: true,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	svc, err := s.State.Service("service")
+	c.Assert(err, jc.ErrorIsNil)
+	err = svc.ReleaseCharmUpgradeUnits([]string{"service/0"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = svc.HaltCharmUpgrade("unit service/0 is in error")
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.serviceApi.CharmUpgradeStatus(params.ServiceGet{"service"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.CharmUpgradeStatusResult{
+		FromCharmURL: curl.String(),
+		ToCharmURL:   newCurl.String(),
+		Strategy: params.CharmUpgradeStrategy{
+			BatchSize:   1,
+			Pause:       time.Minute,
+			HaltOnError: true,
+		},
+		Released:   []string{"service/0"},
+		Pending:    []string{"service/1", "service/2"},
+		HaltReason: "unit service/0 is in error",
+	})
+
+	err = s.serviceApi.ResumeCharmUpgrade(params.ServiceResumeCharmUpgrade{"service"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = svc.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	upgrade, err := svc.CharmUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade.HaltReason, gc.Equals, "")
+}
+
 func (s *serviceSuite) setupServiceSetCharm(c *gc.C) {
 	curl, _ := s.UploadCharm(c, "precise/dummy-0", "dummy")
 	err := service.AddCharmWithAuthorization(s.State, params.AddCharmWithAuthorization{
@@ -1888,7 +1954,7 @@ func (s *serviceSuite) TestServiceExpose(c *gc.C) {
 	c.Assert(svcs[1].IsExposed(), jc.IsTrue)
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err = s.serviceApi.Expose(params.ServiceExpose{t.service})
+		err = s.serviceApi.Expose(params.ServiceExpose{ServiceName: t.service})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1940,7 +2006,7 @@ var serviceExposeTests = []struct {
 func (s *serviceSuite) assertServiceExpose(c *gc.C) {
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.serviceApi.Expose(params.ServiceExpose{t.service})
+		err := s.serviceApi.Expose(params.ServiceExpose{ServiceName: t.service})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1955,7 +2021,7 @@ func (s *serviceSuite) assertServiceExpose(c *gc.C) {
 func (s *serviceSuite) assertServiceExposeBlocked(c *gc.C, msg string) {
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.serviceApi.Expose(params.ServiceExpose{t.service})
+		err := s.serviceApi.Expose(params.ServiceExpose{ServiceName: t.service})
 		s.AssertBlocked(c, err, msg)
 	}
 }
@@ -1978,6 +2044,42 @@ func (s *serviceSuite) TestBlockChangesServiceExpose(c *gc.C) {
 	s.assertServiceExposeBlocked(c, "TestBlockChangesServiceExpose")
 }
 
+func (s *serviceSuite) TestServiceExposeSourceCIDRs(c *gc.C) {
+	svc := s.AddTestingService(c, "dummy-service", s.AddTestingCharm(c, "dummy"))
+	err := s.serviceApi.Expose(params.ServiceExpose{
+		ServiceName: "dummy-service",
+		SourceCIDRs: []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.serviceApi.Expose(params.ServiceExpose{
+		ServiceName: "dummy-service",
+		SourceCIDRs: []string{"0.0.0.0/0"},
+		PortRange:   "80/tcp",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = svc.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.IsExposed(), jc.IsTrue)
+	sources, err := svc.ExposeSourceCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sources, jc.DeepEquals, network.ExposeSourceCIDRs{
+		Default: []string{"10.0.0.0/8"},
+		Ports:   map[network.PortRange][]string{network.MustParsePortRange("80/tcp"): {}},
+	})
+
+	err = s.serviceApi.Expose(params.ServiceExpose{
+		ServiceName: "dummy-service",
+		PortRange:   "80/tcp",
+	})
+	c.Assert(err, gc.ErrorMatches, "source CIDRs must be specified with a port range")
+	err = s.serviceApi.Expose(params.ServiceExpose{
+		ServiceName: "dummy-service",
+		SourceCIDRs: []string{"10.0.0.0"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set source CIDRs for service "dummy-service": source CIDR "10.0.0.0" not valid`)
+}
+
 var serviceUnexposeTests = []struct {
 	about    string
 	service  string
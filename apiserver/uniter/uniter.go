@@ -555,7 +555,7 @@ func (u *UniterAPIV3) HasSubordinates(args params.Entities) (params.BoolResults,
 }
 
 // CharmModifiedVersion returns the most CharmModifiedVersion for all given
-// units or services.
+// units or services, as seen by the authenticated unit.
 func (u *UniterAPIV3) CharmModifiedVersion(args params.Entities) (params.IntResults, error) {
 	results := params.IntResults{
 		Results: make([]params.IntResult, len(args.Entities)),
@@ -590,21 +590,26 @@ func (u *UniterAPIV3) charmModifiedVersion(tagStr string, canAccess func(names.T
 		return -1, err
 	}
 	var service *state.Service
+	var unitName string
 	switch entity := unitOrService.(type) {
 	case *state.Service:
 		service = entity
+		unitName = u.auth.GetAuthTag().Id()
 	case *state.Unit:
 		service, err = entity.Service()
 		if err != nil {
 			return -1, err
 		}
+		unitName = entity.Name()
 	default:
 		return -1, errors.BadRequestf("type %t does not have a CharmModifiedVersion", entity)
 	}
-	return service.CharmModifiedVersion(), nil
+	// The unit may be held on the previous charm by a rolling upgrade.
+	return service.UnitCharmModifiedVersion(unitName), nil
 }
 
-// CharmURL returns the charm URL for all given units or services.
+// CharmURL returns the charm URL for all given units or services. For
+// a service, this is the charm that the authenticated unit should run.
 func (u *UniterAPIV3) CharmURL(args params.Entities) (params.StringBoolResults, error) {
 	result := params.StringBoolResults{
 		Results: make([]params.StringBoolResult, len(args.Entities)),
@@ -625,10 +630,19 @@ func (u *UniterAPIV3) CharmURL(args params.Entities) (params.StringBoolResults,
 			var unitOrService state.Entity
 			unitOrService, err = u.st.FindEntity(tag)
 			if err == nil {
-				charmURLer := unitOrService.(interface {
-					CharmURL() (*charm.URL, bool)
-				})
-				curl, ok := charmURLer.CharmURL()
+				var curl *charm.URL
+				var ok bool
+				switch entity := unitOrService.(type) {
+				case *state.Service:
+					// The unit may be held on the previous charm
+					// by a rolling upgrade.
+					curl, ok = entity.UnitCharmURL(u.auth.GetAuthTag().Id())
+				default:
+					charmURLer := entity.(interface {
+						CharmURL() (*charm.URL, bool)
+					})
+					curl, ok = charmURLer.CharmURL()
+				}
 				if curl != nil {
 					result.Results[i].Result = curl.String()
 					result.Results[i].Ok = ok
@@ -911,6 +925,27 @@ func (u *UniterAPIV3) FinishActions(args params.ActionExecutionResults) (params.
 	return common.FinishActions(args, actionFn), nil
 }
 
+// ActionStatus returns the current status of each given action, so
+// that a unit can tell when a running action has been cancelled.
+func (u *UniterAPIV3) ActionStatus(args params.Entities) (params.StringResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.StringResults{}, err
+	}
+
+	actionFn := common.AuthAndActionFromTagFn(canAccess, u.st.ActionByTag)
+	results := params.StringResults{Results: make([]params.StringResult, len(args.Entities))}
+	for i, entity := range args.Entities {
+		action, err := actionFn(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = string(action.Status())
+	}
+	return results, nil
+}
+
 // RelationById returns information about all given relations,
 // specified by their ids, including their key and the local
 // endpoint.
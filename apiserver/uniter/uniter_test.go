@@ -752,6 +752,37 @@ func (s *uniterSuite) TestCharmURL(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestCharmURLRollingUpgrade(c *gc.C) {
+	oldVersion := s.wordpress.CharmModifiedVersion()
+	newCharm := s.Factory.MakeCharm(c, &jujuFactory.CharmParams{
+		Name: "wordpress",
+		URL:  "cs:quantal/wordpress-4",
+	})
+	err := s.wordpress.SetCharm(state.SetCharmConfig{
+		Charm:          newCharm,
+		RollingUpgrade: &state.CharmUpgradeStrategy{BatchSize: 1},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The unit is held on the old charm until it is released.
+	args := params.Entities{Entities: []params.Entity{{Tag: "service-wordpress"}}}
+	result, err := s.uniter.CharmURL(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.DeepEquals, []params.StringBoolResult{{Result: s.wpCharm.String()}})
+	versions, err := s.uniter.CharmModifiedVersion(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(versions.Results, gc.DeepEquals, []params.IntResult{{Result: oldVersion}})
+
+	err = s.wordpress.ReleaseCharmUpgradeUnits([]string{"wordpress/0"})
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.uniter.CharmURL(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.DeepEquals, []params.StringBoolResult{{Result: newCharm.String()}})
+	versions, err = s.uniter.CharmModifiedVersion(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(versions.Results, gc.DeepEquals, []params.IntResult{{Result: s.wordpress.CharmModifiedVersion()}})
+}
+
 func (s *uniterSuite) TestSetCharmURL(c *gc.C) {
 	_, ok := s.wordpressUnit.CharmURL()
 	c.Assert(ok, jc.IsFalse)
@@ -1322,6 +1353,30 @@ func (s *uniterSuite) TestBeginActions(c *gc.C) {
 	c.Assert(started.After(enqueued) || started.Equal(enqueued), jc.IsTrue, gc.Commentf("started should be after or equal to enqueued time"))
 }
 
+func (s *uniterSuite) TestActionStatus(c *gc.C) {
+	good, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	other, err := s.mysqlUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = good.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = good.Cancel()
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: good.ActionTag().String()},
+		{Tag: other.ActionTag().String()},
+		{Tag: "unit-wordpress-0"},
+	}}
+	results, err := s.uniter.ActionStatus(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Result, gc.Equals, params.ActionAborting)
+	c.Assert(results.Results[1].Error, jc.Satisfies, params.IsCodeUnauthorized)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"unit-wordpress-0" is not a valid action tag`)
+}
+
 func (s *uniterSuite) TestRelation(c *gc.C) {
 	rel := s.addRelation(c, "wordpress", "mysql")
 	wpEp, err := rel.Endpoint("wordpress")
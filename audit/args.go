@@ -0,0 +1,83 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// MaxArgsLength is the longest encoding of request arguments that
+// EncodeArgs will return. Longer encodings are truncated.
+const MaxArgsLength = 4096
+
+// Redacted replaces the values of secret fields in encoded arguments.
+const Redacted = "<redacted>"
+
+// secretFields holds the substrings that mark a field name as holding
+// a secret that must not be recorded.
+var secretFields = []string{
+	"password",
+	"secret",
+	"credential",
+	"macaroon",
+	"private-key",
+	"access-key",
+}
+
+// EncodeArgs returns a JSON encoding of API request arguments that is
+// suitable for recording in the audit log. The values of any fields
+// that look like they hold secrets are replaced with Redacted.
+func EncodeArgs(args interface{}) string {
+	if args == nil {
+		return ""
+	}
+	data, err := json.Marshal(args)
+	if err != nil {
+		return ""
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return ""
+	}
+	data, err = json.Marshal(redact(generic))
+	if err != nil {
+		return ""
+	}
+	encoded := string(data)
+	if len(encoded) > MaxArgsLength {
+		encoded = encoded[:MaxArgsLength] + "...(truncated)"
+	}
+	return encoded
+}
+
+func redact(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, item := range value {
+			if isSecretField(key) {
+				value[key] = Redacted
+			} else {
+				value[key] = redact(item)
+			}
+		}
+		return value
+	case []interface{}:
+		for i, item := range value {
+			value[i] = redact(item)
+		}
+		return value
+	}
+	return value
+}
+
+func isSecretField(name string) bool {
+	name = strings.ToLower(name)
+	for _, secret := range secretFields {
+		if strings.Contains(name, secret) {
+			return true
+		}
+	}
+	return false
+}
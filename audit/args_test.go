@@ -0,0 +1,65 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type argsSuite struct{}
+
+var _ = gc.Suite(&argsSuite{})
+
+func (*argsSuite) TestEncodeArgsNil(c *gc.C) {
+	c.Assert(EncodeArgs(nil), gc.Equals, "")
+}
+
+func (*argsSuite) TestEncodeArgs(c *gc.C) {
+	type entity struct {
+		Tag string `json:"tag"`
+	}
+	args := struct {
+		Entities []entity `json:"entities"`
+	}{[]entity{{"unit-mysql-0"}}}
+	c.Assert(EncodeArgs(args), gc.Equals, `{"entities":[{"tag":"unit-mysql-0"}]}`)
+}
+
+func (*argsSuite) TestEncodeArgsRedactsSecrets(c *gc.C) {
+	args := map[string]interface{}{
+		"auth-tag":    "user-bob",
+		"credentials": "sekrit",
+		"macaroons":   []string{"m1"},
+		"config": map[string]interface{}{
+			"admin-secret": "sekrit",
+			"Password":     "sekrit",
+			"name":         "mymodel",
+		},
+		"changes": []interface{}{
+			map[string]interface{}{"secret-key": "sekrit", "user": "bob"},
+		},
+	}
+	c.Assert(EncodeArgs(args), jc.JSONEquals, map[string]interface{}{
+		"auth-tag":    "user-bob",
+		"credentials": Redacted,
+		"macaroons":   Redacted,
+		"config": map[string]interface{}{
+			"admin-secret": Redacted,
+			"Password":     Redacted,
+			"name":         "mymodel",
+		},
+		"changes": []interface{}{
+			map[string]interface{}{"secret-key": Redacted, "user": "bob"},
+		},
+	})
+}
+
+func (*argsSuite) TestEncodeArgsTruncates(c *gc.C) {
+	args := map[string]string{"data": strings.Repeat("x", MaxArgsLength)}
+	encoded := EncodeArgs(args)
+	c.Assert(encoded, gc.HasLen, MaxArgsLength+len("...(truncated)"))
+	c.Assert(strings.HasSuffix(encoded, "...(truncated)"), jc.IsTrue)
+}
@@ -18,6 +18,9 @@ import (
 type APIClient interface {
 	io.Closer
 
+	// BestAPIVersion returns the version of the Action facade used.
+	BestAPIVersion() int
+
 	// Enqueue takes a list of Actions and queues them up to be executed by
 	// the designated ActionReceiver, returning the params.Action for each
 	// queued Action, or an error if there was a problem queueing up the
@@ -39,13 +42,27 @@ type APIClient interface {
 	// Entities.
 	ListCompleted(params.Entities) (params.ActionsByReceivers, error)
 
-	// Cancel attempts to cancel a queued up Action from running.
-	Cancel(params.Actions) (params.ActionResults, error)
+	// Cancel attempts to cancel queued up or running Actions.
+	Cancel(params.Entities) (params.ActionResults, error)
+
+	// AddSchedules adds schedules on which actions are enqueued on
+	// units, returning the added schedules.
+	AddSchedules(params.ActionSchedules) (params.ActionScheduleResults, error)
+
+	// ListSchedules returns all of the model's action schedules.
+	ListSchedules() (params.ActionSchedules, error)
+
+	// RemoveSchedules removes the action schedules with the given ids.
+	RemoveSchedules(params.ActionScheduleIds) (params.ErrorResults, error)
 
 	// ServiceCharmActions is a single query which uses ServicesCharmActions to
 	// get the charm.Actions for a single Service by tag.
 	ServiceCharmActions(params.Entity) (*charm.Actions, error)
 
+	// ServicesActionReceivers returns the tags of the units of each of
+	// the given services, and of each service's current leader.
+	ServicesActionReceivers(params.Entities) (params.ServicesActionReceiversResults, error)
+
 	// Actions fetches actions by tag.  These Actions can be used to get
 	// the ActionReceiver if necessary.
 	Actions(params.Entities) (params.ActionResults, error)
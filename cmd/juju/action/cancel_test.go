@@ -0,0 +1,106 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"bytes"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+	"github.com/juju/juju/testing"
+)
+
+type CancelSuite struct {
+	BaseActionSuite
+}
+
+var _ = gc.Suite(&CancelSuite{})
+
+func (s *CancelSuite) TestInit(c *gc.C) {
+	for i, t := range []struct {
+		args        []string
+		expectIds   []string
+		expectError string
+	}{{
+		expectError: "no action ID specified",
+	}, {
+		args:      []string{"deadbeef"},
+		expectIds: []string{"deadbeef"},
+	}, {
+		args:      []string{"deadbeef", validActionId},
+		expectIds: []string{"deadbeef", validActionId},
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		wrapped, command := action.NewCancelCommandForTest(s.store)
+		args := append([]string{"-m", "admin"}, t.args...)
+		err := testing.InitCommand(wrapped, args)
+		if t.expectError != "" {
+			c.Check(err, gc.ErrorMatches, t.expectError)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(command.RequestedIds(), jc.DeepEquals, t.expectIds)
+	}
+}
+
+func (s *CancelSuite) TestRun(c *gc.C) {
+	fakeid := "deadbeef-0000-4000-8000-feedfacebeef"
+	fakeid2 := "f00dface-0001-4000-8000-feedfacebeef"
+	results := []params.ActionResult{{
+		Action: &params.Action{Tag: "action-" + fakeid, Receiver: "unit-mysql-0"},
+		Status: params.ActionCancelled,
+	}, {
+		Action: &params.Action{Tag: "action-" + fakeid2, Receiver: "unit-mysql-1"},
+		Status: params.ActionAborting,
+	}}
+	client := &fakeAPIClient{
+		actionTagMatches: params.FindTagsResults{Matches: map[string][]params.Entity{
+			"deadbeef": {{Tag: "action-" + fakeid}},
+			"f00dface": {{Tag: "action-" + fakeid2}},
+		}},
+		actionResults: results,
+	}
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	wrapped, _ := action.NewCancelCommandForTest(s.store)
+	ctx, err := testing.RunCommand(c, wrapped, "-m", "admin", "deadbeef", "f00dface")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(client.cancelledActions, jc.DeepEquals, params.Entities{Entities: []params.Entity{
+		{Tag: "action-" + fakeid},
+		{Tag: "action-" + fakeid2},
+	}})
+
+	buf, err := cmd.DefaultFormatters["yaml"](action.ActionResultsToMap(results))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ctx.Stdout.(*bytes.Buffer).String(), gc.Equals, string(buf)+"\n")
+}
+
+func (s *CancelSuite) TestRunOldAPI(c *gc.C) {
+	client := &fakeAPIClient{oldAPI: true}
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	wrapped, _ := action.NewCancelCommandForTest(s.store)
+	_, err := testing.RunCommand(c, wrapped, "-m", "admin", "deadbeef")
+	c.Assert(err, gc.ErrorMatches, "cannot cancel actions: not supported by the API server")
+	c.Assert(client.cancelledActions.Entities, gc.HasLen, 0)
+}
+
+func (s *CancelSuite) TestRunUnknownPrefix(c *gc.C) {
+	client := &fakeAPIClient{
+		actionTagMatches: tagsForIdPrefix("deadbeef"),
+	}
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	wrapped, _ := action.NewCancelCommandForTest(s.store)
+	_, err := testing.RunCommand(c, wrapped, "-m", "admin", "deadbeef")
+	c.Assert(err, gc.ErrorMatches, `actions for identifier "deadbeef" not found`)
+	c.Assert(client.cancelledActions.Entities, gc.HasLen, 0)
+}
@@ -4,8 +4,11 @@
 package action
 
 import (
+	"time"
+
 	"github.com/juju/cmd"
 	"github.com/juju/names"
+	"github.com/juju/utils/clock"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -14,6 +17,7 @@ import (
 
 var (
 	NewActionAPIClient = &newAPIClient
+	ActionPollInterval = &actionPollInterval
 	AddValueToMap      = addValueToMap
 )
 
@@ -25,6 +29,14 @@ type StatusCommand struct {
 	*statusCommand
 }
 
+type CancelCommand struct {
+	*cancelCommand
+}
+
+func (c *CancelCommand) RequestedIds() []string {
+	return c.requestedIds
+}
+
 type RunCommand struct {
 	*runCommand
 }
@@ -49,6 +61,58 @@ func (c *RunCommand) Args() [][]string {
 	return c.args
 }
 
+func (c *RunCommand) Timeout() time.Duration {
+	return c.timeout
+}
+
+func (c *RunCommand) ServiceName() string {
+	return c.serviceName
+}
+
+func (c *RunCommand) LeaderOnly() bool {
+	return c.leaderOnly
+}
+
+func (c *RunCommand) BatchSize() int {
+	return c.batchSize
+}
+
+func (c *RunCommand) SetClock(clock clock.Clock) {
+	c.clock = clock
+}
+
+type AddScheduleCommand struct {
+	*addScheduleCommand
+}
+
+func (c *AddScheduleCommand) UnitTag() names.UnitTag {
+	return c.unitTag
+}
+
+func (c *AddScheduleCommand) ActionName() string {
+	return c.actionName
+}
+
+func (c *AddScheduleCommand) Schedule() string {
+	return c.schedule
+}
+
+func (c *AddScheduleCommand) Args() [][]string {
+	return c.args
+}
+
+func (c *AddScheduleCommand) Timeout() time.Duration {
+	return c.timeout
+}
+
+type RemoveScheduleCommand struct {
+	*removeScheduleCommand
+}
+
+func (c *RemoveScheduleCommand) Ids() []string {
+	return c.ids
+}
+
 type ListCommand struct {
 	*listCommand
 }
@@ -79,12 +143,36 @@ func NewListCommandForTest(store jujuclient.ClientStore) (cmd.Command, *ListComm
 	return modelcmd.Wrap(c, modelcmd.ModelSkipDefault), &ListCommand{c}
 }
 
+func NewCancelCommandForTest(store jujuclient.ClientStore) (cmd.Command, *CancelCommand) {
+	c := &cancelCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c), &CancelCommand{c}
+}
+
 func NewRunCommandForTest(store jujuclient.ClientStore) (cmd.Command, *RunCommand) {
-	c := &runCommand{}
+	c := &runCommand{clock: clock.WallClock}
 	c.SetClientStore(store)
 	return modelcmd.Wrap(c, modelcmd.ModelSkipDefault), &RunCommand{c}
 }
 
+func NewAddScheduleCommandForTest(store jujuclient.ClientStore) (cmd.Command, *AddScheduleCommand) {
+	c := &addScheduleCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c), &AddScheduleCommand{c}
+}
+
+func NewListSchedulesCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &listSchedulesCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewRemoveScheduleCommandForTest(store jujuclient.ClientStore) (cmd.Command, *RemoveScheduleCommand) {
+	c := &removeScheduleCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c), &RemoveScheduleCommand{c}
+}
+
 func ActionResultsToMap(results []params.ActionResult) map[string]interface{} {
 	return resultsToMap(results)
 }
@@ -126,11 +126,21 @@ type fakeAPIClient struct {
 	timeout            *time.Timer
 	actionResults      []params.ActionResult
 	enqueuedActions    params.Actions
+	cancelledActions   params.Entities
 	actionsByReceivers []params.ActionsByReceiver
 	actionTagMatches   params.FindTagsResults
 	actionsByNames     params.ActionsByNames
 	charmActions       *charm.Actions
+	serviceReceivers   []params.ServiceActionReceiversResult
+	addedSchedules     params.ActionSchedules
+	scheduleResults    []params.ActionScheduleResult
+	schedules          []params.ActionSchedule
+	removedSchedules   params.ActionScheduleIds
+	errorResults       []params.ErrorResult
 	apiErr             error
+
+	// oldAPI makes the client report version 1 of the Action facade.
+	oldAPI bool
 }
 
 var _ action.APIClient = (*fakeAPIClient)(nil)
@@ -145,6 +155,13 @@ func (c *fakeAPIClient) Close() error {
 	return nil
 }
 
+func (c *fakeAPIClient) BestAPIVersion() int {
+	if c.oldAPI {
+		return 1
+	}
+	return 2
+}
+
 func (c *fakeAPIClient) Enqueue(args params.Actions) (params.ActionResults, error) {
 	c.enqueuedActions = args
 	return params.ActionResults{Results: c.actionResults}, c.apiErr
@@ -168,7 +185,8 @@ func (c *fakeAPIClient) ListCompleted(args params.Entities) (params.ActionsByRec
 	}, c.apiErr
 }
 
-func (c *fakeAPIClient) Cancel(args params.Actions) (params.ActionResults, error) {
+func (c *fakeAPIClient) Cancel(args params.Entities) (params.ActionResults, error) {
+	c.cancelledActions = args
 	return params.ActionResults{
 		Results: c.actionResults,
 	}, c.apiErr
@@ -178,6 +196,24 @@ func (c *fakeAPIClient) ServiceCharmActions(params.Entity) (*charm.Actions, erro
 	return c.charmActions, c.apiErr
 }
 
+func (c *fakeAPIClient) ServicesActionReceivers(params.Entities) (params.ServicesActionReceiversResults, error) {
+	return params.ServicesActionReceiversResults{Results: c.serviceReceivers}, c.apiErr
+}
+
+func (c *fakeAPIClient) AddSchedules(args params.ActionSchedules) (params.ActionScheduleResults, error) {
+	c.addedSchedules = args
+	return params.ActionScheduleResults{Results: c.scheduleResults}, c.apiErr
+}
+
+func (c *fakeAPIClient) ListSchedules() (params.ActionSchedules, error) {
+	return params.ActionSchedules{Schedules: c.schedules}, c.apiErr
+}
+
+func (c *fakeAPIClient) RemoveSchedules(args params.ActionScheduleIds) (params.ErrorResults, error) {
+	c.removedSchedules = args
+	return params.ErrorResults{Results: c.errorResults}, c.apiErr
+}
+
 func (c *fakeAPIClient) Actions(args params.Entities) (params.ActionResults, error) {
 	// If the test supplies a delay time too long, we'll return an error
 	// to prevent the test hanging.  If the given wait is up, then return
@@ -7,32 +7,47 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/names"
-	yaml "gopkg.in/yaml.v2"
+	"github.com/juju/utils/clock"
 	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/apiserver/params"
-	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/unitsort"
 )
 
 var keyRule = regexp.MustCompile("^[a-z0-9](?:[a-z0-9-]*[a-z0-9])?$")
 
 func NewRunCommand() cmd.Command {
-	return modelcmd.Wrap(&runCommand{})
+	return modelcmd.Wrap(&runCommand{clock: clock.WallClock})
 }
 
-// runCommand enqueues an Action for running on the given unit with given
-// params
+// leaderSuffix is appended to a service name to target only the unit
+// that is currently the service's leader.
+const leaderSuffix = "/leader"
+
+// actionPollInterval is how often the results of actions run across a
+// service are checked.
+var actionPollInterval = 2 * time.Second
+
+// runCommand enqueues an Action for running on the given unit, or on
+// the units of the given service, with given params
 type runCommand struct {
 	ActionCommandBase
 	unitTag      names.UnitTag
+	serviceName  string
+	leaderOnly   bool
+	batchSize    int
+	wait         time.Duration
+	clock        clock.Clock
 	actionName   string
 	paramsYAML   cmd.FileVar
 	parseStrings bool
+	timeout      time.Duration
 	out          cmd.Output
 	args         [][]string
 }
@@ -41,6 +56,19 @@ const runDoc = `
 Queue an Action for execution on a given unit, with a given set of params.
 Displays the ID of the Action for use with 'juju kill', 'juju status', etc.
 
+The action may instead be run on every unit of a service, by giving the
+service name in place of the unit, or on just the service's leader, by
+giving the service name followed by "/leader".  In either case the command
+waits for the actions to finish, and then displays the results of all of
+them, keyed by unit.  The --batch-size flag limits how many of a service's
+units run the action at once: the units are taken in batches of that size,
+and each batch is started only once the previous one has finished.  If the
+action fails on any unit in a batch, no further batches are started.
+The --wait flag bounds how long the command waits for all the batches to
+finish.  When it expires, the results so far are displayed, no further
+batches are started, and actions that are still running are left to
+finish on their units.
+
 Params are validated according to the charm for the unit's service.  The 
 valid params can be seen using "juju action defined <service> --schema".
 Params may be in a yaml file which is passed with the --params flag, or they
@@ -53,6 +81,11 @@ is a boolean true in YAML.
 If --params is passed, along with key.key...=value explicit arguments, the
 explicit arguments will override the parameter file.
 
+If --timeout is passed, the unit will stop the action and mark it as failed
+if it has not completed within the given duration.  Without --timeout, the
+action's "timeout" key in the charm's actions.yaml is used, if present; for
+example "timeout: 10m".  Otherwise the action may run indefinitely.
+
 Examples:
 
 $ juju run-action mysql/3 backup 
@@ -103,6 +136,26 @@ $ juju run-action sleeper/0 pause time=1000
 $ juju run-action sleeper/0 pause --string-args time=1000
 ...
 The value for the "time" param will be the string literal "1000".
+
+$ juju run-action mysql backup --batch-size 3
+...
+The action is run on every unit of mysql, three units at a time.
+...
+
+$ juju run-action mysql backup --batch-size 3 --wait 1h
+...
+As above, but the command gives up waiting after an hour.
+...
+
+$ juju run-action mysql/leader backup
+...
+The action is run on the mysql unit that is currently the leader.
+...
+
+$ juju run-action mysql/3 backup --timeout 30m
+...
+The action will be stopped and marked as failed if it is still running
+after 30 minutes.
 `
 
 // ActionNameRule describes the format an action name must match to be valid.
@@ -113,57 +166,68 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
 	f.Var(&c.paramsYAML, "params", "path to yaml-formatted params file")
 	f.BoolVar(&c.parseStrings, "string-args", false, "use raw string values of CLI args")
+	f.DurationVar(&c.timeout, "timeout", 0, "stop the action if it runs for longer than this")
+	f.IntVar(&c.batchSize, "batch-size", 0, "when running on a service, the number of units to run on at once")
+	f.DurationVar(&c.wait, "wait", 0, "when running on a service, how long to wait for the actions to finish")
 }
 
 func (c *runCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "run-action",
-		Args:    "<unit> <action name> [key.key.key...=value]",
+		Args:    "<unit>|<service>|<service>/leader <action name> [key.key.key...=value]",
 		Purpose: "queue an action for execution",
 		Doc:     runDoc,
 	}
 }
 
-// Init gets the unit tag, and checks for other correct args.
+// Init gets the unit tag or service name, and checks for other correct
+// args.
 func (c *runCommand) Init(args []string) error {
+	if c.timeout < 0 {
+		return errors.Errorf("invalid timeout %v", c.timeout)
+	}
+	if c.batchSize < 0 {
+		return errors.Errorf("invalid batch size %d", c.batchSize)
+	}
+	if c.wait < 0 {
+		return errors.Errorf("invalid wait %v", c.wait)
+	}
 	switch len(args) {
 	case 0:
 		return errors.New("no unit specified")
 	case 1:
 		return errors.New("no action specified")
 	default:
-		// Grab and verify the unit and action names.
-		unitName := args[0]
-		if !names.IsValidUnit(unitName) {
-			return errors.Errorf("invalid unit name %q", unitName)
+		// Grab and verify the target and action names.
+		target := args[0]
+		serviceName := strings.TrimSuffix(target, leaderSuffix)
+		switch {
+		case names.IsValidUnit(target):
+			c.unitTag = names.NewUnitTag(target)
+		case names.IsValidService(serviceName):
+			c.serviceName = serviceName
+			c.leaderOnly = serviceName != target
+		default:
+			return errors.Errorf("invalid unit or service name %q", target)
+		}
+		if c.batchSize > 0 && (c.serviceName == "" || c.leaderOnly) {
+			return errors.New("--batch-size can only be used when running on a service")
+		}
+		if c.wait > 0 && c.serviceName == "" {
+			return errors.New("--wait can only be used when running on a service")
 		}
 		ActionName := args[1]
 		if valid := ActionNameRule.MatchString(ActionName); !valid {
 			return fmt.Errorf("invalid action name %q", ActionName)
 		}
-		c.unitTag = names.NewUnitTag(unitName)
 		c.actionName = ActionName
 		if len(args) == 2 {
 			return nil
 		}
 		// Parse CLI key-value args if they exist.
-		c.args = make([][]string, 0)
-		for _, arg := range args[2:] {
-			thisArg := strings.SplitN(arg, "=", 2)
-			if len(thisArg) != 2 {
-				return fmt.Errorf("argument %q must be of the form key...=value", arg)
-			}
-			keySlice := strings.Split(thisArg[0], ".")
-			// check each key for validity
-			for _, key := range keySlice {
-				if valid := keyRule.MatchString(key); !valid {
-					return fmt.Errorf("key %q must start and end with lowercase alphanumeric, and contain only lowercase alphanumeric and hyphens", key)
-				}
-			}
-			// c.args={..., [key, key, key, key, value]}
-			c.args = append(c.args, append(keySlice, thisArg[1]))
-		}
-		return nil
+		var err error
+		c.args, err = parseKeyValueArgs(args[2:])
+		return err
 	}
 }
 
@@ -174,57 +238,13 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	}
 	defer api.Close()
 
-	actionParams := map[string]interface{}{}
-
-	if c.paramsYAML.Path != "" {
-		b, err := c.paramsYAML.Read(ctx)
-		if err != nil {
-			return err
-		}
-
-		err = yaml.Unmarshal(b, &actionParams)
-		if err != nil {
-			return err
-		}
-
-		conformantParams, err := common.ConformYAML(actionParams)
-		if err != nil {
-			return err
-		}
-
-		betterParams, ok := conformantParams.(map[string]interface{})
-		if !ok {
-			return errors.New("params must contain a YAML map with string keys")
-		}
-
-		actionParams = betterParams
-	}
-
-	// If we had explicit args {..., [key, key, key, key, value], ...}
-	// then iterate and set params ..., key.key.key.key=value, ...
-	for _, argSlice := range c.args {
-		valueIndex := len(argSlice) - 1
-		keys := argSlice[:valueIndex]
-		value := argSlice[valueIndex]
-		cleansedValue := interface{}(value)
-		if !c.parseStrings {
-			err := yaml.Unmarshal([]byte(value), &cleansedValue)
-			if err != nil {
-				return err
-			}
-		}
-		// Insert the value in the map.
-		addValueToMap(keys, cleansedValue, actionParams)
-	}
-
-	conformantParams, err := common.ConformYAML(actionParams)
+	actionParams, err := buildActionParams(ctx, c.paramsYAML, c.args, c.parseStrings)
 	if err != nil {
 		return err
 	}
 
-	typedConformantParams, ok := conformantParams.(map[string]interface{})
-	if !ok {
-		return errors.Errorf("params must be a map, got %T", typedConformantParams)
+	if c.serviceName != "" {
+		return c.runOnService(ctx, api, actionParams)
 	}
 
 	actionParam := params.Actions{
@@ -232,6 +252,7 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 			Receiver:   c.unitTag.String(),
 			Name:       c.actionName,
 			Parameters: actionParams,
+			Timeout:    c.timeout,
 		}},
 	}
 
@@ -261,3 +282,209 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	output := map[string]string{"Action queued with id": tag.Id()}
 	return c.out.Write(ctx, output)
 }
+
+// runOnService enqueues the action on the units of the target service, or
+// on its leader, in batches, waiting for each batch to finish before
+// starting the next. It writes the combined results keyed by unit name.
+func (c *runCommand) runOnService(ctx *cmd.Context, api APIClient, actionParams map[string]interface{}) error {
+	if api.BestAPIVersion() < 2 {
+		return errors.New("cannot run actions on services: not supported by the API server")
+	}
+	units, err := c.serviceUnits(api)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	batchSize := c.batchSize
+	if batchSize == 0 {
+		batchSize = len(units)
+	}
+
+	var deadline time.Time
+	if c.wait > 0 {
+		deadline = c.clock.Now().Add(c.wait)
+	}
+	output := make(map[string]interface{})
+	var failed, unfinished, skipped int
+	for start := 0; start < len(units); start += batchSize {
+		if failed > 0 || unfinished > 0 {
+			skipped = len(units) - start
+			break
+		}
+		end := start + batchSize
+		if end > len(units) {
+			end = len(units)
+		}
+		batch := units[start:end]
+		if batchSize < len(units) {
+			ctx.Infof("running %s on %s", c.actionName, unitNames(batch))
+		}
+		results, err := c.runBatch(api, batch, actionParams, deadline)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for i, result := range results {
+			output[batch[i].Id()] = formatServiceActionResult(result)
+			switch {
+			case result.Error != nil:
+				failed++
+			case isUnfinished(result.Status):
+				unfinished++
+			case result.Status != params.ActionCompleted:
+				failed++
+			}
+		}
+	}
+
+	if err := c.out.Write(ctx, output); err != nil {
+		return errors.Trace(err)
+	}
+	switch {
+	case unfinished > 0:
+		return errors.Errorf("timed out after %v with %d action(s) unfinished; not run on the remaining %d unit(s)", c.wait, unfinished, skipped)
+	case skipped > 0:
+		return errors.Errorf("action failed on %d unit(s); not run on the remaining %d", failed, skipped)
+	case failed > 0:
+		return errors.Errorf("action failed on %d of %d unit(s)", failed, len(units))
+	}
+	return nil
+}
+
+// serviceUnits returns the tags of the units the action should run on,
+// in unit number order.
+func (c *runCommand) serviceUnits(api APIClient) ([]names.UnitTag, error) {
+	serviceTag := names.NewServiceTag(c.serviceName)
+	results, err := api.ServicesActionReceivers(params.Entities{
+		Entities: []params.Entity{{Tag: serviceTag.String()}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	unitTags := result.Units
+	if c.leaderOnly {
+		if result.Leader == "" {
+			return nil, errors.Errorf("service %q has no leader", c.serviceName)
+		}
+		unitTags = []string{result.Leader}
+	}
+	if len(unitTags) == 0 {
+		return nil, errors.Errorf("service %q has no units", c.serviceName)
+	}
+	unitNames := make([]string, len(unitTags))
+	for i, unitTag := range unitTags {
+		unit, err := names.ParseUnitTag(unitTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		unitNames[i] = unit.Id()
+	}
+	unitsort.Sort(unitNames)
+	units := make([]names.UnitTag, len(unitNames))
+	for i, unitName := range unitNames {
+		units[i] = names.NewUnitTag(unitName)
+	}
+	return units, nil
+}
+
+// runBatch enqueues the action on each of the given units, and waits for
+// all of the actions to finish, or until the deadline passes if it is not
+// zero. It returns their results in unit order.
+func (c *runCommand) runBatch(api APIClient, units []names.UnitTag, actionParams map[string]interface{}, deadline time.Time) ([]params.ActionResult, error) {
+	actions := make([]params.Action, len(units))
+	for i, unit := range units {
+		actions[i] = params.Action{
+			Receiver:   unit.String(),
+			Name:       c.actionName,
+			Parameters: actionParams,
+			Timeout:    c.timeout,
+		}
+	}
+	enqueued, err := api.Enqueue(params.Actions{Actions: actions})
+	if err != nil {
+		return nil, err
+	}
+	if len(enqueued.Results) != len(units) {
+		return nil, errors.Errorf("expected %d results, got %d", len(units), len(enqueued.Results))
+	}
+
+	results := enqueued.Results
+	var waiting []int
+	for i, result := range results {
+		if result.Error == nil && result.Action != nil {
+			waiting = append(waiting, i)
+		}
+	}
+	for len(waiting) > 0 {
+		entities := make([]params.Entity, len(waiting))
+		for j, i := range waiting {
+			entities[j] = params.Entity{Tag: results[i].Action.Tag}
+		}
+		current, err := api.Actions(params.Entities{Entities: entities})
+		if err != nil {
+			return nil, err
+		}
+		if len(current.Results) != len(waiting) {
+			return nil, errors.Errorf("expected %d results, got %d", len(waiting), len(current.Results))
+		}
+		var stillWaiting []int
+		for j, i := range waiting {
+			result := current.Results[j]
+			if result.Action == nil {
+				result.Action = results[i].Action
+			}
+			results[i] = result
+			if isUnfinished(result.Status) {
+				stillWaiting = append(stillWaiting, i)
+			}
+		}
+		waiting = stillWaiting
+		if len(waiting) > 0 {
+			if !deadline.IsZero() && !c.clock.Now().Before(deadline) {
+				break
+			}
+			<-c.clock.After(actionPollInterval)
+		}
+	}
+	return results, nil
+}
+
+// isUnfinished returns whether an action with the given status has yet
+// to finish.
+func isUnfinished(status string) bool {
+	switch status {
+	case params.ActionPending, params.ActionRunning, params.ActionAborting:
+		return true
+	}
+	return false
+}
+
+// formatServiceActionResult formats the result of an action run on one
+// of a service's units for display alongside the others.
+func formatServiceActionResult(result params.ActionResult) map[string]interface{} {
+	if result.Error != nil {
+		return map[string]interface{}{"error": result.Error.Error()}
+	}
+	formatted := FormatActionResult(result)
+	if result.Action != nil {
+		if tag, err := names.ParseActionTag(result.Action.Tag); err == nil {
+			formatted["id"] = tag.Id()
+		}
+	}
+	return formatted
+}
+
+// unitNames returns a comma-separated list of the units' names.
+func unitNames(units []names.UnitTag) string {
+	unitNames := make([]string, len(units))
+	for i, unit := range units {
+		unitNames[i] = unit.Id()
+	}
+	return strings.Join(unitNames, ", ")
+}
@@ -5,10 +5,14 @@ package action_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"strings"
+	"time"
 	"unicode/utf8"
 
+	"github.com/juju/cmd"
 	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
@@ -60,10 +64,14 @@ func (s *RunSuite) TestInit(c *gc.C) {
 		should               string
 		args                 []string
 		expectUnit           names.UnitTag
+		expectService        string
+		expectLeaderOnly     bool
+		expectBatchSize      int
 		expectAction         string
 		expectParamsYamlPath string
 		expectParseStrings   bool
 		expectKVArgs         [][]string
+		expectTimeout        time.Duration
 		expectOutput         string
 		expectError          string
 	}{{
@@ -77,7 +85,44 @@ func (s *RunSuite) TestInit(c *gc.C) {
 	}, {
 		should:      "fail with invalid unit tag",
 		args:        []string{invalidUnitId, "valid-action-name"},
-		expectError: "invalid unit name \"something-strange-\"",
+		expectError: "invalid unit or service name \"something-strange-\"",
+	}, {
+		should:        "init properly with a service",
+		args:          []string{validServiceId, "valid-action-name"},
+		expectService: validServiceId,
+		expectAction:  "valid-action-name",
+	}, {
+		should:           "init properly with a service leader",
+		args:             []string{validServiceId + "/leader", "valid-action-name"},
+		expectService:    validServiceId,
+		expectLeaderOnly: true,
+		expectAction:     "valid-action-name",
+	}, {
+		should:          "handle --batch-size with a service",
+		args:            []string{validServiceId, "valid-action-name", "--batch-size", "3"},
+		expectService:   validServiceId,
+		expectAction:    "valid-action-name",
+		expectBatchSize: 3,
+	}, {
+		should:      "fail with --batch-size and a unit",
+		args:        []string{validUnitId, "valid-action-name", "--batch-size", "3"},
+		expectError: "--batch-size can only be used when running on a service",
+	}, {
+		should:      "fail with --batch-size and a service leader",
+		args:        []string{validServiceId + "/leader", "valid-action-name", "--batch-size", "3"},
+		expectError: "--batch-size can only be used when running on a service",
+	}, {
+		should:      "fail with negative --batch-size",
+		args:        []string{validServiceId, "valid-action-name", "--batch-size", "-1"},
+		expectError: "invalid batch size -1",
+	}, {
+		should:      "fail with --wait and a unit",
+		args:        []string{validUnitId, "valid-action-name", "--wait", "1m"},
+		expectError: "--wait can only be used when running on a service",
+	}, {
+		should:      "fail with negative --wait",
+		args:        []string{validServiceId, "valid-action-name", "--wait", "-1m"},
+		expectError: "invalid wait -1m0s",
 	}, {
 		should:      "fail with invalid action name",
 		args:        []string{validUnitId, "BadName"},
@@ -118,6 +163,16 @@ func (s *RunSuite) TestInit(c *gc.C) {
 		args:         []string{validUnitId, "valid-action-name"},
 		expectUnit:   names.NewUnitTag(validUnitId),
 		expectAction: "valid-action-name",
+	}, {
+		should:        "handle --timeout",
+		args:          []string{validUnitId, "valid-action-name", "--timeout", "10m"},
+		expectUnit:    names.NewUnitTag(validUnitId),
+		expectAction:  "valid-action-name",
+		expectTimeout: 10 * time.Minute,
+	}, {
+		should:      "fail with negative --timeout",
+		args:        []string{validUnitId, "valid-action-name", "--timeout", "-1s"},
+		expectError: "invalid timeout -1s",
 	}, {
 		should:               "handle --params properly",
 		args:                 []string{validUnitId, "valid-action-name", "--params=foo.yml"},
@@ -169,10 +224,14 @@ func (s *RunSuite) TestInit(c *gc.C) {
 			err := testing.InitCommand(wrappedCommand, args)
 			if t.expectError == "" {
 				c.Check(command.UnitTag(), gc.Equals, t.expectUnit)
+				c.Check(command.ServiceName(), gc.Equals, t.expectService)
+				c.Check(command.LeaderOnly(), gc.Equals, t.expectLeaderOnly)
+				c.Check(command.BatchSize(), gc.Equals, t.expectBatchSize)
 				c.Check(command.ActionName(), gc.Equals, t.expectAction)
 				c.Check(command.ParamsYAML().Path, gc.Equals, t.expectParamsYamlPath)
 				c.Check(command.Args(), jc.DeepEquals, t.expectKVArgs)
 				c.Check(command.ParseStrings(), gc.Equals, t.expectParseStrings)
+				c.Check(command.Timeout(), gc.Equals, t.expectTimeout)
 			} else {
 				c.Check(err, gc.ErrorMatches, t.expectError)
 			}
@@ -252,6 +311,18 @@ func (s *RunSuite) TestRun(c *gc.C) {
 			Parameters: map[string]interface{}{},
 			Receiver:   names.NewUnitTag(validUnitId).String(),
 		},
+	}, {
+		should:   "enqueue an action with a timeout",
+		withArgs: []string{validUnitId, "some-action", "--timeout", "90s"},
+		withActionResults: []params.ActionResult{{
+			Action: &params.Action{Tag: validActionTagString},
+		}},
+		expectedActionEnqueued: params.Action{
+			Name:       "some-action",
+			Parameters: map[string]interface{}{},
+			Receiver:   names.NewUnitTag(validUnitId).String(),
+			Timeout:    90 * time.Second,
+		},
 	}, {
 		should: "enqueue an action with some explicit params",
 		withArgs: []string{validUnitId, "some-action",
@@ -399,3 +470,240 @@ func (s *RunSuite) TestRun(c *gc.C) {
 		}
 	}
 }
+
+func (s *RunSuite) TestRunOnService(c *gc.C) {
+	client := s.patchServiceRunClient(c, params.ServiceActionReceiversResult{
+		ServiceTag: "service-mysql",
+		Units:      []string{"unit-mysql-10", "unit-mysql-1", "unit-mysql-2", "unit-mysql-0"},
+	})
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	ctx, err := testing.RunCommand(c, wrappedCommand,
+		"-m", "admin", "mysql", "backup", "--batch-size", "2", "--timeout", "1m", "--format", "json",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(client.batches, jc.DeepEquals, [][]string{
+		{"unit-mysql-0", "unit-mysql-1"},
+		{"unit-mysql-2", "unit-mysql-10"},
+	})
+	c.Check(client.enqueued, gc.HasLen, 4)
+	for _, a := range client.enqueued {
+		c.Check(a.Name, gc.Equals, "backup")
+		c.Check(a.Timeout, gc.Equals, time.Minute)
+	}
+	c.Check(testing.Stderr(ctx), gc.Equals, ""+
+		"running backup on mysql/0, mysql/1\n"+
+		"running backup on mysql/2, mysql/10\n",
+	)
+
+	output := s.serviceRunOutput(c, ctx)
+	c.Check(output, gc.HasLen, 4)
+	for i, unit := range []string{"mysql/0", "mysql/1", "mysql/2", "mysql/10"} {
+		c.Check(output[unit]["id"], gc.Equals, fakeActionId(i+1))
+		c.Check(output[unit]["status"], gc.Equals, params.ActionCompleted)
+		c.Check(output[unit]["results"], jc.DeepEquals, map[string]interface{}{"unit": unit})
+	}
+}
+
+func (s *RunSuite) TestRunOnServiceStopsAfterFailedBatch(c *gc.C) {
+	client := s.patchServiceRunClient(c, params.ServiceActionReceiversResult{
+		ServiceTag: "service-mysql",
+		Units:      []string{"unit-mysql-0", "unit-mysql-1", "unit-mysql-2", "unit-mysql-3"},
+	})
+	client.failUnits["unit-mysql-1"] = true
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	ctx, err := testing.RunCommand(c, wrappedCommand,
+		"-m", "admin", "mysql", "backup", "--batch-size", "1", "--format", "json",
+	)
+	c.Assert(err, gc.ErrorMatches, `action failed on 1 unit\(s\); not run on the remaining 2`)
+	c.Check(client.batches, jc.DeepEquals, [][]string{{"unit-mysql-0"}, {"unit-mysql-1"}})
+
+	output := s.serviceRunOutput(c, ctx)
+	c.Check(output, gc.HasLen, 2)
+	c.Check(output["mysql/0"]["status"], gc.Equals, params.ActionCompleted)
+	c.Check(output["mysql/1"]["status"], gc.Equals, params.ActionFailed)
+	c.Check(output["mysql/1"]["message"], gc.Equals, "oops")
+}
+
+func (s *RunSuite) TestRunOnServiceReportsFailures(c *gc.C) {
+	client := s.patchServiceRunClient(c, params.ServiceActionReceiversResult{
+		ServiceTag: "service-mysql",
+		Units:      []string{"unit-mysql-0", "unit-mysql-1"},
+	})
+	client.failUnits["unit-mysql-0"] = true
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	ctx, err := testing.RunCommand(c, wrappedCommand, "-m", "admin", "mysql", "backup", "--format", "json")
+	c.Assert(err, gc.ErrorMatches, `action failed on 1 of 2 unit\(s\)`)
+	c.Check(client.batches, jc.DeepEquals, [][]string{{"unit-mysql-0", "unit-mysql-1"}})
+	c.Check(testing.Stderr(ctx), gc.Equals, "")
+
+	output := s.serviceRunOutput(c, ctx)
+	c.Check(output["mysql/0"]["status"], gc.Equals, params.ActionFailed)
+	c.Check(output["mysql/1"]["status"], gc.Equals, params.ActionCompleted)
+}
+
+func (s *RunSuite) TestRunOnLeader(c *gc.C) {
+	client := s.patchServiceRunClient(c, params.ServiceActionReceiversResult{
+		ServiceTag: "service-mysql",
+		Units:      []string{"unit-mysql-0", "unit-mysql-1"},
+		Leader:     "unit-mysql-1",
+	})
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	ctx, err := testing.RunCommand(c, wrappedCommand, "-m", "admin", "mysql/leader", "backup", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(client.batches, jc.DeepEquals, [][]string{{"unit-mysql-1"}})
+
+	output := s.serviceRunOutput(c, ctx)
+	c.Check(output, gc.HasLen, 1)
+	c.Check(output["mysql/1"]["status"], gc.Equals, params.ActionCompleted)
+}
+
+func (s *RunSuite) TestRunOnLeaderWithoutLeader(c *gc.C) {
+	client := s.patchServiceRunClient(c, params.ServiceActionReceiversResult{
+		ServiceTag: "service-mysql",
+		Units:      []string{"unit-mysql-0"},
+	})
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	_, err := testing.RunCommand(c, wrappedCommand, "-m", "admin", "mysql/leader", "backup")
+	c.Assert(err, gc.ErrorMatches, `service "mysql" has no leader`)
+	c.Check(client.batches, gc.HasLen, 0)
+}
+
+func (s *RunSuite) TestRunOnServiceWithoutUnits(c *gc.C) {
+	s.patchServiceRunClient(c, params.ServiceActionReceiversResult{
+		ServiceTag: "service-mysql",
+	})
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	_, err := testing.RunCommand(c, wrappedCommand, "-m", "admin", "mysql", "backup")
+	c.Assert(err, gc.ErrorMatches, `service "mysql" has no units`)
+}
+
+func (s *RunSuite) TestRunOnServiceWait(c *gc.C) {
+	client := s.patchServiceRunClient(c, params.ServiceActionReceiversResult{
+		ServiceTag: "service-mysql",
+		Units:      []string{"unit-mysql-0", "unit-mysql-1", "unit-mysql-2"},
+	})
+	client.hangUnits["unit-mysql-1"] = true
+	s.PatchValue(action.ActionPollInterval, time.Minute)
+	clock := testing.NewClock(time.Time{})
+
+	wrappedCommand, command := action.NewRunCommandForTest(s.store)
+	command.SetClock(&testing.AutoAdvancingClock{clock, clock.Advance})
+	ctx, err := testing.RunCommand(c, wrappedCommand,
+		"-m", "admin", "mysql", "backup", "--batch-size", "2", "--wait", "5m", "--format", "json",
+	)
+	c.Assert(err, gc.ErrorMatches, `timed out after 5m0s with 1 action\(s\) unfinished; not run on the remaining 1 unit\(s\)`)
+	c.Check(client.batches, jc.DeepEquals, [][]string{{"unit-mysql-0", "unit-mysql-1"}})
+	c.Check(clock.Now(), gc.Equals, time.Time{}.Add(5*time.Minute))
+
+	output := s.serviceRunOutput(c, ctx)
+	c.Check(output, gc.HasLen, 2)
+	c.Check(output["mysql/0"]["status"], gc.Equals, params.ActionCompleted)
+	c.Check(output["mysql/1"]["status"], gc.Equals, params.ActionRunning)
+}
+
+func (s *RunSuite) TestRunOnServiceOldAPI(c *gc.C) {
+	client := s.patchServiceRunClient(c, params.ServiceActionReceiversResult{
+		ServiceTag: "service-mysql",
+		Units:      []string{"unit-mysql-0"},
+	})
+	client.oldAPI = true
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	_, err := testing.RunCommand(c, wrappedCommand, "-m", "admin", "mysql", "backup")
+	c.Assert(err, gc.ErrorMatches, "cannot run actions on services: not supported by the API server")
+	c.Check(client.batches, gc.HasLen, 0)
+}
+
+func (s *RunSuite) patchServiceRunClient(c *gc.C, receivers params.ServiceActionReceiversResult) *serviceRunClient {
+	client := &serviceRunClient{
+		fakeAPIClient: &fakeAPIClient{
+			serviceReceivers: []params.ServiceActionReceiversResult{receivers},
+		},
+		failUnits: make(map[string]bool),
+		hangUnits: make(map[string]bool),
+		receivers: make(map[string]string),
+		polled:    make(map[string]bool),
+	}
+	s.PatchValue(action.NewActionAPIClient,
+		func(*action.ActionCommandBase) (action.APIClient, error) {
+			return client, nil
+		},
+	)
+	s.PatchValue(action.ActionPollInterval, time.Duration(0))
+	return client
+}
+
+func (s *RunSuite) serviceRunOutput(c *gc.C, ctx *cmd.Context) map[string]map[string]interface{} {
+	var output map[string]map[string]interface{}
+	err := json.Unmarshal(ctx.Stdout.(*bytes.Buffer).Bytes(), &output)
+	c.Assert(err, jc.ErrorIsNil)
+	return output
+}
+
+func fakeActionId(n int) string {
+	return fmt.Sprintf("%08d-0000-4000-8000-000000000000", n)
+}
+
+// serviceRunClient fakes the API calls made when running an action across
+// a service. Each enqueued action is reported as running when first
+// polled, and then as completed, or as failed if its unit is in failUnits.
+// Actions on units in hangUnits never finish.
+type serviceRunClient struct {
+	*fakeAPIClient
+	failUnits map[string]bool
+	hangUnits map[string]bool
+	batches   [][]string
+	enqueued  []params.Action
+	receivers map[string]string
+	polled    map[string]bool
+}
+
+func (c *serviceRunClient) Enqueue(args params.Actions) (params.ActionResults, error) {
+	var batch []string
+	results := make([]params.ActionResult, len(args.Actions))
+	for i, a := range args.Actions {
+		c.enqueued = append(c.enqueued, a)
+		batch = append(batch, a.Receiver)
+		tag := names.NewActionTag(fakeActionId(len(c.enqueued))).String()
+		c.receivers[tag] = a.Receiver
+		results[i] = params.ActionResult{
+			Action: &params.Action{Tag: tag, Receiver: a.Receiver, Name: a.Name},
+			Status: params.ActionPending,
+		}
+	}
+	c.batches = append(c.batches, batch)
+	return params.ActionResults{Results: results}, nil
+}
+
+func (c *serviceRunClient) Actions(args params.Entities) (params.ActionResults, error) {
+	results := make([]params.ActionResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		receiver := c.receivers[entity.Tag]
+		result := params.ActionResult{
+			Action: &params.Action{Tag: entity.Tag, Receiver: receiver},
+		}
+		switch {
+		case !c.polled[entity.Tag] || c.hangUnits[receiver]:
+			c.polled[entity.Tag] = true
+			result.Status = params.ActionRunning
+		case c.failUnits[receiver]:
+			result.Status = params.ActionFailed
+			result.Message = "oops"
+		default:
+			unitTag, err := names.ParseUnitTag(receiver)
+			if err != nil {
+				return params.ActionResults{}, err
+			}
+			result.Status = params.ActionCompleted
+			result.Output = map[string]interface{}{"unit": unitTag.Id()}
+		}
+		results[i] = result
+	}
+	return params.ActionResults{Results: results}, nil
+}
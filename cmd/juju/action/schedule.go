@@ -0,0 +1,319 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+func NewAddScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&addScheduleCommand{})
+}
+
+// addScheduleCommand adds a schedule on which an action is enqueued on
+// a unit.
+type addScheduleCommand struct {
+	ActionCommandBase
+	unitTag      names.UnitTag
+	actionName   string
+	schedule     string
+	paramsYAML   cmd.FileVar
+	parseStrings bool
+	timeout      time.Duration
+	out          cmd.Output
+	args         [][]string
+}
+
+const addScheduleDoc = `
+Schedule an action to be queued for execution on a unit repeatedly, at the
+times given by a cron-style schedule.  Each time the schedule falls due, the
+action is queued just as if it had been run with 'juju run-action', and may
+be followed with 'juju show-action-status', which shows the ID of the
+schedule that queued it.
+
+The schedule has the five standard cron fields, separated by spaces, and
+should be quoted: minute, hour, day of month, month and day of week.  Each
+field may be "*", a value, a range such as "1-5", or a comma-separated list
+of these; values and ranges may be followed by a step such as "/15".  Months
+and days of the week may be given by name, such as "jan" or "mon".  One of
+@yearly, @monthly, @weekly, @daily or @hourly may be given instead.  All
+times are in UTC.
+
+If the controller is unavailable when the schedule falls due, the action is
+queued once when it is available again; further runs that were missed are
+skipped.
+
+Params and --timeout are given as for 'juju run-action', and are validated
+when the schedule is added.  The schedule is removed if the unit is.
+
+Examples:
+
+$ juju add-action-schedule mysql/3 backup "0 3 * * *" out=backup.tar.bz2
+schedule: <ID>
+...
+The backup action is queued on mysql/3 at 03:00 UTC every day.
+...
+
+$ juju add-action-schedule mysql/3 backup "*/30 9-17 * * mon-fri"
+...
+The backup action is queued every half hour during working hours.
+...
+
+See also:
+    list-action-schedules
+    remove-action-schedule
+`
+
+// SetFlags offers an option for YAML output.
+func (c *addScheduleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+	f.Var(&c.paramsYAML, "params", "path to yaml-formatted params file")
+	f.BoolVar(&c.parseStrings, "string-args", false, "use raw string values of CLI args")
+	f.DurationVar(&c.timeout, "timeout", 0, "stop each action if it runs for longer than this")
+}
+
+func (c *addScheduleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-action-schedule",
+		Args:    "<unit> <action name> <schedule> [key.key.key...=value]",
+		Purpose: "queue an action for execution on a schedule",
+		Doc:     addScheduleDoc,
+	}
+}
+
+// Init checks the unit, action name and params. The schedule itself is
+// checked by the controller.
+func (c *addScheduleCommand) Init(args []string) error {
+	if c.timeout < 0 {
+		return errors.Errorf("invalid timeout %v", c.timeout)
+	}
+	switch len(args) {
+	case 0:
+		return errors.New("no unit specified")
+	case 1:
+		return errors.New("no action specified")
+	case 2:
+		return errors.New("no schedule specified")
+	}
+	if !names.IsValidUnit(args[0]) {
+		return errors.Errorf("invalid unit name %q", args[0])
+	}
+	c.unitTag = names.NewUnitTag(args[0])
+	if !ActionNameRule.MatchString(args[1]) {
+		return errors.Errorf("invalid action name %q", args[1])
+	}
+	c.actionName = args[1]
+	c.schedule = args[2]
+	var err error
+	c.args, err = parseKeyValueArgs(args[3:])
+	return err
+}
+
+func (c *addScheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+	if api.BestAPIVersion() < 2 {
+		return errors.New("cannot add action schedules: not supported by the API server")
+	}
+
+	actionParams, err := buildActionParams(ctx, c.paramsYAML, c.args, c.parseStrings)
+	if err != nil {
+		return err
+	}
+	results, err := api.AddSchedules(params.ActionSchedules{
+		Schedules: []params.ActionSchedule{{
+			Receiver:   c.unitTag.String(),
+			Name:       c.actionName,
+			Parameters: actionParams,
+			Timeout:    c.timeout,
+			Schedule:   c.schedule,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	if len(results.Results) != 1 {
+		return errors.New("illegal number of results returned")
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.Schedule == nil {
+		return errors.New("action schedule failed to add")
+	}
+	return c.out.Write(ctx, map[string]interface{}{
+		"schedule": result.Schedule.Id,
+		"next-run": result.Schedule.NextRun,
+	})
+}
+
+func NewListSchedulesCommand() cmd.Command {
+	return modelcmd.Wrap(&listSchedulesCommand{})
+}
+
+// listSchedulesCommand lists the model's action schedules.
+type listSchedulesCommand struct {
+	ActionCommandBase
+	out cmd.Output
+}
+
+const listSchedulesDoc = `
+List the schedules on which actions are queued, with when each is next
+due, and the outcome of the last time it fell due: either the ID of the
+action it queued, or the error that prevented it from queueing one.
+`
+
+// Set up the output.
+func (c *listSchedulesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters)
+}
+
+func (c *listSchedulesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "list-action-schedules",
+		Purpose: "list the schedules on which actions are queued",
+		Doc:     listSchedulesDoc,
+		Aliases: []string{"action-schedules"},
+	}
+}
+
+func (c *listSchedulesCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *listSchedulesCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+	if api.BestAPIVersion() < 2 {
+		return errors.New("cannot list action schedules: not supported by the API server")
+	}
+
+	schedules, err := api.ListSchedules()
+	if err != nil {
+		return err
+	}
+	if len(schedules.Schedules) == 0 {
+		ctx.Infof("no action schedules")
+		return nil
+	}
+	items := make([]map[string]interface{}, len(schedules.Schedules))
+	for i, schedule := range schedules.Schedules {
+		items[i] = scheduleToMap(schedule)
+	}
+	return c.out.Write(ctx, map[string]interface{}{"schedules": items})
+}
+
+// scheduleToMap returns a params.ActionSchedule as a map ready to be
+// served to the formatter for printing.
+func scheduleToMap(schedule params.ActionSchedule) map[string]interface{} {
+	item := map[string]interface{}{
+		"id":       schedule.Id,
+		"action":   schedule.Name,
+		"schedule": schedule.Schedule,
+		"next-run": schedule.NextRun,
+	}
+	if tag, err := names.ParseUnitTag(schedule.Receiver); err != nil {
+		item["unit"] = schedule.Receiver
+	} else {
+		item["unit"] = tag.Id()
+	}
+	if len(schedule.Parameters) > 0 {
+		item["params"] = schedule.Parameters
+	}
+	if schedule.Timeout > 0 {
+		item["timeout"] = schedule.Timeout.String()
+	}
+	if !schedule.LastRun.IsZero() {
+		item["last-run"] = schedule.LastRun
+	}
+	if schedule.LastAction != "" {
+		if tag, err := names.ParseActionTag(schedule.LastAction); err != nil {
+			item["last-action"] = schedule.LastAction
+		} else {
+			item["last-action"] = tag.Id()
+		}
+	}
+	if schedule.LastError != "" {
+		item["last-error"] = schedule.LastError
+	}
+	return item
+}
+
+func NewRemoveScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&removeScheduleCommand{})
+}
+
+// removeScheduleCommand removes action schedules by ID.
+type removeScheduleCommand struct {
+	ActionCommandBase
+	ids []string
+}
+
+const removeScheduleDoc = `
+Remove the action schedules with the given IDs, as shown by
+'juju list-action-schedules'.  Actions already queued by the schedules are
+not affected; use 'juju cancel-action' to cancel them.
+`
+
+func (c *removeScheduleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-action-schedule",
+		Args:    "<schedule ID> [...]",
+		Purpose: "stop queueing actions on a schedule",
+		Doc:     removeScheduleDoc,
+	}
+}
+
+func (c *removeScheduleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no schedule ID specified")
+	}
+	c.ids = args
+	return nil
+}
+
+func (c *removeScheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+	if api.BestAPIVersion() < 2 {
+		return errors.New("cannot remove action schedules: not supported by the API server")
+	}
+
+	results, err := api.RemoveSchedules(params.ActionScheduleIds{Ids: c.ids})
+	if err != nil {
+		return err
+	}
+	if len(results.Results) != len(c.ids) {
+		return errors.Errorf("expected %d results, got %d", len(c.ids), len(results.Results))
+	}
+	failed := 0
+	for i, result := range results.Results {
+		if result.Error != nil {
+			ctx.Infof("cannot remove action schedule %q: %v", c.ids[i], result.Error)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return cmd.ErrSilent
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+	"github.com/juju/juju/testing"
+)
+
+type ScheduleSuite struct {
+	BaseActionSuite
+}
+
+var _ = gc.Suite(&ScheduleSuite{})
+
+func (s *ScheduleSuite) TestAddInit(c *gc.C) {
+	for i, t := range []struct {
+		args           []string
+		expectUnit     string
+		expectAction   string
+		expectSchedule string
+		expectArgs     [][]string
+		expectTimeout  time.Duration
+		expectError    string
+	}{{
+		expectError: "no unit specified",
+	}, {
+		args:        []string{validUnitId},
+		expectError: "no action specified",
+	}, {
+		args:        []string{validUnitId, "backup"},
+		expectError: "no schedule specified",
+	}, {
+		args:        []string{validServiceId, "backup", "@daily"},
+		expectError: `invalid unit name "mysql"`,
+	}, {
+		args:        []string{validUnitId, "BadName", "@daily"},
+		expectError: `invalid action name "BadName"`,
+	}, {
+		args:        []string{validUnitId, "backup", "@daily", "out"},
+		expectError: `argument "out" must be of the form key...=value`,
+	}, {
+		args:        []string{validUnitId, "backup", "@daily", "--timeout", "-1s"},
+		expectError: "invalid timeout -1s",
+	}, {
+		args:           []string{validUnitId, "backup", "0 3 * * *"},
+		expectUnit:     validUnitId,
+		expectAction:   "backup",
+		expectSchedule: "0 3 * * *",
+	}, {
+		args:           []string{validUnitId, "backup", "@hourly", "out=x.tar", "file.kind=xz", "--timeout", "5m"},
+		expectUnit:     validUnitId,
+		expectAction:   "backup",
+		expectSchedule: "@hourly",
+		expectArgs:     [][]string{{"out", "x.tar"}, {"file", "kind", "xz"}},
+		expectTimeout:  5 * time.Minute,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		wrapped, command := action.NewAddScheduleCommandForTest(s.store)
+		args := append([]string{"-m", "admin"}, t.args...)
+		err := testing.InitCommand(wrapped, args)
+		if t.expectError != "" {
+			c.Check(err, gc.ErrorMatches, t.expectError)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(command.UnitTag().Id(), gc.Equals, t.expectUnit)
+		c.Check(command.ActionName(), gc.Equals, t.expectAction)
+		c.Check(command.Schedule(), gc.Equals, t.expectSchedule)
+		if t.expectArgs != nil {
+			c.Check(command.Args(), jc.DeepEquals, t.expectArgs)
+		}
+		c.Check(command.Timeout(), gc.Equals, t.expectTimeout)
+	}
+}
+
+func (s *ScheduleSuite) TestAddRun(c *gc.C) {
+	nextRun := time.Date(2016, time.June, 16, 3, 0, 0, 0, time.UTC)
+	client := &fakeAPIClient{
+		scheduleResults: []params.ActionScheduleResult{{
+			Schedule: &params.ActionSchedule{Id: "7", NextRun: nextRun},
+		}},
+	}
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	wrapped, _ := action.NewAddScheduleCommandForTest(s.store)
+	ctx, err := testing.RunCommand(c, wrapped,
+		"-m", "admin", validUnitId, "backup", "0 3 * * *", "out=x.tar", "--timeout", "1h",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(client.addedSchedules, jc.DeepEquals, params.ActionSchedules{
+		Schedules: []params.ActionSchedule{{
+			Receiver:   "unit-mysql-0",
+			Name:       "backup",
+			Parameters: map[string]interface{}{"out": "x.tar"},
+			Timeout:    time.Hour,
+			Schedule:   "0 3 * * *",
+		}},
+	})
+	c.Check(ctx.Stdout.(*bytes.Buffer).String(), gc.Equals, ""+
+		"next-run: 2016-06-16T03:00:00Z\n"+
+		"schedule: \"7\"\n",
+	)
+}
+
+func (s *ScheduleSuite) TestAddRunError(c *gc.C) {
+	client := &fakeAPIClient{
+		scheduleResults: []params.ActionScheduleResult{{
+			Error: &params.Error{Message: `schedule "0 3 * *": expected 5 fields, got 4`},
+		}},
+	}
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	wrapped, _ := action.NewAddScheduleCommandForTest(s.store)
+	_, err := testing.RunCommand(c, wrapped, "-m", "admin", validUnitId, "backup", "0 3 * *")
+	c.Assert(err, gc.ErrorMatches, `schedule "0 3 \* \*": expected 5 fields, got 4`)
+}
+
+func (s *ScheduleSuite) TestList(c *gc.C) {
+	client := &fakeAPIClient{
+		schedules: []params.ActionSchedule{{
+			Id:         "1",
+			Receiver:   "unit-mysql-0",
+			Name:       "backup",
+			Parameters: map[string]interface{}{"out": "x.tar"},
+			Timeout:    time.Hour,
+			Schedule:   "0 3 * * *",
+			NextRun:    time.Date(2016, time.June, 16, 3, 0, 0, 0, time.UTC),
+			LastRun:    time.Date(2016, time.June, 15, 3, 0, 0, 0, time.UTC),
+			LastAction: validActionTagString,
+		}, {
+			Id:        "2",
+			Receiver:  "unit-mysql-1",
+			Name:      "backup",
+			Schedule:  "@hourly",
+			NextRun:   time.Date(2016, time.June, 15, 11, 0, 0, 0, time.UTC),
+			LastRun:   time.Date(2016, time.June, 15, 10, 0, 0, 0, time.UTC),
+			LastError: `unit "mysql/1" not found`,
+		}},
+	}
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	ctx, err := testing.RunCommand(c, action.NewListSchedulesCommandForTest(s.store), "-m", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ctx.Stdout.(*bytes.Buffer).String(), gc.Equals, `
+schedules:
+- action: backup
+  id: "1"
+  last-action: `+validActionId+`
+  last-run: 2016-06-15T03:00:00Z
+  next-run: 2016-06-16T03:00:00Z
+  params:
+    out: x.tar
+  schedule: 0 3 * * *
+  timeout: 1h0m0s
+  unit: mysql/0
+- action: backup
+  id: "2"
+  last-error: unit "mysql/1" not found
+  last-run: 2016-06-15T10:00:00Z
+  next-run: 2016-06-15T11:00:00Z
+  schedule: '@hourly'
+  unit: mysql/1
+`[1:])
+}
+
+func (s *ScheduleSuite) TestListEmpty(c *gc.C) {
+	restore := s.patchAPIClient(&fakeAPIClient{})
+	defer restore()
+
+	ctx, err := testing.RunCommand(c, action.NewListSchedulesCommandForTest(s.store), "-m", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, "")
+	c.Check(testing.Stderr(ctx), gc.Equals, "no action schedules\n")
+}
+
+func (s *ScheduleSuite) TestListOldAPI(c *gc.C) {
+	restore := s.patchAPIClient(&fakeAPIClient{oldAPI: true})
+	defer restore()
+
+	_, err := testing.RunCommand(c, action.NewListSchedulesCommandForTest(s.store), "-m", "admin")
+	c.Assert(err, gc.ErrorMatches, "cannot list action schedules: not supported by the API server")
+}
+
+func (s *ScheduleSuite) TestRemoveInit(c *gc.C) {
+	wrapped, _ := action.NewRemoveScheduleCommandForTest(s.store)
+	err := testing.InitCommand(wrapped, []string{"-m", "admin"})
+	c.Check(err, gc.ErrorMatches, "no schedule ID specified")
+
+	wrapped, command := action.NewRemoveScheduleCommandForTest(s.store)
+	err = testing.InitCommand(wrapped, []string{"-m", "admin", "1", "2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(command.Ids(), jc.DeepEquals, []string{"1", "2"})
+}
+
+func (s *ScheduleSuite) TestRemoveRun(c *gc.C) {
+	client := &fakeAPIClient{
+		errorResults: []params.ErrorResult{{}, {}},
+	}
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	wrapped, _ := action.NewRemoveScheduleCommandForTest(s.store)
+	_, err := testing.RunCommand(c, wrapped, "-m", "admin", "1", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(client.removedSchedules, jc.DeepEquals, params.ActionScheduleIds{
+		Ids: []string{"1", "2"},
+	})
+}
+
+func (s *ScheduleSuite) TestRemoveRunError(c *gc.C) {
+	client := &fakeAPIClient{
+		errorResults: []params.ErrorResult{{}, {
+			Error: &params.Error{Message: `action schedule "9" not found`},
+		}},
+	}
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	wrapped, _ := action.NewRemoveScheduleCommandForTest(s.store)
+	ctx, err := testing.RunCommand(c, wrapped, "-m", "admin", "1", "9")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Check(testing.Stderr(ctx), gc.Equals, `cannot remove action schedule "9": action schedule "9" not found`+"\n")
+}
@@ -134,6 +134,9 @@ func resultToMap(result params.ActionResult) map[string]interface{} {
 			item["unit"] = rtag.Id()
 		}
 
+		if result.Action.Schedule != "" {
+			item["schedule"] = result.Action.Schedule
+		}
 	}
 	item["status"] = result.Status
 	return item
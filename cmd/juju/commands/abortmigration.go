@@ -0,0 +1,93 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+func newAbortMigrationCommand() cmd.Command {
+	return modelcmd.WrapController(&abortMigrationCommand{})
+}
+
+// abortMigrationCommand aborts an active model migration.
+type abortMigrationCommand struct {
+	modelcmd.ControllerCommandBase
+	api abortMigrationAPI
+
+	model string
+}
+
+type abortMigrationAPI interface {
+	AbortModelMigration(modelUUID string) error
+}
+
+const abortMigrationDoc = `
+abort-migration stops an active migration of a model to another
+controller. Any partially imported copy of the model is removed from
+the target controller and the model continues to be managed by its
+current controller.
+
+A migration can only be aborted before it has succeeded. Once the
+target controller has taken control of the model, the migration can no
+longer be reversed.
+
+The abort happens in the background; its progress can be followed with
+the "status" command and in the logs. It is safe to run the command
+again for a migration that is already being aborted.
+
+See Also:
+   juju help migrate
+   juju help status
+`
+
+// Info implements cmd.Command.
+func (c *abortMigrationCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "abort-migration",
+		Args:    "<model-name>",
+		Purpose: "abort an active model migration",
+		Doc:     abortMigrationDoc,
+	}
+}
+
+// Init implements cmd.Command.
+func (c *abortMigrationCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("model not specified")
+	}
+	if len(args) > 1 {
+		return errors.New("too many arguments specified")
+	}
+	c.model = args[0]
+	return nil
+}
+
+// Run implements cmd.Command.
+func (c *abortMigrationCommand) Run(ctx *cmd.Context) error {
+	store := c.ClientStore()
+	modelInfo, err := store.ModelByName(c.ControllerName(), c.AccountName(), c.model)
+	if err != nil {
+		return err
+	}
+	api, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	if err := api.AbortModelMigration(modelInfo.ModelUUID); err != nil {
+		return errors.Annotate(err, "cannot abort migration")
+	}
+	ctx.Infof("Migration of %q is being aborted", c.model)
+	return nil
+}
+
+func (c *abortMigrationCommand) getAPI() (abortMigrationAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewControllerAPIClient()
+}
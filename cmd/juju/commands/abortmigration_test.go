@@ -0,0 +1,98 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/feature"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type AbortMigrationSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api   *fakeAbortMigrationAPI
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&AbortMigrationSuite{})
+
+func (s *AbortMigrationSuite) SetUpTest(c *gc.C) {
+	s.SetInitialFeatureFlags(feature.Migration)
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclienttesting.NewMemStore()
+	err := s.store.UpdateController("source", jujuclient.ControllerDetails{
+		ControllerUUID: "eeeeeeee-0bad-400d-8000-4b1d0d06f00d",
+		CACert:         "somecert",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.SetCurrentController("source")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.UpdateAccount("source", "source@local", jujuclient.AccountDetails{
+		User: "whatever@local",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.SetCurrentAccount("source", "source@local")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.UpdateModel("source", "source@local", "model", jujuclient.ModelDetails{
+		ModelUUID: modelUUID,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.api = &fakeAbortMigrationAPI{}
+}
+
+func (s *AbortMigrationSuite) TestMissingModel(c *gc.C) {
+	_, err := s.runCommand(c)
+	c.Assert(err, gc.ErrorMatches, "model not specified")
+}
+
+func (s *AbortMigrationSuite) TestTooManyArgs(c *gc.C) {
+	_, err := s.runCommand(c, "one", "too")
+	c.Assert(err, gc.ErrorMatches, "too many arguments specified")
+}
+
+func (s *AbortMigrationSuite) TestSuccess(c *gc.C) {
+	ctx, err := s.runCommand(c, "model")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stderr(ctx), gc.Equals, "Migration of \"model\" is being aborted\n")
+	c.Check(s.api.uuidSeen, gc.Equals, modelUUID)
+}
+
+func (s *AbortMigrationSuite) TestModelDoesntExist(c *gc.C) {
+	_, err := s.runCommand(c, "wat")
+	c.Check(err, gc.ErrorMatches, "model .+ not found")
+	c.Check(s.api.uuidSeen, gc.Equals, "") // API shouldn't have been called
+}
+
+func (s *AbortMigrationSuite) TestAPIError(c *gc.C) {
+	s.api.err = errors.New("no migration in progress")
+	_, err := s.runCommand(c, "model")
+	c.Check(err, gc.ErrorMatches, "cannot abort migration: no migration in progress")
+}
+
+func (s *AbortMigrationSuite) runCommand(c *gc.C, args ...string) (*cmd.Context, error) {
+	cmd := &abortMigrationCommand{
+		api: s.api,
+	}
+	cmd.SetClientStore(s.store)
+	return testing.RunCommand(c, modelcmd.WrapController(cmd), args...)
+}
+
+type fakeAbortMigrationAPI struct {
+	uuidSeen string
+	err      error
+}
+
+func (a *fakeAbortMigrationAPI) AbortModelMigration(modelUUID string) error {
+	a.uuidSeen = modelUUID
+	return a.err
+}
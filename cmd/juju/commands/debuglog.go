@@ -6,9 +6,13 @@ package commands
 import (
 	"fmt"
 	"io"
+	"regexp"
+	"time"
 
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/api"
@@ -43,13 +47,27 @@ The '--include-module' and '--exclude-module' options filter by (dotted)
 logging module name. The module name can be truncated such that all loggers
 with the prefix will match.
 
+The '--since' and '--until' options restrict messages to a time window.
+Each takes either a timestamp, in RFC3339 format or in the format used in
+the log output (taken to be UTC), or a duration such as "2h30m", meaning
+that long ago. '--since' shows all messages from that time onwards, so
+'--lines' is ignored; '--until' implies '--no-tail'.
+
+The '--grep' option only shows messages matching a regular expression. The
+filtering is done by the controller.
+
+The '--format=json' option emits each message as a JSON object, one per
+line, with the fields "model-uuid", "entity", "timestamp", "level",
+"module", "location" and "message".
+
 The filtering options combine as follows:
 * All --include options are logically ORed together.
 * All --exclude options are logically ORed together.
 * All --include-module options are logically ORed together.
 * All --exclude-module options are logically ORed together.
-* The combined --include, --exclude, --include-module and --exclude-module
-  selections are logically ANDed to form the complete filter.
+* The combined --include, --exclude, --include-module, --exclude-module,
+  --since, --until and --grep selections are logically ANDed to form the
+  complete filter.
 
 Examples:
 
@@ -88,6 +106,22 @@ new WARNING and ERROR messages as they are logged:
 
     juju debug-log --replay --level WARNING
 
+Show all messages logged between 09:00 and 10:00 UTC on 2016-06-01 that
+mention a failed hook, as JSON:
+
+    juju debug-log --since "2016-06-01 09:00:00" \
+        --until "2016-06-01 10:00:00" \
+        --grep "hook failed" --format=json
+
+The --grep pattern is matched by the controller's database, which uses
+Perl-compatible regular expressions. Only the syntax shared with Go's
+regular expressions is accepted, so lookaround and backreferences cannot
+be used.
+
+Show all messages from the last 30 minutes, and then continue to append:
+
+    juju debug-log --since 30m
+
 See also: 
     status
     ssh`
@@ -101,13 +135,17 @@ func (c *debugLogCommand) Info() *cmd.Info {
 }
 
 func newDebugLogCommand() cmd.Command {
-	return modelcmd.Wrap(&debugLogCommand{})
+	return modelcmd.Wrap(&debugLogCommand{clock: clock.WallClock})
 }
 
 type debugLogCommand struct {
 	modelcmd.ModelCommandBase
 
+	clock  clock.Clock
 	level  string
+	since  string
+	until  string
+	format string
 	params api.DebugLogParams
 }
 
@@ -128,6 +166,10 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.params.Replay, "replay", false, "Show the entire (possibly filtered) log and continue to append")
 	f.BoolVar(&c.params.NoTail, "T", false, "Stop after returning existing log messages")
 	f.BoolVar(&c.params.NoTail, "no-tail", false, "")
+	f.StringVar(&c.since, "since", "", "Only show log messages logged at or after this time")
+	f.StringVar(&c.until, "until", "", "Only show log messages logged at or before this time, then stop")
+	f.StringVar(&c.params.MessageRegex, "grep", "", "Only show log messages matching this regular expression")
+	f.StringVar(&c.format, "format", "text", "Output format, one of [text, json]")
 }
 
 func (c *debugLogCommand) Init(args []string) error {
@@ -139,9 +181,61 @@ func (c *debugLogCommand) Init(args []string) error {
 		}
 		c.params.Level = level
 	}
+	now := c.clock.Now()
+	if c.since != "" {
+		t, err := parseLogTime(c.since, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --since")
+		}
+		c.params.StartTime = t
+	}
+	if c.until != "" {
+		t, err := parseLogTime(c.until, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --until")
+		}
+		c.params.EndTime = t
+	}
+	if c.since != "" && c.until != "" && c.params.EndTime.Before(c.params.StartTime) {
+		return errors.New("--until must not be before --since")
+	}
+	if c.params.MessageRegex != "" {
+		if _, err := regexp.Compile(c.params.MessageRegex); err != nil {
+			return errors.Annotate(err, "invalid --grep")
+		}
+	}
+	switch c.format {
+	case "text":
+	case "json":
+		c.params.JSON = true
+	default:
+		return errors.Errorf("format value %q is not one of %q, %q", c.format, "text", "json")
+	}
 	return cmd.CheckEmpty(args)
 }
 
+// logTimeFormat is the format of timestamps in debug-log output.
+const logTimeFormat = "2006-01-02 15:04:05"
+
+// parseLogTime parses the value of --since or --until. The value may
+// be a timestamp, either in RFC3339 format or in the format used in
+// debug-log output, or a duration, meaning that long before now.
+func parseLogTime(value string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		if d < 0 {
+			return time.Time{}, errors.Errorf("duration %q must not be negative", value)
+		}
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation(logTimeFormat, value, time.UTC); err == nil {
+		return t, nil
+	}
+	return time.Time{}, errors.Errorf("%q is not a valid timestamp or duration", value)
+}
+
 type DebugLogAPI interface {
 	WatchDebugLog(params api.DebugLogParams) (io.ReadCloser, error)
 	Close() error
@@ -7,6 +7,7 @@ import (
 	"io"
 	"io/ioutil"
 	"strings"
+	"time"
 
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
@@ -24,6 +25,7 @@ type DebugLogSuite struct {
 var _ = gc.Suite(&DebugLogSuite{})
 
 func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
+	now := time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC)
 	for i, test := range []struct {
 		args     []string
 		expected api.DebugLogParams
@@ -91,10 +93,51 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 				Backlog: 10,
 				Limit:   100,
 			},
+		}, {
+			args: []string{"--since", "2016-05-31T10:00:00Z", "--until", "2016-05-31 11:30:00"},
+			expected: api.DebugLogParams{
+				Backlog:   10,
+				StartTime: time.Date(2016, 5, 31, 10, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2016, 5, 31, 11, 30, 0, 0, time.UTC),
+			},
+		}, {
+			args: []string{"--since", "2h30m", "--until", "1h"},
+			expected: api.DebugLogParams{
+				Backlog:   10,
+				StartTime: now.Add(-150 * time.Minute),
+				EndTime:   now.Add(-time.Hour),
+			},
+		}, {
+			args:     []string{"--since", "yesterday"},
+			errMatch: `invalid --since: "yesterday" is not a valid timestamp or duration`,
+		}, {
+			args:     []string{"--until", "-1h"},
+			errMatch: `invalid --until: duration "-1h" must not be negative`,
+		}, {
+			args:     []string{"--since", "1h", "--until", "2h"},
+			errMatch: `--until must not be before --since`,
+		}, {
+			args: []string{"--grep", "hook fail(ed|ure)"},
+			expected: api.DebugLogParams{
+				Backlog:      10,
+				MessageRegex: "hook fail(ed|ure)",
+			},
+		}, {
+			args:     []string{"--grep", "("},
+			errMatch: `invalid --grep: error parsing regexp: .*`,
+		}, {
+			args: []string{"--format", "json"},
+			expected: api.DebugLogParams{
+				Backlog: 10,
+				JSON:    true,
+			},
+		}, {
+			args:     []string{"--format", "yaml"},
+			errMatch: `format value "yaml" is not one of "text", "json"`,
 		},
 	} {
 		c.Logf("test %v", i)
-		command := &debugLogCommand{}
+		command := &debugLogCommand{clock: testing.NewClock(now)}
 		err := testing.InitCommand(modelcmd.Wrap(command), test.args)
 		if test.errMatch == "" {
 			c.Check(err, jc.ErrorIsNil)
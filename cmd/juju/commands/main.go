@@ -246,6 +246,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(newSyncToolsCommand())
 	r.Register(newUpgradeJujuCommand(nil))
 	r.Register(service.NewUpgradeCharmCommand())
+	r.Register(service.NewShowCharmUpgradeCommand())
+	r.Register(service.NewResumeCharmUpgradeCommand())
 
 	// Charm publishing commands.
 	r.Register(newPublishCommand())
@@ -299,9 +301,11 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewGrantCommand())
 	r.Register(model.NewRevokeCommand())
 	r.Register(model.NewShowCommand())
+	r.Register(model.NewExportCommand())
 
 	if featureflag.Enabled(feature.Migration) {
 		r.Register(newMigrateCommand())
+		r.Register(newAbortMigrationCommand())
 	}
 
 	// Manage and control actions
@@ -309,6 +313,10 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(action.NewRunCommand())
 	r.Register(action.NewShowOutputCommand())
 	r.Register(action.NewListCommand())
+	r.Register(action.NewCancelCommand())
+	r.Register(action.NewAddScheduleCommand())
+	r.Register(action.NewListSchedulesCommand())
+	r.Register(action.NewRemoveScheduleCommand())
 
 	// Manage controller availability
 	r.Register(newEnableHACommand())
@@ -318,6 +326,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(service.NewGetCommand())
 	r.Register(service.NewSetCommand())
 	r.Register(service.NewDeployCommand())
+	r.Register(service.NewExportBundleCommand())
+	r.Register(service.NewDiffBundleCommand())
 	r.Register(service.NewExposeCommand())
 	r.Register(service.NewUnexposeCommand())
 	r.Register(service.NewServiceGetConstraintsCommand())
@@ -353,8 +363,10 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 
 	// Manage controllers
 	r.Register(controller.NewAddModelCommand())
+	r.Register(controller.NewAuditLogCommand())
 	r.Register(controller.NewDestroyCommand())
 	r.Register(controller.NewListModelsCommand())
+	r.Register(controller.NewImportModelCommand())
 	r.Register(controller.NewKillCommand())
 	r.Register(controller.NewListControllersCommand())
 	r.Register(controller.NewListBlocksCommand())
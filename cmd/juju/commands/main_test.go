@@ -352,7 +352,9 @@ func assertNoArgs(c *gc.C, argChan <-chan []string) {
 }
 
 var commandNames = []string{
+	"action-schedules",
 	"actions",
+	"add-action-schedule",
 	"add-cloud",
 	"add-credential",
 	"add-machine",
@@ -369,11 +371,13 @@ var commandNames = []string{
 	"add-user",
 	"agree",
 	"allocate",
+	"audit-log",
 	"autoload-credentials",
 	"backups",
 	"block",
 	"bootstrap",
 	"cached-images",
+	"cancel-action",
 	"change-user-password",
 	"charm",
 	"collect-metrics",
@@ -389,11 +393,14 @@ var commandNames = []string{
 	"destroy-relation",
 	"destroy-service",
 	"destroy-unit",
+	"diff-bundle",
 	"disable-user",
 	"download-backup",
 	"enable-ha",
 	"enable-user",
 	"expose",
+	"export-bundle",
+	"export-model",
 	"get-config",
 	"get-configs",
 	"get-constraints",
@@ -403,9 +410,11 @@ var commandNames = []string{
 	"gui",
 	"help",
 	"help-tool",
+	"import-model",
 	"import-ssh-key",
 	"import-ssh-keys",
 	"kill-controller",
+	"list-action-schedules",
 	"list-actions",
 	"list-agreements",
 	"list-all-blocks",
@@ -433,6 +442,7 @@ var commandNames = []string{
 	"machines",
 	"publish",
 	"register",
+	"remove-action-schedule",
 	"remove-all-blocks",
 	"remove-backup",
 	"remove-cached-images",
@@ -446,6 +456,7 @@ var commandNames = []string{
 	"remove-unit", // alias for destroy-unit
 	"resolved",
 	"restore-backup",
+	"resume-charm-upgrade",
 	"retry-provisioning",
 	"revoke",
 	"run",
@@ -467,6 +478,7 @@ var commandNames = []string{
 	"show-action-status",
 	"show-backup",
 	"show-budget",
+	"show-charm-upgrade",
 	"show-cloud",
 	"show-controller",
 	"show-controllers",
@@ -501,6 +513,7 @@ var devFeatures = []string{feature.Migration}
 
 // These are the commands that are behind the `devFeatures`.
 var commandNamesBehindFlags = set.NewStrings(
+	"abort-migration",
 	"migrate",
 )
 
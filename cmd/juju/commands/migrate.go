@@ -6,8 +6,13 @@ package commands
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/api/migrationtarget"
+	"github.com/juju/juju/api/modelarchive"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
@@ -18,16 +23,29 @@ func newMigrateCommand() cmd.Command {
 // migrateCommand initiates a model migration.
 type migrateCommand struct {
 	modelcmd.ControllerCommandBase
-	api migrateAPI
+	api       migrateAPI
+	exportAPI migrateExportAPI
+	targetAPI migrateTargetAPI
 
 	model            string
 	targetController string
+	dryRun           bool
 }
 
 type migrateAPI interface {
 	InitiateModelMigration(spec controller.ModelMigrationSpec) (string, error)
 }
 
+type migrateExportAPI interface {
+	Close() error
+	ExportModel(modelUUID string) ([]byte, error)
+}
+
+type migrateTargetAPI interface {
+	Close() error
+	ValidateImport(bytes []byte) (problems, missing []string, err error)
+}
+
 const migrateDoc = `
 migrate begins the migration of a model from its current controller to
 a new controller. This is useful for load balancing when a controller
@@ -51,6 +69,12 @@ This command only starts a model migration - it does not wait for its
 completion. The progress of a migration can be tracked using the
 "status" command and by consulting the logs.
 
+With --dry-run, the model is exported and checked against the target
+controller without starting a migration. Problems that would stop the
+model being imported, such as a conflicting model name or a provider
+the target controller does not support, are reported along with the
+charms and tools that a migration would need to upload.
+
 See Also:
    juju help login
    juju help controllers
@@ -67,6 +91,11 @@ func (c *migrateCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements cmd.Command.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.dryRun, "dry-run", false, "check the model against the target controller without migrating it")
+}
+
 // Init implements cmd.Command.
 func (c *migrateCommand) Init(args []string) error {
 	if len(args) < 1 {
@@ -128,6 +157,9 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.dryRun {
+		return c.runDryRun(ctx, spec)
+	}
 	api, err := c.getAPI()
 	if err != nil {
 		return err
@@ -140,9 +172,89 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+func (c *migrateCommand) runDryRun(ctx *cmd.Context, spec *controller.ModelMigrationSpec) error {
+	exportAPI, err := c.getExportAPI()
+	if err != nil {
+		return err
+	}
+	defer exportAPI.Close()
+	bytes, err := exportAPI.ExportModel(spec.ModelUUID)
+	if err != nil {
+		return errors.Annotate(err, "cannot export model")
+	}
+
+	targetAPI, err := c.getTargetAPI(spec)
+	if err != nil {
+		return errors.Annotate(err, "cannot connect to target controller")
+	}
+	defer targetAPI.Close()
+	problems, missing, err := targetAPI.ValidateImport(bytes)
+	if err != nil {
+		return errors.Annotate(err, "cannot validate model")
+	}
+
+	if len(missing) > 0 {
+		ctx.Infof("To be uploaded to %q:", c.targetController)
+		for _, item := range missing {
+			ctx.Infof("  %s", item)
+		}
+	}
+	if len(problems) > 0 {
+		for _, problem := range problems {
+			ctx.Infof("Problem: %s", problem)
+		}
+		return errors.Errorf("model %q cannot be migrated to %q", c.model, c.targetController)
+	}
+	ctx.Infof("Model %q can be migrated to %q", c.model, c.targetController)
+	return nil
+}
+
 func (c *migrateCommand) getAPI() (migrateAPI, error) {
 	if c.api != nil {
 		return c.api, nil
 	}
 	return c.NewControllerAPIClient()
 }
+
+func (c *migrateCommand) getExportAPI() (migrateExportAPI, error) {
+	if c.exportAPI != nil {
+		return c.exportAPI, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelarchive.NewClient(root), nil
+}
+
+func (c *migrateCommand) getTargetAPI(spec *controller.ModelMigrationSpec) (migrateTargetAPI, error) {
+	if c.targetAPI != nil {
+		return c.targetAPI, nil
+	}
+	info := &api.Info{
+		Addrs:    spec.TargetAddrs,
+		CACert:   spec.TargetCACert,
+		Tag:      names.NewUserTag(spec.TargetUser),
+		Password: spec.TargetPassword,
+	}
+	conn, err := api.Open(info, api.DefaultDialOpts())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &targetClient{
+		Client: migrationtarget.NewClient(conn),
+		conn:   conn,
+	}, nil
+}
+
+// targetClient pairs a MigrationTarget client with the connection it
+// uses, so that both can be closed together.
+type targetClient struct {
+	migrationtarget.Client
+	conn api.Connection
+}
+
+// Close implements migrateTargetAPI.
+func (t *targetClient) Close() error {
+	return t.conn.Close()
+}
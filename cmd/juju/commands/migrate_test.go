@@ -18,8 +18,10 @@ import (
 
 type MigrateSuite struct {
 	testing.FakeJujuXDGDataHomeSuite
-	api   *fakeMigrateAPI
-	store *jujuclienttesting.MemStore
+	api       *fakeMigrateAPI
+	exportAPI *fakeMigrateExportAPI
+	targetAPI *fakeMigrateTargetAPI
+	store     *jujuclienttesting.MemStore
 }
 
 var _ = gc.Suite(&MigrateSuite{})
@@ -74,6 +76,8 @@ func (s *MigrateSuite) SetUpTest(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.api = &fakeMigrateAPI{}
+	s.exportAPI = &fakeMigrateExportAPI{}
+	s.targetAPI = &fakeMigrateTargetAPI{}
 }
 
 func (s *MigrateSuite) TestMissingModel(c *gc.C) {
@@ -118,9 +122,34 @@ func (s *MigrateSuite) TestControllerDoesntExist(c *gc.C) {
 	c.Check(s.api.specSeen, gc.IsNil) // API shouldn't have been called
 }
 
+func (s *MigrateSuite) TestDryRun(c *gc.C) {
+	ctx, err := s.runCommand(c, "model", "target", "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(testing.Stderr(ctx), gc.Equals, "Model \"model\" can be migrated to \"target\"\n")
+	c.Check(s.api.specSeen, gc.IsNil) // No migration should be started.
+	c.Check(s.exportAPI.uuidSeen, gc.Equals, modelUUID)
+	c.Check(s.targetAPI.bytesSeen, gc.DeepEquals, []byte("model"))
+}
+
+func (s *MigrateSuite) TestDryRunProblems(c *gc.C) {
+	s.targetAPI.problems = []string{"model \"model\" for admin@local already exists"}
+	s.targetAPI.missing = []string{"charm cs:trusty/mysql-1"}
+	ctx, err := s.runCommand(c, "model", "target", "--dry-run")
+	c.Assert(err, gc.ErrorMatches, `model "model" cannot be migrated to "target"`)
+
+	c.Check(testing.Stderr(ctx), gc.Equals, `To be uploaded to "target":
+  charm cs:trusty/mysql-1
+Problem: model "model" for admin@local already exists
+`)
+	c.Check(s.api.specSeen, gc.IsNil)
+}
+
 func (s *MigrateSuite) runCommand(c *gc.C, args ...string) (*cmd.Context, error) {
 	cmd := &migrateCommand{
-		api: s.api,
+		api:       s.api,
+		exportAPI: s.exportAPI,
+		targetAPI: s.targetAPI,
 	}
 	cmd.SetClientStore(s.store)
 	return testing.RunCommand(c, modelcmd.WrapController(cmd), args...)
@@ -134,3 +163,31 @@ func (a *fakeMigrateAPI) InitiateModelMigration(spec controller.ModelMigrationSp
 	a.specSeen = &spec
 	return "uuid:0", nil
 }
+
+type fakeMigrateExportAPI struct {
+	uuidSeen string
+}
+
+func (a *fakeMigrateExportAPI) Close() error {
+	return nil
+}
+
+func (a *fakeMigrateExportAPI) ExportModel(modelUUID string) ([]byte, error) {
+	a.uuidSeen = modelUUID
+	return []byte("model"), nil
+}
+
+type fakeMigrateTargetAPI struct {
+	bytesSeen []byte
+	problems  []string
+	missing   []string
+}
+
+func (a *fakeMigrateTargetAPI) Close() error {
+	return nil
+}
+
+func (a *fakeMigrateTargetAPI) ValidateImport(bytes []byte) ([]string, []string, error) {
+	a.bytesSeen = bytes
+	return a.problems, a.missing, nil
+}
@@ -254,7 +254,7 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		for i, result := range actionResults.Results {
 			if result.Error == nil {
 				switch result.Status {
-				case params.ActionRunning, params.ActionPending:
+				case params.ActionRunning, params.ActionAborting, params.ActionPending:
 					newActionsToQuery = append(newActionsToQuery, actionsToQuery[i])
 					continue
 				}
@@ -6,6 +6,7 @@ package common
 import (
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/juju/errors"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/status"
@@ -22,6 +23,13 @@ type ModelInfo struct {
 	Life           string                   `json:"life" yaml:"life"`
 	Status         ModelStatus              `json:"status" yaml:"status"`
 	Users          map[string]ModelUserInfo `json:"users" yaml:"users"`
+	LogUsage       *ModelLogUsage           `json:"log-usage,omitempty" yaml:"log-usage,omitempty"`
+}
+
+// ModelLogUsage describes the logs the controller holds for a model.
+type ModelLogUsage struct {
+	Records int    `json:"records" yaml:"records"`
+	Size    string `json:"size" yaml:"size"`
 }
 
 // ModelStatus contains the current status of a model.
@@ -52,6 +60,13 @@ func ModelInfoFromParams(info params.ModelInfo, now time.Time) (ModelInfo, error
 	if info.Status.Since != nil {
 		status.Since = UserFriendlyDuration(*info.Status.Since, now)
 	}
+	var logUsage *ModelLogUsage
+	if info.LogUsage != nil {
+		logUsage = &ModelLogUsage{
+			Records: info.LogUsage.Records,
+			Size:    humanize.IBytes(uint64(info.LogUsage.Bytes)),
+		}
+	}
 	return ModelInfo{
 		Name:           info.Name,
 		UUID:           info.UUID,
@@ -61,6 +76,7 @@ func ModelInfoFromParams(info params.ModelInfo, now time.Time) (ModelInfo, error
 		Status:         status,
 		ProviderType:   info.ProviderType,
 		Users:          ModelUserInfoFromParams(info.Users, now),
+		LogUsage:       logUsage,
 	}, nil
 }
 
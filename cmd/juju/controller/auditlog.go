@@ -0,0 +1,219 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewAuditLogCommand returns a command that shows the API requests
+// recorded in the controller's audit log.
+func NewAuditLogCommand() cmd.Command {
+	return modelcmd.WrapController(&auditLogCommand{})
+}
+
+// auditLogCommand shows the controller's audit log.
+type auditLogCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+	api AuditLogAPI
+
+	from      string
+	to        string
+	user      string
+	modelName string
+	limit     int
+
+	filter params.AuditLogFilter
+}
+
+// AuditLogAPI defines the methods on the controller API endpoint that
+// the audit-log command calls.
+type AuditLogAPI interface {
+	Close() error
+	AuditLog(params.AuditLogFilter) ([]params.AuditLogEntry, error)
+}
+
+var auditLogDoc = `
+Shows the API requests made by users of the controller, oldest first.
+Each entry records when the request was made, who made it, the model
+it was made against, the API method called, its arguments and any error
+returned. Passwords and other secrets are removed from the arguments
+before they are recorded.
+
+Requests made by agents are not recorded.
+
+Times given to --from and --to are either RFC3339 timestamps or dates
+of the form YYYY-MM-DD, which are taken to be in UTC.
+
+Only controller administrators can view the audit log.
+
+Examples:
+    juju audit-log
+    juju audit-log --user bob --from 2016-06-01
+    juju audit-log -m mymodel --limit 20 --format yaml
+`
+
+// Info implements Command.Info.
+func (c *auditLogCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "audit-log",
+		Purpose: "shows the API requests recorded by the controller",
+		Doc:     auditLogDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *auditLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.from, "from", "", "only show requests made at or after this time")
+	f.StringVar(&c.to, "to", "", "only show requests made at or before this time")
+	f.StringVar(&c.user, "user", "", "only show requests made by this user")
+	f.StringVar(&c.modelName, "m", "", "only show requests made against this model")
+	f.StringVar(&c.modelName, "model", "", "")
+	f.IntVar(&c.limit, "limit", 0, "only show this many of the most recent requests")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatAuditLogTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *auditLogCommand) Init(args []string) error {
+	var err error
+	if c.filter.From, err = parseAuditTime(c.from); err != nil {
+		return errors.Annotate(err, "invalid --from time")
+	}
+	if c.filter.To, err = parseAuditTime(c.to); err != nil {
+		return errors.Annotate(err, "invalid --to time")
+	}
+	if !c.filter.From.IsZero() && !c.filter.To.IsZero() && c.filter.To.Before(c.filter.From) {
+		return errors.New("--to time is before --from time")
+	}
+	if c.user != "" && !names.IsValidUser(c.user) {
+		return errors.NotValidf("user %q", c.user)
+	}
+	c.filter.User = c.user
+	if c.limit < 0 {
+		return errors.New("--limit must not be negative")
+	}
+	c.filter.Limit = c.limit
+	return cmd.CheckEmpty(args)
+}
+
+func parseAuditTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, errors.Errorf("%q is not an RFC3339 time or a YYYY-MM-DD date", value)
+	}
+	return t, nil
+}
+
+func (c *auditLogCommand) getAPI() (AuditLogAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewControllerAPIClient()
+}
+
+// Run implements Command.Run.
+func (c *auditLogCommand) Run(ctx *cmd.Context) error {
+	filter := c.filter
+	if c.modelName != "" {
+		uuids, err := c.ModelUUIDs([]string{c.modelName})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		filter.ModelTag = names.NewModelTag(uuids[0]).String()
+	}
+
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Annotate(err, "cannot connect to the API")
+	}
+	defer api.Close()
+
+	entries, err := api.AuditLog(filter)
+	if err != nil {
+		return errors.Annotate(err, "cannot read audit log")
+	}
+	if len(entries) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No matching audit log entries.")
+		return nil
+	}
+	formatted := make([]auditLogEntry, len(entries))
+	for i, entry := range entries {
+		formatted[i] = formatAuditLogEntry(entry)
+	}
+	return c.out.Write(ctx, formatted)
+}
+
+// auditLogEntry is the form in which audit log entries are written out.
+type auditLogEntry struct {
+	Time     string `yaml:"time" json:"time"`
+	User     string `yaml:"user" json:"user"`
+	Model    string `yaml:"model,omitempty" json:"model,omitempty"`
+	Method   string `yaml:"method" json:"method"`
+	Args     string `yaml:"args,omitempty" json:"args,omitempty"`
+	Duration string `yaml:"duration" json:"duration"`
+	Error    string `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+func formatAuditLogEntry(entry params.AuditLogEntry) auditLogEntry {
+	var model string
+	if tag, err := names.ParseModelTag(entry.ModelTag); err == nil {
+		model = tag.Id()
+	}
+	return auditLogEntry{
+		Time:     entry.Time.UTC().Format(time.RFC3339),
+		User:     entry.User,
+		Model:    model,
+		Method:   fmt.Sprintf("%s(%d).%s", entry.Facade, entry.Version, entry.Method),
+		Args:     entry.Args,
+		Duration: entry.Duration.String(),
+		Error:    entry.Error,
+	}
+}
+
+func formatAuditLogTabular(value interface{}) ([]byte, error) {
+	entries, ok := value.([]auditLogEntry)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "TIME\tUSER\tMODEL\tMETHOD\tDURATION\tERROR\n")
+	for _, entry := range entries {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			entry.Time, entry.User, entry.Model, entry.Method, entry.Duration, entry.Error)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
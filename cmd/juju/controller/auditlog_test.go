@@ -0,0 +1,174 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type auditLogSuite struct {
+	baseControllerSuite
+	api   *fakeAuditLogAPI
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+const auditModelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+
+func (s *auditLogSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+
+	t0 := time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC)
+	s.api = &fakeAuditLogAPI{
+		entries: []params.AuditLogEntry{{
+			Time:     t0,
+			ModelTag: "model-" + auditModelUUID,
+			User:     "admin@local",
+			Facade:   "Client",
+			Version:  1,
+			Method:   "FullStatus",
+			Args:     `{"Patterns":null}`,
+			Duration: 20 * time.Millisecond,
+		}, {
+			Time:     t0.Add(time.Minute),
+			User:     "bob@local",
+			Facade:   "Admin",
+			Version:  3,
+			Method:   "Login",
+			Args:     `{"credentials":"<redacted>"}`,
+			Error:    "invalid entity name or password",
+			Duration: time.Millisecond,
+		}},
+	}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+	s.store.Models["fake"] = jujuclient.ControllerAccountModels{
+		AccountModels: map[string]*jujuclient.AccountModels{
+			"admin@local": {
+				Models: map[string]jujuclient.ModelDetails{
+					"mymodel": {ModelUUID: auditModelUUID},
+				},
+			},
+		},
+	}
+	s.store.Accounts["fake"] = &jujuclient.ControllerAccounts{
+		Accounts: map[string]jujuclient.AccountDetails{
+			"admin@local": {User: "admin@local"},
+		},
+		CurrentAccount: "admin@local",
+	}
+}
+
+func (s *auditLogSuite) newCommand() cmd.Command {
+	return controller.NewAuditLogCommandForTest(s.api, s.store)
+}
+
+func (s *auditLogSuite) TestTabular(c *gc.C) {
+	ctx, err := testing.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"TIME                  USER         MODEL                                 METHOD                DURATION  ERROR\n"+
+		"2016-06-01T12:00:00Z  admin@local  deadbeef-0bad-400d-8000-4b1d0d06f00d  Client(1).FullStatus  20ms      \n"+
+		"2016-06-01T12:01:00Z  bob@local                                          Admin(3).Login        1ms       invalid entity name or password\n")
+	c.Assert(s.api.filter, jc.DeepEquals, params.AuditLogFilter{})
+}
+
+func (s *auditLogSuite) TestYAML(c *gc.C) {
+	s.api.entries = s.api.entries[:1]
+	ctx, err := testing.RunCommand(c, s.newCommand(), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"- time: \"2016-06-01T12:00:00Z\"\n"+
+		"  user: admin@local\n"+
+		"  model: deadbeef-0bad-400d-8000-4b1d0d06f00d\n"+
+		"  method: Client(1).FullStatus\n"+
+		"  args: '{\"Patterns\":null}'\n"+
+		"  duration: 20ms\n")
+}
+
+func (s *auditLogSuite) TestNoEntries(c *gc.C) {
+	s.api.entries = nil
+	ctx, err := testing.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "No matching audit log entries.\n")
+}
+
+func (s *auditLogSuite) TestFilter(c *gc.C) {
+	_, err := testing.RunCommand(c, s.newCommand(),
+		"--from", "2016-06-01",
+		"--to", "2016-06-02T10:00:00+02:00",
+		"--user", "bob",
+		"-m", "mymodel",
+		"--limit", "5",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.filter, jc.DeepEquals, params.AuditLogFilter{
+		From:     time.Date(2016, 6, 1, 0, 0, 0, 0, time.UTC),
+		To:       time.Date(2016, 6, 2, 8, 0, 0, 0, time.UTC),
+		User:     "bob",
+		ModelTag: "model-" + auditModelUUID,
+		Limit:    5,
+	})
+}
+
+func (s *auditLogSuite) TestInvalidFlags(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--from", "yesterday"},
+		err:  `invalid --from time: "yesterday" is not an RFC3339 time or a YYYY-MM-DD date`,
+	}, {
+		args: []string{"--from", "2016-06-02", "--to", "2016-06-01"},
+		err:  `--to time is before --from time`,
+	}, {
+		args: []string{"--user", "not a user!"},
+		err:  `user "not a user!" not valid`,
+	}, {
+		args: []string{"--limit", "-1"},
+		err:  `--limit must not be negative`,
+	}, {
+		args: []string{"extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("args: %q", test.args)
+		_, err := testing.RunCommand(c, s.newCommand(), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *auditLogSuite) TestAPIError(c *gc.C) {
+	s.api.err = common.ErrPerm
+	_, err := testing.RunCommand(c, s.newCommand())
+	c.Assert(err, gc.ErrorMatches, "cannot read audit log: permission denied")
+}
+
+type fakeAuditLogAPI struct {
+	entries []params.AuditLogEntry
+	filter  params.AuditLogFilter
+	err     error
+}
+
+func (f *fakeAuditLogAPI) Close() error {
+	return nil
+}
+
+func (f *fakeAuditLogAPI) AuditLog(filter params.AuditLogFilter) ([]params.AuditLogEntry, error) {
+	f.filter = filter
+	return f.entries, f.err
+}
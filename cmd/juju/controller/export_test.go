@@ -47,6 +47,26 @@ func NewAddModelCommandForTest(
 	return modelcmd.WrapController(c), &AddModelCommand{c}
 }
 
+// NewAuditLogCommandForTest returns an auditLogCommand with the API
+// provided as specified.
+func NewAuditLogCommandForTest(api AuditLogAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &auditLogCommand{
+		api: api,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewImportModelCommandForTest returns an importModelCommand with the
+// API provided as specified.
+func NewImportModelCommandForTest(api ImportModelAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &importModelCommand{
+		api: api,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
 // NewListModelsCommandForTest returns a ListModelsCommand with the API
 // and userCreds provided as specified.
 func NewListModelsCommandForTest(modelAPI ModelManagerAPI, sysAPI ModelsSysAPI, store jujuclient.ClientStore) cmd.Command {
@@ -0,0 +1,103 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io/ioutil"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/modelarchive"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewImportModelCommand returns a command that imports a model
+// description into a controller.
+func NewImportModelCommand() cmd.Command {
+	return modelcmd.WrapController(&importModelCommand{})
+}
+
+type importModelCommand struct {
+	modelcmd.ControllerCommandBase
+	api      ImportModelAPI
+	filename string
+}
+
+// ImportModelAPI defines the methods on the ModelArchive API that the
+// import-model command calls.
+type ImportModelAPI interface {
+	Close() error
+	ImportModel(bytes []byte) error
+}
+
+var importModelDoc = `
+Recreates a model in the controller from a description written by
+"juju export-model". The model keeps the name, UUID and owner recorded
+in the file, so it cannot be imported into a controller that already
+has a model with the same UUID, or the same name for the same owner.
+
+Charms, tools and resource data are not part of the model description.
+If the model uses charms or tools that the controller does not have,
+the model is left in the importing state and the missing binaries are
+reported. The model cannot be used until they have been uploaded to it.
+
+Only controller administrators can import a model.
+
+Examples:
+    juju import-model model.yaml
+
+See also:
+    export-model
+`
+
+// Info implements Command.Info.
+func (c *importModelCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "import-model",
+		Args:    "<file>",
+		Purpose: "creates a model from a model description file",
+		Doc:     importModelDoc,
+	}
+}
+
+// Init implements Command.Init.
+func (c *importModelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no model description file specified")
+	}
+	c.filename, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+func (c *importModelCommand) getAPI() (ImportModelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelarchive.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *importModelCommand) Run(ctx *cmd.Context) error {
+	bytes, err := ioutil.ReadFile(ctx.AbsPath(c.filename))
+	if err != nil {
+		return errors.Annotate(err, "cannot read model description")
+	}
+
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if err := api.ImportModel(bytes); err != nil {
+		return errors.Annotate(err, "cannot import model")
+	}
+	ctx.Infof("model imported from %s", c.filename)
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type importModelSuite struct {
+	baseControllerSuite
+	api   *fakeImportModelAPI
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&importModelSuite{})
+
+func (s *importModelSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+
+	s.api = &fakeImportModelAPI{}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+}
+
+func (s *importModelSuite) newCommand() cmd.Command {
+	return controller.NewImportModelCommandForTest(s.api, s.store)
+}
+
+func (s *importModelSuite) writeFile(c *gc.C) string {
+	filename := filepath.Join(c.MkDir(), "model.yaml")
+	err := ioutil.WriteFile(filename, []byte("model: description\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	return filename
+}
+
+func (s *importModelSuite) TestImport(c *gc.C) {
+	_, err := testing.RunCommand(c, s.newCommand(), s.writeFile(c))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(s.api.bytes), gc.Equals, "model: description\n")
+}
+
+func (s *importModelSuite) TestNoFile(c *gc.C) {
+	_, err := testing.RunCommand(c, s.newCommand())
+	c.Assert(err, gc.ErrorMatches, "no model description file specified")
+}
+
+func (s *importModelSuite) TestUnrecognizedArg(c *gc.C) {
+	_, err := testing.RunCommand(c, s.newCommand(), "model.yaml", "whoops")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["whoops"\]`)
+}
+
+func (s *importModelSuite) TestMissingFile(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "missing.yaml")
+	_, err := testing.RunCommand(c, s.newCommand(), filename)
+	c.Assert(err, gc.ErrorMatches, "cannot read model description: .*")
+	c.Assert(s.api.bytes, gc.IsNil)
+}
+
+func (s *importModelSuite) TestImportError(c *gc.C) {
+	s.api.err = common.ErrPerm
+	_, err := testing.RunCommand(c, s.newCommand(), s.writeFile(c))
+	c.Assert(err, gc.ErrorMatches, "cannot import model: permission denied")
+}
+
+type fakeImportModelAPI struct {
+	bytes []byte
+	err   error
+}
+
+func (f *fakeImportModelAPI) Close() error {
+	return nil
+}
+
+func (f *fakeImportModelAPI) ImportModel(bytes []byte) error {
+	f.bytes = bytes
+	return f.err
+}
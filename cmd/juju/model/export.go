@@ -0,0 +1,117 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"io/ioutil"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/modelarchive"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const exportModelCommandDoc = `
+Writes a description of the current or specified model to a file, or
+to standard output if no file is given. The description can be read
+back in to a controller with "juju import-model".
+
+Only the model description is exported. Charms, tools and resource
+data are not included in the file and must already be available to
+the controller that the model is imported into.
+
+Only controller administrators can export a model.
+
+Examples:
+    juju export-model -o model.yaml
+    juju export-model -m mymodel -o mymodel.yaml
+
+See also:
+    import-model
+`
+
+// NewExportCommand returns a command that exports a model description.
+func NewExportCommand() cmd.Command {
+	return modelcmd.Wrap(&exportCommand{})
+}
+
+// exportCommand writes the serialized description of a model.
+type exportCommand struct {
+	modelcmd.ModelCommandBase
+	out string
+	api ExportModelAPI
+}
+
+// ExportModelAPI defines the methods on the ModelArchive API that the
+// export-model command calls.
+type ExportModelAPI interface {
+	Close() error
+	ExportModel(modelUUID string) ([]byte, error)
+}
+
+func (c *exportCommand) getAPI() (ExportModelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelarchive.NewClient(root), nil
+}
+
+// Info implements Command.Info.
+func (c *exportCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "export-model",
+		Purpose: "writes a description of a model to a file",
+		Doc:     exportModelCommandDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *exportCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.out, "o", "", "Specify an output file")
+	f.StringVar(&c.out, "output", "", "")
+}
+
+// Init implements Command.Init.
+func (c *exportCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *exportCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	store := c.ClientStore()
+	modelDetails, err := store.ModelByName(
+		c.ControllerName(),
+		c.AccountName(),
+		c.ModelName(),
+	)
+	if err != nil {
+		return errors.Annotate(err, "getting model details")
+	}
+
+	bytes, err := api.ExportModel(modelDetails.ModelUUID)
+	if err != nil {
+		return errors.Annotate(err, "cannot export model")
+	}
+	if c.out == "" {
+		_, err := ctx.Stdout.Write(bytes)
+		return errors.Trace(err)
+	}
+	if err := ioutil.WriteFile(ctx.AbsPath(c.out), bytes, 0600); err != nil {
+		return errors.Annotate(err, "cannot write model description")
+	}
+	ctx.Infof("model %q exported to %s", c.ModelName(), c.out)
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type ExportCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  fakeExportClient
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&ExportCommandSuite{})
+
+type fakeExportClient struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeExportClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeExportClient) ExportModel(modelUUID string) ([]byte, error) {
+	f.MethodCall(f, "ExportModel", modelUUID)
+	return []byte("model: description\n"), f.NextErr()
+}
+
+func (s *ExportCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake.ResetCalls()
+
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = &jujuclient.ControllerAccounts{
+		CurrentAccount: "admin@local",
+	}
+	err := s.store.UpdateModel("testing", "admin@local", "mymodel", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].AccountModels["admin@local"].CurrentModel = "mymodel"
+}
+
+func (s *ExportCommandSuite) TestExportToStdout(c *gc.C) {
+	ctx, err := testing.RunCommand(c, model.NewExportCommandForTest(&s.fake, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "model: description\n")
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ExportModel", []interface{}{testing.ModelTag.Id()}},
+		{"Close", nil},
+	})
+}
+
+func (s *ExportCommandSuite) TestExportToFile(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "model.yaml")
+	ctx, err := testing.RunCommand(c, model.NewExportCommandForTest(&s.fake, s.store), "-o", filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+
+	content, err := ioutil.ReadFile(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(content), gc.Equals, "model: description\n")
+}
+
+func (s *ExportCommandSuite) TestExportError(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	_, err := testing.RunCommand(c, model.NewExportCommandForTest(&s.fake, s.store))
+	c.Assert(err, gc.ErrorMatches, "cannot export model: boom")
+}
+
+func (s *ExportCommandSuite) TestUnrecognizedArg(c *gc.C) {
+	_, err := testing.RunCommand(c, model.NewExportCommandForTest(&s.fake, s.store), "whoops")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["whoops"\]`)
+}
@@ -14,12 +14,15 @@ import (
 )
 
 var usageGrantSummary = `
-Grants access to a Juju user for a model.`[1:]
+Grants access to a Juju user for a model or the controller.`[1:]
 
 var usageGrantDetails = `
 By default, the controller is the current controller.
 Model access can also be granted at user-addition time with the `[1:] + "`juju add-\nuser`" + ` command.
 Users with read access are limited in what they can do with models: ` + "`juju \nlist-models`, `juju list-machines`, and `juju status`" + `.
+Users with write access can deploy, scale and configure services, but
+cannot grant or revoke access to the model or destroy it. Users with admin
+access have full control over the model.
 
 Examples:
 Grant user 'joe' default (read) access to model 'mymodel':
@@ -30,25 +33,38 @@ Grant user 'jim' write access to model 'mymodel':
 
     juju grant --acl=write jim mymodel
 
+Grant user 'ann' admin access to model 'mymodel':
+
+    juju grant --acl=admin ann mymodel
+
 Grant user 'sam' default (read) access to models 'model1' and 'model2':
 
     juju grant sam model1 model2
 
+Controller access is granted by naming a controller permission ('login',
+'add-model' or 'superuser') instead of models. Users with add-model access
+can create their own models; superusers have full control of the controller.
+
+Grant user 'joe' permission to add models to the controller:
+
+    juju grant joe add-model
+
 See also: 
     revoke
     add-user`
 
 var usageRevokeSummary = `
-Revokes access from a Juju user for a model.`[1:]
+Revokes access from a Juju user for a model or the controller.`[1:]
 
 var usageRevokeDetails = `
 By default, the controller is the current controller.
-Revoking write access, from a user who has that permission, will leave
-that user with read access. Revoking read access, however, also revokes
-write access.
+Revoking admin access, from a user who has that permission, will leave
+that user with write access. Revoking write access, from a user who has
+that permission, will leave that user with read access. Revoking read
+access, however, also revokes write and admin access.
 
 Examples:
-Revoke read (and write) access from user 'joe' for model 'mymodel':
+Revoke read (and write and admin) access from user 'joe' for model 'mymodel':
 
     juju revoke joe mymodel
 
@@ -56,20 +72,30 @@ Revoke write access from user 'sam' for models 'model1' and 'model2':
 
     juju revoke --acl=write sam model1 model2
 
+Revoke admin access from user 'ann' for model 'mymodel', leaving write access:
+
+    juju revoke --acl=admin ann mymodel
+
+Revoke permission to add models to the controller from user 'joe', leaving
+login access:
+
+    juju revoke joe add-model
+
 See also: 
     grant`[1:]
 
 type accessCommand struct {
 	modelcmd.ControllerCommandBase
 
-	User        string
-	ModelNames  []string
-	ModelAccess string
+	User             string
+	ModelNames       []string
+	ModelAccess      string
+	ControllerAccess string
 }
 
 // SetFlags implements cmd.Command.
 func (c *accessCommand) SetFlags(f *gnuflag.FlagSet) {
-	f.StringVar(&c.ModelAccess, "acl", "read", "Access control ('read' or 'write')")
+	f.StringVar(&c.ModelAccess, "acl", "read", "Access control ('read', 'write' or 'admin')")
 }
 
 // Init implements cmd.Command.
@@ -82,12 +108,19 @@ func (c *accessCommand) Init(args []string) error {
 		return errors.New("no model specified")
 	}
 
+	c.User = args[0]
+	// A single controller permission in place of the model names
+	// changes the user's access to the controller itself.
+	if len(args) == 2 && permission.IsControllerAccess(args[1]) {
+		c.ControllerAccess = args[1]
+		return nil
+	}
+
 	_, err := permission.ParseModelAccess(c.ModelAccess)
 	if err != nil {
 		return err
 	}
 
-	c.User = args[0]
 	c.ModelNames = args[1:]
 	return nil
 }
@@ -97,17 +130,19 @@ func NewGrantCommand() cmd.Command {
 	return modelcmd.WrapController(&grantCommand{})
 }
 
-// grantCommand represents the command to grant a user access to one or more models.
+// grantCommand represents the command to grant a user access to one or more
+// models, or to the controller.
 type grantCommand struct {
 	accessCommand
-	api GrantModelAPI
+	api           GrantModelAPI
+	controllerAPI GrantControllerAPI
 }
 
 // Info implements Command.Info.
 func (c *grantCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "grant",
-		Args:    "<user name> <model name> ...",
+		Args:    "<user name> (<model name> ... | <controller permission>)",
 		Purpose: usageGrantSummary,
 		Doc:     usageGrantDetails,
 	}
@@ -120,14 +155,31 @@ func (c *grantCommand) getAPI() (GrantModelAPI, error) {
 	return c.NewModelManagerAPIClient()
 }
 
+func (c *grantCommand) getControllerAPI() (GrantControllerAPI, error) {
+	if c.controllerAPI != nil {
+		return c.controllerAPI, nil
+	}
+	return c.NewControllerAPIClient()
+}
+
 // GrantModelAPI defines the API functions used by the grant command.
 type GrantModelAPI interface {
 	Close() error
 	GrantModel(user, access string, modelUUIDs ...string) error
 }
 
+// GrantControllerAPI defines the API functions used by the grant command
+// to grant controller access.
+type GrantControllerAPI interface {
+	Close() error
+	GrantController(user, access string) error
+}
+
 // Run implements cmd.Command.
 func (c *grantCommand) Run(ctx *cmd.Context) error {
+	if c.ControllerAccess != "" {
+		return c.grantController()
+	}
 	client, err := c.getAPI()
 	if err != nil {
 		return err
@@ -141,22 +193,33 @@ func (c *grantCommand) Run(ctx *cmd.Context) error {
 	return block.ProcessBlockedError(client.GrantModel(c.User, c.ModelAccess, models...), block.BlockChange)
 }
 
+func (c *grantCommand) grantController() error {
+	client, err := c.getControllerAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	return block.ProcessBlockedError(client.GrantController(c.User, c.ControllerAccess), block.BlockChange)
+}
+
 // NewRevokeCommand returns a new revoke command.
 func NewRevokeCommand() cmd.Command {
 	return modelcmd.WrapController(&revokeCommand{})
 }
 
-// revokeCommand revokes a user's access to models.
+// revokeCommand revokes a user's access to models, or to the controller.
 type revokeCommand struct {
 	accessCommand
-	api RevokeModelAPI
+	api           RevokeModelAPI
+	controllerAPI RevokeControllerAPI
 }
 
 // Info implements cmd.Command.
 func (c *revokeCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "revoke",
-		Args:    "<user> <model name> ...",
+		Args:    "<user> (<model name> ... | <controller permission>)",
 		Purpose: usageRevokeSummary,
 		Doc:     usageRevokeDetails,
 	}
@@ -169,14 +232,31 @@ func (c *revokeCommand) getAPI() (RevokeModelAPI, error) {
 	return c.NewModelManagerAPIClient()
 }
 
+func (c *revokeCommand) getControllerAPI() (RevokeControllerAPI, error) {
+	if c.controllerAPI != nil {
+		return c.controllerAPI, nil
+	}
+	return c.NewControllerAPIClient()
+}
+
 // RevokeModelAPI defines the API functions used by the revoke command.
 type RevokeModelAPI interface {
 	Close() error
 	RevokeModel(user, access string, modelUUIDs ...string) error
 }
 
+// RevokeControllerAPI defines the API functions used by the revoke command
+// to revoke controller access.
+type RevokeControllerAPI interface {
+	Close() error
+	RevokeController(user, access string) error
+}
+
 // Run implements cmd.Command.
 func (c *revokeCommand) Run(ctx *cmd.Context) error {
+	if c.ControllerAccess != "" {
+		return c.revokeController()
+	}
 	client, err := c.getAPI()
 	if err != nil {
 		return err
@@ -189,3 +269,13 @@ func (c *revokeCommand) Run(ctx *cmd.Context) error {
 	}
 	return block.ProcessBlockedError(client.RevokeModel(c.User, c.ModelAccess, modelUUIDs...), block.BlockChange)
 }
+
+func (c *revokeCommand) revokeController() error {
+	client, err := c.getControllerAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	return block.ProcessBlockedError(client.RevokeController(c.User, c.ControllerAccess), block.BlockChange)
+}
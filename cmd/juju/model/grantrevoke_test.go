@@ -88,6 +88,26 @@ func (s *grantRevokeSuite) TestAccess(c *gc.C) {
 	c.Assert(s.fake.access, gc.Equals, "write")
 }
 
+func (s *grantRevokeSuite) TestAdminAccess(c *gc.C) {
+	_, err := s.run(c, "--acl", "admin", "sam", "model1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.modelUUIDs, jc.DeepEquals, []string{model1ModelUUID})
+	c.Assert(s.fake.access, gc.Equals, "admin")
+}
+
+func (s *grantRevokeSuite) TestInvalidAccess(c *gc.C) {
+	_, err := s.run(c, "--acl", "superuser", "sam", "model1")
+	c.Assert(err, gc.ErrorMatches, `invalid model access permission "superuser"`)
+}
+
+func (s *grantRevokeSuite) TestControllerAccess(c *gc.C) {
+	_, err := s.run(c, "sam", "add-model")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.user, gc.Equals, "sam")
+	c.Assert(s.fake.controllerAccess, gc.Equals, "add-model")
+	c.Assert(s.fake.modelUUIDs, gc.HasLen, 0)
+}
+
 func (s *grantRevokeSuite) TestBlockGrant(c *gc.C) {
 	s.fake.err = &params.Error{Code: params.CodeOperationBlocked}
 	_, err := s.run(c, "sam", "foo")
@@ -104,13 +124,13 @@ var _ = gc.Suite(&grantSuite{})
 func (s *grantSuite) SetUpTest(c *gc.C) {
 	s.grantRevokeSuite.SetUpTest(c)
 	s.cmdFactory = func(fake *fakeGrantRevokeAPI) cmd.Command {
-		c, _ := model.NewGrantCommandForTest(fake, s.store)
+		c, _ := model.NewGrantCommandForTest(fake, fake, s.store)
 		return c
 	}
 }
 
 func (s *grantSuite) TestInit(c *gc.C) {
-	wrappedCmd, grantCmd := model.NewGrantCommandForTest(s.fake, s.store)
+	wrappedCmd, grantCmd := model.NewGrantCommandForTest(s.fake, s.fake, s.store)
 	err := testing.InitCommand(wrappedCmd, []string{})
 	c.Assert(err, gc.ErrorMatches, "no user specified")
 
@@ -127,6 +147,22 @@ func (s *grantSuite) TestInit(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `no model specified`)
 }
 
+func (s *grantSuite) TestInitControllerAccess(c *gc.C) {
+	wrappedCmd, grantCmd := model.NewGrantCommandForTest(s.fake, s.fake, s.store)
+	err := testing.InitCommand(wrappedCmd, []string{"bob", "superuser"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(grantCmd.User, gc.Equals, "bob")
+	c.Assert(grantCmd.ControllerAccess, gc.Equals, "superuser")
+	c.Assert(grantCmd.ModelNames, gc.HasLen, 0)
+
+	// With more than one name, they are all treated as models.
+	wrappedCmd, grantCmd = model.NewGrantCommandForTest(s.fake, s.fake, s.store)
+	err = testing.InitCommand(wrappedCmd, []string{"bob", "add-model", "model1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(grantCmd.ControllerAccess, gc.Equals, "")
+	c.Assert(grantCmd.ModelNames, jc.DeepEquals, []string{"add-model", "model1"})
+}
+
 type revokeSuite struct {
 	grantRevokeSuite
 }
@@ -136,13 +172,13 @@ var _ = gc.Suite(&revokeSuite{})
 func (s *revokeSuite) SetUpTest(c *gc.C) {
 	s.grantRevokeSuite.SetUpTest(c)
 	s.cmdFactory = func(fake *fakeGrantRevokeAPI) cmd.Command {
-		c, _ := model.NewRevokeCommandForTest(fake, s.store)
+		c, _ := model.NewRevokeCommandForTest(fake, fake, s.store)
 		return c
 	}
 }
 
 func (s *revokeSuite) TestInit(c *gc.C) {
-	wrappedCmd, revokeCmd := model.NewRevokeCommandForTest(s.fake, s.store)
+	wrappedCmd, revokeCmd := model.NewRevokeCommandForTest(s.fake, s.fake, s.store)
 	err := testing.InitCommand(wrappedCmd, []string{})
 	c.Assert(err, gc.ErrorMatches, "no user specified")
 
@@ -160,10 +196,11 @@ func (s *revokeSuite) TestInit(c *gc.C) {
 }
 
 type fakeGrantRevokeAPI struct {
-	err        error
-	user       string
-	access     string
-	modelUUIDs []string
+	err              error
+	user             string
+	access           string
+	controllerAccess string
+	modelUUIDs       []string
 }
 
 func (f *fakeGrantRevokeAPI) Close() error { return nil }
@@ -176,6 +213,20 @@ func (f *fakeGrantRevokeAPI) RevokeModel(user, access string, modelUUIDs ...stri
 	return f.fake(user, access, modelUUIDs...)
 }
 
+func (f *fakeGrantRevokeAPI) GrantController(user, access string) error {
+	return f.fakeController(user, access)
+}
+
+func (f *fakeGrantRevokeAPI) RevokeController(user, access string) error {
+	return f.fakeController(user, access)
+}
+
+func (f *fakeGrantRevokeAPI) fakeController(user, access string) error {
+	f.user = user
+	f.controllerAccess = access
+	return f.err
+}
+
 func (f *fakeGrantRevokeAPI) fake(user, access string, modelUUIDs ...string) error {
 	f.user = user
 	f.access = access
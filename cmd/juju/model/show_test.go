@@ -82,6 +82,10 @@ func (s *ShowCommandSuite) SetUpTest(c *gc.C) {
 			Since:  &statusSince,
 		},
 		Users: users,
+		LogUsage: &params.ModelLogUsage{
+			Records: 1500,
+			Bytes:   3 * 1024 * 1024,
+		},
 	}
 
 	s.expectedOutput = attrs{
@@ -107,6 +111,10 @@ func (s *ShowCommandSuite) SetUpTest(c *gc.C) {
 					"last-connection": "never connected",
 				},
 			},
+			"log-usage": attrs{
+				"records": 1500,
+				"size":    "3.0MiB",
+			},
 		},
 	}
 
@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/unitsort"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/state/watcher"
@@ -48,19 +49,10 @@ type deploymentLogger interface {
 	Infof(string, ...interface{})
 }
 
-// deployBundle deploys the given bundle data using the given API client and
-// charm store client. The deployment is not transactional, and its progress is
-// notified using the given deployment logger.
-func deployBundle(
-	bundleFilePath string,
-	data *charm.BundleData,
-	channel csparams.Channel,
-	client *api.Client,
-	serviceDeployer *serviceDeployer,
-	resolver *charmURLResolver,
-	log deploymentLogger,
-	bundleStorage map[string]map[string]storage.Constraints,
-) (map[*charm.URL]*macaroon.Macaroon, error) {
+// verifyBundle checks that the given bundle data is valid. If
+// bundleFilePath is not empty, local charm paths in the bundle are
+// resolved relative to it.
+func verifyBundle(data *charm.BundleData, bundleFilePath string) error {
 	verifyConstraints := func(s string) error {
 		_, err := constraints.Parse(s)
 		return err
@@ -75,15 +67,34 @@ func deployBundle(
 	} else {
 		verifyError = data.VerifyLocal(bundleFilePath, verifyConstraints, verifyStorage)
 	}
-	if verifyError != nil {
-		if verr, ok := verifyError.(*charm.VerificationError); ok {
-			errs := make([]string, len(verr.Errors))
-			for i, err := range verr.Errors {
-				errs[i] = err.Error()
-			}
-			return nil, errors.New("the provided bundle has the following errors:\n" + strings.Join(errs, "\n"))
+	if verr, ok := verifyError.(*charm.VerificationError); ok {
+		errs := make([]string, len(verr.Errors))
+		for i, err := range verr.Errors {
+			errs[i] = err.Error()
 		}
-		return nil, errors.Annotate(verifyError, "cannot deploy bundle")
+		return errors.New("the provided bundle has the following errors:\n" + strings.Join(errs, "\n"))
+	}
+	return verifyError
+}
+
+// deployBundle deploys the given bundle data using the given API client and
+// charm store client. The deployment is not transactional, and its progress is
+// notified using the given deployment logger. Services already in the model
+// are updated to match the bundle; if prune is true, the services and
+// relations that the bundle does not include are removed.
+func deployBundle(
+	bundleFilePath string,
+	data *charm.BundleData,
+	channel csparams.Channel,
+	client *api.Client,
+	serviceDeployer *serviceDeployer,
+	resolver *charmURLResolver,
+	log deploymentLogger,
+	bundleStorage map[string]map[string]storage.Constraints,
+	prune bool,
+) (map[*charm.URL]*macaroon.Macaroon, error) {
+	if err := verifyBundle(data, bundleFilePath); err != nil {
+		return nil, errors.Trace(err)
 	}
 
 	// Retrieve bundle changes.
@@ -179,6 +190,9 @@ func deployBundle(
 			return nil, errors.Annotate(err, "cannot deploy bundle")
 		}
 	}
+	if err := h.reconcileModel(prune); err != nil {
+		return nil, errors.Annotate(err, "cannot deploy bundle")
+	}
 	return csMacs, nil
 }
 
@@ -409,8 +423,16 @@ func (h *bundleHandler) addService(id string, p bundlechanges.AddServiceParams,
 		}
 		h.log.Infof("configuration updated for service %s", p.Service)
 	}
-	// Update service constraints.
-	if p.Constraints != "" {
+	if err := h.resetOptions(p.Service, p.Options); err != nil {
+		return errors.Trace(err)
+	}
+	// Update service constraints, clearing any that the bundle does not
+	// specify.
+	existingCons, err := h.serviceClient.GetConstraints(p.Service)
+	if err != nil {
+		return errors.Annotatef(err, "cannot get constraints for service %q", p.Service)
+	}
+	if p.Constraints != "" || existingCons.String() != "" {
 		if err := h.serviceClient.SetConstraints(p.Service, cons); err != nil {
 			// This should never happen, as the bundle is already verified.
 			return errors.Annotatef(err, "cannot update constraints for service %q", p.Service)
@@ -420,6 +442,34 @@ func (h *bundleHandler) addService(id string, p bundlechanges.AddServiceParams,
 	return nil
 }
 
+// resetOptions restores the defaults of the options of an existing service
+// that have been set in the environment but are not set by the bundle.
+func (h *bundleHandler) resetOptions(service string, options map[string]interface{}) error {
+	results, err := h.serviceClient.Get(service)
+	if err != nil {
+		return errors.Annotatef(err, "cannot get options for service %q", service)
+	}
+	var unset []string
+	for name, info := range results.Config {
+		if _, ok := options[name]; ok {
+			continue
+		}
+		info, _ := info.(map[string]interface{})
+		if isDefault, _ := info["default"].(bool); !isDefault {
+			unset = append(unset, name)
+		}
+	}
+	if len(unset) == 0 {
+		return nil
+	}
+	sort.Strings(unset)
+	if err := h.serviceClient.Unset(service, unset); err != nil {
+		return errors.Annotatef(err, "cannot reset options for service %q", service)
+	}
+	h.log.Infof("options %s reset to defaults for service %s", strings.Join(unset, ", "), service)
+	return nil
+}
+
 // addMachine creates a new top-level machine or container in the environment.
 func (h *bundleHandler) addMachine(id string, p bundlechanges.AddMachineParams) error {
 	services := h.servicesForMachineChange(id)
@@ -698,6 +748,71 @@ func (h *bundleHandler) chooseMachine(services ...string) string {
 	return result
 }
 
+// reconcileModel brings the environment in line with the bundle once all the
+// bundle changes have been applied. Units beyond the number the bundle asks
+// for are removed, newest first. If prune is true, the relations and services
+// that are not included in the bundle are removed too.
+func (h *bundleHandler) reconcileModel(prune bool) error {
+	status, err := h.client.Status(nil)
+	if err != nil {
+		return errors.Annotate(err, "cannot get model status")
+	}
+	serviceNames := make([]string, 0, len(h.data.Services))
+	for name := range h.data.Services {
+		serviceNames = append(serviceNames, name)
+	}
+	sort.Strings(serviceNames)
+	for _, name := range serviceNames {
+		serviceStatus, ok := status.Services[name]
+		if !ok || len(serviceStatus.SubordinateTo) > 0 {
+			// Subordinate units follow their principals.
+			continue
+		}
+		excess := len(serviceStatus.Units) - h.data.Services[name].NumUnits
+		if excess <= 0 {
+			continue
+		}
+		units := make([]string, 0, len(serviceStatus.Units))
+		for unit := range serviceStatus.Units {
+			units = append(units, unit)
+		}
+		unitsort.Sort(units)
+		units = units[len(units)-excess:]
+		if err := h.serviceClient.DestroyUnits(units...); err != nil {
+			return errors.Annotatef(err, "cannot remove units from service %q", name)
+		}
+		h.log.Infof("removed %s from service %s", strings.Join(units, ", "), name)
+	}
+	if !prune {
+		return nil
+	}
+
+	relations, err := diffRelations(h.data.Relations, status.Relations)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, relation := range relations.Removed {
+		if err := h.serviceClient.DestroyRelation(relation...); err != nil {
+			return errors.Annotatef(err, "cannot remove relation between %q and %q", relation[0], relation[1])
+		}
+		h.log.Infof("removed relation between %s and %s", relation[0], relation[1])
+	}
+	var removed []string
+	for name := range status.Services {
+		if _, ok := h.data.Services[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(removed)
+	for _, name := range removed {
+		if err := h.serviceClient.Destroy(name); err != nil {
+			return errors.Annotatef(err, "cannot remove service %q", name)
+		}
+		h.log.Infof("service %s removed", name)
+	}
+	return nil
+}
+
 // updateUnitStatusPeriod is the time duration used to wait for a mega-watcher
 // change to be available.
 var updateUnitStatusPeriod = watcher.Period + 5*time.Second
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -336,9 +337,9 @@ func (s *BundleDeployCharmStoreSuite) Client() *csclient.Client {
 }
 
 // DeployBundleYAML uses the given bundle content to create a bundle in the
-// local repository and then deploy it. It returns the bundle deployment output
-// and error.
-func (s *BundleDeployCharmStoreSuite) DeployBundleYAML(c *gc.C, content string) (string, error) {
+// local repository and then deploy it, passing any additional arguments to
+// the deploy command. It returns the bundle deployment output and error.
+func (s *BundleDeployCharmStoreSuite) DeployBundleYAML(c *gc.C, content string, args ...string) (string, error) {
 	bundlePath := filepath.Join(c.MkDir(), "example")
 	c.Assert(os.Mkdir(bundlePath, 0777), jc.ErrorIsNil)
 	defer os.RemoveAll(bundlePath)
@@ -346,7 +347,7 @@ func (s *BundleDeployCharmStoreSuite) DeployBundleYAML(c *gc.C, content string)
 	c.Assert(err, jc.ErrorIsNil)
 	err = ioutil.WriteFile(filepath.Join(bundlePath, "README.md"), []byte("README"), 0644)
 	c.Assert(err, jc.ErrorIsNil)
-	return runDeployCommand(c, bundlePath)
+	return runDeployCommand(c, bundlePath, args...)
 }
 
 var deployBundleErrorsTests = []struct {
@@ -1122,6 +1123,129 @@ deployment of bundle "local:bundle/example-0" completed`
 	})
 }
 
+func (s *BundleDeployCharmStoreSuite) TestDeployBundleTwiceScaleDown(c *gc.C) {
+	testcharms.UploadCharm(c, s.client, "xenial/django-42", "dummy")
+	_, err := s.DeployBundleYAML(c, `
+        services:
+            django:
+                charm: cs:xenial/django-42
+                num_units: 3
+    `)
+	c.Assert(err, jc.ErrorIsNil)
+	output, err := s.DeployBundleYAML(c, `
+        services:
+            django:
+                charm: cs:xenial/django-42
+                num_units: 1
+    `)
+	c.Assert(err, jc.ErrorIsNil)
+	expectedOutput := `
+added charm cs:xenial/django-42
+reusing service django (charm: cs:xenial/django-42)
+avoid adding new units to service django: 3 units already present
+removed django/1, django/2 from service django
+deployment of bundle "local:bundle/example-0" completed`
+	c.Assert(output, gc.Equals, strings.TrimSpace(expectedOutput))
+	s.assertUnitsRemoved(c, "django/0", "django/1", "django/2")
+}
+
+func (s *BundleDeployCharmStoreSuite) TestDeployBundleResetsOptionsAndConstraints(c *gc.C) {
+	testcharms.UploadCharm(c, s.client, "xenial/wordpress-42", "wordpress")
+	_, err := s.DeployBundleYAML(c, `
+        services:
+            wordpress:
+                charm: wordpress
+                num_units: 1
+                options:
+                    blog-title: these are the voyages
+                constraints: mem=4G
+    `)
+	c.Assert(err, jc.ErrorIsNil)
+	output, err := s.DeployBundleYAML(c, `
+        services:
+            wordpress:
+                charm: wordpress
+                num_units: 1
+    `)
+	c.Assert(err, jc.ErrorIsNil)
+	expectedOutput := `
+added charm cs:xenial/wordpress-42
+reusing service wordpress (charm: cs:xenial/wordpress-42)
+options blog-title reset to defaults for service wordpress
+constraints applied for service wordpress
+avoid adding new units to service wordpress: 1 unit already present
+deployment of bundle "local:bundle/example-0" completed`
+	c.Assert(output, gc.Equals, strings.TrimSpace(expectedOutput))
+	s.assertServicesDeployed(c, map[string]serviceInfo{
+		"wordpress": {charm: "cs:xenial/wordpress-42"},
+	})
+}
+
+func (s *BundleDeployCharmStoreSuite) TestDeployBundlePrune(c *gc.C) {
+	testcharms.UploadCharm(c, s.client, "xenial/mysql-42", "mysql")
+	testcharms.UploadCharm(c, s.client, "xenial/wordpress-47", "wordpress")
+	testcharms.UploadBundle(c, s.client, "bundle/wordpress-simple-1", "wordpress-simple")
+	_, err := runDeployCommand(c, "bundle/wordpress-simple")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Without --prune, services and relations not in the bundle are kept.
+	content := `
+        services:
+            wordpress:
+                charm: cs:xenial/wordpress-47
+                num_units: 1
+    `
+	output, err := s.DeployBundleYAML(c, content)
+	c.Assert(err, jc.ErrorIsNil)
+	expectedOutput := `
+added charm cs:xenial/wordpress-47
+reusing service wordpress (charm: cs:xenial/wordpress-47)
+avoid adding new units to service wordpress: 1 unit already present
+deployment of bundle "local:bundle/example-0" completed`
+	c.Assert(output, gc.Equals, strings.TrimSpace(expectedOutput))
+	s.assertRelationsEstablished(c, "wordpress:db mysql:server")
+
+	output, err = s.DeployBundleYAML(c, content, "--prune")
+	c.Assert(err, jc.ErrorIsNil)
+	expectedOutput = `
+added charm cs:xenial/wordpress-47
+reusing service wordpress (charm: cs:xenial/wordpress-47)
+avoid adding new units to service wordpress: 1 unit already present
+removed relation between mysql:server and wordpress:db
+service mysql removed
+deployment of bundle "local:bundle/example-0" completed`
+	c.Assert(output, gc.Equals, strings.TrimSpace(expectedOutput))
+	s.assertRelationsEstablished(c)
+	mysql, err := s.State.Service("mysql")
+	if err == nil {
+		c.Assert(mysql.Life(), gc.Equals, state.Dying)
+	} else {
+		c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	}
+}
+
+func (s *BundleDeployCharmStoreSuite) TestDeployCharmPruneFlag(c *gc.C) {
+	testcharms.UploadCharm(c, s.client, "xenial/mysql-42", "mysql")
+	_, err := runDeployCommand(c, "xenial/mysql", "--prune")
+	c.Assert(err, gc.ErrorMatches, "Flags provided but not supported when deploying a charm: --prune.")
+}
+
+// assertUnitsRemoved checks that the removed units are dying or gone, and
+// that the remaining unit is still alive.
+func (s *BundleDeployCharmStoreSuite) assertUnitsRemoved(c *gc.C, remaining string, removed ...string) {
+	unit, err := s.State.Unit(remaining)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.Life(), gc.Equals, state.Alive)
+	for _, name := range removed {
+		unit, err := s.State.Unit(name)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(unit.Life(), gc.Not(gc.Equals), state.Alive)
+	}
+}
+
 func (s *BundleDeployCharmStoreSuite) TestDeployBundleUnitPlacedInService(c *gc.C) {
 	testcharms.UploadCharm(c, s.client, "xenial/django-42", "dummy")
 	testcharms.UploadCharm(c, s.client, "xenial/wordpress-0", "wordpress")
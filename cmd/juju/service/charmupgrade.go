@@ -0,0 +1,192 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/service"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageShowCharmUpgradeSummary = `
+Shows the progress of a service's rolling charm upgrade.`[1:]
+
+var usageShowCharmUpgradeDetails = `
+Shows the charms a rolling upgrade started by "juju upgrade-charm --rolling"
+is moving the service between, how the units are upgraded, and which units
+have been released to upgrade so far. If the upgrade has halted, the reason
+is shown.
+
+Examples:
+    juju show-charm-upgrade mysql
+
+See also:
+    upgrade-charm
+    resume-charm-upgrade`[1:]
+
+// NewShowCharmUpgradeCommand returns a command that shows the progress of
+// a service's rolling charm upgrade.
+func NewShowCharmUpgradeCommand() cmd.Command {
+	return modelcmd.Wrap(&showCharmUpgradeCommand{})
+}
+
+// showCharmUpgradeCommand shows the progress of a rolling charm upgrade.
+type showCharmUpgradeCommand struct {
+	modelcmd.ModelCommandBase
+	ServiceName string
+	out         cmd.Output
+	api         charmUpgradeAPI
+}
+
+func (c *showCharmUpgradeCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-charm-upgrade",
+		Args:    "<service name>",
+		Purpose: usageShowCharmUpgradeSummary,
+		Doc:     usageShowCharmUpgradeDetails,
+	}
+}
+
+func (c *showCharmUpgradeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+func (c *showCharmUpgradeCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no service name specified")
+	}
+	c.ServiceName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// charmUpgradeAPI defines the methods on the service API that the
+// rolling charm upgrade commands call.
+type charmUpgradeAPI interface {
+	Close() error
+	CharmUpgradeStatus(serviceName string) (params.CharmUpgradeStatusResult, error)
+	ResumeCharmUpgrade(serviceName string) error
+}
+
+func (c *showCharmUpgradeCommand) getAPI() (charmUpgradeAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return service.NewClient(root), nil
+}
+
+// charmUpgradeStatus is the output format of show-charm-upgrade.
+type charmUpgradeStatus struct {
+	From        string   `yaml:"from" json:"from"`
+	To          string   `yaml:"to" json:"to"`
+	BatchSize   int      `yaml:"batch-size" json:"batch-size"`
+	Pause       string   `yaml:"pause" json:"pause"`
+	HaltOnError bool     `yaml:"halt-on-error" json:"halt-on-error"`
+	Released    []string `yaml:"released" json:"released"`
+	Pending     []string `yaml:"pending" json:"pending"`
+	Halted      string   `yaml:"halted,omitempty" json:"halted,omitempty"`
+}
+
+// Run shows the progress of the service's rolling charm upgrade.
+func (c *showCharmUpgradeCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	result, err := client.CharmUpgradeStatus(c.ServiceName)
+	if err != nil {
+		return err
+	}
+	return c.out.Write(ctx, charmUpgradeStatus{
+		From:        result.FromCharmURL,
+		To:          result.ToCharmURL,
+		BatchSize:   result.Strategy.BatchSize,
+		Pause:       result.Strategy.Pause.String(),
+		HaltOnError: result.Strategy.HaltOnError,
+		Released:    result.Released,
+		Pending:     result.Pending,
+		Halted:      result.HaltReason,
+	})
+}
+
+var usageResumeCharmUpgradeSummary = `
+Resumes a service's halted rolling charm upgrade.`[1:]
+
+var usageResumeCharmUpgradeDetails = `
+A rolling upgrade started with "juju upgrade-charm --rolling" halts when an
+upgraded unit goes into an error state, unless --halt-on-error=false was
+given. Once the failed units have been resolved, this command releases the
+rest of the service's units to continue upgrading.
+
+Examples:
+    juju resume-charm-upgrade mysql
+
+See also:
+    upgrade-charm
+    show-charm-upgrade
+    resolved`[1:]
+
+// NewResumeCharmUpgradeCommand returns a command that resumes a service's
+// halted rolling charm upgrade.
+func NewResumeCharmUpgradeCommand() cmd.Command {
+	return modelcmd.Wrap(&resumeCharmUpgradeCommand{})
+}
+
+// resumeCharmUpgradeCommand resumes a halted rolling charm upgrade.
+type resumeCharmUpgradeCommand struct {
+	modelcmd.ModelCommandBase
+	ServiceName string
+	api         charmUpgradeAPI
+}
+
+func (c *resumeCharmUpgradeCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "resume-charm-upgrade",
+		Args:    "<service name>",
+		Purpose: usageResumeCharmUpgradeSummary,
+		Doc:     usageResumeCharmUpgradeDetails,
+	}
+}
+
+func (c *resumeCharmUpgradeCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no service name specified")
+	}
+	c.ServiceName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *resumeCharmUpgradeCommand) getAPI() (charmUpgradeAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return service.NewClient(root), nil
+}
+
+// Run resumes the service's rolling charm upgrade.
+func (c *resumeCharmUpgradeCommand) Run(_ *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return block.ProcessBlockedError(client.ResumeCharmUpgrade(c.ServiceName), block.BlockChange)
+}
@@ -0,0 +1,102 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/service"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type CharmUpgradeSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	fake *fakeCharmUpgradeAPI
+}
+
+var _ = gc.Suite(&CharmUpgradeSuite{})
+
+func (s *CharmUpgradeSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeCharmUpgradeAPI{
+		result: params.CharmUpgradeStatusResult{
+			FromCharmURL: "cs:trusty/mysql-1",
+			ToCharmURL:   "cs:trusty/mysql-2",
+			Strategy: params.CharmUpgradeStrategy{
+				BatchSize:   2,
+				Pause:       5 * time.Minute,
+				HaltOnError: true,
+			},
+			Released:   []string{"mysql/0", "mysql/1"},
+			Pending:    []string{"mysql/2"},
+			HaltReason: "unit mysql/1 is in error",
+		},
+	}
+}
+
+func (s *CharmUpgradeSuite) TestInit(c *gc.C) {
+	err := coretesting.InitCommand(service.NewShowCharmUpgradeCommandForTest(s.fake), nil)
+	c.Assert(err, gc.ErrorMatches, "no service name specified")
+	err = coretesting.InitCommand(service.NewResumeCharmUpgradeCommandForTest(s.fake), []string{"mysql", "extra"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *CharmUpgradeSuite) TestShowCharmUpgrade(c *gc.C) {
+	ctx, err := coretesting.RunCommand(c, service.NewShowCharmUpgradeCommandForTest(s.fake), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.serviceName, gc.Equals, "mysql")
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, `
+from: cs:trusty/mysql-1
+to: cs:trusty/mysql-2
+batch-size: 2
+pause: 5m0s
+halt-on-error: true
+released:
+- mysql/0
+- mysql/1
+pending:
+- mysql/2
+halted: unit mysql/1 is in error
+`[1:])
+}
+
+func (s *CharmUpgradeSuite) TestShowCharmUpgradeError(c *gc.C) {
+	s.fake.err = errors.NotFoundf(`rolling charm upgrade for service "mysql"`)
+	_, err := coretesting.RunCommand(c, service.NewShowCharmUpgradeCommandForTest(s.fake), "mysql")
+	c.Assert(err, gc.ErrorMatches, `rolling charm upgrade for service "mysql" not found`)
+}
+
+func (s *CharmUpgradeSuite) TestResumeCharmUpgrade(c *gc.C) {
+	_, err := coretesting.RunCommand(c, service.NewResumeCharmUpgradeCommandForTest(s.fake), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.serviceName, gc.Equals, "mysql")
+	c.Assert(s.fake.resumed, jc.IsTrue)
+}
+
+type fakeCharmUpgradeAPI struct {
+	serviceName string
+	result      params.CharmUpgradeStatusResult
+	resumed     bool
+	err         error
+}
+
+func (f *fakeCharmUpgradeAPI) Close() error {
+	return nil
+}
+
+func (f *fakeCharmUpgradeAPI) CharmUpgradeStatus(serviceName string) (params.CharmUpgradeStatusResult, error) {
+	f.serviceName = serviceName
+	return f.result, f.err
+}
+
+func (f *fakeCharmUpgradeAPI) ResumeCharmUpgrade(serviceName string) error {
+	f.serviceName = serviceName
+	f.resumed = true
+	return f.err
+}
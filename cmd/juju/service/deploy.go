@@ -80,6 +80,10 @@ type DeployCommand struct {
 	// the storage name defined in that service's charm storage metadata.
 	BundleStorage map[string]map[string]storage.Constraints
 
+	// Prune indicates whether services and relations that are not in the
+	// deployed bundle should be removed from the model.
+	Prune bool
+
 	// Resources is a map of resource name to filename to be uploaded on deploy.
 	Resources map[string]string
 
@@ -127,6 +131,14 @@ For example:
 
   juju deploy /path/to/bundle/openstack/bundle.yaml
 
+Deploying a bundle into a model that already holds some of its services
+updates those services to match the bundle: their charms are upgraded, config
+options and constraints are set as in the bundle (options the bundle does not
+set are reset to their defaults), and units are added or removed to reach
+the number of units requested. Services and relations not included in the
+bundle are left in place unless --prune is given, in which case they are
+removed. Use "juju diff-bundle" to review the changes beforehand.
+
 <service name>, if omitted, will be derived from <charm name>.
 
 Constraints can be specified when using deploy by specifying the --constraints
@@ -226,7 +238,7 @@ var (
 	// charmOnlyFlags and bundleOnlyFlags are used to validate flags based on
 	// whether we are deploying a charm or a bundle.
 	charmOnlyFlags  = []string{"bind", "config", "constraints", "force", "n", "num-units", "series", "to", "resource"}
-	bundleOnlyFlags = []string{}
+	bundleOnlyFlags = []string{"prune"}
 )
 
 func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
@@ -242,6 +254,7 @@ func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(storageFlag{&c.Storage, &c.BundleStorage}, "storage", "charm storage constraints")
 	f.Var(stringMap{&c.Resources}, "resource", "resource to be uploaded to the controller")
 	f.StringVar(&c.BindToSpaces, "bind", "", "Configure service endpoint bindings to spaces")
+	f.BoolVar(&c.Prune, "prune", false, "remove services and relations that are not in the bundle")
 
 	for _, step := range c.Steps {
 		step.SetFlags(f)
@@ -425,7 +438,7 @@ func (c *DeployCommand) deployCharmOrBundle(ctx *cmd.Context, client *api.Client
 		}
 		// TODO(ericsnow) Do something with the CS macaroons that were returned?
 		if _, err := deployBundle(
-			bundleFilePath, bundleData, c.Channel, client, &deployer, resolver, ctx, c.BundleStorage,
+			bundleFilePath, bundleData, c.Channel, client, &deployer, resolver, ctx, c.BundleStorage, c.Prune,
 		); err != nil {
 			return errors.Trace(err)
 		}
@@ -0,0 +1,549 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/charmrepo.v2-unstable"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api"
+	apiservice "github.com/juju/juju/api/service"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/unitsort"
+)
+
+var usageDiffBundleSummary = `
+Compares a bundle with the current model.`[1:]
+
+var usageDiffBundleDetails = `
+Reports what deploying the given bundle file would change in the current
+model. Services and relations in the bundle but not in the model are listed
+as added, and those in the model but not in the bundle as removed. For
+services in both, differences in charm, unit count, config, constraints,
+expose flag and unit placement are listed as changed, with the bundle's
+value next to the model's.
+
+Config settings the bundle does not mention are compared with the charm
+defaults, which are shown as null. Unit placement is only compared when
+the bundle places the service's units. Bundle machines are matched with
+model machines by the units they host rather than by number, and units
+placed on "new" machines may be on any machine, but each unit must be
+in the container type and alongside the units the bundle asks for.
+
+Examples:
+    juju diff-bundle bundle.yaml
+    juju diff-bundle bundle.yaml --format json
+
+See also:
+    deploy
+    export-bundle`[1:]
+
+// NewDiffBundleCommand returns a command that compares a bundle with the
+// current model.
+func NewDiffBundleCommand() cmd.Command {
+	return modelcmd.Wrap(&diffBundleCommand{})
+}
+
+// diffBundleCommand compares a bundle with the current model.
+type diffBundleCommand struct {
+	modelcmd.ModelCommandBase
+	BundleFile string
+	out        cmd.Output
+	api        diffBundleAPI
+}
+
+func (c *diffBundleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "diff-bundle",
+		Args:    "<bundle file>",
+		Purpose: usageDiffBundleSummary,
+		Doc:     usageDiffBundleDetails,
+	}
+}
+
+func (c *diffBundleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+func (c *diffBundleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no bundle file specified")
+	}
+	c.BundleFile = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// diffBundleAPI defines the methods on the API that the diff-bundle
+// command calls.
+type diffBundleAPI interface {
+	Close() error
+	Status(patterns []string) (*params.FullStatus, error)
+	Get(service string) (*params.ServiceGetResults, error)
+}
+
+// diffBundleClient implements diffBundleAPI using the Client and Service
+// facades.
+type diffBundleClient struct {
+	*apiservice.Client
+	client *api.Client
+}
+
+// Status is part of the diffBundleAPI interface.
+func (c diffBundleClient) Status(patterns []string) (*params.FullStatus, error) {
+	return c.client.Status(patterns)
+}
+
+func (c *diffBundleCommand) getAPI() (diffBundleAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return diffBundleClient{
+		Client: apiservice.NewClient(root),
+		client: root.Client(),
+	}, nil
+}
+
+// Run compares the bundle with the current model and writes the
+// differences.
+func (c *diffBundleCommand) Run(ctx *cmd.Context) error {
+	path := ctx.AbsPath(c.BundleFile)
+	data, err := charmrepo.ReadBundleFile(path)
+	if err != nil {
+		return errors.Annotate(err, "cannot read bundle")
+	}
+	if err := verifyBundle(data, filepath.Dir(path)); err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	status, err := client.Status(nil)
+	if err != nil {
+		return errors.Annotate(err, "cannot get model status")
+	}
+	diff, err := diffBundle(data, status, client.Get)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if diff.empty() {
+		ctx.Infof("The bundle matches the model.")
+		return nil
+	}
+	return c.out.Write(ctx, diff)
+}
+
+// bundleDiff describes the differences between a bundle and a model.
+type bundleDiff struct {
+	Services  *servicesDiff  `yaml:"services,omitempty" json:"services,omitempty"`
+	Relations *relationsDiff `yaml:"relations,omitempty" json:"relations,omitempty"`
+}
+
+func (d *bundleDiff) empty() bool {
+	return d.Services == nil && d.Relations == nil
+}
+
+// servicesDiff describes the differences between a bundle's services and
+// a model's.
+type servicesDiff struct {
+	// Added holds the services in the bundle but not the model.
+	Added []string `yaml:"added,omitempty" json:"added,omitempty"`
+	// Removed holds the services in the model but not the bundle.
+	Removed []string `yaml:"removed,omitempty" json:"removed,omitempty"`
+	// Changed holds the differences in services in both.
+	Changed map[string]*serviceDiff `yaml:"changed,omitempty" json:"changed,omitempty"`
+}
+
+// serviceDiff describes the differences between a service in a bundle and
+// in a model.
+type serviceDiff struct {
+	Charm       *valueDiff            `yaml:"charm,omitempty" json:"charm,omitempty"`
+	NumUnits    *valueDiff            `yaml:"num_units,omitempty" json:"num_units,omitempty"`
+	Config      map[string]*valueDiff `yaml:"config,omitempty" json:"config,omitempty"`
+	Constraints *valueDiff            `yaml:"constraints,omitempty" json:"constraints,omitempty"`
+	Expose      *valueDiff            `yaml:"expose,omitempty" json:"expose,omitempty"`
+	Placement   *valueDiff            `yaml:"placement,omitempty" json:"placement,omitempty"`
+}
+
+func (d *serviceDiff) empty() bool {
+	return reflect.DeepEqual(d, &serviceDiff{})
+}
+
+// valueDiff holds a value that differs between a bundle and a model.
+type valueDiff struct {
+	Bundle interface{} `yaml:"bundle" json:"bundle"`
+	Model  interface{} `yaml:"model" json:"model"`
+}
+
+// relationsDiff describes the differences between a bundle's relations
+// and a model's.
+type relationsDiff struct {
+	// Added holds the relations in the bundle but not the model.
+	Added [][]string `yaml:"added,omitempty" json:"added,omitempty"`
+	// Removed holds the relations in the model but not the bundle.
+	Removed [][]string `yaml:"removed,omitempty" json:"removed,omitempty"`
+}
+
+// diffBundle compares the bundle data with the model described by status,
+// using getService to fetch the config and constraints of the services in
+// both.
+func diffBundle(
+	data *charm.BundleData,
+	status *params.FullStatus,
+	getService func(string) (*params.ServiceGetResults, error),
+) (*bundleDiff, error) {
+	names := make([]string, 0, len(data.Services))
+	for name := range data.Services {
+		names = append(names, name)
+	}
+	// Services are compared in a fixed order so that bundle machines
+	// are always matched with model machines in the same way.
+	sort.Strings(names)
+	placement := newPlacementMatcher(status)
+	services := &servicesDiff{Changed: make(map[string]*serviceDiff)}
+	for _, name := range names {
+		spec := data.Services[name]
+		serviceStatus, ok := status.Services[name]
+		if !ok {
+			services.Added = append(services.Added, name)
+			continue
+		}
+		results, err := getService(name)
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot get service %q", name)
+		}
+		diff, err := diffService(spec, serviceStatus, results, placement)
+		if err != nil {
+			return nil, errors.Annotatef(err, "service %q", name)
+		}
+		if !diff.empty() {
+			services.Changed[name] = diff
+		}
+	}
+	for name := range status.Services {
+		if _, ok := data.Services[name]; !ok {
+			services.Removed = append(services.Removed, name)
+		}
+	}
+	sort.Strings(services.Added)
+	sort.Strings(services.Removed)
+	if len(services.Changed) == 0 {
+		services.Changed = nil
+	}
+
+	var diff bundleDiff
+	if services.Added != nil || services.Removed != nil || services.Changed != nil {
+		diff.Services = services
+	}
+	relations, err := diffRelations(data.Relations, status.Relations)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if relations.Added != nil || relations.Removed != nil {
+		diff.Relations = relations
+	}
+	return &diff, nil
+}
+
+// diffService compares a service in a bundle with the same service in a
+// model.
+func diffService(
+	spec *charm.ServiceSpec,
+	status params.ServiceStatus,
+	results *params.ServiceGetResults,
+	placement *placementMatcher,
+) (*serviceDiff, error) {
+	var diff serviceDiff
+	matches, err := charmMatches(spec.Charm, status.Charm)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !matches {
+		diff.Charm = &valueDiff{spec.Charm, status.Charm}
+	}
+	if spec.Expose != status.Exposed {
+		diff.Expose = &valueDiff{spec.Expose, status.Exposed}
+	}
+	diff.Config = diffConfig(spec.Options, results.Config)
+
+	if len(status.SubordinateTo) > 0 {
+		// Subordinate units follow their principals, and subordinate
+		// services have no constraints.
+		return &diff, nil
+	}
+	if spec.NumUnits != len(status.Units) {
+		diff.NumUnits = &valueDiff{spec.NumUnits, len(status.Units)}
+	}
+	cons, err := constraints.Parse(spec.Constraints)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if cons.String() != results.Constraints.String() {
+		diff.Constraints = &valueDiff{cons.String(), results.Constraints.String()}
+	}
+	if len(spec.To) > 0 {
+		matches, err := placement.matches(spec.To, status.Units)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !matches {
+			diff.Placement = &valueDiff{spec.To, unitPlacement(status.Units)}
+		}
+	}
+	return &diff, nil
+}
+
+// charmMatches reports whether the charm in a bundle refers to the
+// charm URL in a model. The bundle's URL may omit the series and the
+// revision; a local charm path is matched by charm name.
+func charmMatches(bundleCharm, modelCharm string) (bool, error) {
+	modelURL, err := charm.ParseURL(modelCharm)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if strings.HasPrefix(bundleCharm, ".") || filepath.IsAbs(bundleCharm) {
+		return modelURL.Schema == "local" && filepath.Base(bundleCharm) == modelURL.Name, nil
+	}
+	bundleURL, err := charm.ParseURL(bundleCharm)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if bundleURL.Revision == -1 {
+		modelURL = modelURL.WithRevision(-1)
+	}
+	if bundleURL.Series == "" {
+		modelURL.Series = ""
+	}
+	return *bundleURL == *modelURL, nil
+}
+
+// diffConfig compares a service's config options in a bundle with the
+// config reported by ServiceGet. Options that the bundle does not set
+// are expected to have their default values.
+func diffConfig(options map[string]interface{}, config map[string]interface{}) map[string]*valueDiff {
+	diff := make(map[string]*valueDiff)
+	for name, info := range config {
+		info, _ := info.(map[string]interface{})
+		modelValue := info["value"]
+		isDefault, _ := info["default"].(bool)
+		bundleValue, ok := options[name]
+		if !ok {
+			if !isDefault {
+				diff[name] = &valueDiff{nil, modelValue}
+			}
+			continue
+		}
+		// Values have been through YAML or JSON, so compare their
+		// string forms rather than their types.
+		if fmt.Sprint(bundleValue) != fmt.Sprint(modelValue) {
+			diff[name] = &valueDiff{bundleValue, modelValue}
+		}
+	}
+	for name, value := range options {
+		if _, ok := config[name]; !ok {
+			diff[name] = &valueDiff{value, nil}
+		}
+	}
+	if len(diff) == 0 {
+		return nil
+	}
+	return diff
+}
+
+// unitPlacement returns the placement of a service's units, in unit
+// number order, in the form used by bundles.
+func unitPlacement(units map[string]params.UnitStatus) []string {
+	var placement []string
+	for _, name := range placedUnits(units) {
+		machine := units[name].Machine
+		if host, containerType := splitMachine(machine); containerType != "" {
+			machine = fmt.Sprintf("%s:%s", containerType, host)
+		}
+		placement = append(placement, machine)
+	}
+	return placement
+}
+
+// placedUnits returns the names of the units that have been assigned
+// to machines, in unit number order.
+func placedUnits(units map[string]params.UnitStatus) []string {
+	names := make([]string, 0, len(units))
+	for name, unit := range units {
+		if unit.Machine != "" {
+			names = append(names, name)
+		}
+	}
+	unitsort.Sort(names)
+	return names
+}
+
+// splitMachine returns the id of the machine hosting the given machine,
+// and the type of container the given machine is. A machine that is not
+// a container is its own host, and has no container type.
+func splitMachine(machine string) (host, containerType string) {
+	parts := strings.Split(machine, "/")
+	if len(parts) < 3 {
+		return machine, ""
+	}
+	return strings.Join(parts[:len(parts)-2], "/"), parts[len(parts)-2]
+}
+
+// placementMatcher compares the placement directives in a bundle with
+// the machines that a model's units are assigned to. Bundle machines
+// are matched with model machines as the directives are compared, so
+// that each bundle machine stands for the same model machine, and no
+// two bundle machines stand for the same one, across all services.
+type placementMatcher struct {
+	status *params.FullStatus
+
+	// machines maps bundle machine ids to model machine ids.
+	machines map[string]string
+
+	// bundleMachines maps model machine ids to bundle machine ids.
+	bundleMachines map[string]string
+}
+
+func newPlacementMatcher(status *params.FullStatus) *placementMatcher {
+	return &placementMatcher{
+		status:         status,
+		machines:       make(map[string]string),
+		bundleMachines: make(map[string]string),
+	}
+}
+
+// matches reports whether the given units, in unit number order, are
+// placed as the bundle directives require. Units beyond the directives
+// may be placed anywhere.
+func (m *placementMatcher) matches(to []string, units map[string]params.UnitStatus) (bool, error) {
+	for i, name := range placedUnits(units) {
+		if i >= len(to) {
+			break
+		}
+		placement, err := charm.ParsePlacement(to[i])
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		if !m.unitMatches(placement, units[name].Machine) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// unitMatches reports whether a unit on the given model machine is
+// placed as the bundle directive requires.
+func (m *placementMatcher) unitMatches(placement *charm.UnitPlacement, machine string) bool {
+	host, containerType := splitMachine(machine)
+	if containerType != placement.ContainerType {
+		return false
+	}
+	switch {
+	case placement.Machine == "new":
+		return true
+	case placement.Machine != "":
+		return m.matchMachine(placement.Machine, host)
+	}
+	service, ok := m.status.Services[placement.Service]
+	if !ok {
+		return false
+	}
+	for name, unit := range service.Units {
+		if placement.Unit >= 0 && name != fmt.Sprintf("%s/%d", placement.Service, placement.Unit) {
+			continue
+		}
+		if unit.Machine == host {
+			return true
+		}
+	}
+	return false
+}
+
+// matchMachine reports whether the bundle machine can stand for the
+// model machine, and records that it does.
+func (m *placementMatcher) matchMachine(bundleMachine, machine string) bool {
+	if matched, ok := m.machines[bundleMachine]; ok {
+		return matched == machine
+	}
+	if _, ok := m.bundleMachines[machine]; ok {
+		return false
+	}
+	m.machines[bundleMachine] = machine
+	m.bundleMachines[machine] = bundleMachine
+	return true
+}
+
+// diffRelations compares the relations in a bundle with those in a model.
+// Bundle endpoints may omit the relation name.
+func diffRelations(bundleRelations [][]string, modelRelations []params.RelationStatus) (*relationsDiff, error) {
+	var model [][]string
+	for _, relation := range modelRelations {
+		if len(relation.Endpoints) != 2 {
+			// Peer relations are established by the charms.
+			continue
+		}
+		pair := []string{relation.Endpoints[0].String(), relation.Endpoints[1].String()}
+		sort.Strings(pair)
+		model = append(model, pair)
+	}
+	matched := make([]bool, len(model))
+	var diff relationsDiff
+	for _, relation := range bundleRelations {
+		if len(relation) != 2 {
+			return nil, errors.Errorf("invalid relation %v", relation)
+		}
+		found := false
+		for i, pair := range model {
+			if relationMatches(relation, pair) {
+				matched[i] = true
+				found = true
+			}
+		}
+		if !found {
+			diff.Added = append(diff.Added, relation)
+		}
+	}
+	for i, pair := range model {
+		if !matched[i] {
+			diff.Removed = append(diff.Removed, pair)
+		}
+	}
+	return &diff, nil
+}
+
+// relationMatches reports whether a relation in a bundle refers to the
+// given model relation, whose endpoints are sorted.
+func relationMatches(relation, pair []string) bool {
+	return endpointMatches(relation[0], pair[0]) && endpointMatches(relation[1], pair[1]) ||
+		endpointMatches(relation[0], pair[1]) && endpointMatches(relation[1], pair[0])
+}
+
+// endpointMatches reports whether an endpoint in a bundle, which may omit
+// the relation name, refers to the given model endpoint.
+func endpointMatches(bundleEndpoint, modelEndpoint string) bool {
+	if strings.Contains(bundleEndpoint, ":") {
+		return bundleEndpoint == modelEndpoint
+	}
+	return strings.HasPrefix(modelEndpoint, bundleEndpoint+":")
+}
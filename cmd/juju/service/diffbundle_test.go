@@ -0,0 +1,338 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/service"
+	"github.com/juju/juju/constraints"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type DiffBundleSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	fake *fakeDiffBundleAPI
+	dir  string
+}
+
+var _ = gc.Suite(&DiffBundleSuite{})
+
+func (s *DiffBundleSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+	s.fake = &fakeDiffBundleAPI{
+		status: &params.FullStatus{
+			Services: map[string]params.ServiceStatus{
+				"wordpress": {
+					Charm: "cs:trusty/wordpress-42",
+					Units: map[string]params.UnitStatus{
+						"wordpress/0": {Machine: "0"},
+					},
+				},
+				"mysql": {
+					Charm: "cs:trusty/mysql-38",
+					Units: map[string]params.UnitStatus{
+						"mysql/0": {Machine: "0/lxd/1"},
+					},
+				},
+			},
+			Relations: []params.RelationStatus{{
+				Endpoints: []params.EndpointStatus{
+					{ServiceName: "mysql", Name: "server", Role: charm.RoleProvider},
+					{ServiceName: "wordpress", Name: "db", Role: charm.RoleRequirer},
+				},
+			}},
+		},
+		services: map[string]*params.ServiceGetResults{
+			"wordpress": {
+				Config: map[string]interface{}{
+					"blog-title": map[string]interface{}{
+						"value":   "My Title",
+						"default": true,
+					},
+				},
+			},
+			"mysql": {
+				Config: map[string]interface{}{
+					"dataset-size": map[string]interface{}{
+						"value":   "80%",
+						"default": true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (s *DiffBundleSuite) writeBundle(c *gc.C, content string) {
+	err := ioutil.WriteFile(filepath.Join(s.dir, "bundle.yaml"), []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *DiffBundleSuite) runDiffBundle(c *gc.C, args ...string) (*cmd.Context, error) {
+	return coretesting.RunCommandInDir(c, service.NewDiffBundleCommandForTest(s.fake), append([]string{"bundle.yaml"}, args...), s.dir)
+}
+
+func (s *DiffBundleSuite) TestInit(c *gc.C) {
+	err := coretesting.InitCommand(service.NewDiffBundleCommandForTest(s.fake), nil)
+	c.Assert(err, gc.ErrorMatches, "no bundle file specified")
+	err = coretesting.InitCommand(service.NewDiffBundleCommandForTest(s.fake), []string{"bundle.yaml", "foo"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *DiffBundleSuite) TestMatchingBundle(c *gc.C) {
+	s.writeBundle(c, `
+services:
+  wordpress:
+    charm: cs:trusty/wordpress
+    num_units: 1
+    to: ["0"]
+  mysql:
+    charm: cs:trusty/mysql-38
+    num_units: 1
+    to: ["lxd:0"]
+machines:
+  "0": {}
+relations:
+  - [wordpress, mysql]
+`)
+	ctx, err := s.runDiffBundle(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(coretesting.Stderr(ctx), gc.Equals, "The bundle matches the model.\n")
+	c.Assert(s.fake.closed, jc.IsTrue)
+}
+
+func (s *DiffBundleSuite) TestDifferences(c *gc.C) {
+	s.fake.status.Services["varnish"] = params.ServiceStatus{
+		Charm: "cs:trusty/varnish-1",
+		Units: map[string]params.UnitStatus{
+			"varnish/0": {Machine: "1"},
+		},
+	}
+	s.fake.status.Relations = append(s.fake.status.Relations, params.RelationStatus{
+		Endpoints: []params.EndpointStatus{
+			{ServiceName: "varnish", Name: "webcache", Role: charm.RoleRequirer},
+			{ServiceName: "wordpress", Name: "website", Role: charm.RoleProvider},
+		},
+	})
+	s.fake.services["varnish"] = &params.ServiceGetResults{}
+	s.fake.services["mysql"].Constraints = constraints.MustParse("mem=4G")
+	s.writeBundle(c, `
+services:
+  wordpress:
+    charm: cs:trusty/wordpress
+    num_units: 2
+    expose: true
+    options:
+      blog-title: Bundle
+  mysql:
+    charm: cs:trusty/mysql-39
+    num_units: 1
+    to: ["1"]
+  haproxy:
+    charm: cs:trusty/haproxy
+    num_units: 1
+machines:
+  "1": {}
+relations:
+  - [wordpress, mysql]
+  - ["haproxy:reverseproxy", "wordpress:website"]
+`)
+	ctx, err := s.runDiffBundle(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), jc.YAMLEquals, map[string]interface{}{
+		"services": map[string]interface{}{
+			"added":   []string{"haproxy"},
+			"removed": []string{"varnish"},
+			"changed": map[string]interface{}{
+				"wordpress": map[string]interface{}{
+					"num_units": map[string]interface{}{"bundle": 2, "model": 1},
+					"config": map[string]interface{}{
+						"blog-title": map[string]interface{}{"bundle": "Bundle", "model": "My Title"},
+					},
+					"expose": map[string]interface{}{"bundle": true, "model": false},
+				},
+				"mysql": map[string]interface{}{
+					"charm":       map[string]interface{}{"bundle": "cs:trusty/mysql-39", "model": "cs:trusty/mysql-38"},
+					"constraints": map[string]interface{}{"bundle": "", "model": "mem=4096M"},
+					"placement":   map[string]interface{}{"bundle": []string{"1"}, "model": []string{"lxd:0"}},
+				},
+			},
+		},
+		"relations": map[string]interface{}{
+			"added":   [][]string{{"haproxy:reverseproxy", "wordpress:website"}},
+			"removed": [][]string{{"varnish:webcache", "wordpress:website"}},
+		},
+	})
+}
+
+// placementBundle returns a bundle with wordpress and mysql placed by
+// the given directives, and the given bundle machines.
+func placementBundle(wordpressTo, mysqlTo, machines string) string {
+	return `
+services:
+  wordpress:
+    charm: cs:trusty/wordpress
+    num_units: 1
+    to: ` + wordpressTo + `
+  mysql:
+    charm: cs:trusty/mysql
+    num_units: 1
+    to: ` + mysqlTo + `
+machines: ` + machines + `
+relations:
+  - [wordpress, mysql]
+`
+}
+
+func (s *DiffBundleSuite) TestPlacementMatches(c *gc.C) {
+	for i, test := range []struct {
+		wordpressTo string
+		mysqlTo     string
+		machines    string
+	}{
+		{`["5"]`, `["lxd:5"]`, `{"5": {}}`},
+		{`["new"]`, `["lxd:new"]`, `{}`},
+		{`["new"]`, `["lxd:wordpress/0"]`, `{}`},
+		{`["new"]`, `["lxd:wordpress"]`, `{}`},
+	} {
+		c.Logf("test %d: %s %s", i, test.wordpressTo, test.mysqlTo)
+		s.writeBundle(c, placementBundle(test.wordpressTo, test.mysqlTo, test.machines))
+		ctx, err := s.runDiffBundle(c)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(coretesting.Stdout(ctx), gc.Equals, "")
+	}
+}
+
+func (s *DiffBundleSuite) TestPlacementDiffers(c *gc.C) {
+	for i, test := range []struct {
+		about       string
+		wordpressTo string
+		mysqlTo     string
+		machines    string
+		expected    map[string]interface{}
+	}{{
+		about:       "the model has the services on one machine",
+		wordpressTo: `["1"]`,
+		mysqlTo:     `["lxd:2"]`,
+		machines:    `{"1": {}, "2": {}}`,
+		expected: map[string]interface{}{
+			"wordpress": map[string]interface{}{
+				"placement": map[string]interface{}{"bundle": []string{"1"}, "model": []string{"0"}},
+			},
+		},
+	}, {
+		about:       "the model has mysql in a container",
+		wordpressTo: `["1"]`,
+		mysqlTo:     `["new"]`,
+		machines:    `{"1": {}}`,
+		expected: map[string]interface{}{
+			"mysql": map[string]interface{}{
+				"placement": map[string]interface{}{"bundle": []string{"new"}, "model": []string{"lxd:0"}},
+			},
+		},
+	}, {
+		about:       "the model has mysql in an lxd container",
+		wordpressTo: `["new"]`,
+		mysqlTo:     `["kvm:wordpress/0"]`,
+		machines:    `{}`,
+		expected: map[string]interface{}{
+			"mysql": map[string]interface{}{
+				"placement": map[string]interface{}{"bundle": []string{"kvm:wordpress/0"}, "model": []string{"lxd:0"}},
+			},
+		},
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		s.writeBundle(c, placementBundle(test.wordpressTo, test.mysqlTo, test.machines))
+		ctx, err := s.runDiffBundle(c)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(coretesting.Stdout(ctx), jc.YAMLEquals, map[string]interface{}{
+			"services": map[string]interface{}{"changed": test.expected},
+		})
+	}
+}
+
+func (s *DiffBundleSuite) TestConfigNotInBundle(c *gc.C) {
+	s.fake.services["mysql"].Config["dataset-size"] = map[string]interface{}{
+		"value":   "50%",
+		"default": false,
+	}
+	s.writeBundle(c, `
+services:
+  wordpress:
+    charm: cs:trusty/wordpress
+    num_units: 1
+  mysql:
+    charm: cs:trusty/mysql
+    num_units: 1
+relations:
+  - ["wordpress:db", "mysql:server"]
+`)
+	ctx, err := s.runDiffBundle(c, "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals,
+		`{"services":{"changed":{"mysql":{"config":{"dataset-size":{"bundle":null,"model":"50%"}}}}}}`+"\n")
+}
+
+func (s *DiffBundleSuite) TestInvalidBundle(c *gc.C) {
+	s.writeBundle(c, `
+services:
+  wordpress:
+    charm: cs:trusty/wordpress
+    num_units: 1
+relations:
+  - [wordpress, mysql]
+`)
+	_, err := s.runDiffBundle(c)
+	c.Assert(err, gc.ErrorMatches, `(?s)the provided bundle has the following errors:.*`)
+	c.Assert(s.fake.closed, jc.IsFalse)
+}
+
+func (s *DiffBundleSuite) TestStatusError(c *gc.C) {
+	s.fake.err = errors.New("boom")
+	s.writeBundle(c, `
+services:
+  wordpress:
+    charm: cs:trusty/wordpress
+`)
+	_, err := s.runDiffBundle(c)
+	c.Assert(err, gc.ErrorMatches, "cannot get model status: boom")
+}
+
+type fakeDiffBundleAPI struct {
+	status   *params.FullStatus
+	services map[string]*params.ServiceGetResults
+	err      error
+	closed   bool
+}
+
+func (f *fakeDiffBundleAPI) Close() error {
+	f.closed = true
+	return nil
+}
+
+func (f *fakeDiffBundleAPI) Status(patterns []string) (*params.FullStatus, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.status, nil
+}
+
+func (f *fakeDiffBundleAPI) Get(service string) (*params.ServiceGetResults, error) {
+	results, ok := f.services[service]
+	if !ok {
+		return nil, errors.NotFoundf("service %q", service)
+	}
+	return results, nil
+}
@@ -32,6 +32,38 @@ func NewAddUnitCommandForTest(api serviceAddUnitAPI) cmd.Command {
 	})
 }
 
+// NewShowCharmUpgradeCommandForTest returns a ShowCharmUpgradeCommand with
+// the api provided as specified.
+func NewShowCharmUpgradeCommandForTest(api charmUpgradeAPI) cmd.Command {
+	return modelcmd.Wrap(&showCharmUpgradeCommand{
+		api: api,
+	})
+}
+
+// NewResumeCharmUpgradeCommandForTest returns a ResumeCharmUpgradeCommand
+// with the api provided as specified.
+func NewResumeCharmUpgradeCommandForTest(api charmUpgradeAPI) cmd.Command {
+	return modelcmd.Wrap(&resumeCharmUpgradeCommand{
+		api: api,
+	})
+}
+
+// NewExportBundleCommandForTest returns an ExportBundleCommand with the
+// api provided as specified.
+func NewExportBundleCommandForTest(api exportBundleAPI) cmd.Command {
+	return modelcmd.Wrap(&exportBundleCommand{
+		api: api,
+	})
+}
+
+// NewDiffBundleCommandForTest returns a DiffBundleCommand with the api
+// provided as specified.
+func NewDiffBundleCommandForTest(api diffBundleAPI) cmd.Command {
+	return modelcmd.Wrap(&diffBundleCommand{
+		api: api,
+	})
+}
+
 type Patcher interface {
 	PatchValue(dest, value interface{})
 }
@@ -0,0 +1,98 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service
+
+import (
+	"io/ioutil"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageExportBundleSummary = `
+Exports the current model as a bundle.`[1:]
+
+var usageExportBundleDetails = `
+Writes a bundle that reproduces the services of the current model: their
+charms, config settings that differ from the charm defaults, constraints,
+storage directives, endpoint bindings, unit placement, relations, expose
+flags and annotations. The bundle is written to stdout unless --filename
+is given, and can be deployed elsewhere with "juju deploy".
+
+Examples:
+    juju export-bundle
+    juju export-bundle --filename mymodel.yaml
+
+See also:
+    deploy`[1:]
+
+// NewExportBundleCommand returns a command that exports the current
+// model as a bundle.
+func NewExportBundleCommand() cmd.Command {
+	return modelcmd.Wrap(&exportBundleCommand{})
+}
+
+// exportBundleCommand exports the current model as a bundle.
+type exportBundleCommand struct {
+	modelcmd.ModelCommandBase
+	Filename string
+	api      exportBundleAPI
+}
+
+func (c *exportBundleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "export-bundle",
+		Purpose: usageExportBundleSummary,
+		Doc:     usageExportBundleDetails,
+	}
+}
+
+func (c *exportBundleCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.Filename, "filename", "", "write the bundle to this file rather than stdout")
+}
+
+func (c *exportBundleCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// exportBundleAPI defines the methods on the client API that the
+// export-bundle command calls.
+type exportBundleAPI interface {
+	Close() error
+	ExportBundle() (string, error)
+}
+
+func (c *exportBundleCommand) getAPI() (exportBundleAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewAPIClient()
+}
+
+// Run exports the current model as a bundle.
+func (c *exportBundleCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	bundle, err := client.ExportBundle()
+	if err != nil {
+		return err
+	}
+	if c.Filename == "" {
+		_, err := ctx.Stdout.Write([]byte(bundle))
+		return err
+	}
+	path := ctx.AbsPath(c.Filename)
+	if err := ioutil.WriteFile(path, []byte(bundle), 0644); err != nil {
+		return errors.Annotate(err, "cannot write bundle")
+	}
+	ctx.Infof("Bundle written to %s", path)
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/service"
+	coretesting "github.com/juju/juju/testing"
+)
+
+const exportedBundle = `
+services:
+  mysql:
+    charm: cs:trusty/mysql-38
+    num_units: 1
+`
+
+type ExportBundleSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	fake *fakeExportBundleAPI
+}
+
+var _ = gc.Suite(&ExportBundleSuite{})
+
+func (s *ExportBundleSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeExportBundleAPI{bundle: exportedBundle}
+}
+
+func (s *ExportBundleSuite) TestInit(c *gc.C) {
+	err := coretesting.InitCommand(service.NewExportBundleCommandForTest(s.fake), []string{"foo"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *ExportBundleSuite) TestExportBundle(c *gc.C) {
+	ctx, err := coretesting.RunCommand(c, service.NewExportBundleCommandForTest(s.fake))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, exportedBundle)
+	c.Assert(s.fake.closed, jc.IsTrue)
+}
+
+func (s *ExportBundleSuite) TestExportBundleToFile(c *gc.C) {
+	dir := c.MkDir()
+	ctx, err := coretesting.RunCommandInDir(c, service.NewExportBundleCommandForTest(s.fake), []string{"--filename", "bundle.yaml"}, dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, "")
+	content, err := ioutil.ReadFile(filepath.Join(dir, "bundle.yaml"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(content), gc.Equals, exportedBundle)
+}
+
+func (s *ExportBundleSuite) TestExportBundleError(c *gc.C) {
+	s.fake.err = errors.New("boom")
+	_, err := coretesting.RunCommand(c, service.NewExportBundleCommandForTest(s.fake))
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeExportBundleAPI struct {
+	bundle string
+	err    error
+	closed bool
+}
+
+func (f *fakeExportBundleAPI) Close() error {
+	f.closed = true
+	return nil
+}
+
+func (f *fakeExportBundleAPI) ExportBundle() (string, error) {
+	return f.bundle, f.err
+}
@@ -6,10 +6,12 @@ package service
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/api/service"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/network"
 )
 
 var usageExposeSummary = `
@@ -19,8 +21,18 @@ var usageExposeDetails = `
 Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to allow public access to the service.
 
+Access can be restricted to a comma-separated list of networks with
+--source-cidrs. Combined with --port, the networks apply only to that
+port range of the service, overriding the service-wide list; use
+0.0.0.0/0 to allow access from anywhere. Exposing a service again
+without --source-cidrs keeps any networks set previously, and
+unexposing it clears them. Only some clouds support restricting
+access; on other clouds the restricted ports are not opened at all.
+
 Examples:
     juju expose wordpress
+    juju expose wordpress --source-cidrs 10.0.0.0/8,192.168.0.0/16
+    juju expose wordpress --port 443/tcp --source-cidrs 0.0.0.0/0
 
 See also: 
     unexpose`[1:]
@@ -34,6 +46,8 @@ func NewExposeCommand() cmd.Command {
 type exposeCommand struct {
 	modelcmd.ModelCommandBase
 	ServiceName string
+	SourceCIDRs []string
+	PortRange   string
 }
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -45,17 +59,31 @@ func (c *exposeCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.Var(cmd.NewStringsValue(nil, &c.SourceCIDRs), "source-cidrs", "Networks from which the service may be reached")
+	f.StringVar(&c.PortRange, "port", "", "Port range the source CIDRs apply to")
+}
+
 func (c *exposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no service name specified")
 	}
 	c.ServiceName = args[0]
+	if c.PortRange != "" {
+		if len(c.SourceCIDRs) == 0 {
+			return errors.New("--port requires --source-cidrs")
+		}
+		if _, err := network.ParsePortRange(c.PortRange); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	return cmd.CheckEmpty(args[1:])
 }
 
 type serviceExposeAPI interface {
 	Close() error
 	Expose(serviceName string) error
+	ExposeFromSources(serviceName, portRange string, sourceCIDRs []string) error
 	Unexpose(serviceName string) error
 }
 
@@ -75,5 +103,10 @@ func (c *exposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Expose(c.ServiceName), block.BlockChange)
+	if len(c.SourceCIDRs) > 0 {
+		err = client.ExposeFromSources(c.ServiceName, c.PortRange, c.SourceCIDRs)
+	} else {
+		err = client.Expose(c.ServiceName)
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/juju/cmd/juju/common"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/testcharms"
 	"github.com/juju/juju/testing"
@@ -60,6 +61,37 @@ func (s *ExposeSuite) TestExpose(c *gc.C) {
 	})
 }
 
+func (s *ExposeSuite) TestExposeSourceCIDRs(c *gc.C) {
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "dummy")
+	err := runDeploy(c, ch, "some-service-name", "--series", "trusty")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = runExpose(c, "some-service-name", "--source-cidrs", "192.168.0.0/16,10.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+	err = runExpose(c, "some-service-name", "--port", "443/tcp", "--source-cidrs", "0.0.0.0/0")
+	c.Assert(err, jc.ErrorIsNil)
+	// Exposing again without source CIDRs keeps them.
+	err = runExpose(c, "some-service-name")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertExposed(c, "some-service-name")
+
+	svc, err := s.State.Service("some-service-name")
+	c.Assert(err, jc.ErrorIsNil)
+	sources, err := svc.ExposeSourceCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sources, jc.DeepEquals, network.ExposeSourceCIDRs{
+		Default: []string{"10.0.0.0/8", "192.168.0.0/16"},
+		Ports:   map[network.PortRange][]string{network.MustParsePortRange("443/tcp"): {}},
+	})
+}
+
+func (s *ExposeSuite) TestExposeInitErrors(c *gc.C) {
+	err := runExpose(c, "some-service-name", "--port", "443/tcp")
+	c.Assert(err, gc.ErrorMatches, "--port requires --source-cidrs")
+	err = runExpose(c, "some-service-name", "--port", "foo", "--source-cidrs", "10.0.0.0/8")
+	c.Assert(err, gc.ErrorMatches, `invalid port "foo".*`)
+}
+
 func (s *ExposeSuite) TestBlockExpose(c *gc.C) {
 	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "dummy")
 	err := runDeploy(c, ch, "some-service-name", "--series", "trusty")
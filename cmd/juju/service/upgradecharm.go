@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -20,6 +21,7 @@ import (
 
 	"github.com/juju/juju/api"
 	apiservice "github.com/juju/juju/api/service"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -47,6 +49,13 @@ type upgradeCharmCommand struct {
 	// Channel holds the charmstore channel to use when obtaining
 	// the charm to be upgraded to.
 	Channel csclientparams.Channel
+
+	// Rolling, BatchSize, Pause and HaltOnError describe a rolling
+	// upgrade of the service's units.
+	Rolling     bool
+	BatchSize   int
+	Pause       time.Duration
+	HaltOnError bool
 }
 
 const upgradeCharmDoc = `
@@ -108,6 +117,16 @@ would specify revision number 5 of the wordpress charm.
 Use of the --force-units flag is not generally recommended; units upgraded while in an
 error state will not have upgrade-charm hooks executed, and may cause unexpected
 behavior.
+
+By default all of the service's units are upgraded at once. The --rolling flag
+upgrades them in batches instead: --batch-size units are released to upgrade at a
+time, and the next batch is released once they all run the new charm and the
+--pause duration has passed. Unless --halt-on-error=false is given, the upgrade
+halts as soon as an upgraded unit goes into an error state. Use
+"juju show-charm-upgrade" to follow the upgrade and "juju resume-charm-upgrade"
+to continue it once the failed units have been resolved.
+
+  juju upgrade-charm mysql --rolling --batch-size 2 --pause 5m
 `
 
 func (c *upgradeCharmCommand) Info() *cmd.Info {
@@ -127,6 +146,10 @@ func (c *upgradeCharmCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.CharmPath, "path", "", "upgrade to a charm located at path")
 	f.IntVar(&c.Revision, "revision", -1, "explicit revision of current charm")
 	f.Var(stringMap{&c.Resources}, "resource", "resource to be uploaded to the controller")
+	f.BoolVar(&c.Rolling, "rolling", false, "upgrade the service's units in batches")
+	f.IntVar(&c.BatchSize, "batch-size", 1, "number of units to upgrade at once during a rolling upgrade")
+	f.DurationVar(&c.Pause, "pause", 0, "time to wait between batches during a rolling upgrade")
+	f.BoolVar(&c.HaltOnError, "halt-on-error", true, "halt a rolling upgrade when an upgraded unit goes into an error state")
 }
 
 func (c *upgradeCharmCommand) Init(args []string) error {
@@ -150,6 +173,15 @@ func (c *upgradeCharmCommand) Init(args []string) error {
 	if c.SwitchURL != "" && c.CharmPath != "" {
 		return fmt.Errorf("--switch and --path are mutually exclusive")
 	}
+	if !c.Rolling && (c.BatchSize != 1 || c.Pause != 0) {
+		return fmt.Errorf("--batch-size and --pause require --rolling")
+	}
+	if c.BatchSize < 1 {
+		return fmt.Errorf("--batch-size must be at least 1")
+	}
+	if c.Pause < 0 {
+		return fmt.Errorf("--pause must not be negative")
+	}
 	return nil
 }
 
@@ -223,6 +255,13 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 		ForceUnits:  c.ForceUnits,
 		ResourceIDs: ids,
 	}
+	if c.Rolling {
+		cfg.RollingUpgrade = &params.CharmUpgradeStrategy{
+			BatchSize:   c.BatchSize,
+			Pause:       c.Pause,
+			HaltOnError: c.HaltOnError,
+		}
+	}
 
 	return block.ProcessBlockedError(serviceClient.SetCharm(cfg), block.BlockChange)
 }
@@ -9,6 +9,7 @@ import (
 	"net/http/httptest"
 	"path"
 	"path/filepath"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -125,6 +126,20 @@ func (s *UpgradeCharmErrorsSuite) TestSwitchAndPathFails(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "--switch and --path are mutually exclusive")
 }
 
+func (s *UpgradeCharmErrorsSuite) TestRollingFlagsWithoutRollingFails(c *gc.C) {
+	s.deployService(c)
+	err := runUpgradeCharm(c, "riak", "--batch-size=2")
+	c.Assert(err, gc.ErrorMatches, "--batch-size and --pause require --rolling")
+	err = runUpgradeCharm(c, "riak", "--pause=1m")
+	c.Assert(err, gc.ErrorMatches, "--batch-size and --pause require --rolling")
+}
+
+func (s *UpgradeCharmErrorsSuite) TestInvalidBatchSize(c *gc.C) {
+	s.deployService(c)
+	err := runUpgradeCharm(c, "riak", "--rolling", "--batch-size=0")
+	c.Assert(err, gc.ErrorMatches, "--batch-size must be at least 1")
+}
+
 func (s *UpgradeCharmErrorsSuite) TestInvalidRevision(c *gc.C) {
 	s.deployService(c)
 	err := runUpgradeCharm(c, "riak", "--revision=blah")
@@ -282,6 +297,20 @@ func (s *UpgradeCharmSuccessSuite) TestBlockForcedUnitsUpgrade(c *gc.C) {
 	s.assertLocalRevision(c, 7, s.path)
 }
 
+func (s *UpgradeCharmSuccessSuite) TestRollingUpgrade(c *gc.C) {
+	err := runUpgradeCharm(c, "riak", "--path", s.path, "--rolling", "--batch-size", "2", "--pause", "5m")
+	c.Assert(err, jc.ErrorIsNil)
+	curl := s.assertUpgraded(c, s.riak, 8, false)
+	upgrade, err := s.riak.CharmUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade.ToCharmURL, gc.DeepEquals, curl)
+	c.Assert(upgrade.Strategy, jc.DeepEquals, state.CharmUpgradeStrategy{
+		BatchSize:   2,
+		Pause:       5 * time.Minute,
+		HaltOnError: true,
+	})
+}
+
 func (s *UpgradeCharmSuccessSuite) TestCharmPath(c *gc.C) {
 	myriakPath := testcharms.Repo.ClonedDirPath(c.MkDir(), "riak")
 
@@ -36,6 +36,7 @@ type statusCommand struct {
 	out      cmd.Output
 	patterns []string
 	isoTime  bool
+	watch    bool
 	api      statusAPI
 }
 
@@ -67,10 +68,15 @@ Explanation of the different formats:
 - yaml: Displays information on machines, services, and units in yaml format.
 Note: AZ above is the cloud region's availability zone.
 
+With --watch, the tabular and oneline formats are displayed again whenever
+the model changes, until interrupted. When writing to a terminal, the lines
+that changed since the previous display are highlighted.
+
 Examples:
     juju status
     juju status mysql
     juju status nova-*
+    juju status --watch
 `
 
 func (c *statusCommand) Info() *cmd.Info {
@@ -85,6 +91,7 @@ func (c *statusCommand) Info() *cmd.Info {
 
 func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	f.BoolVar(&c.watch, "watch", false, "Display the status again whenever it changes")
 
 	defaultFormat := "tabular"
 
@@ -101,6 +108,11 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 
 func (c *statusCommand) Init(args []string) error {
 	c.patterns = args
+	if c.watch {
+		if _, ok := watchFormatters[c.out.Name()]; !ok {
+			return errors.Errorf("--watch is only supported with the tabular and oneline formats")
+		}
+	}
 	// If use of ISO time not specified on command line,
 	// check env var.
 	if !c.isoTime {
@@ -126,19 +138,34 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	}
 	defer apiclient.Close()
 
+	if c.watch {
+		return c.runWatch(ctx, apiclient)
+	}
+
+	status, err := c.getStatus(ctx, apiclient)
+	if err != nil {
+		return err
+	}
+
+	formatter := NewStatusFormatter(status, c.isoTime)
+	formatted := formatter.format()
+	return c.out.Write(ctx, formatted)
+}
+
+// getStatus returns the status of the entities matching the command's
+// patterns. Any error that still allows some status to be reported is
+// written to stderr.
+func (c *statusCommand) getStatus(ctx *cmd.Context, apiclient statusAPI) (*params.FullStatus, error) {
 	status, err := apiclient.Status(c.patterns)
 	if err != nil {
 		if status == nil {
 			// Status call completely failed, there is nothing to report
-			return err
+			return nil, err
 		}
 		// Display any error, but continue to print status if some was returned
 		fmt.Fprintf(ctx.Stderr, "%v\n", err)
 	} else if status == nil {
-		return errors.Errorf("unable to obtain the current status")
+		return nil, errors.Errorf("unable to obtain the current status")
 	}
-
-	formatter := NewStatusFormatter(status, c.isoTime)
-	formatted := formatter.format()
-	return c.out.Write(ctx, formatted)
+	return status, nil
 }
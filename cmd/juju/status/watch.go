@@ -0,0 +1,441 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"golang.org/x/crypto/ssh/terminal"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// watchFormatters holds the formats that may be used with --watch.
+var watchFormatters = map[string]cmd.Formatter{
+	"short":   FormatOneline,
+	"oneline": FormatOneline,
+	"line":    FormatOneline,
+	"tabular": FormatTabular,
+}
+
+const (
+	// clearScreen moves the cursor to the top left of the terminal
+	// and clears it.
+	clearScreen = "\x1b[H\x1b[2J"
+
+	// highlightOn and highlightOff surround highlighted lines.
+	highlightOn  = "\x1b[1m"
+	highlightOff = "\x1b[0m"
+)
+
+// allWatcher is the part of api.AllWatcher used by status --watch.
+type allWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+var newAllWatcherForStatus = func(apiclient statusAPI) (allWatcher, error) {
+	client, ok := apiclient.(*api.Client)
+	if !ok {
+		return nil, errors.NotSupportedf("watching status")
+	}
+	watcher, err := client.WatchAll()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return watcher, nil
+}
+
+// deltasResult holds the result of a call to allWatcher.Next.
+type deltasResult struct {
+	deltas []multiwatcher.Delta
+	err    error
+}
+
+// runWatch displays the status, then displays it again each time the
+// model's AllWatcher reports a change to it, until interrupted.
+func (c *statusCommand) runWatch(ctx *cmd.Context, apiclient statusAPI) error {
+	watcher, err := newAllWatcherForStatus(apiclient)
+	if err != nil {
+		return errors.Annotate(err, "cannot watch status")
+	}
+	defer watcher.Stop()
+
+	// Fetch the status only once the watcher has started, so no
+	// change can be missed.
+	status, err := c.getStatus(ctx, apiclient)
+	if err != nil {
+		return err
+	}
+	live := newLiveStatus(status)
+	renderer := &statusRenderer{
+		out:       ctx.Stdout,
+		formatter: watchFormatters[c.out.Name()],
+		terminal:  isTerminal(ctx.Stdout),
+	}
+	if err := renderer.render(NewStatusFormatter(live.status, c.isoTime).format()); err != nil {
+		return errors.Trace(err)
+	}
+
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+
+	done := make(chan struct{})
+	defer close(done)
+	results := make(chan deltasResult)
+	go func() {
+		for {
+			deltas, err := watcher.Next()
+			select {
+			case results <- deltasResult{deltas, err}:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-interrupted:
+			return nil
+		case result := <-results:
+			if result.err != nil {
+				return errors.Annotate(result.err, "watching status")
+			}
+			if !live.apply(result.deltas) {
+				status, err := c.getStatus(ctx, apiclient)
+				if err != nil {
+					return err
+				}
+				live.reset(status)
+			}
+			if err := renderer.render(NewStatusFormatter(live.status, c.isoTime).format()); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// liveStatus holds the status returned by a FullStatus call, and keeps
+// it up to date with the deltas reported by an AllWatcher. Changes that
+// the deltas do not fully describe, such as new entities or relations,
+// require the status to be fetched again.
+type liveStatus struct {
+	status *params.FullStatus
+
+	// unknown holds the keys of the entities, changes to which
+	// could not be applied since the status was last fetched.
+	unknown set.Strings
+
+	// filtered holds the keys of the entities that were missing
+	// from the status when it was fetched, even though they were
+	// known to exist. They were excluded by the command's patterns,
+	// so changes to them are ignored.
+	filtered set.Strings
+}
+
+func newLiveStatus(status *params.FullStatus) *liveStatus {
+	return &liveStatus{
+		status:   status,
+		unknown:  make(set.Strings),
+		filtered: make(set.Strings),
+	}
+}
+
+// apply updates the status with the deltas. It returns false if the
+// status must be fetched again to reflect them.
+func (s *liveStatus) apply(deltas []multiwatcher.Delta) bool {
+	complete := true
+	for _, delta := range deltas {
+		key := entityKey(delta.Entity.EntityId())
+		if s.filtered.Contains(key) {
+			continue
+		}
+		if !s.applyDelta(delta) {
+			s.unknown.Add(key)
+			complete = false
+		}
+	}
+	return complete
+}
+
+// reset replaces the status with a freshly fetched one. Entities whose
+// changes could not be applied, and which are still missing, are
+// ignored from now on.
+func (s *liveStatus) reset(status *params.FullStatus) {
+	s.status = status
+	for _, key := range s.unknown.Values() {
+		if !s.contains(key) {
+			s.filtered.Add(key)
+		}
+	}
+	s.unknown = make(set.Strings)
+}
+
+func entityKey(id multiwatcher.EntityId) string {
+	return id.Kind + ":" + id.Id
+}
+
+// contains reports whether the status includes the entity with the
+// given key.
+func (s *liveStatus) contains(key string) bool {
+	parts := strings.SplitN(key, ":", 2)
+	kind, id := parts[0], parts[1]
+	switch kind {
+	case "machine":
+		_, ok := s.machines(id)[id]
+		return ok
+	case "service":
+		_, ok := s.status.Services[id]
+		return ok
+	case "unit":
+		for _, service := range s.status.Services {
+			if findUnit(service.Units, id) != nil {
+				return true
+			}
+		}
+		return false
+	case "relation":
+		return s.hasRelation(id)
+	}
+	return true
+}
+
+// applyDelta applies a single delta to the status, and reports whether
+// it could do so.
+func (s *liveStatus) applyDelta(delta multiwatcher.Delta) bool {
+	switch info := delta.Entity.(type) {
+	case *multiwatcher.MachineInfo:
+		return s.applyMachine(info, delta.Removed)
+	case *multiwatcher.ServiceInfo:
+		return s.applyService(info, delta.Removed)
+	case *multiwatcher.UnitInfo:
+		return s.applyUnit(info, delta.Removed)
+	case *multiwatcher.RelationInfo:
+		// Relations are shown as part of each service's status, so
+		// fetch the status again when they are added or removed.
+		return s.hasRelation(info.Key) != delta.Removed
+	}
+	// Nothing else is shown by status.
+	return true
+}
+
+func (s *liveStatus) applyMachine(info *multiwatcher.MachineInfo, removed bool) bool {
+	machines := s.machines(info.Id)
+	machine, ok := machines[info.Id]
+	if !ok {
+		return removed
+	}
+	if removed {
+		delete(machines, info.Id)
+		return true
+	}
+	machine.AgentStatus = updateDetailedStatus(machine.AgentStatus, info.JujuStatus)
+	machine.InstanceStatus = updateDetailedStatus(machine.InstanceStatus, info.MachineStatus)
+	if info.InstanceId != "" {
+		machine.InstanceId = instance.Id(info.InstanceId)
+	}
+	if addr, ok := network.SelectPublicAddress(info.Addresses); ok {
+		machine.DNSName = addr.Value
+	}
+	machine.Series = info.Series
+	machine.HasVote = info.HasVote
+	machine.WantsVote = info.WantsVote
+	machines[info.Id] = machine
+	return true
+}
+
+func (s *liveStatus) applyService(info *multiwatcher.ServiceInfo, removed bool) bool {
+	service, ok := s.status.Services[info.Name]
+	if !ok {
+		return removed
+	}
+	if removed {
+		delete(s.status.Services, info.Name)
+		return true
+	}
+	service.Charm = info.CharmURL
+	service.Exposed = info.Exposed
+	service.Life = processLife(info.Life)
+	if !info.Subordinate {
+		service.Status = updateDetailedStatus(service.Status, info.Status)
+	}
+	s.status.Services[info.Name] = service
+	return true
+}
+
+func (s *liveStatus) applyUnit(info *multiwatcher.UnitInfo, removed bool) bool {
+	// Subordinate units are shown beneath their principals, as part
+	// of the principal's service.
+	var units map[string]params.UnitStatus
+	var serviceCharm string
+	for _, service := range s.status.Services {
+		if units = findUnit(service.Units, info.Name); units != nil {
+			serviceCharm = service.Charm
+			break
+		}
+	}
+	if units == nil {
+		return removed
+	}
+	if removed {
+		delete(units, info.Name)
+		return true
+	}
+	unit := units[info.Name]
+	unit.WorkloadStatus = updateDetailedStatus(unit.WorkloadStatus, info.WorkloadStatus)
+	unit.AgentStatus = updateDetailedStatus(unit.AgentStatus, info.JujuStatus)
+	unit.PublicAddress = info.PublicAddress
+	if !info.Subordinate {
+		unit.Machine = info.MachineId
+	}
+	unit.OpenedPorts = nil
+	for _, portRange := range info.PortRanges {
+		unit.OpenedPorts = append(unit.OpenedPorts, portRange.String())
+	}
+	unit.Charm = ""
+	if serviceCharm != "" && info.CharmURL != "" && info.CharmURL != serviceCharm {
+		unit.Charm = info.CharmURL
+	}
+	units[info.Name] = unit
+	return true
+}
+
+// machines returns the map that holds, or would hold, the status of
+// the machine with the given id. Containers are held by their host.
+func (s *liveStatus) machines(id string) map[string]params.MachineStatus {
+	parts := strings.Split(id, "/")
+	if len(parts) < 3 {
+		return s.status.Machines
+	}
+	hostId := strings.Join(parts[:len(parts)-2], "/")
+	return s.machines(hostId)[hostId].Containers
+}
+
+func (s *liveStatus) hasRelation(key string) bool {
+	for _, relation := range s.status.Relations {
+		if relation.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// findUnit returns the map, within units or their subordinates, that
+// holds the named unit, or nil if there is none.
+func findUnit(units map[string]params.UnitStatus, name string) map[string]params.UnitStatus {
+	for unitName, unit := range units {
+		if unitName == name {
+			return units
+		}
+		if found := findUnit(unit.Subordinates, name); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// updateDetailedStatus returns the status updated with the status info
+// reported by an AllWatcher.
+func updateDetailedStatus(status params.DetailedStatus, info multiwatcher.StatusInfo) params.DetailedStatus {
+	status.Status = string(info.Current)
+	status.Info = info.Message
+	status.Data = info.Data
+	status.Since = info.Since
+	status.Err = info.Err
+	if info.Version != "" {
+		status.Version = info.Version
+	}
+	return status
+}
+
+// processLife returns the life as reported by FullStatus, which omits
+// the usual "alive".
+func processLife(life multiwatcher.Life) string {
+	if life == multiwatcher.Life(params.Alive) {
+		return ""
+	}
+	return string(life)
+}
+
+// statusRenderer writes the formatted status each time it changes.
+// When writing to a terminal, the screen is cleared before each
+// rendering and the lines that changed are highlighted.
+type statusRenderer struct {
+	out       io.Writer
+	formatter cmd.Formatter
+	terminal  bool
+	previous  []byte
+}
+
+func (r *statusRenderer) render(status formattedStatus) error {
+	output, err := r.formatter(status)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(output) == 0 || output[len(output)-1] != '\n' {
+		output = append(output, '\n')
+	}
+	if r.previous != nil && bytes.Equal(output, r.previous) {
+		return nil
+	}
+	var buf bytes.Buffer
+	if r.terminal {
+		buf.WriteString(clearScreen)
+		buf.Write(highlightChanges(r.previous, output))
+	} else {
+		if r.previous != nil {
+			buf.WriteString("\n")
+		}
+		buf.Write(output)
+	}
+	r.previous = output
+	_, err = r.out.Write(buf.Bytes())
+	return errors.Trace(err)
+}
+
+// highlightChanges returns current with the lines that do not appear
+// in previous highlighted. Lines are compared ignoring differences in
+// spacing, so that a change in one column's width does not highlight
+// every line. Nothing is highlighted if previous is nil.
+func highlightChanges(previous, current []byte) []byte {
+	if previous == nil {
+		return current
+	}
+	seen := make(set.Strings)
+	for _, line := range strings.Split(string(previous), "\n") {
+		seen.Add(normaliseLine(line))
+	}
+	lines := strings.Split(string(current), "\n")
+	for i, line := range lines {
+		normalised := normaliseLine(line)
+		if normalised != "" && !seen.Contains(normalised) {
+			lines[i] = highlightOn + line + highlightOff
+		}
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
+
+func normaliseLine(line string) string {
+	return strings.Join(strings.Fields(line), " ")
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && terminal.IsTerminal(int(f.Fd()))
+}
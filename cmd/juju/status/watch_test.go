@@ -0,0 +1,295 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type watchSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&watchSuite{})
+
+func watchTestStatus() *params.FullStatus {
+	return &params.FullStatus{
+		ModelName: "test",
+		Machines: map[string]params.MachineStatus{
+			"0": {
+				Id:          "0",
+				AgentStatus: params.DetailedStatus{Status: "started"},
+				InstanceId:  "i-0",
+				Series:      "trusty",
+				Containers: map[string]params.MachineStatus{
+					"0/lxd/0": {
+						Id:          "0/lxd/0",
+						AgentStatus: params.DetailedStatus{Status: "pending"},
+					},
+				},
+			},
+		},
+		Services: map[string]params.ServiceStatus{
+			"mysql": {
+				Charm: "cs:trusty/mysql-1",
+				Units: map[string]params.UnitStatus{
+					"mysql/0": {
+						WorkloadStatus: params.DetailedStatus{Status: "maintenance"},
+						AgentStatus:    params.DetailedStatus{Status: "executing"},
+						Machine:        "0",
+						Subordinates: map[string]params.UnitStatus{
+							"logging/0": {
+								WorkloadStatus: params.DetailedStatus{Status: "unknown"},
+							},
+						},
+					},
+				},
+				Relations: map[string][]string{"juju-info": {"logging"}},
+			},
+			"logging": {
+				Charm:         "cs:trusty/logging-1",
+				SubordinateTo: []string{"mysql"},
+				Relations:     map[string][]string{"info": {"mysql"}},
+			},
+		},
+		Relations: []params.RelationStatus{{
+			Id:  0,
+			Key: "logging:info mysql:juju-info",
+		}},
+	}
+}
+
+func (s *watchSuite) TestInitWatchFormats(c *gc.C) {
+	for _, format := range []string{"tabular", "oneline", "short", "line"} {
+		err := coretesting.InitCommand(&statusCommand{}, []string{"--watch", "--format", format})
+		c.Check(err, jc.ErrorIsNil)
+	}
+	for _, format := range []string{"yaml", "json", "summary"} {
+		err := coretesting.InitCommand(&statusCommand{}, []string{"--watch", "--format", format})
+		c.Check(err, gc.ErrorMatches, "--watch is only supported with the tabular and oneline formats")
+	}
+}
+
+func (s *watchSuite) TestApplyUnitChange(c *gc.C) {
+	live := newLiveStatus(watchTestStatus())
+	ok := live.apply([]multiwatcher.Delta{{
+		Entity: &multiwatcher.UnitInfo{
+			Name:          "mysql/0",
+			Service:       "mysql",
+			CharmURL:      "cs:trusty/mysql-2",
+			MachineId:     "0",
+			PublicAddress: "10.0.0.1",
+			PortRanges:    []network.PortRange{{FromPort: 3306, ToPort: 3306, Protocol: "tcp"}},
+			WorkloadStatus: multiwatcher.StatusInfo{
+				Current: status.StatusActive,
+				Message: "ready",
+			},
+			JujuStatus: multiwatcher.StatusInfo{
+				Current: status.StatusIdle,
+				Version: "2.0.0",
+			},
+		},
+	}})
+	c.Assert(ok, jc.IsTrue)
+	unit := live.status.Services["mysql"].Units["mysql/0"]
+	c.Assert(unit.WorkloadStatus.Status, gc.Equals, "active")
+	c.Assert(unit.WorkloadStatus.Info, gc.Equals, "ready")
+	c.Assert(unit.AgentStatus.Status, gc.Equals, "idle")
+	c.Assert(unit.AgentStatus.Version, gc.Equals, "2.0.0")
+	c.Assert(unit.PublicAddress, gc.Equals, "10.0.0.1")
+	c.Assert(unit.OpenedPorts, jc.DeepEquals, []string{"3306/tcp"})
+	c.Assert(unit.Charm, gc.Equals, "cs:trusty/mysql-2")
+	c.Assert(unit.Subordinates, gc.HasLen, 1)
+}
+
+func (s *watchSuite) TestApplySubordinateUnitChange(c *gc.C) {
+	live := newLiveStatus(watchTestStatus())
+	ok := live.apply([]multiwatcher.Delta{{
+		Entity: &multiwatcher.UnitInfo{
+			Name:        "logging/0",
+			Service:     "logging",
+			Subordinate: true,
+			WorkloadStatus: multiwatcher.StatusInfo{
+				Current: status.StatusActive,
+			},
+		},
+	}})
+	c.Assert(ok, jc.IsTrue)
+	sub := live.status.Services["mysql"].Units["mysql/0"].Subordinates["logging/0"]
+	c.Assert(sub.WorkloadStatus.Status, gc.Equals, "active")
+}
+
+func (s *watchSuite) TestApplyMachineChanges(c *gc.C) {
+	live := newLiveStatus(watchTestStatus())
+	ok := live.apply([]multiwatcher.Delta{{
+		Entity: &multiwatcher.MachineInfo{
+			Id:         "0",
+			InstanceId: "i-0",
+			Series:     "trusty",
+			JujuStatus: multiwatcher.StatusInfo{Current: status.StatusDown},
+			Addresses:  network.NewAddresses("8.8.8.8"),
+		},
+	}, {
+		Removed: true,
+		Entity:  &multiwatcher.MachineInfo{Id: "0/lxd/0"},
+	}})
+	c.Assert(ok, jc.IsTrue)
+	machine := live.status.Machines["0"]
+	c.Assert(machine.AgentStatus.Status, gc.Equals, "down")
+	c.Assert(machine.DNSName, gc.Equals, "8.8.8.8")
+	c.Assert(machine.Containers, gc.HasLen, 0)
+}
+
+func (s *watchSuite) TestApplyServiceChange(c *gc.C) {
+	live := newLiveStatus(watchTestStatus())
+	ok := live.apply([]multiwatcher.Delta{{
+		Entity: &multiwatcher.ServiceInfo{
+			Name:     "mysql",
+			CharmURL: "cs:trusty/mysql-2",
+			Exposed:  true,
+			Life:     "dying",
+			Status:   multiwatcher.StatusInfo{Current: status.StatusBlocked},
+		},
+	}})
+	c.Assert(ok, jc.IsTrue)
+	service := live.status.Services["mysql"]
+	c.Assert(service.Charm, gc.Equals, "cs:trusty/mysql-2")
+	c.Assert(service.Exposed, jc.IsTrue)
+	c.Assert(service.Life, gc.Equals, "dying")
+	c.Assert(service.Status.Status, gc.Equals, "blocked")
+	c.Assert(service.Units, gc.HasLen, 1)
+}
+
+func (s *watchSuite) TestApplyIgnoresOtherEntities(c *gc.C) {
+	live := newLiveStatus(watchTestStatus())
+	ok := live.apply([]multiwatcher.Delta{{
+		Entity: &multiwatcher.AnnotationInfo{Tag: "unit-mysql-0"},
+	}, {
+		Entity: &multiwatcher.RelationInfo{Key: "logging:info mysql:juju-info"},
+	}, {
+		Removed: true,
+		Entity:  &multiwatcher.UnitInfo{Name: "wordpress/0", Service: "wordpress"},
+	}})
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(live.status, jc.DeepEquals, watchTestStatus())
+}
+
+func (s *watchSuite) TestApplyNeedsFetch(c *gc.C) {
+	for i, delta := range []multiwatcher.Delta{{
+		Entity: &multiwatcher.UnitInfo{Name: "mysql/1", Service: "mysql"},
+	}, {
+		Entity: &multiwatcher.MachineInfo{Id: "1"},
+	}, {
+		Entity: &multiwatcher.ServiceInfo{Name: "wordpress"},
+	}, {
+		Entity: &multiwatcher.RelationInfo{Key: "mysql:db wordpress:db"},
+	}, {
+		Removed: true,
+		Entity:  &multiwatcher.RelationInfo{Key: "logging:info mysql:juju-info"},
+	}} {
+		c.Logf("test %d: %#v", i, delta.Entity)
+		live := newLiveStatus(watchTestStatus())
+		ok := live.apply([]multiwatcher.Delta{delta})
+		c.Check(ok, jc.IsFalse)
+	}
+}
+
+func (s *watchSuite) TestResetIgnoresFilteredEntities(c *gc.C) {
+	live := newLiveStatus(watchTestStatus())
+	newUnit := []multiwatcher.Delta{{
+		Entity: &multiwatcher.UnitInfo{Name: "wordpress/0", Service: "wordpress"},
+	}}
+	c.Assert(live.apply(newUnit), jc.IsFalse)
+
+	// The unit is still missing when the status is fetched again,
+	// so it must have been filtered out.
+	live.reset(watchTestStatus())
+	c.Assert(live.apply(newUnit), jc.IsTrue)
+}
+
+func (s *watchSuite) TestHighlightChanges(c *gc.C) {
+	previous := []byte("NAME  STATUS\nmysql active\nwordpress waiting\n")
+	current := []byte("NAME      STATUS\nmysql     active\nwordpress active\nhaproxy   active\n")
+	c.Assert(string(highlightChanges(nil, current)), gc.Equals, string(current))
+	c.Assert(string(highlightChanges(previous, current)), gc.Equals, ""+
+		"NAME      STATUS\n"+
+		"mysql     active\n"+
+		highlightOn+"wordpress active"+highlightOff+"\n"+
+		highlightOn+"haproxy   active"+highlightOff+"\n",
+	)
+}
+
+func (s *watchSuite) TestRunWatch(c *gc.C) {
+	deltas := make(chan []multiwatcher.Delta, 2)
+	deltas <- []multiwatcher.Delta{{
+		Entity: &multiwatcher.UnitInfo{
+			Name:           "mysql/0",
+			Service:        "mysql",
+			MachineId:      "0",
+			CharmURL:       "cs:trusty/mysql-1",
+			WorkloadStatus: multiwatcher.StatusInfo{Current: status.StatusActive, Message: "ready"},
+			JujuStatus:     multiwatcher.StatusInfo{Current: status.StatusIdle},
+		},
+	}}
+	close(deltas)
+	watcher := &fakeAllWatcher{deltas: deltas}
+	s.PatchValue(&newAllWatcherForStatus, func(statusAPI) (allWatcher, error) {
+		return watcher, nil
+	})
+
+	command := &statusCommand{}
+	err := coretesting.InitCommand(command, []string{"--watch", "--format", "oneline"})
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := coretesting.Context(c)
+	err = command.runWatch(ctx, &fakeStatusAPI{status: watchTestStatus()})
+	c.Assert(err, gc.ErrorMatches, "watching status: watcher stopped")
+	c.Assert(watcher.stopped, jc.IsTrue)
+
+	stdout := coretesting.Stdout(ctx)
+	c.Assert(strings.Count(stdout, "- mysql/0:"), gc.Equals, 2)
+	before := strings.Index(stdout, "agent:executing, workload:maintenance")
+	after := strings.Index(stdout, "agent:idle, workload:active")
+	c.Assert(before, jc.GreaterThan, -1)
+	c.Assert(after, jc.GreaterThan, before)
+}
+
+type fakeStatusAPI struct {
+	status *params.FullStatus
+}
+
+func (f *fakeStatusAPI) Status(patterns []string) (*params.FullStatus, error) {
+	return f.status, nil
+}
+
+func (f *fakeStatusAPI) Close() error {
+	return nil
+}
+
+type fakeAllWatcher struct {
+	deltas  chan []multiwatcher.Delta
+	stopped bool
+}
+
+func (w *fakeAllWatcher) Next() ([]multiwatcher.Delta, error) {
+	deltas, ok := <-w.deltas
+	if !ok {
+		return nil, errors.New("watcher stopped")
+	}
+	return deltas, nil
+}
+
+func (w *fakeAllWatcher) Stop() error {
+	w.stopped = true
+	return nil
+}
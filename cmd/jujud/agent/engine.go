@@ -0,0 +1,19 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent
+
+import (
+	"github.com/juju/juju/introspection"
+	"github.com/juju/juju/worker/dependency"
+)
+
+// registerEngine makes the engine's report available on the agent's
+// introspection socket under the given name, until the engine stops.
+func registerEngine(name string, engine *dependency.Engine) {
+	unregister := introspection.Register(introspection.KindEngine, name, engine)
+	go func() {
+		engine.Wait()
+		unregister()
+	}()
+}
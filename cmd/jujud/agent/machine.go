@@ -65,13 +65,16 @@ import (
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/apicaller"
+	"github.com/juju/juju/worker/auditforwarder"
 	"github.com/juju/juju/worker/certupdater"
+	"github.com/juju/juju/worker/charmupgrader"
 	"github.com/juju/juju/worker/conv2state"
 	"github.com/juju/juju/worker/dblogpruner"
 	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/deployer"
 	"github.com/juju/juju/worker/gate"
 	"github.com/juju/juju/worker/imagemetadataworker"
+	"github.com/juju/juju/worker/logforwarder"
 	"github.com/juju/juju/worker/logsender"
 	"github.com/juju/juju/worker/modelworkermanager"
 	"github.com/juju/juju/worker/mongoupgrader"
@@ -83,9 +86,10 @@ import (
 )
 
 var (
-	logger       = loggo.GetLogger("juju.cmd.jujud")
-	jujuRun      = paths.MustSucceed(paths.JujuRun(series.HostSeries()))
-	jujuDumpLogs = paths.MustSucceed(paths.JujuDumpLogs(series.HostSeries()))
+	logger         = loggo.GetLogger("juju.cmd.jujud")
+	jujuRun        = paths.MustSucceed(paths.JujuRun(series.HostSeries()))
+	jujuDumpLogs   = paths.MustSucceed(paths.JujuDumpLogs(series.HostSeries()))
+	jujuIntrospect = paths.MustSucceed(paths.JujuIntrospect(series.HostSeries()))
 
 	// The following are defined as variables to allow the tests to
 	// intercept calls to the functions.
@@ -460,6 +464,7 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 			}
 			return nil, err
 		}
+		registerEngine(a.Tag().String(), engine)
 		return engine, nil
 	}
 }
@@ -960,6 +965,33 @@ func (a *MachineAgent) startStateWorkers(st *state.State) (worker.Worker, error)
 				return dblogpruner.New(st, dblogpruner.NewLogPruneParams()), nil
 			})
 
+			a.startWorkerAfterUpgrade(singularRunner, "auditforwarder", func() (worker.Worker, error) {
+				newLastSent := func(sink string) auditforwarder.LastSent {
+					return state.NewAuditForwardPosition(st, sink)
+				}
+				return auditforwarder.New(auditforwarder.DefaultConfig(st, newLastSent))
+			})
+
+			a.startWorkerAfterUpgrade(singularRunner, "logforwarder", func() (worker.Worker, error) {
+				return modelworkermanager.New(modelworkermanager.Config{
+					Backend: st,
+					NewWorker: func(uuid string) (worker.Worker, error) {
+						return newModelLogForwarder(st, uuid)
+					},
+					ErrorDelay: worker.RestartDelay,
+				})
+			})
+
+			a.startWorkerAfterUpgrade(singularRunner, "charmupgrader", func() (worker.Worker, error) {
+				return modelworkermanager.New(modelworkermanager.Config{
+					Backend: st,
+					NewWorker: func(uuid string) (worker.Worker, error) {
+						return newModelCharmUpgrader(st, uuid)
+					},
+					ErrorDelay: worker.RestartDelay,
+				})
+			})
+
 			a.startWorkerAfterUpgrade(singularRunner, "txnpruner", func() (worker.Worker, error) {
 				return txnpruner.New(st, time.Hour*2), nil
 			})
@@ -995,6 +1027,7 @@ func (a *MachineAgent) startModelWorkers(uuid string) (worker.Worker, error) {
 		Clock:                       clock.WallClock,
 		RunFlagDuration:             time.Minute,
 		CharmRevisionUpdateInterval: 24 * time.Hour,
+		ActionSchedulerRetryDelay:   10 * time.Second,
 		InstPollerAggregationDelay:  3 * time.Second,
 		// TODO(perrito666) the status history pruning numbers need
 		// to be adjusting, after collecting user data from large install
@@ -1010,9 +1043,62 @@ func (a *MachineAgent) startModelWorkers(uuid string) (worker.Worker, error) {
 		}
 		return nil, errors.Trace(err)
 	}
+	registerEngine(names.NewModelTag(uuid).String(), engine)
 	return engine, nil
 }
 
+// newModelLogForwarder returns a worker that forwards the logs of the
+// model with the given UUID to the syslog server set in the model's
+// configuration.
+func newModelLogForwarder(st *state.State, uuid string) (worker.Worker, error) {
+	return newModelStateWorker(st, uuid, func(modelSt *state.State) (worker.Worker, error) {
+		newLogTailer := func(params *state.LogTailerParams) (state.LogTailer, error) {
+			return state.NewLogTailer(modelSt, params)
+		}
+		newLastSent := func(sink string) logforwarder.LastSent {
+			return state.NewLastSentLogger(modelSt, sink)
+		}
+		return logforwarder.New(logforwarder.DefaultConfig(modelSt, newLogTailer, newLastSent))
+	})
+}
+
+// newModelCharmUpgrader returns a worker that drives the rolling charm
+// upgrades of the model with the given UUID.
+func newModelCharmUpgrader(st *state.State, uuid string) (worker.Worker, error) {
+	return newModelStateWorker(st, uuid, func(modelSt *state.State) (worker.Worker, error) {
+		return charmupgrader.New(charmupgrader.DefaultConfig(charmupgrader.NewStateBackend(modelSt)))
+	})
+}
+
+// newModelStateWorker opens the state of the model with the given UUID
+// and returns the worker created from it by newWorker. The model's state
+// is closed when the worker stops.
+func newModelStateWorker(
+	st *state.State, uuid string,
+	newWorker func(*state.State) (worker.Worker, error),
+) (worker.Worker, error) {
+	modelSt, err := st.ForModel(names.NewModelTag(uuid))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := newWorker(modelSt)
+	if err != nil {
+		modelSt.Close()
+		return nil, errors.Trace(err)
+	}
+	return worker.NewSimpleWorker(func(stop <-chan struct{}) error {
+		defer modelSt.Close()
+		done := make(chan error, 1)
+		go func() { done <- w.Wait() }()
+		select {
+		case err := <-done:
+			return errors.Trace(err)
+		case <-stop:
+			return worker.Stop(w)
+		}
+	}), nil
+}
+
 // stateWorkerDialOpts is a mongo.DialOpts suitable
 // for use by StateWorker to dial mongo.
 //
@@ -1299,7 +1385,7 @@ func (a *MachineAgent) Tag() names.Tag {
 
 func (a *MachineAgent) createJujudSymlinks(dataDir string) error {
 	jujud := filepath.Join(tools.ToolsDir(dataDir, a.Tag().String()), jujunames.Jujud)
-	for _, link := range []string{jujuRun, jujuDumpLogs} {
+	for _, link := range []string{jujuRun, jujuDumpLogs, jujuIntrospect} {
 		err := a.createSymlink(jujud, link)
 		if err != nil {
 			return errors.Annotatef(err, "failed to create %s symlink", link)
@@ -1333,7 +1419,7 @@ func (a *MachineAgent) createSymlink(target, link string) error {
 }
 
 func (a *MachineAgent) removeJujudSymlinks() (errs []error) {
-	for _, link := range []string{jujuRun, jujuDumpLogs} {
+	for _, link := range []string{jujuRun, jujuDumpLogs, jujuIntrospect} {
 		err := os.Remove(utils.EnsureBaseDir(a.rootDir, link))
 		if err != nil && !os.IsNotExist(err) {
 			errs = append(errs, errors.Annotatef(err, "failed to remove %s symlink", link))
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/actionscheduler"
 	"github.com/juju/juju/worker/addresser"
 	"github.com/juju/juju/worker/agent"
 	"github.com/juju/juju/worker/apicaller"
@@ -71,6 +72,11 @@ type ManifoldsConfig struct {
 	// to be held.
 	RunFlagDuration time.Duration
 
+	// ActionSchedulerRetryDelay determines how long the action-
+	// scheduler worker waits before checking again whether the
+	// scheduled actions it has run were due.
+	ActionSchedulerRetryDelay time.Duration
+
 	// CharmRevisionUpdateInterval determines how often the charm-
 	// revision worker will check for new revisions of known charms.
 	CharmRevisionUpdateInterval time.Duration
@@ -233,6 +239,13 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade: charmrevisionmanifold.NewAPIFacade,
 			NewWorker: charmrevision.NewWorker,
 		})),
+		actionSchedulerName: ifNotDead(actionscheduler.Manifold(actionscheduler.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+			RetryDelay:    config.ActionSchedulerRetryDelay,
+			NewFacade:     actionscheduler.NewFacade,
+			NewWorker:     actionscheduler.NewWorker,
+		})),
 		metricWorkerName: ifNotDead(metricworker.Manifold(metricworker.ManifoldConfig{
 			APICallerName: apiCallerName,
 		})),
@@ -315,6 +328,7 @@ const (
 	serviceScalerName        = "service-scaler"
 	instancePollerName       = "instance-poller"
 	charmRevisionUpdaterName = "charm-revision-updater"
+	actionSchedulerName      = "action-scheduler"
 	metricWorkerName         = "metric-worker"
 	stateCleanerName         = "state-cleaner"
 	addressCleanerName       = "address-cleaner"
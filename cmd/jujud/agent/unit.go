@@ -190,6 +190,7 @@ func (a *UnitAgent) APIWorkers() (worker.Worker, error) {
 		}
 		return nil, err
 	}
+	registerEngine(a.Tag().String(), engine)
 	return engine, nil
 }
 
@@ -34,6 +34,7 @@ var (
 		"not-dead-flag",
 	}
 	aliveModelWorkers = []string{
+		"action-scheduler",
 		"charm-revision-updater",
 		"compute-provisioner",
 		"environ-tracker",
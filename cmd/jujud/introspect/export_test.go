@@ -0,0 +1,12 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspect
+
+import "github.com/juju/cmd"
+
+// NewCommandForTest returns a juju-introspect command that looks for
+// sockets in the given directory.
+func NewCommandForTest(socketDir string) cmd.Command {
+	return &introspectCommand{socketDir: socketDir}
+}
@@ -0,0 +1,224 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package introspect provides the juju-introspect command, which
+// fetches reports from the introspection sockets of the jujud
+// processes running on the local host.
+package introspect
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/yaml.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/cmd/pprof"
+	"github.com/juju/juju/introspection"
+	corenames "github.com/juju/juju/juju/names"
+)
+
+var logger = loggo.GetLogger("juju.cmd.jujud.introspect")
+
+// NewCommand returns a new Command instance which implements the
+// "juju-introspect" command.
+func NewCommand() cmd.Command {
+	return &introspectCommand{socketDir: os.TempDir()}
+}
+
+type introspectCommand struct {
+	cmd.CommandBase
+	socketDir string
+
+	socket string
+	agent  string
+	format string
+	report string
+}
+
+// reports holds the reports that may be requested, in the order they
+// are documented.
+var reports = []string{
+	introspection.KindEngine,
+	introspection.GoroutinesPath,
+	introspection.KindLeases,
+	introspection.KindPresence,
+}
+
+// Info implements cmd.Command.
+func (c *introspectCommand) Info() *cmd.Info {
+	doc := `
+This tool fetches reports describing the internal state of the jujud
+processes running on this host, from the introspection sockets they
+serve. It must be run on the host, as the user running the agents.
+
+The report may be one of:
+
+    depengine   the state of each manifold in the agent's dependency
+                engines, including the last error each worker stopped
+                with, and the resources it used
+    goroutines  the stacks of all goroutines in the process
+    leases      the leadership and singular leases known to a
+                controller, by model
+    presence    the agents a controller considers alive, by model
+
+If no report is given, the names of the engines, and of the models
+whose leases and presence are known, are shown.
+
+If only one jujud process is running, its socket is used. Otherwise,
+--agent chooses the process running the named agent (for example,
+"machine-0" or "unit-mysql-0"), or --socket names a socket directly.
+The depengine report is restricted to the named agent's engine.
+`[1:]
+	return &cmd.Info{
+		Name:    corenames.JujuIntrospect,
+		Args:    "[<report>]",
+		Purpose: "show internal state of the jujud processes on this host",
+		Doc:     doc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *introspectCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.socket, "socket", "", "path of the introspection socket to query")
+	f.StringVar(&c.agent, "agent", "", "name of the agent whose process to query")
+	f.StringVar(&c.format, "format", "yaml", "output format (yaml|json)")
+}
+
+// Init implements cmd.Command.
+func (c *introspectCommand) Init(args []string) error {
+	switch c.format {
+	case "yaml", "json":
+	default:
+		return errors.Errorf("format %q not supported", c.format)
+	}
+	if c.socket != "" && c.agent != "" {
+		return errors.New("only one of --socket and --agent may be specified")
+	}
+	if len(args) > 0 {
+		c.report, args = args[0], args[1:]
+		known := false
+		for _, report := range reports {
+			known = known || report == c.report
+		}
+		if !known {
+			return errors.Errorf("unknown report %q; expected one of %s", c.report, strings.Join(reports, ", "))
+		}
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements cmd.Command.
+func (c *introspectCommand) Run(ctx *cmd.Context) error {
+	socket, err := c.findSocket()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	query := url.Values{"format": {c.format}}
+	if c.agent != "" && c.report == introspection.KindEngine {
+		query.Set("name", c.agent)
+	}
+	body, err := get(socket, c.report, query)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer body.Close()
+	_, err = io.Copy(ctx.Stdout, body)
+	return errors.Trace(err)
+}
+
+// findSocket returns the path of the socket to query.
+func (c *introspectCommand) findSocket() (string, error) {
+	if c.socket != "" {
+		return c.socket, nil
+	}
+	pattern := filepath.Join(c.socketDir, fmt.Sprintf("pprof.%s.*", corenames.Jujud))
+	sockets, err := filepath.Glob(pattern)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	sort.Strings(sockets)
+	if c.agent == "" {
+		switch len(sockets) {
+		case 0:
+			return "", errors.Errorf("no introspection sockets found matching %q", pattern)
+		case 1:
+			return sockets[0], nil
+		}
+		return "", errors.Errorf(
+			"%d introspection sockets found; specify --agent or --socket:\n  %s",
+			len(sockets), strings.Join(sockets, "\n  "),
+		)
+	}
+	for _, socket := range sockets {
+		names, err := engineNames(socket)
+		if err != nil {
+			logger.Debugf("cannot query %q: %v", socket, err)
+			continue
+		}
+		for _, name := range names {
+			if name == c.agent {
+				return socket, nil
+			}
+		}
+	}
+	return "", errors.NotFoundf("introspection socket for agent %q", c.agent)
+}
+
+// engineNames returns the names of the engines registered with the
+// process serving the socket.
+func engineNames(socket string) ([]string, error) {
+	body, err := get(socket, "", url.Values{"format": {"yaml"}})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer body.Close()
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var index map[string][]string
+	if err := yaml.Unmarshal(data, &index); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return index[introspection.KindEngine], nil
+}
+
+// get requests a report from the introspection socket, and returns
+// the response body.
+func get(socket, report string, query url.Values) (io.ReadCloser, error) {
+	client := &http.Client{
+		Transport: &http.Transport{
+			Dial: func(string, string) (net.Conn, error) {
+				return net.Dial("unix", socket)
+			},
+		},
+	}
+	u := url.URL{
+		Scheme:   "http",
+		Host:     "unix.socket",
+		Path:     pprof.IntrospectionPath + report,
+		RawQuery: query.Encode(),
+	}
+	resp, err := client.Get(u.String())
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot query %q", socket)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		message, _ := ioutil.ReadAll(resp.Body)
+		return nil, errors.Errorf("cannot query %q: %s", socket, strings.TrimSpace(string(message)))
+	}
+	return resp.Body, nil
+}
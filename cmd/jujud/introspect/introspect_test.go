@@ -0,0 +1,145 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspect_test
+
+import (
+	"net"
+	"net/http"
+	"path/filepath"
+	"runtime"
+
+	"github.com/juju/cmd"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/jujud/introspect"
+	"github.com/juju/juju/cmd/pprof"
+	"github.com/juju/juju/introspection"
+	"github.com/juju/juju/testing"
+)
+
+type IntrospectSuite struct {
+	jujutesting.IsolationSuite
+	dir string
+}
+
+var _ = gc.Suite(&IntrospectSuite{})
+
+func (s *IntrospectSuite) SetUpTest(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("introspection sockets not supported on windows")
+	}
+	s.IsolationSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+}
+
+// serve serves the reports of engines with the given names on a socket
+// named for the given pid, and returns the socket's path.
+func (s *IntrospectSuite) serve(c *gc.C, pid string, engines ...string) string {
+	registry := introspection.NewRegistry()
+	for _, name := range engines {
+		name := name
+		registry.Register(introspection.KindEngine, name, introspection.ReporterFunc(
+			func() map[string]interface{} {
+				return map[string]interface{}{"agent": name}
+			},
+		))
+	}
+	mux := http.NewServeMux()
+	mux.Handle(pprof.IntrospectionPath, introspection.NewHandler(registry))
+
+	path := filepath.Join(s.dir, "pprof.jujud."+pid)
+	listener, err := net.Listen("unix", path)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { listener.Close() })
+	go http.Serve(listener, mux)
+	return path
+}
+
+func (s *IntrospectSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, introspect.NewCommandForTest(s.dir), args...)
+}
+
+func (s *IntrospectSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"bananas"},
+		err:  `unknown report "bananas"; expected one of depengine, goroutines, leases, presence`,
+	}, {
+		args: []string{"depengine", "leases"},
+		err:  `unrecognized args: \["leases"\]`,
+	}, {
+		args: []string{"--format", "xml"},
+		err:  `format "xml" not supported`,
+	}, {
+		args: []string{"--socket", "/tmp/foo", "--agent", "machine-0"},
+		err:  "only one of --socket and --agent may be specified",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *IntrospectSuite) TestIndex(c *gc.C) {
+	s.serve(c, "123", "machine-0")
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, "depengine:\n- machine-0\n")
+}
+
+func (s *IntrospectSuite) TestSingleSocket(c *gc.C) {
+	s.serve(c, "123", "machine-0")
+	ctx, err := s.run(c, "depengine")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, "machine-0:\n  agent: machine-0\n")
+}
+
+func (s *IntrospectSuite) TestNoSockets(c *gc.C) {
+	_, err := s.run(c, "depengine")
+	c.Check(err, gc.ErrorMatches, `no introspection sockets found matching ".*/pprof.jujud.\*"`)
+}
+
+func (s *IntrospectSuite) TestMultipleSockets(c *gc.C) {
+	s.serve(c, "123", "machine-0")
+	s.serve(c, "456", "unit-mysql-0")
+	_, err := s.run(c, "depengine")
+	c.Check(err, gc.ErrorMatches, `(?s)2 introspection sockets found; specify --agent or --socket:.*`)
+}
+
+func (s *IntrospectSuite) TestAgent(c *gc.C) {
+	s.serve(c, "123", "machine-0", "model-deadbeef")
+	s.serve(c, "456", "unit-mysql-0")
+	ctx, err := s.run(c, "--agent", "unit-mysql-0", "--format", "json", "depengine")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, `{
+  "unit-mysql-0": {
+    "agent": "unit-mysql-0"
+  }
+}
+`)
+}
+
+func (s *IntrospectSuite) TestAgentNotFound(c *gc.C) {
+	s.serve(c, "123", "machine-0")
+	_, err := s.run(c, "--agent", "machine-1", "depengine")
+	c.Check(err, gc.ErrorMatches, `introspection socket for agent "machine-1" not found`)
+}
+
+func (s *IntrospectSuite) TestSocket(c *gc.C) {
+	s.serve(c, "123", "machine-0")
+	path := s.serve(c, "456", "unit-mysql-0")
+	ctx, err := s.run(c, "--socket", path, "presence")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, "{}\n")
+}
+
+func (s *IntrospectSuite) TestSocketError(c *gc.C) {
+	path := filepath.Join(s.dir, "nonexistent")
+	_, err := s.run(c, "--socket", path, "depengine")
+	c.Check(err, gc.ErrorMatches, `cannot query ".*/nonexistent": .*`)
+}
@@ -17,10 +17,12 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/exec"
+	"gopkg.in/mgo.v2"
 
 	jujucmd "github.com/juju/juju/cmd"
 	agentcmd "github.com/juju/juju/cmd/jujud/agent"
 	"github.com/juju/juju/cmd/jujud/dumplogs"
+	"github.com/juju/juju/cmd/jujud/introspect"
 	"github.com/juju/juju/cmd/pprof"
 	components "github.com/juju/juju/component/all"
 	"github.com/juju/juju/juju/names"
@@ -136,6 +138,10 @@ func jujuDMain(args []string, ctx *cmd.Context) (code int, err error) {
 		return 1, errors.Trace(err)
 	}
 
+	// The API server reports mongo socket usage in its metrics;
+	// mgo only keeps those statistics once asked to.
+	mgo.SetStats(true)
+
 	jujud := jujucmd.NewSuperCommand(cmd.SuperCommandParams{
 		Name: "jujud",
 		Doc:  jujudDoc,
@@ -205,6 +211,8 @@ func Main(args []string) int {
 		code = cmd.Main(&RunCommand{}, ctx, args[1:])
 	case names.JujuDumpLogs:
 		code = cmd.Main(dumplogs.NewCommand(), ctx, args[1:])
+	case names.JujuIntrospect:
+		code = cmd.Main(introspect.NewCommand(), ctx, args[1:])
 	default:
 		code, err = jujuCMain(commandName, ctx, args)
 	}
@@ -12,6 +12,8 @@ import (
 	"runtime"
 
 	"github.com/juju/loggo"
+
+	"github.com/juju/juju/introspection"
 )
 
 var logger = loggo.GetLogger("juju.cmd.pprof")
@@ -25,8 +27,13 @@ var Filename = fmt.Sprintf(
 	os.Getpid(),
 )
 
+// IntrospectionPath is the path under which the reports registered with
+// introspection.DefaultRegistry are served.
+const IntrospectionPath = "/introspection/"
+
 // Start starts a pprof server listening on a unix socket which will be
-// created at the specified path.
+// created at the specified path. The server also serves the reports
+// registered with introspection.DefaultRegistry under IntrospectionPath.
 func Start(path string) func() error {
 	if runtime.GOOS != "linux" {
 		logger.Infof("pprof debugging not supported on %q", runtime.GOOS)
@@ -38,6 +45,7 @@ func Start(path string) func() error {
 	mux.Handle("/debug/pprof/cmdline", http.HandlerFunc(Cmdline))
 	mux.Handle("/debug/pprof/profile", http.HandlerFunc(Profile))
 	mux.Handle("/debug/pprof/symbol", http.HandlerFunc(Symbol))
+	mux.Handle(IntrospectionPath, introspection.NewHandler(introspection.DefaultRegistry))
 
 	srv := http.Server{
 		Handler: mux,
@@ -116,6 +116,18 @@ func (s *pprofSuite) TestGoroutineProfile(c *gc.C) {
 	matches(c, buf, `^goroutine profile: total \d+`)
 }
 
+func (s *pprofSuite) TestIntrospectionIndex(c *gc.C) {
+	buf := s.call(c, "/introspection/?format=json")
+	c.Assert(buf, gc.NotNil)
+	matches(c, buf, `^Content-Type: application/json`)
+}
+
+func (s *pprofSuite) TestIntrospectionGoroutines(c *gc.C) {
+	buf := s.call(c, "/introspection/goroutines")
+	c.Assert(buf, gc.NotNil)
+	matches(c, buf, `^count: \d+`)
+}
+
 // matches fails if regex is not found in the contents of b.
 // b is expected to be the response from the pprof http server, and will
 // contain some HTTP preamble that should be ignored.
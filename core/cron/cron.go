@@ -0,0 +1,226 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package cron parses cron-style schedules, and calculates the times
+// at which they fall due.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// Schedule is a parsed cron-style schedule. All times are considered
+// in UTC.
+type Schedule struct {
+	spec   string
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+
+	// domStar and dowStar record whether the day-of-month and
+	// day-of-week fields were unrestricted; if neither was, a day
+	// matching either field is due.
+	domStar bool
+	dowStar bool
+}
+
+// field describes the range and names of a cron field.
+type field struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dowField = field{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// macros holds the schedules that may be given by name.
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse parses a schedule of the standard five fields: minute, hour,
+// day of month, month, and day of week. Each field may be "*", a value,
+// a range such as "1-5", or a comma-separated list of these, and values
+// and ranges may be followed by a step such as "/15". Months and days of
+// the week may also be given by their three-letter English names. One of
+// the macros @yearly, @annually, @monthly, @weekly, @daily, @midnight or
+// @hourly may be given instead of the fields.
+func Parse(spec string) (*Schedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) == 1 {
+		if expanded, ok := macros[strings.ToLower(fields[0])]; ok {
+			fields = strings.Fields(expanded)
+		}
+	}
+	if len(fields) != 5 {
+		return nil, errors.NewNotValid(nil, fmt.Sprintf(
+			"schedule %q: expected 5 fields, got %d", spec, len(fields),
+		))
+	}
+	s := &Schedule{
+		spec:    strings.Join(strings.Fields(spec), " "),
+		domStar: strings.HasPrefix(fields[2], "*"),
+		dowStar: strings.HasPrefix(fields[4], "*"),
+	}
+	for i, target := range []struct {
+		field field
+		bits  *uint64
+	}{
+		{minuteField, &s.minute},
+		{hourField, &s.hour},
+		{domField, &s.dom},
+		{monthField, &s.month},
+		{dowField, &s.dow},
+	} {
+		bits, err := target.field.parse(fields[i])
+		if err != nil {
+			return nil, errors.Annotatef(err, "schedule %q", spec)
+		}
+		*target.bits = bits
+	}
+	// Sunday may be given as either 0 or 7.
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	return s, nil
+}
+
+// String returns the schedule as it was given to Parse.
+func (s *Schedule) String() string {
+	return s.spec
+}
+
+// Next returns the first time after t at which the schedule is due,
+// to the minute. It returns the zero time if the schedule will not
+// fall due within the next five years, as for "0 0 30 feb *".
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case !has(s.month, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case !has(s.hour, t.Hour()):
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case !has(s.minute, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// dayMatches reports whether the schedule may fall due on the day of t.
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := has(s.dom, t.Day())
+	dowMatch := has(s.dow, int(t.Weekday()))
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+func has(bits uint64, value int) bool {
+	return bits&(1<<uint(value)) != 0
+}
+
+// parse returns the set of values matched by the field's spec, as
+// a bitmask.
+func (f field) parse(spec string) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(spec, ",") {
+		itemBits, err := f.parseItem(item)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		bits |= itemBits
+	}
+	return bits, nil
+}
+
+// parseItem parses a single element of a comma-separated field.
+func (f field) parseItem(item string) (uint64, error) {
+	rangeSpec, step := item, 1
+	if i := strings.Index(item, "/"); i >= 0 {
+		var err error
+		rangeSpec = item[:i]
+		step, err = strconv.Atoi(item[i+1:])
+		if err != nil || step <= 0 {
+			return 0, errors.NotValidf("%s step %q", f.name, item[i+1:])
+		}
+	}
+
+	var first, last int
+	switch {
+	case rangeSpec == "*":
+		first, last = f.min, f.max
+	case strings.Contains(rangeSpec, "-"):
+		bounds := strings.SplitN(rangeSpec, "-", 2)
+		var err error
+		if first, err = f.value(bounds[0]); err != nil {
+			return 0, errors.Trace(err)
+		}
+		if last, err = f.value(bounds[1]); err != nil {
+			return 0, errors.Trace(err)
+		}
+		if last < first {
+			return 0, errors.NotValidf("%s range %q", f.name, rangeSpec)
+		}
+	default:
+		var err error
+		if first, err = f.value(rangeSpec); err != nil {
+			return 0, errors.Trace(err)
+		}
+		last = first
+		if step > 1 {
+			// A single value with a step, such as "5/15", runs
+			// to the end of the field's range.
+			last = f.max
+		}
+	}
+
+	var bits uint64
+	for value := first; value <= last; value += step {
+		bits |= 1 << uint(value)
+	}
+	return bits, nil
+}
+
+// value parses a single value or name of the field.
+func (f field) value(s string) (int, error) {
+	if value, ok := f.names[strings.ToLower(s)]; ok {
+		return value, nil
+	}
+	value, err := strconv.Atoi(s)
+	if err != nil || value < f.min || value > f.max {
+		return 0, errors.NotValidf("%s %q", f.name, s)
+	}
+	return value, nil
+}
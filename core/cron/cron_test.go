@@ -0,0 +1,153 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cron_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/cron"
+)
+
+type CronSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&CronSuite{})
+
+// base is a Wednesday.
+var base = time.Date(2016, time.June, 15, 10, 30, 45, 0, time.UTC)
+
+func (*CronSuite) TestNext(c *gc.C) {
+	for i, test := range []struct {
+		spec   string
+		expect []string
+	}{{
+		spec:   "* * * * *",
+		expect: []string{"2016-06-15 10:31", "2016-06-15 10:32"},
+	}, {
+		spec:   "0 3 * * *",
+		expect: []string{"2016-06-16 03:00", "2016-06-17 03:00"},
+	}, {
+		spec:   "*/15 * * * *",
+		expect: []string{"2016-06-15 10:45", "2016-06-15 11:00"},
+	}, {
+		spec:   "5/20 * * * *",
+		expect: []string{"2016-06-15 10:45", "2016-06-15 11:05"},
+	}, {
+		spec:   "0,30 8 * * *",
+		expect: []string{"2016-06-16 08:00", "2016-06-16 08:30"},
+	}, {
+		spec:   "0 0 1 * *",
+		expect: []string{"2016-07-01 00:00", "2016-08-01 00:00"},
+	}, {
+		spec:   "0 0 1 1 *",
+		expect: []string{"2017-01-01 00:00", "2018-01-01 00:00"},
+	}, {
+		spec:   "30 4 * * sun",
+		expect: []string{"2016-06-19 04:30", "2016-06-26 04:30"},
+	}, {
+		spec:   "0 0 * * 7",
+		expect: []string{"2016-06-19 00:00", "2016-06-26 00:00"},
+	}, {
+		spec:   "0 9-17/4 * * mon-fri",
+		expect: []string{"2016-06-15 13:00", "2016-06-15 17:00", "2016-06-16 09:00"},
+	}, {
+		// Restricting both days matches either.
+		spec:   "0 0 13 * fri",
+		expect: []string{"2016-06-17 00:00", "2016-06-24 00:00", "2016-07-01 00:00"},
+	}, {
+		spec:   "0 0 */2 * *",
+		expect: []string{"2016-06-17 00:00", "2016-06-19 00:00"},
+	}, {
+		spec:   "0 0 29 FEB *",
+		expect: []string{"2020-02-29 00:00", "2024-02-29 00:00"},
+	}, {
+		spec:   "@hourly",
+		expect: []string{"2016-06-15 11:00", "2016-06-15 12:00"},
+	}, {
+		spec:   "@daily",
+		expect: []string{"2016-06-16 00:00", "2016-06-17 00:00"},
+	}, {
+		spec:   "@weekly",
+		expect: []string{"2016-06-19 00:00", "2016-06-26 00:00"},
+	}, {
+		spec:   "@monthly",
+		expect: []string{"2016-07-01 00:00", "2016-08-01 00:00"},
+	}} {
+		c.Logf("test %d: %s", i, test.spec)
+		schedule, err := cron.Parse(test.spec)
+		c.Assert(err, jc.ErrorIsNil)
+		t := base
+		for _, expect := range test.expect {
+			t = schedule.Next(t)
+			c.Check(t.Format("2006-01-02 15:04"), gc.Equals, expect)
+		}
+	}
+}
+
+func (*CronSuite) TestNextNever(c *gc.C) {
+	schedule, err := cron.Parse("0 0 30 feb *")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.Next(base).IsZero(), jc.IsTrue)
+}
+
+func (*CronSuite) TestNextUsesUTC(c *gc.C) {
+	schedule, err := cron.Parse("0 3 * * *")
+	c.Assert(err, jc.ErrorIsNil)
+	local := base.In(time.FixedZone("UTC+5", 5*60*60))
+	c.Check(schedule.Next(local), gc.Equals, time.Date(2016, time.June, 16, 3, 0, 0, 0, time.UTC))
+}
+
+func (*CronSuite) TestString(c *gc.C) {
+	schedule, err := cron.Parse("  0   3 * *  mon ")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.String(), gc.Equals, "0 3 * * mon")
+}
+
+func (*CronSuite) TestParseInvalid(c *gc.C) {
+	for i, test := range []struct {
+		spec string
+		err  string
+	}{{
+		spec: "",
+		err:  `schedule "": expected 5 fields, got 0`,
+	}, {
+		spec: "* * * *",
+		err:  `schedule "\* \* \* \*": expected 5 fields, got 4`,
+	}, {
+		spec: "@fortnightly",
+		err:  `schedule "@fortnightly": expected 5 fields, got 1`,
+	}, {
+		spec: "60 * * * *",
+		err:  `schedule "60 \* \* \* \*": minute "60" not valid`,
+	}, {
+		spec: "* 24 * * *",
+		err:  `schedule "\* 24 \* \* \*": hour "24" not valid`,
+	}, {
+		spec: "* * 0 * *",
+		err:  `schedule "\* \* 0 \* \*": day of month "0" not valid`,
+	}, {
+		spec: "* * * foo *",
+		err:  `schedule "\* \* \* foo \*": month "foo" not valid`,
+	}, {
+		spec: "* * * * 8",
+		err:  `schedule "\* \* \* \* 8": day of week "8" not valid`,
+	}, {
+		spec: "*/0 * * * *",
+		err:  `schedule "\*/0 \* \* \* \*": minute step "0" not valid`,
+	}, {
+		spec: "10-5 * * * *",
+		err:  `schedule "10-5 \* \* \* \*": minute range "10-5" not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.spec)
+		_, err := cron.Parse(test.spec)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
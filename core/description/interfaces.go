@@ -60,6 +60,27 @@ type Model interface {
 	Relations() []Relation
 	AddRelation(RelationArgs) Relation
 
+	Storages() []Storage
+	AddStorage(StorageArgs) Storage
+
+	StoragePools() []StoragePool
+	AddStoragePool(StoragePoolArgs) StoragePool
+
+	Volumes() []Volume
+	AddVolume(VolumeArgs) Volume
+
+	Filesystems() []Filesystem
+	AddFilesystem(FilesystemArgs) Filesystem
+
+	Spaces() []Space
+	AddSpace(SpaceArgs) Space
+
+	Subnets() []Subnet
+	AddSubnet(SubnetArgs) Subnet
+
+	LinkLayerDevices() []LinkLayerDevice
+	AddLinkLayerDevice(LinkLayerDeviceArgs) LinkLayerDevice
+
 	Sequences() map[string]int
 	SetSequence(name string, value int)
 
@@ -75,6 +96,7 @@ type User interface {
 	DateCreated() time.Time
 	LastConnection() time.Time
 	ReadOnly() bool
+	Access() string
 }
 
 // Address represents an IP Address of some form.
@@ -218,6 +240,13 @@ type Service interface {
 	Exposed() bool
 	MinUnits() int
 
+	// ExposedSourceCIDRs returns the networks from which the ports of
+	// the exposed service may be reached; empty means anywhere.
+	ExposedSourceCIDRs() []string
+	// ExposedPortSourceCIDRs returns the source CIDRs of individual
+	// port ranges, keyed on the port range string.
+	ExposedPortSourceCIDRs() map[string][]string
+
 	Settings() map[string]interface{}
 	SettingsRefCount() int
 
@@ -229,9 +258,20 @@ type Service interface {
 	Status() Status
 	SetStatus(StatusArgs)
 
+	StorageConstraints() map[string]StorageConstraint
+
+	// EndpointBindings returns a map of the service's endpoint names
+	// to the space names they are bound to.
+	EndpointBindings() map[string]string
+
 	Units() []Unit
 	AddUnit(UnitArgs) Unit
 
+	// Resources returns the resources of the service, including the
+	// latest revisions polled from the charm store.
+	Resources() []Resource
+	AddResource(ResourceArgs) Resource
+
 	Validate() error
 }
 
@@ -269,6 +309,14 @@ type Unit interface {
 	AgentStatusHistory() []Status
 	SetAgentStatusHistory([]StatusArgs)
 
+	// Resources returns the revisions of the service's resources that
+	// are in use by the unit.
+	Resources() []Resource
+	AddResource(ResourceArgs) Resource
+
+	Payloads() []Payload
+	AddPayload(PayloadArgs) Payload
+
 	Validate() error
 }
 
@@ -304,3 +352,182 @@ type Endpoint interface {
 	Settings(unitName string) map[string]interface{}
 	SetUnitSettings(unitName string, settings map[string]interface{})
 }
+
+// StorageConstraint represents the user-specified constraints for
+// provisioning storage instances for a service unit.
+type StorageConstraint interface {
+	// Pool is the name of the storage pool from which to provision the
+	// storage instances.
+	Pool() string
+	// Size is the required size of the storage instances, in MiB.
+	Size() uint64
+	// Count is the required number of storage instances.
+	Count() uint64
+}
+
+// Storage represents the state of a unit or service-wide storage instance
+// in the model.
+type Storage interface {
+	Tag() names.StorageTag
+	Kind() string
+	// Owner returns the tag of the service or unit that owns this storage
+	// instance.
+	Owner() (names.Tag, error)
+	Name() string
+
+	Attachments() []names.UnitTag
+
+	Validate() error
+}
+
+// StoragePool represents a named storage pool and its settings.
+type StoragePool interface {
+	Name() string
+	Provider() string
+	Attributes() map[string]interface{}
+}
+
+// Volume represents a volume (disk, logical volume, etc.) in the model.
+type Volume interface {
+	HasStatusHistory
+
+	Tag() names.VolumeTag
+	Storage() names.StorageTag
+
+	// Binding returns the tag of the entity the volume's lifecycle
+	// is bound to, if any.
+	Binding() (names.Tag, error)
+
+	Provisioned() bool
+
+	Size() uint64
+	Pool() string
+
+	HardwareID() string
+	VolumeID() string
+	Persistent() bool
+
+	Status() Status
+	SetStatus(StatusArgs)
+
+	Attachments() []VolumeAttachment
+	AddAttachment(VolumeAttachmentArgs) VolumeAttachment
+
+	Validate() error
+}
+
+// VolumeAttachment represents a volume attached to a machine.
+type VolumeAttachment interface {
+	Machine() names.MachineTag
+	Provisioned() bool
+	ReadOnly() bool
+	DeviceName() string
+	DeviceLink() string
+	BusAddress() string
+}
+
+// Filesystem represents a filesystem in the model.
+type Filesystem interface {
+	HasStatusHistory
+
+	Tag() names.FilesystemTag
+	Volume() names.VolumeTag
+	Storage() names.StorageTag
+
+	// Binding returns the tag of the entity the filesystem's lifecycle
+	// is bound to, if any.
+	Binding() (names.Tag, error)
+
+	Provisioned() bool
+
+	Size() uint64
+	Pool() string
+
+	FilesystemID() string
+
+	Status() Status
+	SetStatus(StatusArgs)
+
+	Attachments() []FilesystemAttachment
+	AddAttachment(FilesystemAttachmentArgs) FilesystemAttachment
+
+	Validate() error
+}
+
+// FilesystemAttachment represents a filesystem attached to a machine.
+type FilesystemAttachment interface {
+	Machine() names.MachineTag
+	Provisioned() bool
+	MountPoint() string
+	ReadOnly() bool
+}
+
+// Space represents a network space in the model.
+type Space interface {
+	Name() string
+	Public() bool
+	ProviderID() string
+}
+
+// Subnet represents a network subnet in the model.
+type Subnet interface {
+	CIDR() string
+	ProviderID() string
+	VLANTag() int
+	AvailabilityZone() string
+	SpaceName() string
+	AllocatableIPHigh() string
+	AllocatableIPLow() string
+}
+
+// LinkLayerDevice represents a link-layer network device on a machine.
+type LinkLayerDevice interface {
+	Name() string
+	MTU() uint
+	ProviderID() string
+	MachineID() string
+	Type() string
+	MACAddress() string
+	IsAutoStart() bool
+	IsUp() bool
+	ParentName() string
+
+	Validate() error
+}
+
+// Resource represents a resource of a service or unit. The blob for the
+// resource revision is not part of the model description, and is
+// transferred separately during migration.
+type Resource interface {
+	Name() string
+	Revision() ResourceRevision
+	// CharmStoreRevision returns nil if the resource has never been
+	// polled from the charm store.
+	CharmStoreRevision() ResourceRevision
+
+	Validate() error
+}
+
+// ResourceRevision represents the metadata of a single revision of a
+// resource.
+type ResourceRevision interface {
+	Revision() int
+	Type() string
+	Path() string
+	Description() string
+	Origin() string
+	FingerprintHex() string
+	Size() int64
+	// Timestamp returns the zero time for placeholder resources.
+	Timestamp() time.Time
+	Username() string
+}
+
+// Payload represents a workload payload tracked by a unit.
+type Payload interface {
+	Name() string
+	Type() string
+	RawID() string
+	State() string
+	Labels() []string
+}
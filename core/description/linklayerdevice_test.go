@@ -0,0 +1,91 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type LinkLayerDeviceSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&LinkLayerDeviceSerializationSuite{})
+
+func (s *LinkLayerDeviceSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "link-layer devices"
+	s.sliceName = "link-layer-devices"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importLinkLayerDevices(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["link-layer-devices"] = []interface{}{}
+	}
+}
+
+func testLinkLayerDeviceArgs() LinkLayerDeviceArgs {
+	return LinkLayerDeviceArgs{
+		Name:        "eth0.100",
+		MTU:         1500,
+		ProviderID:  "magic",
+		MachineID:   "42",
+		Type:        "802.1q",
+		MACAddress:  "aa:bb:cc:dd:ee:ff",
+		IsAutoStart: true,
+		IsUp:        true,
+		ParentName:  "eth0",
+	}
+}
+
+func (s *LinkLayerDeviceSerializationSuite) TestNewLinkLayerDevice(c *gc.C) {
+	args := testLinkLayerDeviceArgs()
+	device := newLinkLayerDevice(args)
+	c.Assert(device.Name(), gc.Equals, args.Name)
+	c.Assert(device.MTU(), gc.Equals, args.MTU)
+	c.Assert(device.ProviderID(), gc.Equals, args.ProviderID)
+	c.Assert(device.MachineID(), gc.Equals, args.MachineID)
+	c.Assert(device.Type(), gc.Equals, args.Type)
+	c.Assert(device.MACAddress(), gc.Equals, args.MACAddress)
+	c.Assert(device.IsAutoStart(), jc.IsTrue)
+	c.Assert(device.IsUp(), jc.IsTrue)
+	c.Assert(device.ParentName(), gc.Equals, args.ParentName)
+}
+
+func (s *LinkLayerDeviceSerializationSuite) TestValidate(c *gc.C) {
+	device := newLinkLayerDevice(testLinkLayerDeviceArgs())
+	c.Assert(device.Validate(), jc.ErrorIsNil)
+
+	device.MachineID_ = "bad-id"
+	err := device.Validate()
+	c.Assert(err, gc.ErrorMatches, `link-layer device "eth0.100" machine id "bad-id" not valid`)
+}
+
+func (s *LinkLayerDeviceSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	initial := linklayerdevices{
+		Version: 1,
+		LinkLayerDevices_: []*linklayerdevice{
+			newLinkLayerDevice(testLinkLayerDeviceArgs()),
+			newLinkLayerDevice(LinkLayerDeviceArgs{
+				Name:      "eth0",
+				MachineID: "42",
+				Type:      "ethernet",
+			}),
+		},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	devices, err := importLinkLayerDevices(source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(devices, jc.DeepEquals, initial.LinkLayerDevices_)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/names"
 	"github.com/juju/schema"
+	"github.com/juju/utils/set"
 	"github.com/juju/version"
 )
 
@@ -246,6 +247,16 @@ func (m *machine) Containers() []Machine {
 	return result
 }
 
+// machineIds returns the id of the machine along with the ids of all of
+// its containers, recursively.
+func (m *machine) machineIds() set.Strings {
+	result := set.NewStrings(m.Id_)
+	for _, container := range m.Containers_ {
+		result = result.Union(container.machineIds())
+	}
+	return result
+}
+
 // AddContainer implements Machine.
 func (m *machine) AddContainer(args MachineArgs) Machine {
 	container := newMachine(args)
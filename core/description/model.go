@@ -40,6 +40,13 @@ func NewModel(args ModelArgs) Model {
 	m.setMachines(nil)
 	m.setServices(nil)
 	m.setRelations(nil)
+	m.setStorages(nil)
+	m.setStoragePools(nil)
+	m.setVolumes(nil)
+	m.setFilesystems(nil)
+	m.setSpaces(nil)
+	m.setSubnets(nil)
+	m.setLinkLayerDevices(nil)
 	return m
 }
 
@@ -80,15 +87,20 @@ type model struct {
 	Services_  services  `yaml:"services"`
 	Relations_ relations `yaml:"relations"`
 
+	Storages_     storages     `yaml:"storages"`
+	StoragePools_ storagepools `yaml:"storage-pools"`
+	Volumes_      volumes      `yaml:"volumes"`
+	Filesystems_  filesystems  `yaml:"filesystems"`
+
+	Spaces_           spaces           `yaml:"spaces"`
+	Subnets_          subnets          `yaml:"subnets"`
+	LinkLayerDevices_ linklayerdevices `yaml:"link-layer-devices"`
+
 	Sequences_ map[string]int `yaml:"sequences"`
 
 	Annotations_ `yaml:"annotations,omitempty"`
 
 	Constraints_ *constraints `yaml:"constraints,omitempty"`
-
-	// TODO:
-	// Spaces
-	// Storage
 }
 
 func (m *model) Tag() names.ModelTag {
@@ -237,6 +249,167 @@ func (m *model) setRelations(relationList []*relation) {
 	}
 }
 
+// Storages implements Model.
+func (m *model) Storages() []Storage {
+	var result []Storage
+	for _, storage := range m.Storages_.Storages_ {
+		result = append(result, storage)
+	}
+	return result
+}
+
+// AddStorage implements Model.
+func (m *model) AddStorage(args StorageArgs) Storage {
+	storage := newStorage(args)
+	m.Storages_.Storages_ = append(m.Storages_.Storages_, storage)
+	return storage
+}
+
+func (m *model) setStorages(storageList []*storage) {
+	m.Storages_ = storages{
+		Version:   1,
+		Storages_: storageList,
+	}
+}
+
+// StoragePools implements Model.
+func (m *model) StoragePools() []StoragePool {
+	var result []StoragePool
+	for _, pool := range m.StoragePools_.Pools_ {
+		result = append(result, pool)
+	}
+	return result
+}
+
+// AddStoragePool implements Model.
+func (m *model) AddStoragePool(args StoragePoolArgs) StoragePool {
+	pool := newStoragePool(args)
+	m.StoragePools_.Pools_ = append(m.StoragePools_.Pools_, pool)
+	return pool
+}
+
+func (m *model) setStoragePools(poolList []*storagepool) {
+	m.StoragePools_ = storagepools{
+		Version: 1,
+		Pools_:  poolList,
+	}
+}
+
+// Volumes implements Model.
+func (m *model) Volumes() []Volume {
+	var result []Volume
+	for _, volume := range m.Volumes_.Volumes_ {
+		result = append(result, volume)
+	}
+	return result
+}
+
+// AddVolume implements Model.
+func (m *model) AddVolume(args VolumeArgs) Volume {
+	volume := newVolume(args)
+	m.Volumes_.Volumes_ = append(m.Volumes_.Volumes_, volume)
+	return volume
+}
+
+func (m *model) setVolumes(volumeList []*volume) {
+	m.Volumes_ = volumes{
+		Version:  1,
+		Volumes_: volumeList,
+	}
+}
+
+// Filesystems implements Model.
+func (m *model) Filesystems() []Filesystem {
+	var result []Filesystem
+	for _, filesystem := range m.Filesystems_.Filesystems_ {
+		result = append(result, filesystem)
+	}
+	return result
+}
+
+// AddFilesystem implements Model.
+func (m *model) AddFilesystem(args FilesystemArgs) Filesystem {
+	filesystem := newFilesystem(args)
+	m.Filesystems_.Filesystems_ = append(m.Filesystems_.Filesystems_, filesystem)
+	return filesystem
+}
+
+func (m *model) setFilesystems(filesystemList []*filesystem) {
+	m.Filesystems_ = filesystems{
+		Version:      1,
+		Filesystems_: filesystemList,
+	}
+}
+
+// Spaces implements Model.
+func (m *model) Spaces() []Space {
+	var result []Space
+	for _, space := range m.Spaces_.Spaces_ {
+		result = append(result, space)
+	}
+	return result
+}
+
+// AddSpace implements Model.
+func (m *model) AddSpace(args SpaceArgs) Space {
+	space := newSpace(args)
+	m.Spaces_.Spaces_ = append(m.Spaces_.Spaces_, space)
+	return space
+}
+
+func (m *model) setSpaces(spaceList []*space) {
+	m.Spaces_ = spaces{
+		Version: 1,
+		Spaces_: spaceList,
+	}
+}
+
+// Subnets implements Model.
+func (m *model) Subnets() []Subnet {
+	var result []Subnet
+	for _, subnet := range m.Subnets_.Subnets_ {
+		result = append(result, subnet)
+	}
+	return result
+}
+
+// AddSubnet implements Model.
+func (m *model) AddSubnet(args SubnetArgs) Subnet {
+	subnet := newSubnet(args)
+	m.Subnets_.Subnets_ = append(m.Subnets_.Subnets_, subnet)
+	return subnet
+}
+
+func (m *model) setSubnets(subnetList []*subnet) {
+	m.Subnets_ = subnets{
+		Version:  1,
+		Subnets_: subnetList,
+	}
+}
+
+// LinkLayerDevices implements Model.
+func (m *model) LinkLayerDevices() []LinkLayerDevice {
+	var result []LinkLayerDevice
+	for _, device := range m.LinkLayerDevices_.LinkLayerDevices_ {
+		result = append(result, device)
+	}
+	return result
+}
+
+// AddLinkLayerDevice implements Model.
+func (m *model) AddLinkLayerDevice(args LinkLayerDeviceArgs) LinkLayerDevice {
+	device := newLinkLayerDevice(args)
+	m.LinkLayerDevices_.LinkLayerDevices_ = append(m.LinkLayerDevices_.LinkLayerDevices_, device)
+	return device
+}
+
+func (m *model) setLinkLayerDevices(deviceList []*linklayerdevice) {
+	m.LinkLayerDevices_ = linklayerdevices{
+		Version:           1,
+		LinkLayerDevices_: deviceList,
+	}
+}
+
 // Sequences implements Model.
 func (m *model) Sequences() map[string]int {
 	return m.Sequences_
@@ -292,9 +465,123 @@ func (m *model) Validate() error {
 		return errors.Errorf("unknown unit names in open ports: %s", unknownUnitsWithPorts.SortedValues())
 	}
 
+	if err := m.validateStorage(allUnits); err != nil {
+		return errors.Trace(err)
+	}
+	if err := m.validateNetwork(); err != nil {
+		return errors.Trace(err)
+	}
+
 	return m.validateRelations()
 }
 
+// validateStorage makes sure that the storage instances, volumes and
+// filesystems are valid, and that every unit and machine they reference
+// exists in the model.
+func (m *model) validateStorage(allUnits set.Strings) error {
+	allMachines := set.NewStrings()
+	for _, machine := range m.Machines_.Machines_ {
+		allMachines = allMachines.Union(machine.machineIds())
+	}
+	allServices := set.NewStrings()
+	for _, service := range m.Services_.Services_ {
+		allServices.Add(service.Name())
+	}
+	allStorage := set.NewStrings()
+	for _, storage := range m.Storages_.Storages_ {
+		if err := storage.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		allStorage.Add(storage.ID_)
+		// The storage validated, so we know the owner parses.
+		owner, _ := storage.Owner()
+		var ownerKnown bool
+		switch owner := owner.(type) {
+		case names.ServiceTag:
+			ownerKnown = allServices.Contains(owner.Id())
+		case names.UnitTag:
+			ownerKnown = allUnits.Contains(owner.Id())
+		}
+		if !ownerKnown {
+			return errors.Errorf("unknown owner %q for storage %q", storage.Owner_, storage.ID_)
+		}
+		for _, unit := range storage.Attachments_ {
+			if !allUnits.Contains(unit) {
+				return errors.Errorf("unknown unit %q attached to storage %q", unit, storage.ID_)
+			}
+		}
+	}
+	allVolumes := set.NewStrings()
+	for _, volume := range m.Volumes_.Volumes_ {
+		if err := volume.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		allVolumes.Add(volume.ID_)
+		if volume.StorageID_ != "" && !allStorage.Contains(volume.StorageID_) {
+			return errors.Errorf("unknown storage %q for volume %q", volume.StorageID_, volume.ID_)
+		}
+		for _, attachment := range volume.Attachments_.Attachments_ {
+			if !allMachines.Contains(attachment.MachineID_) {
+				return errors.Errorf("unknown machine %q attached to volume %q", attachment.MachineID_, volume.ID_)
+			}
+		}
+	}
+	for _, filesystem := range m.Filesystems_.Filesystems_ {
+		if err := filesystem.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		if filesystem.StorageID_ != "" && !allStorage.Contains(filesystem.StorageID_) {
+			return errors.Errorf("unknown storage %q for filesystem %q", filesystem.StorageID_, filesystem.ID_)
+		}
+		if filesystem.VolumeID_ != "" && !allVolumes.Contains(filesystem.VolumeID_) {
+			return errors.Errorf("unknown volume %q for filesystem %q", filesystem.VolumeID_, filesystem.ID_)
+		}
+		for _, attachment := range filesystem.Attachments_.Attachments_ {
+			if !allMachines.Contains(attachment.MachineID_) {
+				return errors.Errorf("unknown machine %q attached to filesystem %q", attachment.MachineID_, filesystem.ID_)
+			}
+		}
+	}
+	return nil
+}
+
+// validateNetwork makes sure that the spaces referenced by subnets and
+// endpoint bindings exist, and that every link-layer device belongs to a
+// machine in the model.
+func (m *model) validateNetwork() error {
+	allSpaces := set.NewStrings()
+	for _, space := range m.Spaces_.Spaces_ {
+		allSpaces.Add(space.Name_)
+	}
+	for _, subnet := range m.Subnets_.Subnets_ {
+		if subnet.SpaceName_ != "" && !allSpaces.Contains(subnet.SpaceName_) {
+			return errors.Errorf("unknown space %q for subnet %q", subnet.SpaceName_, subnet.CIDR_)
+		}
+	}
+	for _, service := range m.Services_.Services_ {
+		for endpoint, space := range service.EndpointBindings_ {
+			// An empty space name means the endpoint is bound to
+			// the default space.
+			if space != "" && !allSpaces.Contains(space) {
+				return errors.Errorf("unknown space %q bound to endpoint %q of service %q", space, endpoint, service.Name_)
+			}
+		}
+	}
+	allMachines := set.NewStrings()
+	for _, machine := range m.Machines_.Machines_ {
+		allMachines = allMachines.Union(machine.machineIds())
+	}
+	for _, device := range m.LinkLayerDevices_.LinkLayerDevices_ {
+		if err := device.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		if !allMachines.Contains(device.MachineID_) {
+			return errors.Errorf("unknown machine %q for link-layer device %q", device.MachineID_, device.Name_)
+		}
+	}
+	return nil
+}
+
 // validateRelations makes sure that for each endpoint in each relation there
 // are settings for all units of that service for that endpoint.
 func (m *model) validateRelations() error {
@@ -345,20 +632,34 @@ var modelDeserializationFuncs = map[int]modelDeserializationFunc{
 
 func importModelV1(source map[string]interface{}) (*model, error) {
 	fields := schema.Fields{
-		"owner":        schema.String(),
-		"config":       schema.StringMap(schema.Any()),
-		"latest-tools": schema.String(),
-		"blocks":       schema.StringMap(schema.String()),
-		"users":        schema.StringMap(schema.Any()),
-		"machines":     schema.StringMap(schema.Any()),
-		"services":     schema.StringMap(schema.Any()),
-		"relations":    schema.StringMap(schema.Any()),
-		"sequences":    schema.StringMap(schema.Int()),
+		"owner":              schema.String(),
+		"config":             schema.StringMap(schema.Any()),
+		"latest-tools":       schema.String(),
+		"blocks":             schema.StringMap(schema.String()),
+		"users":              schema.StringMap(schema.Any()),
+		"machines":           schema.StringMap(schema.Any()),
+		"services":           schema.StringMap(schema.Any()),
+		"relations":          schema.StringMap(schema.Any()),
+		"storages":           schema.StringMap(schema.Any()),
+		"storage-pools":      schema.StringMap(schema.Any()),
+		"volumes":            schema.StringMap(schema.Any()),
+		"filesystems":        schema.StringMap(schema.Any()),
+		"spaces":             schema.StringMap(schema.Any()),
+		"subnets":            schema.StringMap(schema.Any()),
+		"link-layer-devices": schema.StringMap(schema.Any()),
+		"sequences":          schema.StringMap(schema.Int()),
 	}
 	// Some values don't have to be there.
 	defaults := schema.Defaults{
-		"latest-tools": schema.Omit,
-		"blocks":       schema.Omit,
+		"latest-tools":       schema.Omit,
+		"blocks":             schema.Omit,
+		"storages":           schema.Omit,
+		"storage-pools":      schema.Omit,
+		"volumes":            schema.Omit,
+		"filesystems":        schema.Omit,
+		"spaces":             schema.Omit,
+		"subnets":            schema.Omit,
+		"link-layer-devices": schema.Omit,
 	}
 	addAnnotationSchema(fields, defaults)
 	addConstraintsSchema(fields, defaults)
@@ -429,5 +730,68 @@ func importModelV1(source map[string]interface{}) (*model, error) {
 	}
 	result.setRelations(relations)
 
+	var storageList []*storage
+	if storageMap, ok := valid["storages"]; ok {
+		storageList, err = importStorages(storageMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "storages")
+		}
+	}
+	result.setStorages(storageList)
+
+	var poolList []*storagepool
+	if poolMap, ok := valid["storage-pools"]; ok {
+		poolList, err = importStoragePools(poolMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "storage-pools")
+		}
+	}
+	result.setStoragePools(poolList)
+
+	var volumeList []*volume
+	if volumeMap, ok := valid["volumes"]; ok {
+		volumeList, err = importVolumes(volumeMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "volumes")
+		}
+	}
+	result.setVolumes(volumeList)
+
+	var filesystemList []*filesystem
+	if filesystemMap, ok := valid["filesystems"]; ok {
+		filesystemList, err = importFilesystems(filesystemMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "filesystems")
+		}
+	}
+	result.setFilesystems(filesystemList)
+
+	var spaceList []*space
+	if spaceMap, ok := valid["spaces"]; ok {
+		spaceList, err = importSpaces(spaceMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "spaces")
+		}
+	}
+	result.setSpaces(spaceList)
+
+	var subnetList []*subnet
+	if subnetMap, ok := valid["subnets"]; ok {
+		subnetList, err = importSubnets(subnetMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "subnets")
+		}
+	}
+	result.setSubnets(subnetList)
+
+	var deviceList []*linklayerdevice
+	if deviceMap, ok := valid["link-layer-devices"]; ok {
+		deviceList, err = importLinkLayerDevices(deviceMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "link-layer-devices")
+		}
+	}
+	result.setLinkLayerDevices(deviceList)
+
 	return result, nil
 }
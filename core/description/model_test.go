@@ -326,3 +326,155 @@ func (s *ModelSerializationSuite) TestModelSerializationWithRelations(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(model, jc.DeepEquals, initial)
 }
+
+func (s *ModelSerializationSuite) TestModelValidationChecksStorage(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	s.addServiceToModel(model, "postgresql", 1)
+	model.AddStorage(StorageArgs{
+		Tag:         names.NewStorageTag("data/0"),
+		Kind:        "block",
+		Owner:       names.NewUnitTag("postgresql/0"),
+		Name:        "data",
+		Attachments: []names.UnitTag{names.NewUnitTag("postgresql/0")},
+	})
+	volume := model.AddVolume(VolumeArgs{
+		Tag:     names.NewVolumeTag("0"),
+		Storage: names.NewStorageTag("data/0"),
+		Size:    1024,
+	})
+	volume.SetStatus(minimalStatusArgs())
+	volume.AddAttachment(VolumeAttachmentArgs{Machine: names.NewMachineTag("0")})
+	filesystem := model.AddFilesystem(FilesystemArgs{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+		Size:   1024,
+	})
+	filesystem.SetStatus(minimalStatusArgs())
+	filesystem.AddAttachment(FilesystemAttachmentArgs{Machine: names.NewMachineTag("0")})
+	err := model.Validate()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksStorageOwner(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	model.AddStorage(StorageArgs{
+		Tag:   names.NewStorageTag("data/0"),
+		Owner: names.NewUnitTag("postgresql/0"),
+		Name:  "data",
+	})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `unknown owner "unit-postgresql-0" for storage "data/0"`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksStorageAttachments(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	s.addServiceToModel(model, "postgresql", 1)
+	model.AddStorage(StorageArgs{
+		Tag:         names.NewStorageTag("data/0"),
+		Owner:       names.NewServiceTag("postgresql"),
+		Name:        "data",
+		Attachments: []names.UnitTag{names.NewUnitTag("postgresql/1")},
+	})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `unknown unit "postgresql/1" attached to storage "data/0"`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksVolumeStorage(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	volume := model.AddVolume(VolumeArgs{
+		Tag:     names.NewVolumeTag("0"),
+		Storage: names.NewStorageTag("data/0"),
+		Size:    1024,
+	})
+	volume.SetStatus(minimalStatusArgs())
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `unknown storage "data/0" for volume "0"`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksVolumeAttachments(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	volume := model.AddVolume(VolumeArgs{
+		Tag:  names.NewVolumeTag("0"),
+		Size: 1024,
+	})
+	volume.SetStatus(minimalStatusArgs())
+	volume.AddAttachment(VolumeAttachmentArgs{Machine: names.NewMachineTag("42")})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `unknown machine "42" attached to volume "0"`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksFilesystemVolume(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	filesystem := model.AddFilesystem(FilesystemArgs{
+		Tag:    names.NewFilesystemTag("0"),
+		Volume: names.NewVolumeTag("0"),
+		Size:   1024,
+	})
+	filesystem.SetStatus(minimalStatusArgs())
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `unknown volume "0" for filesystem "0"`)
+}
+
+func (s *ModelSerializationSuite) TestModelSerializationWithStorage(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	initial.AddStorage(testStorageArgs())
+	initial.AddStoragePool(testStoragePoolArgs())
+	volume := initial.AddVolume(testVolumeArgs())
+	volume.SetStatus(minimalStatusArgs())
+	volume.AddAttachment(testVolumeAttachmentArgs())
+	filesystem := initial.AddFilesystem(testFilesystemArgs())
+	filesystem.SetStatus(minimalStatusArgs())
+	filesystem.AddAttachment(testFilesystemAttachmentArgs())
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model, jc.DeepEquals, initial)
+	c.Assert(model.Storages(), gc.HasLen, 1)
+	c.Assert(model.StoragePools(), gc.HasLen, 1)
+	c.Assert(model.Volumes(), gc.HasLen, 1)
+	c.Assert(model.Filesystems(), gc.HasLen, 1)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksSubnetSpace(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	model.AddSubnet(SubnetArgs{CIDR: "10.0.0.0/24", SpaceName: "missing"})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `unknown space "missing" for subnet "10.0.0.0/24"`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksEndpointBindings(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	args := minimalServiceArgs()
+	args.EndpointBindings = map[string]string{"db": "missing"}
+	service := model.AddService(args)
+	service.SetStatus(minimalStatusArgs())
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `unknown space "missing" bound to endpoint "db" of service "ubuntu"`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksLinkLayerDeviceMachine(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	model.AddLinkLayerDevice(LinkLayerDeviceArgs{Name: "eth0", MachineID: "42"})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `unknown machine "42" for link-layer device "eth0"`)
+}
+
+func (s *ModelSerializationSuite) TestModelSerializationWithNetwork(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	initial.AddSpace(SpaceArgs{Name: "special"})
+	initial.AddSubnet(testSubnetArgs())
+	s.addMachineToModel(initial, "42")
+	initial.AddLinkLayerDevice(testLinkLayerDeviceArgs())
+	c.Assert(initial.Validate(), jc.ErrorIsNil)
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model, jc.DeepEquals, initial)
+	c.Assert(model.Spaces(), gc.HasLen, 1)
+	c.Assert(model.Subnets(), gc.HasLen, 1)
+	c.Assert(model.LinkLayerDevices(), gc.HasLen, 1)
+}
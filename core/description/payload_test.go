@@ -0,0 +1,81 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type PayloadSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&PayloadSerializationSuite{})
+
+func (s *PayloadSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "payloads"
+	s.sliceName = "payloads"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importPayloads(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["payloads"] = []interface{}{}
+	}
+}
+
+func emptyPayloadsMap() map[interface{}]interface{} {
+	return map[interface{}]interface{}{
+		"version":  1,
+		"payloads": []interface{}{},
+	}
+}
+
+func testPayloadArgs() PayloadArgs {
+	return PayloadArgs{
+		Name:   "spam",
+		Type:   "docker",
+		RawID:  "abcd",
+		State:  "running",
+		Labels: []string{"a-tag"},
+	}
+}
+
+func (s *PayloadSerializationSuite) TestNewPayload(c *gc.C) {
+	payload := newPayload(testPayloadArgs())
+	c.Assert(payload.Name(), gc.Equals, "spam")
+	c.Assert(payload.Type(), gc.Equals, "docker")
+	c.Assert(payload.RawID(), gc.Equals, "abcd")
+	c.Assert(payload.State(), gc.Equals, "running")
+	c.Assert(payload.Labels(), jc.DeepEquals, []string{"a-tag"})
+}
+
+func (s *PayloadSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	initial := payloads{
+		Version: 1,
+		Payloads_: []*payload{
+			newPayload(testPayloadArgs()),
+			newPayload(PayloadArgs{
+				Name:  "eggs",
+				Type:  "kvm",
+				RawID: "efgh",
+				State: "stopped",
+			}),
+		},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	payloads, err := importPayloads(source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(payloads, jc.DeepEquals, initial.Payloads_)
+}
@@ -0,0 +1,291 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+)
+
+type resources struct {
+	Version    int         `yaml:"version"`
+	Resources_ []*resource `yaml:"resources"`
+}
+
+type resource struct {
+	Name_               string            `yaml:"name"`
+	Revision_           *resourceRevision `yaml:"revision"`
+	CharmStoreRevision_ *resourceRevision `yaml:"charmstore-revision,omitempty"`
+}
+
+type resourceRevision struct {
+	Revision_       int        `yaml:"revision"`
+	Type_           string     `yaml:"type"`
+	Path_           string     `yaml:"path"`
+	Description_    string     `yaml:"description,omitempty"`
+	Origin_         string     `yaml:"origin"`
+	FingerprintHex_ string     `yaml:"fingerprint"`
+	Size_           int64      `yaml:"size"`
+	Timestamp_      *time.Time `yaml:"timestamp,omitempty"`
+	Username_       string     `yaml:"username,omitempty"`
+}
+
+// ResourceArgs is an argument struct used to add a resource to a Service
+// or a Unit in the Model.
+type ResourceArgs struct {
+	Name     string
+	Revision ResourceRevisionArgs
+	// CharmStoreRevision is only set for service resources that have
+	// been polled from the charm store.
+	CharmStoreRevision *ResourceRevisionArgs
+}
+
+// ResourceRevisionArgs is an argument struct that describes one revision
+// of a resource.
+type ResourceRevisionArgs struct {
+	Revision       int
+	Type           string
+	Path           string
+	Description    string
+	Origin         string
+	FingerprintHex string
+	Size           int64
+	// Timestamp is the zero time for placeholder resources that
+	// have not had any data uploaded.
+	Timestamp time.Time
+	Username  string
+}
+
+func newResource(args ResourceArgs) *resource {
+	result := &resource{
+		Name_:     args.Name,
+		Revision_: newResourceRevision(args.Revision),
+	}
+	if args.CharmStoreRevision != nil {
+		result.CharmStoreRevision_ = newResourceRevision(*args.CharmStoreRevision)
+	}
+	return result
+}
+
+func newResourceRevision(args ResourceRevisionArgs) *resourceRevision {
+	result := &resourceRevision{
+		Revision_:       args.Revision,
+		Type_:           args.Type,
+		Path_:           args.Path,
+		Description_:    args.Description,
+		Origin_:         args.Origin,
+		FingerprintHex_: args.FingerprintHex,
+		Size_:           args.Size,
+		Username_:       args.Username,
+	}
+	if !args.Timestamp.IsZero() {
+		timestamp := args.Timestamp.UTC()
+		result.Timestamp_ = &timestamp
+	}
+	return result
+}
+
+// Name implements Resource.
+func (r *resource) Name() string {
+	return r.Name_
+}
+
+// Revision implements Resource.
+func (r *resource) Revision() ResourceRevision {
+	// To avoid a typed nil, check before returning.
+	if r.Revision_ == nil {
+		return nil
+	}
+	return r.Revision_
+}
+
+// CharmStoreRevision implements Resource.
+func (r *resource) CharmStoreRevision() ResourceRevision {
+	// To avoid a typed nil, check before returning.
+	if r.CharmStoreRevision_ == nil {
+		return nil
+	}
+	return r.CharmStoreRevision_
+}
+
+// Validate implements Resource.
+func (r *resource) Validate() error {
+	if r.Name_ == "" {
+		return errors.NotValidf("resource missing name")
+	}
+	if r.Revision_ == nil {
+		return errors.NotValidf("resource %q missing revision", r.Name_)
+	}
+	return nil
+}
+
+// Revision implements ResourceRevision.
+func (r *resourceRevision) Revision() int {
+	return r.Revision_
+}
+
+// Type implements ResourceRevision.
+func (r *resourceRevision) Type() string {
+	return r.Type_
+}
+
+// Path implements ResourceRevision.
+func (r *resourceRevision) Path() string {
+	return r.Path_
+}
+
+// Description implements ResourceRevision.
+func (r *resourceRevision) Description() string {
+	return r.Description_
+}
+
+// Origin implements ResourceRevision.
+func (r *resourceRevision) Origin() string {
+	return r.Origin_
+}
+
+// FingerprintHex implements ResourceRevision.
+func (r *resourceRevision) FingerprintHex() string {
+	return r.FingerprintHex_
+}
+
+// Size implements ResourceRevision.
+func (r *resourceRevision) Size() int64 {
+	return r.Size_
+}
+
+// Timestamp implements ResourceRevision.
+func (r *resourceRevision) Timestamp() time.Time {
+	if r.Timestamp_ == nil {
+		return time.Time{}
+	}
+	return *r.Timestamp_
+}
+
+// Username implements ResourceRevision.
+func (r *resourceRevision) Username() string {
+	return r.Username_
+}
+
+func importResources(source map[string]interface{}) ([]*resource, error) {
+	checker := versionedChecker("resources")
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "resources version schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+
+	version := int(valid["version"].(int64))
+	importFunc, ok := resourceDeserializationFuncs[version]
+	if !ok {
+		return nil, errors.NotValidf("version %d", version)
+	}
+	sourceList := valid["resources"].([]interface{})
+	return importResourceList(sourceList, importFunc)
+}
+
+func importResourceList(sourceList []interface{}, importFunc resourceDeserializationFunc) ([]*resource, error) {
+	result := make([]*resource, 0, len(sourceList))
+	for i, value := range sourceList {
+		source, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("unexpected value for resource %d, %T", i, value)
+		}
+		resource, err := importFunc(source)
+		if err != nil {
+			return nil, errors.Annotatef(err, "resource %d", i)
+		}
+		result = append(result, resource)
+	}
+	return result, nil
+}
+
+type resourceDeserializationFunc func(map[string]interface{}) (*resource, error)
+
+var resourceDeserializationFuncs = map[int]resourceDeserializationFunc{
+	1: importResourceV1,
+}
+
+func importResourceV1(source map[string]interface{}) (*resource, error) {
+	fields := schema.Fields{
+		"name":                schema.String(),
+		"revision":            schema.StringMap(schema.Any()),
+		"charmstore-revision": schema.StringMap(schema.Any()),
+	}
+	defaults := schema.Defaults{
+		"charmstore-revision": schema.Omit,
+	}
+	checker := schema.FieldMap(fields, defaults)
+
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "resource v1 schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+	// From here we know that the map returned from the schema coercion
+	// contains fields of the right type.
+	result := &resource{
+		Name_: valid["name"].(string),
+	}
+
+	revision, err := importResourceRevisionV1(valid["revision"].(map[string]interface{}))
+	if err != nil {
+		return nil, errors.Annotatef(err, "resource %q revision", result.Name_)
+	}
+	result.Revision_ = revision
+
+	if source, ok := valid["charmstore-revision"]; ok {
+		revision, err := importResourceRevisionV1(source.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotatef(err, "resource %q charmstore revision", result.Name_)
+		}
+		result.CharmStoreRevision_ = revision
+	}
+	return result, nil
+}
+
+func importResourceRevisionV1(source map[string]interface{}) (*resourceRevision, error) {
+	fields := schema.Fields{
+		"revision":    schema.Int(),
+		"type":        schema.String(),
+		"path":        schema.String(),
+		"description": schema.String(),
+		"origin":      schema.String(),
+		"fingerprint": schema.String(),
+		"size":        schema.Int(),
+		"timestamp":   schema.Time(),
+		"username":    schema.String(),
+	}
+	defaults := schema.Defaults{
+		"description": "",
+		"timestamp":   schema.Omit,
+		"username":    "",
+	}
+	checker := schema.FieldMap(fields, defaults)
+
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "resource revision v1 schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+	// From here we know that the map returned from the schema coercion
+	// contains fields of the right type.
+	result := &resourceRevision{
+		Revision_:       int(valid["revision"].(int64)),
+		Type_:           valid["type"].(string),
+		Path_:           valid["path"].(string),
+		Description_:    valid["description"].(string),
+		Origin_:         valid["origin"].(string),
+		FingerprintHex_: valid["fingerprint"].(string),
+		Size_:           valid["size"].(int64),
+		Username_:       valid["username"].(string),
+	}
+	if timestamp, ok := valid["timestamp"]; ok {
+		value := timestamp.(time.Time)
+		result.Timestamp_ = &value
+	}
+	return result, nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type ResourceSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&ResourceSerializationSuite{})
+
+func (s *ResourceSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "resources"
+	s.sliceName = "resources"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importResources(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["resources"] = []interface{}{}
+	}
+}
+
+func emptyResourcesMap() map[interface{}]interface{} {
+	return map[interface{}]interface{}{
+		"version":   1,
+		"resources": []interface{}{},
+	}
+}
+
+func testResourceRevisionArgs() ResourceRevisionArgs {
+	return ResourceRevisionArgs{
+		Revision:       3,
+		Type:           "file",
+		Path:           "blob.tgz",
+		Description:    "the blob",
+		Origin:         "upload",
+		FingerprintHex: "a0b1c2d3",
+		Size:           1024,
+		Timestamp:      time.Date(2016, 1, 28, 11, 50, 0, 0, time.UTC),
+		Username:       "bob",
+	}
+}
+
+func testResourceArgs() ResourceArgs {
+	charmStoreRevision := ResourceRevisionArgs{
+		Revision:       4,
+		Type:           "file",
+		Path:           "blob.tgz",
+		Origin:         "store",
+		FingerprintHex: "e4f5",
+		Size:           2048,
+	}
+	return ResourceArgs{
+		Name:               "blob",
+		Revision:           testResourceRevisionArgs(),
+		CharmStoreRevision: &charmStoreRevision,
+	}
+}
+
+func (s *ResourceSerializationSuite) TestNewResource(c *gc.C) {
+	args := testResourceArgs()
+	resource := newResource(args)
+
+	c.Assert(resource.Name(), gc.Equals, "blob")
+	revision := resource.Revision()
+	c.Assert(revision.Revision(), gc.Equals, 3)
+	c.Assert(revision.Type(), gc.Equals, "file")
+	c.Assert(revision.Path(), gc.Equals, "blob.tgz")
+	c.Assert(revision.Description(), gc.Equals, "the blob")
+	c.Assert(revision.Origin(), gc.Equals, "upload")
+	c.Assert(revision.FingerprintHex(), gc.Equals, "a0b1c2d3")
+	c.Assert(revision.Size(), gc.Equals, int64(1024))
+	c.Assert(revision.Timestamp(), gc.Equals, args.Revision.Timestamp)
+	c.Assert(revision.Username(), gc.Equals, "bob")
+
+	charmStoreRevision := resource.CharmStoreRevision()
+	c.Assert(charmStoreRevision.Revision(), gc.Equals, 4)
+	c.Assert(charmStoreRevision.Origin(), gc.Equals, "store")
+	c.Assert(charmStoreRevision.Timestamp().IsZero(), jc.IsTrue)
+	c.Assert(charmStoreRevision.Username(), gc.Equals, "")
+}
+
+func (s *ResourceSerializationSuite) TestNewResourceNoCharmStoreRevision(c *gc.C) {
+	resource := newResource(ResourceArgs{
+		Name:     "blob",
+		Revision: testResourceRevisionArgs(),
+	})
+	c.Assert(resource.CharmStoreRevision(), gc.IsNil)
+}
+
+func (s *ResourceSerializationSuite) TestValidate(c *gc.C) {
+	resource := newResource(testResourceArgs())
+	c.Assert(resource.Validate(), jc.ErrorIsNil)
+
+	resource.Name_ = ""
+	c.Assert(resource.Validate(), gc.ErrorMatches, `resource missing name not valid`)
+
+	resource.Name_ = "blob"
+	resource.Revision_ = nil
+	c.Assert(resource.Validate(), gc.ErrorMatches, `resource "blob" missing revision not valid`)
+}
+
+func (s *ResourceSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	initial := resources{
+		Version: 1,
+		Resources_: []*resource{
+			newResource(testResourceArgs()),
+			newResource(ResourceArgs{
+				Name: "placeholder",
+				Revision: ResourceRevisionArgs{
+					Type:   "file",
+					Path:   "placeholder.txt",
+					Origin: "upload",
+				},
+			}),
+		},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	resources, err := importResources(source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(resources, jc.DeepEquals, initial.Resources_)
+}
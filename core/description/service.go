@@ -32,6 +32,9 @@ type service struct {
 	Exposed_    bool `yaml:"exposed,omitempty"`
 	MinUnits_   int  `yaml:"min-units,omitempty"`
 
+	ExposedSourceCIDRs_     []string            `yaml:"exposed-source-cidrs,omitempty"`
+	ExposedPortSourceCIDRs_ map[string][]string `yaml:"exposed-port-source-cidrs,omitempty"`
+
 	Status_        *status `yaml:"status"`
 	StatusHistory_ `yaml:"status-history"`
 
@@ -46,11 +49,15 @@ type service struct {
 	// unit count will be assumed by the number of units associated.
 	Units_ units `yaml:"units"`
 
+	Resources_ resources `yaml:"resources"`
+
 	Annotations_ `yaml:"annotations,omitempty"`
 
 	Constraints_ *constraints `yaml:"constraints,omitempty"`
 
-	// Storage Constraints
+	StorageConstraints_ map[string]*storageconstraint `yaml:"storage-constraints,omitempty"`
+
+	EndpointBindings_ map[string]string `yaml:"endpoint-bindings,omitempty"`
 }
 
 // ServiceArgs is an argument struct used to add a service to the Model.
@@ -69,6 +76,14 @@ type ServiceArgs struct {
 	Leader               string
 	LeadershipSettings   map[string]interface{}
 	MetricsCredentials   []byte
+	StorageConstraints   map[string]StorageConstraintArgs
+	EndpointBindings     map[string]string
+
+	// ExposedSourceCIDRs and ExposedPortSourceCIDRs hold the networks
+	// from which the ports of the exposed service may be reached, for
+	// all ports and for individual port ranges respectively.
+	ExposedSourceCIDRs     []string
+	ExposedPortSourceCIDRs map[string][]string
 }
 
 func newService(args ServiceArgs) *service {
@@ -88,9 +103,20 @@ func newService(args ServiceArgs) *service {
 		Leader_:               args.Leader,
 		LeadershipSettings_:   args.LeadershipSettings,
 		MetricsCredentials_:   creds,
+		EndpointBindings_:     args.EndpointBindings,
 		StatusHistory_:        newStatusHistory(),
+
+		ExposedSourceCIDRs_:     args.ExposedSourceCIDRs,
+		ExposedPortSourceCIDRs_: args.ExposedPortSourceCIDRs,
 	}
 	svc.setUnits(nil)
+	svc.setResources(nil)
+	if len(args.StorageConstraints) > 0 {
+		svc.StorageConstraints_ = make(map[string]*storageconstraint)
+		for key, value := range args.StorageConstraints {
+			svc.StorageConstraints_[key] = newStorageConstraint(value)
+		}
+	}
 	return svc
 }
 
@@ -139,6 +165,16 @@ func (s *service) Exposed() bool {
 	return s.Exposed_
 }
 
+// ExposedSourceCIDRs implements Service.
+func (s *service) ExposedSourceCIDRs() []string {
+	return s.ExposedSourceCIDRs_
+}
+
+// ExposedPortSourceCIDRs implements Service.
+func (s *service) ExposedPortSourceCIDRs() map[string][]string {
+	return s.ExposedPortSourceCIDRs_
+}
+
 // MinUnits implements Service.
 func (s *service) MinUnits() int {
 	return s.MinUnits_
@@ -188,6 +224,20 @@ func (s *service) SetStatus(args StatusArgs) {
 	s.Status_ = newStatus(args)
 }
 
+// StorageConstraints implements Service.
+func (s *service) StorageConstraints() map[string]StorageConstraint {
+	result := make(map[string]StorageConstraint)
+	for key, value := range s.StorageConstraints_ {
+		result[key] = value
+	}
+	return result
+}
+
+// EndpointBindings implements Service.
+func (s *service) EndpointBindings() map[string]string {
+	return s.EndpointBindings_
+}
+
 // Units implements Service.
 func (s *service) Units() []Unit {
 	result := make([]Unit, len(s.Units_.Units_))
@@ -219,6 +269,29 @@ func (s *service) setUnits(unitList []*unit) {
 	}
 }
 
+// Resources implements Service.
+func (s *service) Resources() []Resource {
+	result := make([]Resource, len(s.Resources_.Resources_))
+	for i, r := range s.Resources_.Resources_ {
+		result[i] = r
+	}
+	return result
+}
+
+// AddResource implements Service.
+func (s *service) AddResource(args ResourceArgs) Resource {
+	r := newResource(args)
+	s.Resources_.Resources_ = append(s.Resources_.Resources_, r)
+	return r
+}
+
+func (s *service) setResources(resourceList []*resource) {
+	s.Resources_ = resources{
+		Version:    1,
+		Resources_: resourceList,
+	}
+}
+
 // Constraints implements HasConstraints.
 func (s *service) Constraints() Constraints {
 	if s.Constraints_ == nil {
@@ -240,6 +313,13 @@ func (s *service) Validate() error {
 	if s.Status_ == nil {
 		return errors.NotValidf("service %q missing status", s.Name_)
 	}
+	resourceNames := set.NewStrings()
+	for _, r := range s.Resources_.Resources_ {
+		if err := r.Validate(); err != nil {
+			return errors.Annotatef(err, "service %q", s.Name_)
+		}
+		resourceNames.Add(r.Name_)
+	}
 	// If leader is set, it must match one of the units.
 	var leaderFound bool
 	// All of the services units should also be valid.
@@ -247,6 +327,12 @@ func (s *service) Validate() error {
 		if err := u.Validate(); err != nil {
 			return errors.Trace(err)
 		}
+		// Units can only use the resources defined for the service.
+		for _, r := range u.Resources() {
+			if !resourceNames.Contains(r.Name()) {
+				return errors.NotValidf("unit %q resource %q", u.Name(), r.Name())
+			}
+		}
 		// We know that the unit has a name, because it validated correctly.
 		if u.Name() == s.Leader_ {
 			leaderFound = true
@@ -315,15 +401,27 @@ func importServiceV1(source map[string]interface{}) (*service, error) {
 		"leadership-settings": schema.StringMap(schema.Any()),
 		"metrics-creds":       schema.String(),
 		"units":               schema.StringMap(schema.Any()),
+		"resources":           schema.StringMap(schema.Any()),
+		"storage-constraints": schema.StringMap(schema.StringMap(schema.Any())),
+		"endpoint-bindings":   schema.StringMap(schema.String()),
+
+		"exposed-source-cidrs":      schema.List(schema.String()),
+		"exposed-port-source-cidrs": schema.StringMap(schema.List(schema.String())),
 	}
 
 	defaults := schema.Defaults{
-		"subordinate":   false,
-		"force-charm":   false,
-		"exposed":       false,
-		"min-units":     int64(0),
-		"leader":        "",
-		"metrics-creds": "",
+		"subordinate":         false,
+		"force-charm":         false,
+		"exposed":             false,
+		"min-units":           int64(0),
+		"leader":              "",
+		"metrics-creds":       "",
+		"storage-constraints": schema.Omit,
+		"endpoint-bindings":   schema.Omit,
+		"resources":           schema.Omit,
+
+		"exposed-source-cidrs":      schema.Omit,
+		"exposed-port-source-cidrs": schema.Omit,
 	}
 	addAnnotationSchema(fields, defaults)
 	addConstraintsSchema(fields, defaults)
@@ -351,8 +449,18 @@ func importServiceV1(source map[string]interface{}) (*service, error) {
 		SettingsRefCount_:     int(valid["settings-refcount"].(int64)),
 		Leader_:               valid["leader"].(string),
 		LeadershipSettings_:   valid["leadership-settings"].(map[string]interface{}),
+		EndpointBindings_:     convertToStringMap(valid["endpoint-bindings"]),
 		StatusHistory_:        newStatusHistory(),
 	}
+	if sourceCIDRs, ok := valid["exposed-source-cidrs"]; ok {
+		result.ExposedSourceCIDRs_ = convertToStringSlice(sourceCIDRs)
+	}
+	if portSourceCIDRs, ok := valid["exposed-port-source-cidrs"]; ok {
+		result.ExposedPortSourceCIDRs_ = make(map[string][]string)
+		for portRange, sourceCIDRs := range portSourceCIDRs.(map[string]interface{}) {
+			result.ExposedPortSourceCIDRs_[portRange] = convertToStringSlice(sourceCIDRs)
+		}
+	}
 	result.importAnnotations(valid)
 	if err := result.importStatusHistory(valid); err != nil {
 		return nil, errors.Trace(err)
@@ -366,6 +474,14 @@ func importServiceV1(source map[string]interface{}) (*service, error) {
 		result.Constraints_ = constraints
 	}
 
+	if constraintsMap, ok := valid["storage-constraints"]; ok {
+		constraints, err := importStorageConstraints(constraintsMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.StorageConstraints_ = constraints
+	}
+
 	encodedCreds := valid["metrics-creds"].(string)
 	// The model stores the creds encoded, but we want to make sure that
 	// we are storing something that can be decoded.
@@ -386,5 +502,14 @@ func importServiceV1(source map[string]interface{}) (*service, error) {
 	}
 	result.setUnits(units)
 
+	var resourceList []*resource
+	if resourceMap, ok := valid["resources"]; ok {
+		resourceList, err = importResources(resourceMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "resources")
+		}
+	}
+	result.setResources(resourceList)
+
 	return result, nil
 }
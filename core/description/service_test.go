@@ -59,6 +59,7 @@ func minimalServiceMap() map[interface{}]interface{} {
 				minimalUnitMap(),
 			},
 		},
+		"resources": emptyResourcesMap(),
 	}
 }
 
@@ -205,6 +206,56 @@ func (s *ServiceSerializationSuite) TestConstraints(c *gc.C) {
 	c.Assert(service.Constraints(), jc.DeepEquals, newConstraints(args))
 }
 
+func (s *ServiceSerializationSuite) TestStorageConstraints(c *gc.C) {
+	args := minimalServiceArgs()
+	args.StorageConstraints = map[string]StorageConstraintArgs{
+		"data": {
+			Pool:  "loop",
+			Size:  1024,
+			Count: 2,
+		},
+	}
+	initial := newService(args)
+	initial.SetStatus(minimalStatusArgs())
+
+	service := s.exportImport(c, initial)
+	constraints := service.StorageConstraints()
+	c.Assert(constraints, gc.HasLen, 1)
+	data := constraints["data"]
+	c.Check(data.Pool(), gc.Equals, "loop")
+	c.Check(data.Size(), gc.Equals, uint64(1024))
+	c.Check(data.Count(), gc.Equals, uint64(2))
+}
+
+func (s *ServiceSerializationSuite) TestEndpointBindings(c *gc.C) {
+	args := minimalServiceArgs()
+	args.EndpointBindings = map[string]string{
+		"rel-name": "some-space",
+		"other":    "other-space",
+	}
+	initial := newService(args)
+	initial.SetStatus(minimalStatusArgs())
+
+	service := s.exportImport(c, initial)
+	c.Assert(service.EndpointBindings(), jc.DeepEquals, args.EndpointBindings)
+}
+
+func (s *ServiceSerializationSuite) TestExposedSourceCIDRs(c *gc.C) {
+	args := minimalServiceArgs()
+	args.Exposed = true
+	args.ExposedSourceCIDRs = []string{"10.0.0.0/8"}
+	args.ExposedPortSourceCIDRs = map[string][]string{
+		"8443/tcp": {"10.1.0.0/16", "192.168.0.0/16"},
+		"80/tcp":   {},
+	}
+	initial := newService(args)
+	initial.SetStatus(minimalStatusArgs())
+
+	service := s.exportImport(c, initial)
+	c.Assert(service.ExposedSourceCIDRs(), jc.DeepEquals, args.ExposedSourceCIDRs)
+	c.Assert(service.ExposedPortSourceCIDRs(), jc.DeepEquals, args.ExposedPortSourceCIDRs)
+}
+
 func (s *ServiceSerializationSuite) TestLeaderValid(c *gc.C) {
 	args := minimalServiceArgs()
 	args.Leader = "ubuntu/1"
@@ -214,3 +265,26 @@ func (s *ServiceSerializationSuite) TestLeaderValid(c *gc.C) {
 	err := service.Validate()
 	c.Assert(err, gc.ErrorMatches, `missing unit for leader "ubuntu/1" not valid`)
 }
+
+func (s *ServiceSerializationSuite) TestResources(c *gc.C) {
+	initial := minimalService()
+	args := testResourceArgs()
+	initial.AddResource(args)
+
+	service := s.exportImport(c, initial)
+	c.Assert(service.Resources(), jc.DeepEquals, []Resource{newResource(args)})
+}
+
+func (s *ServiceSerializationSuite) TestUnitResourceValid(c *gc.C) {
+	service := minimalService()
+	service.Units_.Units_[0].AddResource(ResourceArgs{
+		Name:     "blob",
+		Revision: testResourceRevisionArgs(),
+	})
+
+	err := service.Validate()
+	c.Assert(err, gc.ErrorMatches, `unit "ubuntu/0" resource "blob" not valid`)
+
+	service.AddResource(testResourceArgs())
+	c.Assert(service.Validate(), jc.ErrorIsNil)
+}
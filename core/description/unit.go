@@ -41,6 +41,9 @@ type unit struct {
 	Annotations_ `yaml:"annotations,omitempty"`
 
 	Constraints_ *constraints `yaml:"constraints,omitempty"`
+
+	Resources_ resources `yaml:"resources"`
+	Payloads_  payloads  `yaml:"payloads"`
 }
 
 // UnitArgs is an argument struct used to add a Unit to a Service in the Model.
@@ -62,7 +65,7 @@ func newUnit(args UnitArgs) *unit {
 	for _, s := range args.Subordinates {
 		subordinates = append(subordinates, s.Id())
 	}
-	return &unit{
+	u := &unit{
 		Name_:                  args.Tag.Id(),
 		Machine_:               args.Machine.Id(),
 		PasswordHash_:          args.PasswordHash,
@@ -73,6 +76,9 @@ func newUnit(args UnitArgs) *unit {
 		WorkloadStatusHistory_: newStatusHistory(),
 		AgentStatusHistory_:    newStatusHistory(),
 	}
+	u.setResources(nil)
+	u.setPayloads(nil)
+	return u
 }
 
 // Tag implements Unit.
@@ -197,6 +203,52 @@ func (u *unit) SetConstraints(args ConstraintsArgs) {
 	u.Constraints_ = newConstraints(args)
 }
 
+// Resources implements Unit.
+func (u *unit) Resources() []Resource {
+	result := make([]Resource, len(u.Resources_.Resources_))
+	for i, r := range u.Resources_.Resources_ {
+		result[i] = r
+	}
+	return result
+}
+
+// AddResource implements Unit.
+func (u *unit) AddResource(args ResourceArgs) Resource {
+	r := newResource(args)
+	u.Resources_.Resources_ = append(u.Resources_.Resources_, r)
+	return r
+}
+
+func (u *unit) setResources(resourceList []*resource) {
+	u.Resources_ = resources{
+		Version:    1,
+		Resources_: resourceList,
+	}
+}
+
+// Payloads implements Unit.
+func (u *unit) Payloads() []Payload {
+	result := make([]Payload, len(u.Payloads_.Payloads_))
+	for i, p := range u.Payloads_.Payloads_ {
+		result[i] = p
+	}
+	return result
+}
+
+// AddPayload implements Unit.
+func (u *unit) AddPayload(args PayloadArgs) Payload {
+	p := newPayload(args)
+	u.Payloads_.Payloads_ = append(u.Payloads_.Payloads_, p)
+	return p
+}
+
+func (u *unit) setPayloads(payloadList []*payload) {
+	u.Payloads_ = payloads{
+		Version:   1,
+		Payloads_: payloadList,
+	}
+}
+
 // Validate impelements Unit.
 func (u *unit) Validate() error {
 	if u.Name_ == "" {
@@ -211,6 +263,11 @@ func (u *unit) Validate() error {
 	if u.Tools_ == nil {
 		return errors.NotValidf("unit %q missing tools", u.Name_)
 	}
+	for _, r := range u.Resources_.Resources_ {
+		if err := r.Validate(); err != nil {
+			return errors.Annotatef(err, "unit %q", u.Name_)
+		}
+	}
 	return nil
 }
 
@@ -271,12 +328,17 @@ func importUnitV1(source map[string]interface{}) (*unit, error) {
 
 		"meter-status-code": schema.String(),
 		"meter-status-info": schema.String(),
+
+		"resources": schema.StringMap(schema.Any()),
+		"payloads":  schema.StringMap(schema.Any()),
 	}
 	defaults := schema.Defaults{
 		"principal":         "",
 		"subordinates":      schema.Omit,
 		"meter-status-code": "",
 		"meter-status-info": "",
+		"resources":         schema.Omit,
+		"payloads":          schema.Omit,
 	}
 	addAnnotationSchema(fields, defaults)
 	addConstraintsSchema(fields, defaults)
@@ -340,5 +402,23 @@ func importUnitV1(source map[string]interface{}) (*unit, error) {
 	}
 	result.WorkloadStatus_ = workloadStatus
 
+	var resourceList []*resource
+	if resourceMap, ok := valid["resources"]; ok {
+		resourceList, err = importResources(resourceMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "resources")
+		}
+	}
+	result.setResources(resourceList)
+
+	var payloadList []*payload
+	if payloadMap, ok := valid["payloads"]; ok {
+		payloadList, err = importPayloads(payloadMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "payloads")
+		}
+	}
+	result.setPayloads(payloadList)
+
 	return result, nil
 }
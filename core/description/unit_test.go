@@ -38,6 +38,8 @@ func minimalUnitMap() map[interface{}]interface{} {
 		"workload-status-history": emptyStatusHistoryMap(),
 		"password-hash":           "secure-hash",
 		"tools":                   minimalAgentToolsMap(),
+		"resources":               emptyResourcesMap(),
+		"payloads":                emptyPayloadsMap(),
 	}
 }
 
@@ -191,3 +193,24 @@ func (s *UnitSerializationSuite) TestWorkloadStatusHistory(c *gc.C) {
 		c.Check(point.Updated(), gc.Equals, args[i].Updated)
 	}
 }
+
+func (s *UnitSerializationSuite) TestResources(c *gc.C) {
+	initial := minimalUnit()
+	args := ResourceArgs{
+		Name:     "blob",
+		Revision: testResourceRevisionArgs(),
+	}
+	initial.AddResource(args)
+
+	unit := s.exportImport(c, initial)
+	c.Assert(unit.Resources(), jc.DeepEquals, []Resource{newResource(args)})
+}
+
+func (s *UnitSerializationSuite) TestPayloads(c *gc.C) {
+	initial := minimalUnit()
+	args := testPayloadArgs()
+	initial.AddPayload(args)
+
+	unit := s.exportImport(c, initial)
+	c.Assert(unit.Payloads(), jc.DeepEquals, []Payload{newPayload(args)})
+}
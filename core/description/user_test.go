@@ -71,6 +71,14 @@ func (*UserSerializationSuite) TestParsingSerializedData(c *gc.C) {
 				CreatedBy_:   "admin@local",
 				DateCreated_: time.Date(2015, 10, 9, 12, 34, 56, 0, time.UTC),
 				ReadOnly_:    true,
+				Access_:      "read",
+			},
+			&user{
+				Name_:        "writer@local",
+				DisplayName_: "A user with write access",
+				CreatedBy_:   "admin@local",
+				DateCreated_: time.Date(2015, 10, 9, 12, 34, 56, 0, time.UTC),
+				Access_:      "write",
 			},
 		},
 	}
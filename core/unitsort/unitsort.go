@@ -0,0 +1,40 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package unitsort sorts unit names in the order people expect.
+package unitsort
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Sort sorts unit names by service name and then by unit number, so
+// that "mysql/10" follows "mysql/9" rather than "mysql/1".
+func Sort(names []string) {
+	sort.Sort(byNumber(names))
+}
+
+type byNumber []string
+
+func (s byNumber) Len() int      { return len(s) }
+func (s byNumber) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byNumber) Less(i, j int) bool {
+	iService, iNumber := split(s[i])
+	jService, jNumber := split(s[j])
+	if iService != jService {
+		return iService < jService
+	}
+	return iNumber < jNumber
+}
+
+// split returns the service name and unit number of the named unit.
+func split(name string) (string, int) {
+	i := strings.LastIndex(name, "/")
+	n, _ := strconv.Atoi(name[i+1:])
+	if i < 0 {
+		return name, n
+	}
+	return name[:i], n
+}
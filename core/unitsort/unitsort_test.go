@@ -0,0 +1,27 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package unitsort_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/unitsort"
+)
+
+type SortSuite struct{}
+
+var _ = gc.Suite(&SortSuite{})
+
+func (*SortSuite) TestSortByNumber(c *gc.C) {
+	names := []string{"mysql/10", "mysql/2", "mysql/0", "mysql/1"}
+	unitsort.Sort(names)
+	c.Assert(names, jc.DeepEquals, []string{"mysql/0", "mysql/1", "mysql/2", "mysql/10"})
+}
+
+func (*SortSuite) TestSortByService(c *gc.C) {
+	names := []string{"wordpress/0", "mysql/10", "mysql-proxy/1", "mysql/9"}
+	unitsort.Sort(names)
+	c.Assert(names, jc.DeepEquals, []string{"mysql/9", "mysql/10", "mysql-proxy/1", "wordpress/0"})
+}
@@ -197,6 +197,35 @@ const (
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
 
+	// AuditSinkURL sets the destination to which the controller
+	// forwards its audit log. Only the controller model's setting
+	// is used.
+	AuditSinkURL = "audit-sink-url"
+
+	// AuditSinkCACert sets the certificate of the CA that signed the
+	// audit sink's certificate, for sinks reached over TLS.
+	AuditSinkCACert = "audit-sink-ca-cert"
+
+	// AuditLogMaxAge sets how long the controller keeps its audit log.
+	// Only the controller model's setting is used.
+	AuditLogMaxAge = "audit-log-max-age"
+
+	// LogForwardURL sets the syslog server to which the controller
+	// forwards the model's logs.
+	LogForwardURL = "log-forward-url"
+
+	// LogForwardCACert sets the certificate of the CA that signed the
+	// log forwarding syslog server's certificate.
+	LogForwardCACert = "log-forward-ca-cert"
+
+	// MaxLogAge sets how long the controller keeps the model's logs.
+	// It can only shorten the controller-wide limit.
+	MaxLogAge = "max-log-age"
+
+	// MaxLogSize sets the most space the model's logs may use in the
+	// controller's database.
+	MaxLogSize = "max-log-size"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -597,6 +626,60 @@ func Validate(cfg, old *Config) error {
 
 	}
 
+	if v, ok := cfg.defined[AuditSinkURL].(string); ok && v != "" {
+		u, err := url.Parse(v)
+		if err != nil {
+			return fmt.Errorf("invalid audit sink URL: %v", err)
+		}
+		switch u.Scheme {
+		case "syslog+tcp", "syslog+tls", "http", "https":
+		default:
+			return fmt.Errorf("audit sink URL scheme %q not supported, expected syslog+tcp, syslog+tls, http or https", u.Scheme)
+		}
+		if u.Host == "" {
+			return fmt.Errorf("audit sink URL %q has no host", v)
+		}
+	}
+
+	if v, ok := cfg.defined[AuditLogMaxAge].(string); ok && v != "" {
+		if d, err := time.ParseDuration(v); err != nil {
+			return fmt.Errorf("invalid audit log max age: %v", err)
+		} else if d < 0 {
+			return fmt.Errorf("audit log max age %q must not be negative", v)
+		}
+	}
+
+	if v, ok := cfg.defined[LogForwardURL].(string); ok && v != "" {
+		u, err := url.Parse(v)
+		if err != nil {
+			return fmt.Errorf("invalid log forward URL: %v", err)
+		}
+		switch u.Scheme {
+		case "syslog+tcp", "syslog+tls":
+		default:
+			return fmt.Errorf("log forward URL scheme %q not supported, expected syslog+tcp or syslog+tls", u.Scheme)
+		}
+		if u.Host == "" {
+			return fmt.Errorf("log forward URL %q has no host", v)
+		}
+	}
+
+	if v, ok := cfg.defined[MaxLogAge].(string); ok && v != "" {
+		if d, err := time.ParseDuration(v); err != nil {
+			return fmt.Errorf("invalid max log age: %v", err)
+		} else if d <= 0 {
+			return fmt.Errorf("max log age %q must be positive", v)
+		}
+	}
+
+	if v, ok := cfg.defined[MaxLogSize].(string); ok && v != "" {
+		if mb, err := utils.ParseSize(v); err != nil {
+			return fmt.Errorf("invalid max log size: %v", err)
+		} else if mb == 0 {
+			return fmt.Errorf("max log size %q must be at least 1M", v)
+		}
+	}
+
 	if v, ok := cfg.defined[IdentityPublicKey].(string); ok {
 		var key bakery.PublicKey
 		if err := key.UnmarshalText([]byte(v)); err != nil {
@@ -1225,6 +1308,71 @@ func (c *Config) IdentityURL() string {
 	return c.asString(IdentityURL)
 }
 
+// AuditSinkURL returns the URL to which the controller forwards its
+// audit log, or "" if it is not forwarded.
+func (c *Config) AuditSinkURL() string {
+	return c.asString(AuditSinkURL)
+}
+
+// AuditSinkCACert returns the certificate of the CA that signed the
+// audit sink's certificate, in PEM format, and whether the setting is
+// available.
+func (c *Config) AuditSinkCACert() (string, bool) {
+	if s, ok := c.defined[AuditSinkCACert].(string); ok && s != "" {
+		return s, true
+	}
+	return "", false
+}
+
+// AuditLogMaxAge returns how long the controller keeps its audit log.
+// Zero means entries are kept forever.
+func (c *Config) AuditLogMaxAge() time.Duration {
+	if v, ok := c.defined[AuditLogMaxAge].(string); ok && v != "" {
+		// Validate ensures the value parses.
+		d, _ := time.ParseDuration(v)
+		return d
+	}
+	return 0
+}
+
+// LogForwardURL returns the URL of the syslog server to which the
+// controller forwards the model's logs, or "" if they are not forwarded.
+func (c *Config) LogForwardURL() string {
+	return c.asString(LogForwardURL)
+}
+
+// LogForwardCACert returns the certificate of the CA that signed the
+// log forwarding syslog server's certificate, in PEM format, and
+// whether the setting is available.
+func (c *Config) LogForwardCACert() (string, bool) {
+	if s, ok := c.defined[LogForwardCACert].(string); ok && s != "" {
+		return s, true
+	}
+	return "", false
+}
+
+// MaxLogAge returns how long the controller keeps the model's logs,
+// and whether the setting is available.
+func (c *Config) MaxLogAge() (time.Duration, bool) {
+	if v, ok := c.defined[MaxLogAge].(string); ok && v != "" {
+		// Validate ensures the value parses.
+		d, _ := time.ParseDuration(v)
+		return d, true
+	}
+	return 0, false
+}
+
+// MaxLogSizeMB returns the most space, in megabytes, that the model's
+// logs may use, and whether the setting is available.
+func (c *Config) MaxLogSizeMB() (uint64, bool) {
+	if v, ok := c.defined[MaxLogSize].(string); ok && v != "" {
+		// Validate ensures the value parses.
+		mb, _ := utils.ParseSize(v)
+		return mb, true
+	}
+	return 0, false
+}
+
 // IdentityPublicKey returns the public key of the identity manager.
 func (c *Config) IdentityPublicKey() *bakery.PublicKey {
 	key := c.asString(IdentityPublicKey)
@@ -1286,6 +1434,13 @@ var alwaysOptional = schema.Defaults{
 	AgentStreamKey:               schema.Omit,
 	IdentityURL:                  schema.Omit,
 	IdentityPublicKey:            schema.Omit,
+	AuditSinkURL:                 schema.Omit,
+	AuditSinkCACert:              schema.Omit,
+	AuditLogMaxAge:               schema.Omit,
+	LogForwardURL:                schema.Omit,
+	LogForwardCACert:             schema.Omit,
+	MaxLogAge:                    schema.Omit,
+	MaxLogSize:                   schema.Omit,
 	SetNumaControlPolicyKey:      DefaultNumaControlPolicy,
 	AllowLXCLoopMounts:           false,
 	ResourceTagsKey:              schema.Omit,
@@ -1871,4 +2026,39 @@ data of the store. (default false)`,
 		Type:        environschema.Tbool,
 		Group:       environschema.EnvironGroup,
 	},
+	AuditSinkURL: {
+		Description: "The destination to which the controller forwards its audit log: a syslog+tcp:// or syslog+tls:// URL for an RFC5424 syslog server, or an http:// or https:// URL for a webhook. Only the controller model's setting is used.",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	AuditSinkCACert: {
+		Description: "The certificate of the CA that signed the audit sink's certificate, in PEM format.",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	AuditLogMaxAge: {
+		Description: `How long the controller keeps its audit log, as a duration such as "720h". Entries are kept forever if it is unset or zero, and are never removed before they have been forwarded to the audit sink. Only the controller model's setting is used.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogForwardURL: {
+		Description: "The RFC5424 syslog server to which the controller forwards the model's logs, as a syslog+tls:// or syslog+tcp:// URL.",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogForwardCACert: {
+		Description: "The certificate of the CA that signed the log forwarding syslog server's certificate, in PEM format.",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	MaxLogAge: {
+		Description: `How long the controller keeps the model's logs, as a duration such as "72h". Logs are never kept for longer than the controller-wide limit.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	MaxLogSize: {
+		Description: `The most space the model's logs may use in the controller's database, such as "512M" or "2G". The oldest logs are removed first.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 }
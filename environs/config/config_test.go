@@ -809,6 +809,95 @@ var configTests = []configTest{
 			"identity-url":        "https://test-identity",
 			"identity-public-key": "o/yOqSNWncMo1GURWuez/dGR30TscmmuIxgjztpoHEY=",
 		}),
+	}, {
+		about:       "Valid syslog audit sink URL",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"audit-sink-url":     "syslog+tls://siem.example.com:6514",
+			"audit-sink-ca-cert": testing.CACert,
+		}),
+	}, {
+		about:       "Valid webhook audit sink URL",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"audit-sink-url": "https://siem.example.com/audit",
+		}),
+	}, {
+		about:       "Unsupported audit sink URL scheme",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"audit-sink-url": "udp://siem.example.com:514",
+		}),
+		err: `audit sink URL scheme "udp" not supported, expected syslog\+tcp, syslog\+tls, http or https`,
+	}, {
+		about:       "Audit sink URL without a host",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"audit-sink-url": "syslog+tcp:///var/log",
+		}),
+		err: `audit sink URL "syslog\+tcp:///var/log" has no host`,
+	}, {
+		about:       "Valid audit log max age",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"audit-log-max-age": "720h",
+		}),
+	}, {
+		about:       "Negative audit log max age",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"audit-log-max-age": "-1h",
+		}),
+		err: `audit log max age "-1h" must not be negative`,
+	}, {
+		about:       "Valid log forward URL",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"log-forward-url":     "syslog+tls://logs.example.com:6514",
+			"log-forward-ca-cert": testing.CACert,
+		}),
+	}, {
+		about:       "Unsupported log forward URL scheme",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"log-forward-url": "https://logs.example.com/",
+		}),
+		err: `log forward URL scheme "https" not supported, expected syslog\+tcp or syslog\+tls`,
+	}, {
+		about:       "Log forward URL without a host",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"log-forward-url": "syslog+tls:///var/log",
+		}),
+		err: `log forward URL "syslog\+tls:///var/log" has no host`,
+	}, {
+		about:       "Valid log limits",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"max-log-age":  "72h",
+			"max-log-size": "2G",
+		}),
+	}, {
+		about:       "Invalid max log age",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"max-log-age": "3 days",
+		}),
+		err: `invalid max log age: .*`,
+	}, {
+		about:       "Negative max log age",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"max-log-age": "-1h",
+		}),
+		err: `max log age "-1h" must be positive`,
+	}, {
+		about:       "Invalid max log size",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"max-log-size": "lots",
+		}),
+		err: `invalid max log size: .*`,
 	},
 }
 
@@ -1041,6 +1130,25 @@ func (test configTest) check(c *gc.C, home *gitjujutesting.FakeHome) {
 	if expected, ok := test.attrs["controller-uuid"]; ok {
 		c.Assert(cfg.ControllerUUID(), gc.Equals, expected)
 	}
+	if auditSinkURL, ok := test.attrs["audit-sink-url"]; ok {
+		c.Assert(cfg.AuditSinkURL(), gc.Equals, auditSinkURL)
+	}
+	if _, ok := test.attrs["audit-log-max-age"]; ok {
+		c.Assert(cfg.AuditLogMaxAge(), gc.Equals, 720*time.Hour)
+	}
+	if logForwardURL, ok := test.attrs["log-forward-url"]; ok {
+		c.Assert(cfg.LogForwardURL(), gc.Equals, logForwardURL)
+	}
+	if _, ok := test.attrs["max-log-age"]; ok {
+		age, ok := cfg.MaxLogAge()
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(age, gc.Equals, 72*time.Hour)
+	}
+	if _, ok := test.attrs["max-log-size"]; ok {
+		sizeMB, ok := cfg.MaxLogSizeMB()
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(sizeMB, gc.Equals, uint64(2048))
+	}
 	if identityURL, ok := test.attrs["identity-url"]; ok {
 		c.Assert(cfg.IdentityURL(), gc.Equals, identityURL)
 	}
@@ -258,6 +258,28 @@ type Firewaller interface {
 	Ports() ([]network.PortRange, error)
 }
 
+// IngressRulesFirewaller is an optional interface that a Firewaller
+// can implement to restrict the networks from which opened ports may
+// be reached. Firewallers that do not implement it can only open
+// ports to traffic from anywhere.
+type IngressRulesFirewaller interface {
+	// OpenIngressRules opens the given ingress rules for the whole
+	// environment. Must only be used if the environment was setup
+	// with the FwGlobal firewall mode.
+	OpenIngressRules(rules []network.IngressRule) error
+
+	// CloseIngressRules closes the given ingress rules for the whole
+	// environment. Must only be used if the environment was setup
+	// with the FwGlobal firewall mode.
+	CloseIngressRules(rules []network.IngressRule) error
+
+	// IngressRules returns the ingress rules opened for the whole
+	// environment, including those open to traffic from anywhere.
+	// Must only be used if the environment was setup with the
+	// FwGlobal firewall mode.
+	IngressRules() ([]network.IngressRule, error)
+}
+
 // InstanceTagger is an interface that can be used for tagging instances.
 type InstanceTagger interface {
 	// TagInstance tags the given instance with the specified tags.
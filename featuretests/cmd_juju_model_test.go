@@ -96,7 +96,7 @@ func (s *cmdModelSuite) TestModelUsersCmd(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(testing.Stdout(context), gc.Equals, ""+
 		"NAME                 ACCESS  LAST CONNECTION\n"+
-		"admin@local (admin)  write   just now\n"+
+		"admin@local (admin)  admin   just now\n"+
 		"bar@ubuntuone        read    never connected\n"+
 		"\n")
 
@@ -0,0 +1,157 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"runtime"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// GoroutinesPath is the final element of the path on which the
+// handler serves a dump of the stacks of all goroutines.
+const GoroutinesPath = "goroutines"
+
+// NewHandler returns an http.Handler that serves the registry's
+// reports. It is expected to be mounted on a path ending in "/"; the
+// final element of each request's path chooses what is served:
+//
+//   - nothing, for the names registered under each kind;
+//   - a kind, such as "depengine", for the reports of that kind,
+//     by name, optionally restricted to the name given in the
+//     "name" query parameter;
+//   - "goroutines", for the stacks of all goroutines.
+//
+// Reports are rendered as YAML, or as JSON if the "format" query
+// parameter is "json".
+func NewHandler(registry *Registry) http.Handler {
+	return &handler{registry}
+}
+
+type handler struct {
+	registry *Registry
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var format string
+	switch format = req.URL.Query().Get("format"); format {
+	case "", "yaml", "json":
+	default:
+		http.Error(w, fmt.Sprintf("format %q not supported", format), http.StatusBadRequest)
+		return
+	}
+
+	var result interface{}
+	switch kind := path.Base(req.URL.Path); kind {
+	case "/", ".", "introspection":
+		result = h.registry.Names()
+	case GoroutinesPath:
+		result = goroutines()
+	case KindEngine, KindLeases, KindPresence:
+		reporters := h.registry.Reporters(kind)
+		if name := req.URL.Query().Get("name"); name != "" {
+			reporter, ok := reporters[name]
+			if !ok {
+				http.Error(w, fmt.Sprintf("%s %q not found", kind, name), http.StatusNotFound)
+				return
+			}
+			reporters = map[string]Reporter{name: reporter}
+		}
+		reports := make(map[string]interface{})
+		for name, reporter := range reporters {
+			reports[name] = reporter.Report()
+		}
+		result = reports
+	default:
+		http.Error(w, fmt.Sprintf("report %q not found", kind), http.StatusNotFound)
+		return
+	}
+	writeResult(w, format, plain(result))
+}
+
+// writeResult renders the result in the given format.
+func writeResult(w http.ResponseWriter, format string, result interface{}) {
+	var data []byte
+	var err error
+	if format == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		data, err = json.MarshalIndent(result, "", "  ")
+		data = append(data, '\n')
+	} else {
+		w.Header().Set("Content-Type", "application/x-yaml")
+		data, err = yaml.Marshal(result)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Write(data)
+}
+
+// goroutines returns the number of goroutines, and a dump of all their
+// stacks.
+func goroutines() map[string]interface{} {
+	buf := make([]byte, 64*1024)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+	return map[string]interface{}{
+		"count":  runtime.NumGoroutine(),
+		"stacks": string(buf),
+	}
+}
+
+// plain converts a report into values that render sensibly as both
+// YAML and JSON: errors and times become strings, and maps and slices
+// are converted recursively.
+func plain(value interface{}) interface{} {
+	switch value := value.(type) {
+	case nil:
+		return nil
+	case error:
+		return value.Error()
+	case time.Time:
+		return value.Format(time.RFC3339Nano)
+	case time.Duration:
+		return value.String()
+	case string, bool, int, int64, float64:
+		return value
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Map:
+		result := make(map[string]interface{}, v.Len())
+		for _, key := range v.MapKeys() {
+			result[fmt.Sprint(key.Interface())] = plain(v.MapIndex(key).Interface())
+		}
+		return result
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		result := make([]interface{}, v.Len())
+		for i := range result {
+			result[i] = plain(v.Index(i).Interface())
+		}
+		return result
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return plain(v.Elem().Interface())
+	}
+	return value
+}
@@ -0,0 +1,165 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/introspection"
+)
+
+type HandlerSuite struct {
+	testing.IsolationSuite
+	handler http.Handler
+}
+
+var _ = gc.Suite(&HandlerSuite{})
+
+func (s *HandlerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	registry := introspection.NewRegistry()
+	registry.Register(introspection.KindEngine, "machine-0", introspection.ReporterFunc(
+		func() map[string]interface{} {
+			return map[string]interface{}{
+				"state": "started",
+				"error": nil,
+				"manifolds": map[string]interface{}{
+					"api-caller": map[string]interface{}{
+						"state":  "stopped",
+						"error":  errors.New("connection refused"),
+						"inputs": []string{"agent"},
+					},
+				},
+			}
+		},
+	))
+	registry.Register(introspection.KindEngine, "unit-mysql-0", introspection.ReporterFunc(
+		func() map[string]interface{} {
+			return map[string]interface{}{"state": "stopping"}
+		},
+	))
+	registry.Register(introspection.KindLeases, "deadbeef", introspection.ReporterFunc(
+		func() map[string]interface{} {
+			return map[string]interface{}{
+				"expiry": time.Date(2016, time.June, 15, 10, 30, 0, 0, time.UTC),
+			}
+		},
+	))
+	s.handler = introspection.NewHandler(registry)
+}
+
+func (s *HandlerSuite) get(c *gc.C, url string) *httptest.ResponseRecorder {
+	req, err := http.NewRequest("GET", url, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	recorder := httptest.NewRecorder()
+	s.handler.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func (s *HandlerSuite) getYAML(c *gc.C, url string) interface{} {
+	recorder := s.get(c, url)
+	c.Assert(recorder.Code, gc.Equals, http.StatusOK)
+	c.Check(recorder.Header().Get("Content-Type"), gc.Equals, "application/x-yaml")
+	var result interface{}
+	err := yaml.Unmarshal(recorder.Body.Bytes(), &result)
+	c.Assert(err, jc.ErrorIsNil)
+	return result
+}
+
+func (s *HandlerSuite) TestIndex(c *gc.C) {
+	result := s.getYAML(c, "/introspection/")
+	c.Check(result, jc.DeepEquals, map[interface{}]interface{}{
+		"depengine": []interface{}{"machine-0", "unit-mysql-0"},
+		"leases":    []interface{}{"deadbeef"},
+	})
+}
+
+func (s *HandlerSuite) TestEngineYAML(c *gc.C) {
+	result := s.getYAML(c, "/introspection/depengine")
+	c.Check(result, jc.DeepEquals, map[interface{}]interface{}{
+		"machine-0": map[interface{}]interface{}{
+			"state": "started",
+			"error": nil,
+			"manifolds": map[interface{}]interface{}{
+				"api-caller": map[interface{}]interface{}{
+					"state":  "stopped",
+					"error":  "connection refused",
+					"inputs": []interface{}{"agent"},
+				},
+			},
+		},
+		"unit-mysql-0": map[interface{}]interface{}{
+			"state": "stopping",
+		},
+	})
+}
+
+func (s *HandlerSuite) TestEngineJSONByName(c *gc.C) {
+	recorder := s.get(c, "/introspection/depengine?name=unit-mysql-0&format=json")
+	c.Assert(recorder.Code, gc.Equals, http.StatusOK)
+	c.Check(recorder.Header().Get("Content-Type"), gc.Equals, "application/json")
+	var result interface{}
+	err := json.Unmarshal(recorder.Body.Bytes(), &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, map[string]interface{}{
+		"unit-mysql-0": map[string]interface{}{
+			"state": "stopping",
+		},
+	})
+}
+
+func (s *HandlerSuite) TestLeasesTimes(c *gc.C) {
+	result := s.getYAML(c, "/introspection/leases")
+	c.Check(result, jc.DeepEquals, map[interface{}]interface{}{
+		"deadbeef": map[interface{}]interface{}{
+			"expiry": "2016-06-15T10:30:00Z",
+		},
+	})
+}
+
+func (s *HandlerSuite) TestPresenceEmpty(c *gc.C) {
+	result := s.getYAML(c, "/introspection/presence")
+	c.Check(result, jc.DeepEquals, map[interface{}]interface{}{})
+}
+
+func (s *HandlerSuite) TestGoroutines(c *gc.C) {
+	recorder := s.get(c, "/introspection/goroutines?format=json")
+	c.Assert(recorder.Code, gc.Equals, http.StatusOK)
+	var result struct {
+		Count  int    `json:"count"`
+		Stacks string `json:"stacks"`
+	}
+	err := json.Unmarshal(recorder.Body.Bytes(), &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Count > 0, jc.IsTrue)
+	c.Check(strings.Contains(result.Stacks, "TestGoroutines"), jc.IsTrue)
+}
+
+func (s *HandlerSuite) TestNameNotFound(c *gc.C) {
+	recorder := s.get(c, "/introspection/depengine?name=machine-1")
+	c.Check(recorder.Code, gc.Equals, http.StatusNotFound)
+	c.Check(recorder.Body.String(), gc.Equals, "depengine \"machine-1\" not found\n")
+}
+
+func (s *HandlerSuite) TestReportNotFound(c *gc.C) {
+	recorder := s.get(c, "/introspection/bananas")
+	c.Check(recorder.Code, gc.Equals, http.StatusNotFound)
+	c.Check(recorder.Body.String(), gc.Equals, "report \"bananas\" not found\n")
+}
+
+func (s *HandlerSuite) TestFormatNotSupported(c *gc.C) {
+	recorder := s.get(c, "/introspection/depengine?format=xml")
+	c.Check(recorder.Code, gc.Equals, http.StatusBadRequest)
+	c.Check(recorder.Body.String(), gc.Equals, "format \"xml\" not supported\n")
+}
@@ -0,0 +1,121 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package introspection collects reports describing the internal state
+// of a running agent, and serves them over HTTP so that they can be
+// inspected on the agent's introspection socket.
+package introspection
+
+import (
+	"sort"
+	"sync"
+)
+
+// The Kind constants name the sorts of report that may be registered.
+const (
+	// KindEngine reports come from dependency engines.
+	KindEngine = "depengine"
+
+	// KindLeases reports describe the leases held in a model.
+	KindLeases = "leases"
+
+	// KindPresence reports describe the agents alive in a model.
+	KindPresence = "presence"
+)
+
+// Reporter describes the state of some component.
+type Reporter interface {
+
+	// Report returns a map describing the state of the receiver. It
+	// must be goroutine-safe.
+	Report() map[string]interface{}
+}
+
+// ReporterFunc adapts a func to the Reporter interface.
+type ReporterFunc func() map[string]interface{}
+
+// Report is part of the Reporter interface.
+func (f ReporterFunc) Report() map[string]interface{} {
+	return f()
+}
+
+// Registry holds the reporters known to a process, by kind and name.
+type Registry struct {
+	mu        sync.Mutex
+	reporters map[string]map[string]*registration
+}
+
+// registration records a registered reporter; it lets a registration
+// be identified even when its reporter is not comparable.
+type registration struct {
+	reporter Reporter
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		reporters: make(map[string]map[string]*registration),
+	}
+}
+
+// Register adds a reporter of the given kind under the given name,
+// replacing any already registered there. It returns a func that
+// removes the registration, if it has not since been replaced.
+func (r *Registry) Register(kind, name string, reporter Reporter) func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	named := r.reporters[kind]
+	if named == nil {
+		named = make(map[string]*registration)
+		r.reporters[kind] = named
+	}
+	reg := &registration{reporter}
+	named[name] = reg
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if r.reporters[kind][name] == reg {
+			delete(r.reporters[kind], name)
+		}
+	}
+}
+
+// Names returns the sorted names registered under each kind.
+func (r *Registry) Names() map[string][]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := make(map[string][]string)
+	for kind, named := range r.reporters {
+		if len(named) == 0 {
+			continue
+		}
+		names := make([]string, 0, len(named))
+		for name := range named {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		result[kind] = names
+	}
+	return result
+}
+
+// Reporters returns the reporters registered under the given kind, by
+// name.
+func (r *Registry) Reporters(kind string) map[string]Reporter {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := make(map[string]Reporter)
+	for name, reg := range r.reporters[kind] {
+		result[name] = reg.reporter
+	}
+	return result
+}
+
+// DefaultRegistry is the registry served on the agent's introspection
+// socket.
+var DefaultRegistry = NewRegistry()
+
+// Register adds a reporter to the DefaultRegistry; see Registry.Register.
+func Register(kind, name string, reporter Reporter) func() {
+	return DefaultRegistry.Register(kind, name, reporter)
+}
@@ -0,0 +1,64 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/introspection"
+)
+
+type RegistrySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&RegistrySuite{})
+
+func (*RegistrySuite) TestRegister(c *gc.C) {
+	registry := introspection.NewRegistry()
+	registry.Register(introspection.KindEngine, "machine-0", fixedReport("m0"))
+	registry.Register(introspection.KindEngine, "model-abc", fixedReport("abc"))
+	registry.Register(introspection.KindLeases, "abc", fixedReport("leases"))
+
+	c.Check(registry.Names(), jc.DeepEquals, map[string][]string{
+		"depengine": {"machine-0", "model-abc"},
+		"leases":    {"abc"},
+	})
+	reporters := registry.Reporters(introspection.KindEngine)
+	c.Assert(reporters, gc.HasLen, 2)
+	c.Check(reporters["model-abc"].Report(), jc.DeepEquals, map[string]interface{}{
+		"value": "abc",
+	})
+	c.Check(registry.Reporters(introspection.KindPresence), gc.HasLen, 0)
+}
+
+func (*RegistrySuite) TestUnregister(c *gc.C) {
+	registry := introspection.NewRegistry()
+	unregister := registry.Register(introspection.KindEngine, "machine-0", fixedReport("m0"))
+	unregister()
+	c.Check(registry.Names(), gc.HasLen, 0)
+	c.Check(registry.Reporters(introspection.KindEngine), gc.HasLen, 0)
+}
+
+func (*RegistrySuite) TestUnregisterReplaced(c *gc.C) {
+	registry := introspection.NewRegistry()
+	unregister := registry.Register(introspection.KindEngine, "machine-0", fixedReport("old"))
+	registry.Register(introspection.KindEngine, "machine-0", fixedReport("new"))
+
+	// Removing the old registration leaves the new one in place.
+	unregister()
+	reporters := registry.Reporters(introspection.KindEngine)
+	c.Assert(reporters, gc.HasLen, 1)
+	c.Check(reporters["machine-0"].Report(), jc.DeepEquals, map[string]interface{}{
+		"value": "new",
+	})
+}
+
+func fixedReport(value string) introspection.Reporter {
+	return introspection.ReporterFunc(func() map[string]interface{} {
+		return map[string]interface{}{"value": value}
+	})
+}
@@ -0,0 +1,36 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package permission_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/juju/permission"
+)
+
+type controllerPermissionSuite struct{}
+
+var _ = gc.Suite(&controllerPermissionSuite{})
+
+func (s *controllerPermissionSuite) TestParseControllerAccessValid(c *gc.C) {
+	for access, expected := range map[string]permission.ControllerAccess{
+		"login":     permission.ControllerLoginAccess,
+		"add-model": permission.ControllerAddModelAccess,
+		"superuser": permission.ControllerSuperuserAccess,
+	} {
+		result, err := permission.ParseControllerAccess(access)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(result, gc.Equals, expected)
+		c.Check(permission.IsControllerAccess(access), jc.IsTrue)
+	}
+}
+
+func (s *controllerPermissionSuite) TestParseControllerAccessInvalid(c *gc.C) {
+	for _, access := range []string{"", "read", "write", "admin", "preposterous"} {
+		_, err := permission.ParseControllerAccess(access)
+		c.Check(err, gc.ErrorMatches, "invalid controller access permission.*")
+		c.Check(permission.IsControllerAccess(access), jc.IsFalse)
+	}
+}
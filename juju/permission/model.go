@@ -16,8 +16,12 @@ const (
 	// ModelReadAccess allows a user to read a model but not to change it.
 	ModelReadAccess ModelAccess = iota
 
-	// ModelWriteAccess allows a user write access to the model.
+	// ModelWriteAccess allows a user write access to the model, without
+	// being able to manage the model's users or destroy it.
 	ModelWriteAccess ModelAccess = iota
+
+	// ModelAdminAccess allows a user full control over the model.
+	ModelAdminAccess ModelAccess = iota
 )
 
 // ParseModelAccess parses a user-facing string representation of a model
@@ -29,6 +33,8 @@ func ParseModelAccess(access string) (ModelAccess, error) {
 		return ModelReadAccess, nil
 	case "write":
 		return ModelWriteAccess, nil
+	case "admin":
+		return ModelAdminAccess, nil
 	default:
 		return fail, errors.Errorf("invalid model access permission %q", access)
 	}
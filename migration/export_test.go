@@ -7,4 +7,5 @@ var (
 	ControllerValues         = controllerValues
 	UpdateConfigFromProvider = updateConfigFromProvider
 	GetCharmStoragePath      = getCharmStoragePath
+	KnownClouds              = &knownClouds
 )
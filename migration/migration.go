@@ -7,6 +7,7 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"sort"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -16,9 +17,13 @@ import (
 	"gopkg.in/mgo.v2"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/resource"
+	resourceclient "github.com/juju/juju/resource/api/client"
+	resourceserver "github.com/juju/juju/resource/api/server"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/binarystorage"
 	"github.com/juju/juju/state/storage"
@@ -123,6 +128,7 @@ type UploadBackend interface {
 	ModelUUID() string
 	MongoSession() *mgo.Session
 	ToolsStorage() (binarystorage.StorageCloser, error)
+	Resources() (state.Resources, error)
 }
 
 // CharmUploader defines a simple single method interface that is used to
@@ -137,6 +143,19 @@ type ToolsUploader interface {
 	UploadTools(io.ReadSeeker, version.Binary, ...string) (tools.List, error)
 }
 
+// ResourceUploader defines a simple single method interface that is used
+// to upload resource blobs to the target controller. The resource metadata
+// is imported with the model, so only the blob is sent.
+type ResourceUploader interface {
+	UploadResource(resource.Resource, io.ReadSeeker) error
+}
+
+// ResourceDownloader defines a simple single method interface that is used
+// to read the resource blobs from the source controller.
+type ResourceDownloader interface {
+	OpenResource(serviceID, name string) (resource.Resource, io.ReadCloser, error)
+}
+
 // UploadBinariesConfig provides all the configuration that the UploadBinaries
 // function needs to operate. The functions are configurable for testing
 // purposes. To construct the config with the default functions, use
@@ -146,8 +165,11 @@ type UploadBinariesConfig struct {
 	Model  description.Model
 	Target api.Connection
 
-	GetCharmUploader func(api.Connection) CharmUploader
-	GetToolsUploader func(api.Connection) ToolsUploader
+	GetCharmUploader    func(api.Connection) CharmUploader
+	GetToolsUploader    func(api.Connection) ToolsUploader
+	GetResourceUploader func(api.Connection) (ResourceUploader, error)
+
+	GetResourceDownloader func(UploadBackend) (ResourceDownloader, error)
 
 	GetStateStorage     func(UploadBackend) storage.Storage
 	GetCharmStoragePath func(UploadBackend, *charm.URL) (string, error)
@@ -162,10 +184,12 @@ func NewUploadBinariesConfig(backend UploadBackend, model description.Model, tar
 		Model:  model,
 		Target: target,
 
-		GetCharmUploader:    getCharmUploader,
-		GetStateStorage:     getStateStorage,
-		GetToolsUploader:    getToolsUploader,
-		GetCharmStoragePath: getCharmStoragePath,
+		GetCharmUploader:      getCharmUploader,
+		GetStateStorage:       getStateStorage,
+		GetToolsUploader:      getToolsUploader,
+		GetCharmStoragePath:   getCharmStoragePath,
+		GetResourceUploader:   getResourceUploader,
+		GetResourceDownloader: getResourceDownloader,
 	}
 }
 
@@ -192,6 +216,12 @@ func (c *UploadBinariesConfig) Validate() error {
 	if c.GetCharmStoragePath == nil {
 		return errors.NotValidf("missing GetCharmStoragePath")
 	}
+	if c.GetResourceUploader == nil {
+		return errors.NotValidf("missing GetResourceUploader")
+	}
+	if c.GetResourceDownloader == nil {
+		return errors.NotValidf("missing GetResourceDownloader")
+	}
 	return nil
 }
 
@@ -209,6 +239,10 @@ func UploadBinaries(config UploadBinariesConfig) error {
 		return errors.Trace(err)
 	}
 
+	if err := uploadResources(config); err != nil {
+		return errors.Trace(err)
+	}
+
 	return nil
 }
 
@@ -224,6 +258,23 @@ func getCharmUploader(target api.Connection) CharmUploader {
 	return target.Client()
 }
 
+func getResourceUploader(target api.Connection) (ResourceUploader, error) {
+	caller := base.NewFacadeCallerForVersion(target, resource.ComponentName, resourceserver.Version)
+	httpClient, err := target.HTTPClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return resourceclient.NewClient(caller, httpClient, target), nil
+}
+
+func getResourceDownloader(backend UploadBackend) (ResourceDownloader, error) {
+	resources, err := backend.Resources()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return resources, nil
+}
+
 func uploadTools(config UploadBinariesConfig) error {
 	storage, err := config.State.ToolsStorage()
 	if err != nil {
@@ -345,6 +396,51 @@ func uploadCharms(config UploadBinariesConfig) error {
 	return nil
 }
 
+func uploadResources(config UploadBinariesConfig) error {
+	downloader, err := config.GetResourceDownloader(config.State)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	uploader, err := config.GetResourceUploader(config.Target)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, service := range config.Model.Services() {
+		for _, res := range service.Resources() {
+			// Placeholder resources have no data to send.
+			if res.Revision().Timestamp().IsZero() {
+				continue
+			}
+			if err := uploadResource(downloader, uploader, service.Name(), res.Name()); err != nil {
+				return errors.Annotatef(err, "resource %q of service %q", res.Name(), service.Name())
+			}
+		}
+	}
+	return nil
+}
+
+func uploadResource(downloader ResourceDownloader, uploader ResourceUploader, serviceID, name string) error {
+	logger.Debugf("send resource %s/%s to target", serviceID, name)
+
+	res, reader, err := downloader.OpenResource(serviceID, name)
+	if err != nil {
+		return errors.Annotate(err, "cannot get resource from storage")
+	}
+	defer reader.Close()
+
+	content, cleanup, err := streamThroughTempFile(reader)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer cleanup()
+
+	if err := uploader.UploadResource(res, content); err != nil {
+		return errors.Annotate(err, "cannot upload resource")
+	}
+	return nil
+}
+
 func getUsedCharms(model description.Model) set.Strings {
 	result := set.NewStrings()
 	for _, service := range model.Services() {
@@ -362,6 +458,44 @@ func getCharmStoragePath(st UploadBackend, curl *charm.URL) (string, error) {
 	return ch.StoragePath(), nil
 }
 
+// MissingBinaries returns the charms and tools used by the model for
+// st that have not been uploaded to it. A model is not usable until
+// they are all present.
+func MissingBinaries(st *state.State) ([]string, error) {
+	model, err := st.Export()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	storage, err := st.ToolsStorage()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer storage.Close()
+
+	var missing []string
+	for _, charmURL := range getUsedCharms(model).SortedValues() {
+		curl, err := charm.ParseURL(charmURL)
+		if err != nil {
+			return nil, errors.Annotate(err, "bad charm URL")
+		}
+		if _, err := st.Charm(curl); errors.IsNotFound(err) {
+			missing = append(missing, "charm "+charmURL)
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	var tools []string
+	for toolsVersion := range getUsedToolsVersions(model) {
+		if _, err := storage.Metadata(toolsVersion.String()); errors.IsNotFound(err) {
+			tools = append(tools, "tools "+toolsVersion.String())
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	sort.Strings(tools)
+	return append(missing, tools...), nil
+}
+
 // PrecheckBackend is implemented by *state.State but defined as an interface
 // for easier testing.
 type PrecheckBackend interface {
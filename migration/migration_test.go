@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names"
@@ -16,9 +17,11 @@ import (
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 	"gopkg.in/mgo.v2"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/environs"
@@ -26,6 +29,7 @@ import (
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/provider/dummy"
 	_ "github.com/juju/juju/provider/dummy"
+	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/binarystorage"
 	"github.com/juju/juju/state/storage"
@@ -147,6 +151,12 @@ func (s *ImportSuite) TestUploadBinariesTools(c *gc.C) {
 		},
 		GetStateStorage:     func(migration.UploadBackend) storage.Storage { return &fakeCharmsStorage{} },
 		GetCharmStoragePath: func(migration.UploadBackend, *charm.URL) (string, error) { return "", nil },
+		GetResourceUploader: func(api.Connection) (migration.ResourceUploader, error) {
+			return &noOpUploader{}, nil
+		},
+		GetResourceDownloader: func(migration.UploadBackend) (migration.ResourceDownloader, error) {
+			return &fakeResourceDownloader{}, nil
+		},
 	}
 	err := migration.UploadBinaries(config)
 	c.Assert(err, jc.ErrorIsNil)
@@ -182,6 +192,12 @@ func (s *ImportSuite) TestStreamCharmsTools(c *gc.C) {
 		GetCharmStoragePath: func(_ migration.UploadBackend, u *charm.URL) (string, error) {
 			return "/path/for/" + u.String(), nil
 		},
+		GetResourceUploader: func(api.Connection) (migration.ResourceUploader, error) {
+			return &noOpUploader{}, nil
+		},
+		GetResourceDownloader: func(migration.UploadBackend) (migration.ResourceDownloader, error) {
+			return &fakeResourceDownloader{}, nil
+		},
 	}
 	err := migration.UploadBinaries(config)
 	c.Assert(err, jc.ErrorIsNil)
@@ -192,6 +208,58 @@ func (s *ImportSuite) TestStreamCharmsTools(c *gc.C) {
 	})
 }
 
+func (s *ImportSuite) TestStreamResources(c *gc.C) {
+	model := description.NewModel(description.ModelArgs{
+		Owner: names.NewUserTag("me"),
+	})
+	service := model.AddService(description.ServiceArgs{
+		Tag:      names.NewServiceTag("magic"),
+		CharmURL: "local:trusty/magic",
+	})
+	service.AddResource(description.ResourceArgs{
+		Name: "blob",
+		Revision: description.ResourceRevisionArgs{
+			Type:      "file",
+			Path:      "blob.tgz",
+			Origin:    "upload",
+			Timestamp: time.Now(),
+			Username:  "bob",
+		},
+	})
+	// Placeholders have no data, so there is nothing to upload.
+	service.AddResource(description.ResourceArgs{
+		Name: "placeholder",
+		Revision: description.ResourceRevisionArgs{
+			Type:   "file",
+			Path:   "placeholder.tgz",
+			Origin: "upload",
+		},
+	})
+
+	uploader := &fakeUploader{resources: make(map[string]string)}
+	config := migration.UploadBinariesConfig{
+		State:               &fakeStateStorage{},
+		Model:               model,
+		Target:              &fakeAPIConnection{},
+		GetCharmUploader:    func(api.Connection) migration.CharmUploader { return &noOpUploader{} },
+		GetToolsUploader:    func(target api.Connection) migration.ToolsUploader { return &noOpUploader{} },
+		GetStateStorage:     func(migration.UploadBackend) storage.Storage { return &fakeCharmsStorage{} },
+		GetCharmStoragePath: func(migration.UploadBackend, *charm.URL) (string, error) { return "", nil },
+		GetResourceUploader: func(api.Connection) (migration.ResourceUploader, error) {
+			return uploader, nil
+		},
+		GetResourceDownloader: func(migration.UploadBackend) (migration.ResourceDownloader, error) {
+			return &fakeResourceDownloader{}, nil
+		},
+	}
+	err := migration.UploadBinaries(config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(uploader.resources, jc.DeepEquals, map[string]string{
+		"magic/blob": "fake resource magic/blob",
+	})
+}
+
 type fakeStateStorage struct {
 	tools  fakeToolsStorage
 	charms fakeCharmsStorage
@@ -226,6 +294,23 @@ func (f *fakeStateStorage) Charm(*charm.URL) (*state.Charm, error) {
 	return nil, nil
 }
 
+func (f *fakeStateStorage) Resources() (state.Resources, error) {
+	return nil, nil
+}
+
+type fakeResourceDownloader struct{}
+
+func (*fakeResourceDownloader) OpenResource(serviceID, name string) (resource.Resource, io.ReadCloser, error) {
+	res := resource.Resource{
+		Resource: charmresource.Resource{
+			Meta: charmresource.Meta{Name: name},
+		},
+		ServiceID: serviceID,
+	}
+	buff := bytes.NewBufferString(fmt.Sprintf("fake resource %s/%s", serviceID, name))
+	return res, ioutil.NopCloser(buff), nil
+}
+
 func (f *fakeToolsStorage) Open(v string) (binarystorage.Metadata, io.ReadCloser, error) {
 	buff := bytes.NewBufferString(fmt.Sprintf("fake tools %s", v))
 	return binarystorage.Metadata{}, ioutil.NopCloser(buff), nil
@@ -242,8 +327,9 @@ func (f *fakeCharmsStorage) Get(path string) (io.ReadCloser, int64, error) {
 }
 
 type fakeUploader struct {
-	tools  map[version.Binary]string
-	charms map[string]string
+	tools     map[version.Binary]string
+	charms    map[string]string
+	resources map[string]string
 }
 
 func (f *fakeUploader) UploadTools(r io.ReadSeeker, v version.Binary, _ ...string) (tools.List, error) {
@@ -270,6 +356,16 @@ func (f *fakeUploader) UploadCharm(u *charm.URL, r io.ReadSeeker) (*charm.URL, e
 	return u, nil
 }
 
+func (f *fakeUploader) UploadResource(res resource.Resource, r io.ReadSeeker) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	f.resources[res.ServiceID+"/"+res.Name] = string(data)
+	return nil
+}
+
 type noOpUploader struct{}
 
 func (*noOpUploader) UploadCharm(*charm.URL, io.ReadSeeker) (*charm.URL, error) {
@@ -280,6 +376,167 @@ func (*noOpUploader) UploadTools(io.ReadSeeker, version.Binary, ...string) (tool
 	return nil, nil
 }
 
+func (*noOpUploader) UploadResource(resource.Resource, io.ReadSeeker) error {
+	return nil
+}
+
+func (s *ImportSuite) exportWithConfig(c *gc.C, attrs map[string]interface{}) []byte {
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	model.UpdateConfig(attrs)
+	bytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+	return bytes
+}
+
+func (s *ImportSuite) TestValidateImport(c *gc.C) {
+	uuid := utils.MustNewUUID().String()
+	bytes := s.exportWithConfig(c, map[string]interface{}{
+		"name": "new-model",
+		"uuid": uuid,
+	})
+
+	result, err := migration.ValidateImport(s.State, bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Problems, gc.HasLen, 0)
+	c.Assert(result.Missing, gc.HasLen, 0)
+
+	// Nothing is written to the controller.
+	_, err = s.State.GetModel(names.NewModelTag(uuid))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ImportSuite) TestValidateImportMissingUser(c *gc.C) {
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	model.UpdateConfig(map[string]interface{}{
+		"name": "new-model",
+		"uuid": utils.MustNewUUID().String(),
+	})
+	model.AddUser(description.UserArgs{
+		Name:        names.NewUserTag("nobody@local"),
+		CreatedBy:   s.Owner,
+		DateCreated: time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	model.AddUser(description.UserArgs{
+		Name:        names.NewUserTag("elsewhere@external"),
+		CreatedBy:   s.Owner,
+		DateCreated: time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	bytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := migration.ValidateImport(s.State, bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Problems, jc.DeepEquals, []string{
+		`user "nobody@local" does not exist in the controller`,
+	})
+}
+
+func (s *ImportSuite) TestValidateImportListsBinaries(c *gc.C) {
+	service := s.Factory.MakeService(c, nil)
+	curl, _ := service.CharmURL()
+	bytes := s.exportWithConfig(c, map[string]interface{}{
+		"name": "new-model",
+		"uuid": utils.MustNewUUID().String(),
+	})
+
+	result, err := migration.ValidateImport(s.State, bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Problems, gc.HasLen, 0)
+	c.Assert(result.Missing, jc.DeepEquals, []string{"charm " + curl.String()})
+}
+
+func (s *ImportSuite) TestValidateImportExistingModel(c *gc.C) {
+	bytes, err := migration.ExportModel(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := migration.ValidateImport(s.State, bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Problems, jc.DeepEquals, []string{
+		fmt.Sprintf("model with UUID %q already exists", s.State.ModelUUID()),
+	})
+
+	// The existing model is untouched.
+	_, err = s.State.GetModel(s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ImportSuite) TestValidateImportNameConflict(c *gc.C) {
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	bytes := s.exportWithConfig(c, map[string]interface{}{
+		"uuid": utils.MustNewUUID().String(),
+	})
+
+	result, err := migration.ValidateImport(s.State, bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Problems, jc.DeepEquals, []string{
+		fmt.Sprintf("model %q for %s already exists", model.Name(), model.Owner().Canonical()),
+	})
+}
+
+func (s *ImportSuite) TestValidateImportUnknownProvider(c *gc.C) {
+	bytes := s.exportWithConfig(c, map[string]interface{}{
+		"name": "new-model",
+		"uuid": utils.MustNewUUID().String(),
+		"type": "nope",
+	})
+
+	result, err := migration.ValidateImport(s.State, bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Problems, jc.DeepEquals, []string{
+		`provider "nope" is not supported by the controller`,
+	})
+}
+
+func (s *ImportSuite) TestValidateImportUnknownRegion(c *gc.C) {
+	s.PatchValue(migration.KnownClouds, func() (map[string]cloud.Cloud, error) {
+		return map[string]cloud.Cloud{
+			"dummy-cloud": {
+				Type:    "dummy",
+				Regions: []cloud.Region{{Name: "dummy-region"}},
+			},
+		}, nil
+	})
+	bytes := s.exportWithConfig(c, map[string]interface{}{
+		"name":   "new-model",
+		"uuid":   utils.MustNewUUID().String(),
+		"region": "nowhere",
+	})
+
+	result, err := migration.ValidateImport(s.State, bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Problems, jc.DeepEquals, []string{
+		`cloud region "nowhere" is not known for provider "dummy"`,
+	})
+}
+
+func (s *ImportSuite) TestValidateImportKnownRegion(c *gc.C) {
+	s.PatchValue(migration.KnownClouds, func() (map[string]cloud.Cloud, error) {
+		return map[string]cloud.Cloud{
+			"dummy-cloud": {
+				Type:    "dummy",
+				Regions: []cloud.Region{{Name: "dummy-region"}},
+			},
+		}, nil
+	})
+	bytes := s.exportWithConfig(c, map[string]interface{}{
+		"name":   "new-model",
+		"uuid":   utils.MustNewUUID().String(),
+		"region": "dummy-region",
+	})
+
+	result, err := migration.ValidateImport(s.State, bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Problems, gc.HasLen, 0)
+}
+
+func (s *ImportSuite) TestValidateImportBadBytes(c *gc.C) {
+	_, err := migration.ValidateImport(s.State, []byte("not a model"))
+	c.Assert(err, gc.ErrorMatches, "yaml: unmarshal errors:\n.*")
+}
+
 type ExportSuite struct {
 	statetesting.StateSuite
 }
@@ -0,0 +1,178 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/state"
+	jujuversion "github.com/juju/juju/version"
+)
+
+// ImportValidation holds the outcome of checking a serialized model
+// against a controller.
+type ImportValidation struct {
+	// Problems describes the reasons the model could not be imported
+	// into the controller.
+	Problems []string
+
+	// Missing describes the charms and tools used by the model. The
+	// controller holds charms and tools per model, so none of them are
+	// available to a model that does not exist yet; a migration uploads
+	// them from the source controller.
+	Missing []string
+}
+
+// knownClouds returns the cloud definitions that the controller checks
+// model regions against.
+var knownClouds = func() (map[string]cloud.Cloud, error) {
+	clouds, _, err := cloud.PublicCloudMetadata()
+	return clouds, err
+}
+
+// ValidateImport checks whether the serialized model could be imported
+// into the controller for st. The checks cover model name and UUID
+// conflicts, the model's local users, the provider type, the cloud
+// region, the tools versions and the charms in use. The model is only
+// checked in memory; nothing is written to the controller.
+func ValidateImport(st *state.State, bytes []byte) (*ImportValidation, error) {
+	model, err := description.Deserialize(bytes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := &ImportValidation{}
+	problemf := func(format string, args ...interface{}) {
+		result.Problems = append(result.Problems, fmt.Sprintf(format, args...))
+	}
+
+	if err := checkModelConflicts(st, model, problemf); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if err := checkUsers(st, model, problemf); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	providerType, _ := model.Config()["type"].(string)
+	if _, err := environs.Provider(providerType); err != nil {
+		problemf("provider %q is not supported by the controller", providerType)
+	}
+
+	if err := checkRegion(model, problemf); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	result.Missing = append(result.Missing, checkCharms(model, problemf)...)
+	result.Missing = append(result.Missing, checkToolsVersions(model, problemf)...)
+	return result, nil
+}
+
+func checkModelConflicts(st *state.State, model description.Model, problemf func(string, ...interface{})) error {
+	name, _ := model.Config()["name"].(string)
+	owner := model.Owner().Canonical()
+	models, err := st.AllModels()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, existing := range models {
+		if existing.UUID() == model.Tag().Id() {
+			problemf("model with UUID %q already exists", existing.UUID())
+		} else if existing.Name() == name && existing.Owner().Canonical() == owner {
+			problemf("model %q for %s already exists", name, owner)
+		}
+	}
+	return nil
+}
+
+func checkRegion(model description.Model, problemf func(string, ...interface{})) error {
+	providerType, _ := model.Config()["type"].(string)
+	region, _ := model.Config()["region"].(string)
+	if region == "" {
+		return nil
+	}
+	clouds, err := knownClouds()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Only providers with known clouds have a fixed set of regions.
+	var knownProvider bool
+	for _, cloud := range clouds {
+		if cloud.Type != providerType {
+			continue
+		}
+		knownProvider = true
+		for _, cloudRegion := range cloud.Regions {
+			if cloudRegion.Name == region {
+				return nil
+			}
+		}
+	}
+	if knownProvider {
+		problemf("cloud region %q is not known for provider %q", region, providerType)
+	}
+	return nil
+}
+
+// checkUsers reports the local users of the model that do not exist
+// in the controller. Users from other domains are not managed by the
+// controller and are not checked.
+func checkUsers(st *state.State, model description.Model, problemf func(string, ...interface{})) error {
+	checked := set.NewStrings()
+	check := func(tag names.UserTag) error {
+		if !tag.IsLocal() || checked.Contains(tag.Canonical()) {
+			return nil
+		}
+		checked.Add(tag.Canonical())
+		if _, err := st.User(tag); errors.IsNotFound(err) {
+			problemf("user %q does not exist in the controller", tag.Canonical())
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		return nil
+	}
+	if err := check(model.Owner()); err != nil {
+		return errors.Trace(err)
+	}
+	for _, user := range model.Users() {
+		if err := check(user.Name()); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func checkToolsVersions(model description.Model, problemf func(string, ...interface{})) []string {
+	var missing []string
+	for toolsVersion := range getUsedToolsVersions(model) {
+		if toolsVersion.Number.Compare(jujuversion.Current) > 0 {
+			problemf("tools version %s is newer than the controller version %s", toolsVersion, jujuversion.Current)
+			continue
+		}
+		missing = append(missing, fmt.Sprintf("tools %s", toolsVersion))
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+func checkCharms(model description.Model, problemf func(string, ...interface{})) []string {
+	var missing []string
+	for _, charmURL := range getUsedCharms(model).SortedValues() {
+		curl, err := charm.ParseURL(charmURL)
+		if err != nil {
+			problemf("charm URL %q is not valid: %v", charmURL, err)
+			continue
+		}
+		missing = append(missing, fmt.Sprintf("charm %s", curl))
+	}
+	return missing
+}
@@ -0,0 +1,158 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// IngressRule represents a port range that is reachable from the given
+// source networks.
+type IngressRule struct {
+	PortRange
+
+	// SourceCIDRs holds the networks from which the port range may be
+	// reached, sorted. An empty slice means the port range may be
+	// reached from anywhere.
+	SourceCIDRs []string
+}
+
+// NewIngressRule returns an ingress rule for the given port range and
+// source CIDRs. If sourceCIDRs includes 0.0.0.0/0, or is empty, the port
+// range may be reached from anywhere.
+func NewIngressRule(portRange PortRange, sourceCIDRs ...string) (IngressRule, error) {
+	rule := IngressRule{PortRange: portRange}
+	for _, cidr := range sourceCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return IngressRule{}, errors.NotValidf("source CIDR %q", cidr)
+		}
+		if cidr == "0.0.0.0/0" {
+			return IngressRule{PortRange: portRange}, nil
+		}
+		rule.SourceCIDRs = append(rule.SourceCIDRs, cidr)
+	}
+	sort.Strings(rule.SourceCIDRs)
+	return rule, nil
+}
+
+// IsOpen reports whether the rule allows traffic from anywhere.
+func (r IngressRule) IsOpen() bool {
+	return len(r.SourceCIDRs) == 0
+}
+
+// Equals reports whether the two rules are identical.
+func (r IngressRule) Equals(other IngressRule) bool {
+	return r.PortRange == other.PortRange && stringsEqual(r.SourceCIDRs, other.SourceCIDRs)
+}
+
+func (r IngressRule) String() string {
+	if r.IsOpen() {
+		return r.PortRange.String()
+	}
+	return fmt.Sprintf("%s from %s", r.PortRange, strings.Join(r.SourceCIDRs, ","))
+}
+
+func (r IngressRule) GoString() string {
+	return r.String()
+}
+
+type ingressRuleSlice []IngressRule
+
+func (s ingressRuleSlice) Len() int      { return len(s) }
+func (s ingressRuleSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s ingressRuleSlice) Less(i, j int) bool {
+	if s[i].PortRange != s[j].PortRange {
+		return portRangeSlice{s[i].PortRange, s[j].PortRange}.Less(0, 1)
+	}
+	return strings.Join(s[i].SourceCIDRs, ",") < strings.Join(s[j].SourceCIDRs, ",")
+}
+
+// SortIngressRules sorts the given rules by port range, then by source
+// CIDRs.
+func SortIngressRules(rules []IngressRule) {
+	sort.Sort(ingressRuleSlice(rules))
+}
+
+// SplitIngressRulesBySource returns the given rules with one source CIDR
+// each. Providers such as EC2 hold one permission per port range and
+// source, so rules sharing some of their sources can only be opened,
+// closed and compared correctly at that granularity. Rules open to
+// anywhere are returned unchanged.
+func SplitIngressRulesBySource(rules []IngressRule) []IngressRule {
+	var split []IngressRule
+	for _, rule := range rules {
+		if rule.IsOpen() {
+			split = append(split, rule)
+			continue
+		}
+		for _, cidr := range rule.SourceCIDRs {
+			split = append(split, IngressRule{
+				PortRange:   rule.PortRange,
+				SourceCIDRs: []string{cidr},
+			})
+		}
+	}
+	return split
+}
+
+// OpenIngressRules returns rules that allow traffic from anywhere to the
+// given port ranges.
+func OpenIngressRules(portRanges []PortRange) []IngressRule {
+	rules := make([]IngressRule, len(portRanges))
+	for i, portRange := range portRanges {
+		rules[i] = IngressRule{PortRange: portRange}
+	}
+	return rules
+}
+
+// ExposeSourceCIDRs describes the networks from which the opened ports of
+// an exposed service may be reached.
+type ExposeSourceCIDRs struct {
+	// Default holds the source CIDRs for port ranges without an entry
+	// in Ports. An empty slice means anywhere.
+	Default []string
+
+	// Ports holds the source CIDRs for individual port ranges.
+	Ports map[PortRange][]string
+}
+
+// IngressRule returns the ingress rule for the given port range.
+func (s ExposeSourceCIDRs) IngressRule(portRange PortRange) (IngressRule, error) {
+	sourceCIDRs, ok := s.Ports[portRange]
+	if !ok {
+		sourceCIDRs = s.Default
+	}
+	return NewIngressRule(portRange, sourceCIDRs...)
+}
+
+// Equals reports whether the two sets of source CIDRs are identical.
+func (s ExposeSourceCIDRs) Equals(other ExposeSourceCIDRs) bool {
+	if !stringsEqual(s.Default, other.Default) || len(s.Ports) != len(other.Ports) {
+		return false
+	}
+	for portRange, sourceCIDRs := range s.Ports {
+		otherCIDRs, ok := other.Ports[portRange]
+		if !ok || !stringsEqual(sourceCIDRs, otherCIDRs) {
+			return false
+		}
+	}
+	return true
+}
+
+func stringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,121 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+)
+
+type IngressRuleSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&IngressRuleSuite{})
+
+func (*IngressRuleSuite) TestNewIngressRule(c *gc.C) {
+	portRange := network.MustParsePortRange("80/tcp")
+	rule, err := network.NewIngressRule(portRange, "192.168.0.0/16", "10.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule, jc.DeepEquals, network.IngressRule{
+		PortRange:   portRange,
+		SourceCIDRs: []string{"10.0.0.0/8", "192.168.0.0/16"},
+	})
+	c.Assert(rule.IsOpen(), jc.IsFalse)
+	c.Assert(rule.String(), gc.Equals, "80/tcp from 10.0.0.0/8,192.168.0.0/16")
+}
+
+func (*IngressRuleSuite) TestNewIngressRuleOpen(c *gc.C) {
+	portRange := network.MustParsePortRange("8000-8080/udp")
+	for _, sourceCIDRs := range [][]string{nil, {"10.0.0.0/8", "0.0.0.0/0"}} {
+		rule, err := network.NewIngressRule(portRange, sourceCIDRs...)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(rule, jc.DeepEquals, network.IngressRule{PortRange: portRange})
+		c.Assert(rule.IsOpen(), jc.IsTrue)
+		c.Assert(rule.String(), gc.Equals, "8000-8080/udp")
+	}
+}
+
+func (*IngressRuleSuite) TestNewIngressRuleInvalidCIDR(c *gc.C) {
+	_, err := network.NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0")
+	c.Assert(err, gc.ErrorMatches, `source CIDR "10.0.0.0" not valid`)
+}
+
+func (*IngressRuleSuite) TestEquals(c *gc.C) {
+	rule := network.IngressRule{
+		PortRange:   network.MustParsePortRange("80/tcp"),
+		SourceCIDRs: []string{"10.0.0.0/8"},
+	}
+	c.Assert(rule.Equals(rule), jc.IsTrue)
+	c.Assert(rule.Equals(network.IngressRule{PortRange: rule.PortRange}), jc.IsFalse)
+	c.Assert(rule.Equals(network.IngressRule{
+		PortRange:   network.MustParsePortRange("81/tcp"),
+		SourceCIDRs: []string{"10.0.0.0/8"},
+	}), jc.IsFalse)
+}
+
+func (*IngressRuleSuite) TestSortIngressRules(c *gc.C) {
+	rules := []network.IngressRule{{
+		PortRange:   network.MustParsePortRange("80/tcp"),
+		SourceCIDRs: []string{"192.168.0.0/16"},
+	}, {
+		PortRange: network.MustParsePortRange("53/udp"),
+	}, {
+		PortRange:   network.MustParsePortRange("80/tcp"),
+		SourceCIDRs: []string{"10.0.0.0/8"},
+	}, {
+		PortRange: network.MustParsePortRange("22/tcp"),
+	}}
+	network.SortIngressRules(rules)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{{
+		PortRange: network.MustParsePortRange("22/tcp"),
+	}, {
+		PortRange:   network.MustParsePortRange("80/tcp"),
+		SourceCIDRs: []string{"10.0.0.0/8"},
+	}, {
+		PortRange:   network.MustParsePortRange("80/tcp"),
+		SourceCIDRs: []string{"192.168.0.0/16"},
+	}, {
+		PortRange: network.MustParsePortRange("53/udp"),
+	}})
+}
+
+func (*IngressRuleSuite) TestSplitIngressRulesBySource(c *gc.C) {
+	web := network.MustParsePortRange("80/tcp")
+	admin := network.MustParsePortRange("8443/tcp")
+	rules := network.SplitIngressRulesBySource([]network.IngressRule{
+		{PortRange: web},
+		{PortRange: admin, SourceCIDRs: []string{"10.0.0.0/8", "192.168.0.0/16"}},
+	})
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		{PortRange: web},
+		{PortRange: admin, SourceCIDRs: []string{"10.0.0.0/8"}},
+		{PortRange: admin, SourceCIDRs: []string{"192.168.0.0/16"}},
+	})
+}
+
+func (*IngressRuleSuite) TestExposeSourceCIDRs(c *gc.C) {
+	admin := network.MustParsePortRange("8443/tcp")
+	web := network.MustParsePortRange("80/tcp")
+	sources := network.ExposeSourceCIDRs{
+		Ports: map[network.PortRange][]string{admin: {"10.0.0.0/8"}},
+	}
+	rule, err := sources.IngressRule(admin)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule, jc.DeepEquals, network.IngressRule{PortRange: admin, SourceCIDRs: []string{"10.0.0.0/8"}})
+	rule, err = sources.IngressRule(web)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule, jc.DeepEquals, network.IngressRule{PortRange: web})
+
+	sources.Default = []string{"192.168.0.0/16"}
+	rule, err = sources.IngressRule(web)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule, jc.DeepEquals, network.IngressRule{PortRange: web, SourceCIDRs: []string{"192.168.0.0/16"}})
+
+	c.Assert(sources.Equals(sources), jc.IsTrue)
+	c.Assert(sources.Equals(network.ExposeSourceCIDRs{Default: sources.Default}), jc.IsFalse)
+}
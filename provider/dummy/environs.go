@@ -208,6 +208,20 @@ type OpClosePorts struct {
 	Ports      []network.PortRange
 }
 
+type OpOpenIngressRules struct {
+	Env        string
+	MachineId  string
+	InstanceId instance.Id
+	Rules      []network.IngressRule
+}
+
+type OpCloseIngressRules struct {
+	Env        string
+	MachineId  string
+	InstanceId instance.Id
+	Rules      []network.IngressRule
+}
+
 type OpPutFile struct {
 	Env      string
 	FileName string
@@ -237,6 +251,7 @@ type environState struct {
 	maxAddr         int // maximum allocated address last byte
 	insts           map[instance.Id]*dummyInstance
 	globalPorts     map[network.PortRange]bool
+	globalRules     map[string]network.IngressRule
 	bootstrapped    bool
 	apiListener     net.Listener
 	apiServer       *apiserver.Server
@@ -373,6 +388,7 @@ func newState(name string, ops chan<- Operation, policy state.Policy) *environSt
 		statePolicy: policy,
 		insts:       make(map[instance.Id]*dummyInstance),
 		globalPorts: make(map[network.PortRange]bool),
+		globalRules: make(map[string]network.IngressRule),
 	}
 	return s
 }
@@ -706,6 +722,7 @@ func (e *environ) Bootstrap(ctx environs.BootstrapContext, args environs.Bootstr
 		id:           BootstrapInstanceId,
 		addresses:    network.NewAddresses("localhost"),
 		ports:        make(map[network.PortRange]bool),
+		ingressRules: make(map[string]network.IngressRule),
 		machineId:    agent.BootstrapMachineId,
 		series:       series,
 		firewallMode: e.Config().FirewallMode(),
@@ -914,6 +931,7 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		id:           instance.Id(idString),
 		addresses:    addrs,
 		ports:        make(map[network.PortRange]bool),
+		ingressRules: make(map[string]network.IngressRule),
 		machineId:    machineId,
 		series:       series,
 		firewallMode: e.Config().FirewallMode(),
@@ -1501,6 +1519,73 @@ func (e *environ) Ports() (ports []network.PortRange, err error) {
 	return
 }
 
+// OpenIngressRules is specified on the environs.IngressRulesFirewaller
+// interface. Rules open to traffic from anywhere are recorded as open
+// ports.
+func (e *environ) OpenIngressRules(rules []network.IngressRule) error {
+	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
+		return fmt.Errorf("invalid firewall mode %q for opening ports on model", mode)
+	}
+	estate, err := e.state()
+	if err != nil {
+		return err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	for _, r := range rules {
+		if r.IsOpen() {
+			estate.globalPorts[r.PortRange] = true
+		} else {
+			estate.globalRules[r.String()] = r
+		}
+	}
+	return nil
+}
+
+// CloseIngressRules is specified on the environs.IngressRulesFirewaller
+// interface.
+func (e *environ) CloseIngressRules(rules []network.IngressRule) error {
+	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
+		return fmt.Errorf("invalid firewall mode %q for closing ports on model", mode)
+	}
+	estate, err := e.state()
+	if err != nil {
+		return err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	for _, r := range rules {
+		if r.IsOpen() {
+			delete(estate.globalPorts, r.PortRange)
+		} else {
+			delete(estate.globalRules, r.String())
+		}
+	}
+	return nil
+}
+
+// IngressRules is specified on the environs.IngressRulesFirewaller
+// interface.
+func (e *environ) IngressRules() (rules []network.IngressRule, err error) {
+	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from model", mode)
+	}
+	estate, err := e.state()
+	if err != nil {
+		return nil, err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	for p := range estate.globalPorts {
+		rules = append(rules, network.IngressRule{PortRange: p})
+	}
+	for _, r := range estate.globalRules {
+		rules = append(rules, r)
+	}
+	network.SortIngressRules(rules)
+	return
+}
+
 func (*environ) Provider() environs.EnvironProvider {
 	return &dummy
 }
@@ -1508,6 +1593,7 @@ func (*environ) Provider() environs.EnvironProvider {
 type dummyInstance struct {
 	state        *environState
 	ports        map[network.PortRange]bool
+	ingressRules map[string]network.IngressRule
 	id           instance.Id
 	status       string
 	machineId    string
@@ -1663,6 +1749,98 @@ func (inst *dummyInstance) Ports(machineId string) (ports []network.PortRange, e
 	return
 }
 
+// OpenIngressRules is specified on the instance.IngressRulesFirewaller
+// interface. Rules open to traffic from anywhere are recorded as open
+// ports.
+func (inst *dummyInstance) OpenIngressRules(machineId string, rules []network.IngressRule) error {
+	defer delay()
+	logger.Infof("openIngressRules %s, %#v", machineId, rules)
+	if inst.firewallMode != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for opening ports on instance",
+			inst.firewallMode)
+	}
+	if inst.machineId != machineId {
+		panic(fmt.Errorf("OpenIngressRules with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+	}
+	inst.state.mu.Lock()
+	defer inst.state.mu.Unlock()
+	if err := inst.checkBroken("OpenIngressRules"); err != nil {
+		return err
+	}
+	inst.state.ops <- OpOpenIngressRules{
+		Env:        inst.state.name,
+		MachineId:  machineId,
+		InstanceId: inst.Id(),
+		Rules:      rules,
+	}
+	for _, r := range rules {
+		if r.IsOpen() {
+			inst.ports[r.PortRange] = true
+		} else {
+			inst.ingressRules[r.String()] = r
+		}
+	}
+	return nil
+}
+
+// CloseIngressRules is specified on the instance.IngressRulesFirewaller
+// interface.
+func (inst *dummyInstance) CloseIngressRules(machineId string, rules []network.IngressRule) error {
+	defer delay()
+	if inst.firewallMode != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for closing ports on instance",
+			inst.firewallMode)
+	}
+	if inst.machineId != machineId {
+		panic(fmt.Errorf("CloseIngressRules with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+	}
+	inst.state.mu.Lock()
+	defer inst.state.mu.Unlock()
+	if err := inst.checkBroken("CloseIngressRules"); err != nil {
+		return err
+	}
+	inst.state.ops <- OpCloseIngressRules{
+		Env:        inst.state.name,
+		MachineId:  machineId,
+		InstanceId: inst.Id(),
+		Rules:      rules,
+	}
+	for _, r := range rules {
+		if r.IsOpen() {
+			delete(inst.ports, r.PortRange)
+		} else {
+			delete(inst.ingressRules, r.String())
+		}
+	}
+	return nil
+}
+
+// IngressRules is specified on the instance.IngressRulesFirewaller
+// interface.
+func (inst *dummyInstance) IngressRules(machineId string) (rules []network.IngressRule, err error) {
+	defer delay()
+	if inst.firewallMode != config.FwInstance {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from instance",
+			inst.firewallMode)
+	}
+	if inst.machineId != machineId {
+		panic(fmt.Errorf("IngressRules with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+	}
+	inst.state.mu.Lock()
+	defer inst.state.mu.Unlock()
+	if err := inst.checkBroken("IngressRules"); err != nil {
+		return nil, err
+	}
+	for p := range inst.ports {
+		rules = append(rules, network.IngressRule{PortRange: p})
+	}
+	for _, r := range inst.ingressRules {
+		rules = append(rules, r)
+	}
+	network.SortIngressRules(rules)
+	return
+}
+
 // providerDelay controls the delay before dummy responds.
 // non empty values in JUJU_DUMMY_DELAY will be parsed as
 // time.Durations into this value.
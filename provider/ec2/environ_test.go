@@ -10,16 +10,19 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/simplestreams"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 )
 
 // Ensure EC2 provider supports the expected interfaces,
 var (
-	_ environs.NetworkingEnviron = (*environ)(nil)
-	_ simplestreams.HasRegion    = (*environ)(nil)
-	_ state.Prechecker           = (*environ)(nil)
-	_ state.InstanceDistributor  = (*environ)(nil)
+	_ environs.NetworkingEnviron      = (*environ)(nil)
+	_ environs.IngressRulesFirewaller = (*environ)(nil)
+	_ simplestreams.HasRegion         = (*environ)(nil)
+	_ state.Prechecker                = (*environ)(nil)
+	_ state.InstanceDistributor       = (*environ)(nil)
+	_ instance.IngressRulesFirewaller = (*ec2Instance)(nil)
 )
 
 type Suite struct{}
@@ -164,3 +167,23 @@ func (*Suite) TestPortsToIPPerms(c *gc.C) {
 		c.Assert(ipperms, gc.DeepEquals, t.expected)
 	}
 }
+
+func (*Suite) TestIngressRulesToIPPerms(c *gc.C) {
+	rules := []network.IngressRule{{
+		PortRange: network.MustParsePortRange("80/tcp"),
+	}, {
+		PortRange:   network.MustParsePortRange("8000-8080/tcp"),
+		SourceCIDRs: []string{"10.0.0.0/8", "192.168.0.0/16"},
+	}}
+	c.Assert(ingressRulesToIPPerms(rules), gc.DeepEquals, []amzec2.IPPerm{{
+		Protocol:  "tcp",
+		FromPort:  80,
+		ToPort:    80,
+		SourceIPs: []string{"0.0.0.0/0"},
+	}, {
+		Protocol:  "tcp",
+		FromPort:  8000,
+		ToPort:    8080,
+		SourceIPs: []string{"10.0.0.0/8", "192.168.0.0/16"},
+	}})
+}
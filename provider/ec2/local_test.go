@@ -725,6 +725,41 @@ func (t *localServerSuite) TestInstanceStatus(c *gc.C) {
 	c.Assert(inst.Status().Message, gc.Equals, "terminated")
 }
 
+func (t *localServerSuite) TestIngressRulesBySource(c *gc.C) {
+	env := t.Prepare(c)
+	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, bootstrap.BootstrapParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	inst, _ := testing.AssertStartInstance(c, env, "1")
+	fw := inst.(instance.IngressRulesFirewaller)
+
+	portRange := network.MustParsePortRange("80/tcp")
+	err = fw.OpenIngressRules("1", []network.IngressRule{
+		{PortRange: portRange},
+		{PortRange: portRange, SourceCIDRs: []string{"10.0.0.0/8", "192.168.0.0/16"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The sources of the port range are reported one by one.
+	rules, err := fw.IngressRules("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		{PortRange: portRange},
+		{PortRange: portRange, SourceCIDRs: []string{"10.0.0.0/8"}},
+		{PortRange: portRange, SourceCIDRs: []string{"192.168.0.0/16"}},
+	})
+
+	err = fw.CloseIngressRules("1", []network.IngressRule{
+		{PortRange: portRange, SourceCIDRs: []string{"10.0.0.0/8"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = fw.IngressRules("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		{PortRange: portRange},
+		{PortRange: portRange, SourceCIDRs: []string{"192.168.0.0/16"}},
+	})
+}
+
 func (t *localServerSuite) TestStartInstanceHardwareCharacteristics(c *gc.C) {
 	env := t.Prepare(c)
 	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, bootstrap.BootstrapParams{})
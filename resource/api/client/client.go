@@ -102,6 +102,28 @@ func (c Client) Upload(service, name, filename string, reader io.ReadSeeker) err
 	return nil
 }
 
+// UploadResource sends the blob for a resource whose metadata was
+// imported with a migrated model. The metadata on the controller is
+// kept as it is, so the blob must match its fingerprint and size.
+func (c Client) UploadResource(res resource.Resource, reader io.ReadSeeker) error {
+	uReq, err := api.NewUploadRequest(res.ServiceID, res.Name, res.Path, reader)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	uReq.Migrated = true
+	req, err := uReq.HTTPRequest()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var response api.UploadResult // ignored
+	if err := c.doer.Do(req, reader, &response); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
 // AddPendingResourcesArgs holds the arguments to AddPendingResources().
 type AddPendingResourcesArgs struct {
 	// ServiceID identifies the service being deployed.
@@ -18,6 +18,7 @@ import (
 	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 
 	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/api/client"
 )
 
@@ -52,6 +53,30 @@ func (s *UploadSuite) TestOkay(c *gc.C) {
 	s.stub.CheckCall(c, 3, "Do", req, reader, s.response)
 }
 
+func (s *UploadSuite) TestUploadResource(c *gc.C) {
+	data := "<data>"
+	reader := &stubFile{stub: s.stub}
+	reader.returnRead = strings.NewReader(data)
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	var res resource.Resource
+	res, s.response.Resource = newResource(c, "spam", "a-user", data)
+
+	err := cl.UploadResource(res, reader)
+	c.Assert(err, jc.ErrorIsNil)
+
+	req, err := http.NewRequest("PUT", "/services/a-service/resources/spam?migrated=true", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-SHA384", res.Fingerprint.String())
+	req.Header.Set("Content-Length", fmt.Sprint(len(data)))
+	req.Header.Set("Content-Disposition", "form-data; filename="+res.Path)
+	req.ContentLength = int64(len(data))
+
+	s.stub.CheckCallNames(c, "Read", "Read", "Seek", "Do")
+	s.stub.CheckCall(c, 3, "Do", req, reader, s.response)
+}
+
 func (s *UploadSuite) TestBadService(c *gc.C) {
 	cl := client.NewClient(s.facade, s, s.facade)
 
@@ -59,6 +59,10 @@ const (
 	MediaTypeFormData = "form-data"
 	// QueryParamPendingID is the query parameter we use to send up the pending id.
 	QueryParamPendingID = "pendingid"
+	// QueryParamMigrated is the query parameter we use to indicate that
+	// the upload holds the blob for a resource whose metadata was
+	// imported with a migrated model.
+	QueryParamMigrated = "migrated"
 )
 
 const (
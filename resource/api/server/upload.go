@@ -47,6 +47,10 @@ type UploadedResource struct {
 
 	// Data holds the resource blob.
 	Data io.ReadCloser
+
+	// Username is the user that originally added a migrated resource.
+	// It is empty for regular uploads.
+	Username string
 }
 
 // UploadHandler provides the functionality to handle upload requests.
@@ -67,14 +71,19 @@ func (uh UploadHandler) HandleRequest(req *http.Request) (*api.UploadResult, err
 		return nil, errors.Trace(err)
 	}
 
+	username := uh.Username
+	if uploaded.Username != "" {
+		username = uploaded.Username
+	}
+
 	var stored resource.Resource
 	if uploaded.PendingID != "" {
-		stored, err = uh.Store.UpdatePendingResource(uploaded.Service, uploaded.PendingID, uh.Username, uploaded.Resource, uploaded.Data)
+		stored, err = uh.Store.UpdatePendingResource(uploaded.Service, uploaded.PendingID, username, uploaded.Resource, uploaded.Data)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
 	} else {
-		stored, err = uh.Store.SetResource(uploaded.Service, uh.Username, uploaded.Resource, uploaded.Data)
+		stored, err = uh.Store.SetResource(uploaded.Service, username, uploaded.Resource, uploaded.Data)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -110,6 +119,10 @@ func (uh UploadHandler) ReadResource(req *http.Request) (*UploadedResource, erro
 		return nil, errors.Errorf("incorrect extension on resource upload %q, expected %q", uReq.Filename, ext)
 	}
 
+	if uReq.Migrated {
+		return uh.readMigratedResource(req, uReq, res)
+	}
+
 	chRes, err := uh.updateResource(res.Resource, uReq.Fingerprint, uReq.Size)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -124,6 +137,26 @@ func (uh UploadHandler) ReadResource(req *http.Request) (*UploadedResource, erro
 	return uploaded, nil
 }
 
+// readMigratedResource checks that the uploaded blob is the one described
+// by the metadata imported with a migrated model. The metadata is kept
+// unchanged, rather than being treated as a new upload.
+func (uh UploadHandler) readMigratedResource(req *http.Request, uReq api.UploadRequest, res resource.Resource) (*UploadedResource, error) {
+	if uReq.PendingID != "" {
+		return nil, errors.NotValidf("migrated upload for pending resource %q", uReq.Name)
+	}
+	if res.Fingerprint.String() != uReq.Fingerprint.String() || res.Size != uReq.Size {
+		return nil, errors.Errorf("migrated resource %q does not match the imported metadata", uReq.Name)
+	}
+
+	uploaded := &UploadedResource{
+		Service:  uReq.Service,
+		Resource: res.Resource,
+		Data:     req.Body,
+		Username: res.Username,
+	}
+	return uploaded, nil
+}
+
 // updateResource returns a copy of the provided resource, updated with
 // the given information.
 func (uh UploadHandler) updateResource(res charmresource.Resource, fp charmresource.Fingerprint, size int64) (charmresource.Resource, error) {
@@ -180,6 +180,47 @@ func (s *UploadSuite) TestReadResourcePending(c *gc.C) {
 	})
 }
 
+func (s *UploadSuite) TestReadResourceMigrated(c *gc.C) {
+	content := "<some data>"
+	stored, _ := newResource(c, "spam", "another-user", content)
+	stored.Origin = charmresource.OriginStore
+	stored.Revision = 3
+	s.data.ReturnGetResource = stored
+	uh := server.UploadHandler{
+		Username: "a-user",
+		Store:    s.data,
+	}
+	req, body := newUploadRequest(c, "spam", "a-service", content)
+	req.URL.RawQuery += "&migrated=true"
+
+	uploaded, err := uh.ReadResource(req)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "GetResource")
+	c.Check(uploaded, jc.DeepEquals, &server.UploadedResource{
+		Service:  "a-service",
+		Resource: stored.Resource,
+		Data:     ioutil.NopCloser(body),
+		Username: "another-user",
+	})
+}
+
+func (s *UploadSuite) TestReadResourceMigratedMismatch(c *gc.C) {
+	stored, _ := newResource(c, "spam", "another-user", "<some data>")
+	s.data.ReturnGetResource = stored
+	uh := server.UploadHandler{
+		Username: "a-user",
+		Store:    s.data,
+	}
+	req, _ := newUploadRequest(c, "spam", "a-service", "<other data>")
+	req.URL.RawQuery += "&migrated=true"
+
+	_, err := uh.ReadResource(req)
+
+	c.Check(err, gc.ErrorMatches, `migrated resource "spam" does not match the imported metadata`)
+	s.stub.CheckCallNames(c, "GetResource")
+}
+
 func (s *UploadSuite) TestReadResourceBadContentType(c *gc.C) {
 	uh := server.UploadHandler{
 		Username: "a-user",
@@ -35,6 +35,11 @@ type UploadRequest struct {
 
 	// PendingID is the pending ID to associate with this upload, if any.
 	PendingID string
+
+	// Migrated indicates that the upload is the blob for a resource
+	// imported with a migrated model, so the existing metadata must
+	// be kept.
+	Migrated bool
 }
 
 // NewUploadRequest generates a new upload request for the given resource.
@@ -75,6 +80,7 @@ func ExtractUploadRequest(req *http.Request) (UploadRequest, error) {
 	fingerprint := req.Header.Get(HeaderContentSha384) // This parallels "Content-MD5".
 	sizeRaw := req.Header.Get(HeaderContentLength)
 	pendingID := req.URL.Query().Get(QueryParamPendingID)
+	migrated := req.URL.Query().Get(QueryParamMigrated) != ""
 
 	fp, err := charmresource.ParseFingerprint(fingerprint)
 	if err != nil {
@@ -98,6 +104,7 @@ func ExtractUploadRequest(req *http.Request) (UploadRequest, error) {
 		Size:        size,
 		Fingerprint: fp,
 		PendingID:   pendingID,
+		Migrated:    migrated,
 	}
 	return ur, nil
 }
@@ -163,11 +170,14 @@ func (ur UploadRequest) HTTPRequest() (*http.Request, error) {
 
 	req.ContentLength = ur.Size
 
+	query := req.URL.Query()
 	if ur.PendingID != "" {
-		query := req.URL.Query()
 		query.Set(QueryParamPendingID, ur.PendingID)
-		req.URL.RawQuery = query.Encode()
 	}
+	if ur.Migrated {
+		query.Set(QueryParamMigrated, "true")
+	}
+	req.URL.RawQuery = query.Encode()
 
 	return req, nil
 }
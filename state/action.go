@@ -9,6 +9,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names"
+	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -45,6 +46,10 @@ const (
 
 	// ActionRunning indicates that the Action is currently running.
 	ActionRunning ActionStatus = "running"
+
+	// ActionAborting indicates that the Action was cancelled while
+	// running, and the receiver has been asked to stop it.
+	ActionAborting ActionStatus = "aborting"
 )
 
 type actionNotificationDoc struct {
@@ -64,6 +69,10 @@ type actionNotificationDoc struct {
 	// ActionID is the unique identifier for the Action this notification
 	// represents.
 	ActionID string `bson:"actionid"`
+
+	// Aborting is set when a running Action is cancelled, so that
+	// the receiver's notification watcher fires again.
+	Aborting bool `bson:"aborting,omitempty"`
 }
 
 type actionDoc struct {
@@ -104,6 +113,14 @@ type actionDoc struct {
 
 	// Results are the structured results from the action.
 	Results map[string]interface{} `bson:"results"`
+
+	// Timeout is how long the action may run before it is stopped and
+	// marked as failed; zero means the action may run indefinitely.
+	Timeout time.Duration `bson:"timeout,omitempty"`
+
+	// Schedule is the id of the action schedule that enqueued the
+	// action, if any.
+	Schedule string `bson:"schedule,omitempty"`
 }
 
 // action represents an instruction to do some "action" and is expected
@@ -162,6 +179,18 @@ func (a *action) Results() (map[string]interface{}, string) {
 	return a.doc.Results, a.doc.Message
 }
 
+// Timeout returns how long the action may run before it is stopped,
+// or zero if it may run indefinitely.
+func (a *action) Timeout() time.Duration {
+	return a.doc.Timeout
+}
+
+// Schedule returns the id of the action schedule that enqueued the
+// action, or "" if it was not enqueued by a schedule.
+func (a *action) Schedule() string {
+	return a.doc.Schedule
+}
+
 // Tag implements the Entity interface and returns a names.Tag that
 // is a names.ActionTag.
 func (a *action) Tag() names.Tag {
@@ -207,37 +236,96 @@ func (a *action) Finish(results ActionResults) (Action, error) {
 	return a.removeAndLog(results.Status, results.Results, results.Message)
 }
 
+// Cancel stops the action. A pending action is taken off the queue
+// and marked as cancelled; a running action is marked as aborting, and
+// its receiver is notified so that it can stop the action and record
+// the outcome. Only units can stop running actions, so cancelling a
+// running action on any other receiver is not supported.
+func (a *action) Cancel() (Action, error) {
+	const message = "action cancelled via the API"
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		current := a
+		if attempt > 0 {
+			fresh, err := a.st.Action(a.Id())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			current = fresh.(*action)
+		}
+		switch current.doc.Status {
+		case ActionPending:
+			return current.removeAndLogOps(ActionCancelled, nil, message, ActionPending), nil
+		case ActionRunning:
+			if !names.IsValidUnit(current.doc.Receiver) {
+				return nil, errors.NotSupportedf("cancelling running actions on %q", current.doc.Receiver)
+			}
+			return []txn.Op{{
+				C:      actionsC,
+				Id:     current.doc.DocId,
+				Assert: bson.D{{"status", ActionRunning}},
+				Update: bson.D{{"$set", bson.D{
+					{"status", ActionAborting},
+					{"message", message},
+				}}},
+			}, {
+				C:      actionNotificationsC,
+				Id:     current.notificationDocId(),
+				Update: bson.D{{"$set", bson.D{{"aborting", true}}}},
+			}}, nil
+		case ActionAborting:
+			return nil, jujutxn.ErrNoOperations
+		default:
+			return nil, errors.Errorf("action %q already %s", a.Id(), current.doc.Status)
+		}
+	}
+	if err := a.st.run(buildTxn); err != nil {
+		return nil, errors.Annotatef(err, "cannot cancel action %q", a.Id())
+	}
+	return a.st.Action(a.Id())
+}
+
 // removeAndLog takes the action off of the pending queue, and creates
 // an actionresult to capture the outcome of the action. It asserts that
 // the action is not already completed.
 func (a *action) removeAndLog(finalStatus ActionStatus, results map[string]interface{}, message string) (Action, error) {
-	err := a.st.runTransaction([]txn.Op{
-		{
-			C:  actionsC,
-			Id: a.doc.DocId,
-			Assert: bson.D{{"status", bson.D{
-				{"$nin", []interface{}{
-					ActionCompleted,
-					ActionCancelled,
-					ActionFailed,
-				}}}}},
-			Update: bson.D{{"$set", bson.D{
-				{"status", finalStatus},
-				{"message", message},
-				{"results", results},
-				{"completed", nowToTheSecond()},
-			}}},
-		}, {
-			C:      actionNotificationsC,
-			Id:     a.st.docID(ensureActionMarker(a.Receiver()) + a.Id()),
-			Remove: true,
-		}})
-	if err != nil {
+	ops := a.removeAndLogOps(finalStatus, results, message, bson.D{{"$nin", []interface{}{
+		ActionCompleted,
+		ActionCancelled,
+		ActionFailed,
+	}}})
+	if err := a.st.runTransaction(ops); err != nil {
 		return nil, err
 	}
 	return a.st.Action(a.Id())
 }
 
+// removeAndLogOps returns the operations needed to record the outcome
+// of the action and remove its notification, asserting that the
+// action's current status matches the given condition.
+func (a *action) removeAndLogOps(finalStatus ActionStatus, results map[string]interface{}, message string, statusCondition interface{}) []txn.Op {
+	return []txn.Op{{
+		C:      actionsC,
+		Id:     a.doc.DocId,
+		Assert: bson.D{{"status", statusCondition}},
+		Update: bson.D{{"$set", bson.D{
+			{"status", finalStatus},
+			{"message", message},
+			{"results", results},
+			{"completed", nowToTheSecond()},
+		}}},
+	}, {
+		C:      actionNotificationsC,
+		Id:     a.notificationDocId(),
+		Remove: true,
+	}}
+}
+
+// notificationDocId returns the id of the action's notification
+// document.
+func (a *action) notificationDocId() string {
+	return a.st.docID(ensureActionMarker(a.Receiver()) + a.Id())
+}
+
 // newAction builds an Action for the given State and actionDoc.
 func newAction(st *State, adoc actionDoc) Action {
 	return &action{
@@ -246,8 +334,9 @@ func newAction(st *State, adoc actionDoc) Action {
 	}
 }
 
-// newActionDoc builds the actionDoc with the given name and parameters.
-func newActionDoc(st *State, receiverTag names.Tag, actionName string, parameters map[string]interface{}) (actionDoc, actionNotificationDoc, error) {
+// newActionDoc builds the actionDoc with the given name, parameters,
+// timeout and schedule id.
+func newActionDoc(st *State, receiverTag names.Tag, actionName string, parameters map[string]interface{}, timeout time.Duration, schedule string) (actionDoc, actionNotificationDoc, error) {
 	prefix := ensureActionMarker(receiverTag.Id())
 	actionId, err := NewUUID()
 	if err != nil {
@@ -263,6 +352,8 @@ func newActionDoc(st *State, receiverTag names.Tag, actionName string, parameter
 			Parameters: parameters,
 			Enqueued:   nowToTheSecond(),
 			Status:     ActionPending,
+			Timeout:    timeout,
+			Schedule:   schedule,
 		}, actionNotificationDoc{
 			DocId:     st.docID(prefix + actionId.String()),
 			ModelUUID: modelUUID,
@@ -336,18 +427,29 @@ func (st *State) FindActionsByName(name string) ([]Action, error) {
 	return results, errors.Trace(iter.Close())
 }
 
-// EnqueueAction
+// EnqueueAction queues an action with the given name and payload for
+// the given receiver.
 func (st *State) EnqueueAction(receiver names.Tag, actionName string, payload map[string]interface{}) (Action, error) {
+	return st.enqueueAction(receiver, actionName, payload, 0, "")
+}
+
+// enqueueAction queues an action that may run for at most the given
+// timeout; a zero timeout lets it run indefinitely. If the action was
+// enqueued by an action schedule, schedule holds the schedule's id.
+func (st *State) enqueueAction(receiver names.Tag, actionName string, payload map[string]interface{}, timeout time.Duration, schedule string) (Action, error) {
 	if len(actionName) == 0 {
 		return nil, errors.New("action name required")
 	}
+	if timeout < 0 {
+		return nil, errors.NotValidf("negative action timeout %v", timeout)
+	}
 
 	receiverCollectionName, receiverId, err := st.tagToCollectionAndId(receiver)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 
-	doc, ndoc, err := newActionDoc(st, receiver, actionName, payload)
+	doc, ndoc, err := newActionDoc(st, receiver, actionName, payload, timeout, schedule)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -412,9 +514,12 @@ func (st *State) matchingActionsPending(ar ActionReceiver) ([]Action, error) {
 }
 
 // matchingActionsRunning finds actions that match ActionReceiver and
-// that are running.
+// that are running, including those that are being aborted.
 func (st *State) matchingActionsRunning(ar ActionReceiver) ([]Action, error) {
-	completed := bson.D{{"status", ActionRunning}}
+	completed := bson.D{{"$or", []bson.D{
+		{{"status", ActionRunning}},
+		{{"status", ActionAborting}},
+	}}}
 	return st.matchingActionsByReceiverAndStatus(ar.Tag(), completed)
 }
 
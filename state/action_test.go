@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names"
@@ -261,6 +262,52 @@ act:
 	}
 }
 
+func (s *ActionSuite) TestAddActionTimeout(c *gc.C) {
+	units := make(map[string]*state.Unit)
+	schemas := map[string]string{
+		"simple": `
+act:
+  timeout: 5m
+  params:
+    val:
+      type: string
+`[1:],
+		"complicated": `
+act:
+  timeout: soon
+`[1:],
+		"none": `
+act:
+  params:
+    val:
+      type: string
+`[1:]}
+	makeUnits(c, s, units, schemas)
+
+	// Without a timeout, actions run indefinitely.
+	action, err := units["none"].AddAction("act", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(action.Timeout(), gc.Equals, time.Duration(0))
+
+	// The charm's default applies unless a timeout is given.
+	action, err = units["simple"].AddAction("act", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(action.Timeout(), gc.Equals, 5*time.Minute)
+	action, err = units["simple"].AddActionWithTimeout("act", nil, time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(action.Timeout(), gc.Equals, time.Minute)
+
+	// The timeout is stored with the action.
+	action, err = s.State.Action(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(action.Timeout(), gc.Equals, time.Minute)
+
+	_, err = units["complicated"].AddAction("act", nil)
+	c.Check(err, gc.ErrorMatches, `action "act": timeout "soon" not valid`)
+	_, err = units["none"].AddActionWithTimeout("act", nil, -time.Minute)
+	c.Check(err, gc.ErrorMatches, `negative action timeout -1m0s not valid`)
+}
+
 // makeUnits prepares units with given Action schemas
 func makeUnits(c *gc.C, s *ActionSuite, units map[string]*state.Unit, schemas map[string]string) {
 	// A few dummy charms that haven't been used yet
@@ -463,6 +510,102 @@ func (s *ActionSuite) TestComplete(c *gc.C) {
 	c.Assert(len(actions), gc.Equals, 0)
 }
 
+func (s *ActionSuite) TestCancelPending(c *gc.C) {
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	preventUnitDestroyRemove(c, unit)
+
+	a, err := unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := a.Cancel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Status(), gc.Equals, state.ActionCancelled)
+	_, message := result.Results()
+	c.Assert(message, gc.Equals, "action cancelled via the API")
+
+	actions, err := unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 0)
+	actions, err = unit.CompletedActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 1)
+
+	_, err = a.Cancel()
+	c.Assert(err, gc.ErrorMatches, `cannot cancel action ".*": action ".*" already cancelled`)
+}
+
+func (s *ActionSuite) TestCancelRunning(c *gc.C) {
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	preventUnitDestroyRemove(c, unit)
+
+	a, err := unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := unit.WatchActionNotifications()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange(a.Id())
+	wc.AssertNoChange()
+
+	// Cancelling a running action marks it as aborting, and tells
+	// the unit about it.
+	result, err := a.Cancel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Status(), gc.Equals, state.ActionAborting)
+	wc.AssertChange(a.Id())
+	wc.AssertNoChange()
+
+	actions, err := unit.RunningActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 1)
+	c.Assert(actions[0].Id(), gc.Equals, a.Id())
+
+	// Cancelling again is a no-op.
+	result, err = a.Cancel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Status(), gc.Equals, state.ActionAborting)
+	wc.AssertNoChange()
+
+	// The unit records the outcome once the action has stopped.
+	output := map[string]interface{}{"partial": "output"}
+	result, err = a.Finish(state.ActionResults{
+		Status:  state.ActionCancelled,
+		Results: output,
+		Message: "action cancelled while running",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Status(), gc.Equals, state.ActionCancelled)
+	res, message := result.Results()
+	c.Assert(res, gc.DeepEquals, output)
+	c.Assert(message, gc.Equals, "action cancelled while running")
+}
+
+func (s *ActionSuite) TestCancelRunningOnMachine(c *gc.C) {
+	machine, err := s.State.AddMachine("trusty", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err := machine.AddAction("juju-run", map[string]interface{}{
+		"command": "sleep 100",
+		"timeout": 5.0,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Nothing on the machine would stop the action, so it would be
+	// left aborting forever.
+	_, err = a.Cancel()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `cannot cancel action ".*": cancelling running actions on ".*" not supported`)
+
+	a, err = s.State.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Status(), gc.Equals, state.ActionRunning)
+}
+
 func (s *ActionSuite) TestFindActionTagsByPrefix(c *gc.C) {
 	prefix := "feedbeef"
 	uuidMock := uuidMockHelper{}
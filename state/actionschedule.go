@@ -0,0 +1,328 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/cron"
+)
+
+// ActionSchedule causes an action to be enqueued on a unit each time
+// its cron-style schedule falls due.
+type ActionSchedule struct {
+	st  *State
+	doc actionScheduleDoc
+}
+
+type actionScheduleDoc struct {
+	DocId     string `bson:"_id"`
+	Id        string `bson:"id"`
+	ModelUUID string `bson:"model-uuid"`
+
+	// Receiver is the name of the unit the action is run on.
+	Receiver string `bson:"receiver"`
+
+	// Name, Parameters and Timeout describe the action to enqueue.
+	Name       string                 `bson:"name"`
+	Parameters map[string]interface{} `bson:"parameters"`
+	Timeout    time.Duration          `bson:"timeout,omitempty"`
+
+	// Schedule is the cron-style schedule on which the action is run.
+	Schedule string `bson:"schedule"`
+
+	Created time.Time `bson:"created"`
+
+	// NextRun is the time at which the action is next due.
+	NextRun time.Time `bson:"next-run"`
+
+	// LastRun is the time the schedule last fell due, and LastAction
+	// and LastError record the action enqueued then, or the error
+	// that prevented it from being enqueued.
+	LastRun    time.Time `bson:"last-run"`
+	LastAction string    `bson:"last-action,omitempty"`
+	LastError  string    `bson:"last-error,omitempty"`
+}
+
+// Id returns the schedule's id, which is unique within the model.
+func (s *ActionSchedule) Id() string {
+	return s.doc.Id
+}
+
+// Receiver returns the name of the unit the action is run on.
+func (s *ActionSchedule) Receiver() string {
+	return s.doc.Receiver
+}
+
+// Name returns the name of the scheduled action.
+func (s *ActionSchedule) Name() string {
+	return s.doc.Name
+}
+
+// Parameters returns the parameters the action is run with.
+func (s *ActionSchedule) Parameters() map[string]interface{} {
+	return s.doc.Parameters
+}
+
+// Timeout returns how long each scheduled action may run, or zero
+// if the action's default applies.
+func (s *ActionSchedule) Timeout() time.Duration {
+	return s.doc.Timeout
+}
+
+// Schedule returns the cron-style schedule on which the action is run.
+func (s *ActionSchedule) Schedule() string {
+	return s.doc.Schedule
+}
+
+// Created returns the time the schedule was added.
+func (s *ActionSchedule) Created() time.Time {
+	return s.doc.Created
+}
+
+// NextRun returns the time at which the action is next due.
+func (s *ActionSchedule) NextRun() time.Time {
+	return s.doc.NextRun
+}
+
+// LastRun returns the time the schedule last fell due, or the zero
+// time if it never has.
+func (s *ActionSchedule) LastRun() time.Time {
+	return s.doc.LastRun
+}
+
+// LastAction returns the id of the action enqueued the last time the
+// schedule fell due, if any.
+func (s *ActionSchedule) LastAction() string {
+	return s.doc.LastAction
+}
+
+// LastError returns the error that prevented the action from being
+// enqueued the last time the schedule fell due, if any.
+func (s *ActionSchedule) LastError() string {
+	return s.doc.LastError
+}
+
+// Remove removes the schedule. Actions it has already enqueued are
+// not affected.
+func (s *ActionSchedule) Remove() error {
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     s.doc.DocId,
+		Remove: true,
+	}}
+	if err := s.st.runTransaction(ops); err != nil {
+		return errors.Annotatef(err, "cannot remove action schedule %q", s.doc.Id)
+	}
+	return nil
+}
+
+// AddActionSchedule adds a schedule on which the named action will be
+// enqueued on the unit, with the given parameters and timeout. A zero
+// timeout means the action's default timeout applies.
+func (u *Unit) AddActionSchedule(name string, payload map[string]interface{}, schedule string, timeout time.Duration) (*ActionSchedule, error) {
+	sched, err := cron.Parse(schedule)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if timeout < 0 {
+		return nil, errors.NotValidf("negative action timeout %v", timeout)
+	}
+	spec, err := u.actionSpec(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := spec.ValidateParams(payload); err != nil {
+		return nil, errors.Trace(err)
+	}
+	now := GetClock().Now()
+	nextRun := sched.Next(now)
+	if nextRun.IsZero() {
+		return nil, errors.NotValidf("schedule %q, which never falls due,", schedule)
+	}
+
+	seq, err := u.st.sequence("actionschedule")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	id := strconv.Itoa(seq)
+	doc := actionScheduleDoc{
+		DocId:      u.st.docID(id),
+		Id:         id,
+		ModelUUID:  u.st.ModelUUID(),
+		Receiver:   u.Name(),
+		Name:       name,
+		Parameters: payload,
+		Timeout:    timeout,
+		Schedule:   sched.String(),
+		Created:    now,
+		NextRun:    nextRun,
+	}
+	ops := []txn.Op{{
+		C:      unitsC,
+		Id:     u.doc.DocID,
+		Assert: isAliveDoc,
+	}, {
+		C:      actionSchedulesC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}}
+	if err := u.st.runTransaction(ops); err == txn.ErrAborted {
+		return nil, errors.Annotatef(errNotAlive, "cannot schedule action on unit %q", u.Name())
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot schedule action on unit %q", u.Name())
+	}
+	return &ActionSchedule{st: u.st, doc: doc}, nil
+}
+
+// ActionSchedule returns the action schedule with the given id.
+func (st *State) ActionSchedule(id string) (*ActionSchedule, error) {
+	schedules, closer := st.getCollection(actionSchedulesC)
+	defer closer()
+
+	var doc actionScheduleDoc
+	err := schedules.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("action schedule %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get action schedule %q", id)
+	}
+	return &ActionSchedule{st: st, doc: doc}, nil
+}
+
+// AllActionSchedules returns all of the model's action schedules,
+// ordered by id.
+func (st *State) AllActionSchedules() ([]*ActionSchedule, error) {
+	return st.findActionSchedules(nil)
+}
+
+// findActionSchedules returns the action schedules matching the
+// query, ordered by id.
+func (st *State) findActionSchedules(query bson.D) ([]*ActionSchedule, error) {
+	schedules, closer := st.getCollection(actionSchedulesC)
+	defer closer()
+
+	var docs []actionScheduleDoc
+	if err := schedules.Find(query).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get action schedules")
+	}
+	result := make([]*ActionSchedule, len(docs))
+	for i, doc := range docs {
+		result[i] = &ActionSchedule{st: st, doc: doc}
+	}
+	sort.Sort(actionSchedulesById(result))
+	return result, nil
+}
+
+// RunActionSchedule enqueues the schedule's action if the schedule is
+// due, and advances the schedule to the next time it falls due. It
+// returns the enqueued action, or nil if the schedule was not due,
+// which may be because another caller has already run it.
+func (st *State) RunActionSchedule(id string) (Action, error) {
+	s, err := st.ActionSchedule(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	now := GetClock().Now()
+	if s.doc.NextRun.IsZero() || s.doc.NextRun.After(now) {
+		return nil, nil
+	}
+	sched, err := cron.Parse(s.doc.Schedule)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot run action schedule %q", id)
+	}
+
+	// Claim this run by advancing the schedule; if the schedule has
+	// changed, it has been run (or removed) by someone else.
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     s.doc.DocId,
+		Assert: bson.D{{"next-run", s.doc.NextRun}},
+		Update: bson.D{{"$set", bson.D{{"next-run", sched.Next(now)}}}},
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot run action schedule %q", id)
+	}
+
+	var action Action
+	unit, err := st.Unit(s.doc.Receiver)
+	if err == nil {
+		action, err = unit.addAction(s.doc.Name, copyParameters(s.doc.Parameters), s.doc.Timeout, id)
+	}
+	lastRun := bson.D{{"last-run", now}}
+	if err != nil {
+		lastRun = append(lastRun, bson.DocElem{"last-error", err.Error()})
+		lastRun = append(lastRun, bson.DocElem{"last-action", ""})
+	} else {
+		lastRun = append(lastRun, bson.DocElem{"last-error", ""})
+		lastRun = append(lastRun, bson.DocElem{"last-action", action.Id()})
+	}
+	ops = []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     s.doc.DocId,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", lastRun}},
+	}}
+	if recordErr := st.runTransaction(ops); recordErr != nil && recordErr != txn.ErrAborted {
+		logger.Errorf("cannot record run of action schedule %q: %v", id, recordErr)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot run action schedule %q", id)
+	}
+	return action, nil
+}
+
+// WatchActionSchedules returns a StringsWatcher that notifies of
+// the ids of action schedules as they are added, changed or removed.
+func (st *State) WatchActionSchedules() StringsWatcher {
+	return newcollectionWatcher(st, colWCfg{col: actionSchedulesC})
+}
+
+// removeActionSchedulesForUnit removes all the action schedules
+// that run on the named unit.
+func (st *State) removeActionSchedulesForUnit(unitName string) error {
+	schedules, err := st.findActionSchedules(bson.D{{"receiver", unitName}})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, s := range schedules {
+		if err := s.Remove(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// copyParameters returns a shallow copy of an action's parameters, so
+// that inserting the action's defaults does not alter the original.
+func copyParameters(parameters map[string]interface{}) map[string]interface{} {
+	if parameters == nil {
+		return nil
+	}
+	result := make(map[string]interface{}, len(parameters))
+	for k, v := range parameters {
+		result[k] = v
+	}
+	return result
+}
+
+type actionSchedulesById []*ActionSchedule
+
+func (s actionSchedulesById) Len() int      { return len(s) }
+func (s actionSchedulesById) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s actionSchedulesById) Less(i, j int) bool {
+	a, _ := strconv.Atoi(s[i].doc.Id)
+	b, _ := strconv.Atoi(s[j].doc.Id)
+	return a < b
+}
@@ -0,0 +1,236 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ActionScheduleSuite struct {
+	ConnSuite
+	clock *coretesting.Clock
+	unit  *state.Unit
+}
+
+var _ = gc.Suite(&ActionScheduleSuite{})
+
+// scheduleStart is a Wednesday.
+var scheduleStart = time.Date(2016, time.June, 15, 10, 30, 0, 0, time.UTC)
+
+func (s *ActionScheduleSuite) SetUpSuite(c *gc.C) {
+	s.ConnSuite.SetUpSuite(c)
+	s.PatchValue(&state.GetClock, func() clock.Clock {
+		return s.clock
+	})
+}
+
+func (s *ActionScheduleSuite) SetUpTest(c *gc.C) {
+	s.clock = coretesting.NewClock(scheduleStart)
+	s.ConnSuite.SetUpTest(c)
+	service := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	var err error
+	s.unit, err = service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionScheduleSuite) TestAddActionSchedule(c *gc.C) {
+	params := map[string]interface{}{"outfile": "snap.tar"}
+	schedule, err := s.unit.AddActionSchedule("snapshot", params, " 0  3 * * * ", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.Receiver(), gc.Equals, s.unit.Name())
+	c.Check(schedule.Name(), gc.Equals, "snapshot")
+	c.Check(schedule.Parameters(), jc.DeepEquals, params)
+	c.Check(schedule.Schedule(), gc.Equals, "0 3 * * *")
+	c.Check(schedule.Timeout(), gc.Equals, time.Minute)
+	c.Check(schedule.Created(), gc.Equals, scheduleStart)
+	c.Check(schedule.NextRun(), gc.Equals, time.Date(2016, time.June, 16, 3, 0, 0, 0, time.UTC))
+	c.Check(schedule.LastRun().IsZero(), jc.IsTrue)
+
+	stored, err := s.State.ActionSchedule(schedule.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(stored.Receiver(), gc.Equals, s.unit.Name())
+	c.Check(stored.Parameters(), jc.DeepEquals, params)
+	c.Check(stored.Schedule(), gc.Equals, "0 3 * * *")
+	c.Check(stored.Timeout(), gc.Equals, time.Minute)
+	c.Check(stored.NextRun().Equal(schedule.NextRun()), jc.IsTrue)
+	c.Check(stored.LastRun().IsZero(), jc.IsTrue)
+	c.Check(stored.LastAction(), gc.Equals, "")
+	c.Check(stored.LastError(), gc.Equals, "")
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleInvalid(c *gc.C) {
+	for i, test := range []struct {
+		name     string
+		params   map[string]interface{}
+		schedule string
+		timeout  time.Duration
+		err      string
+	}{{
+		name:     "snapshot",
+		schedule: "0 3 * *",
+		err:      `schedule "0 3 \* \*": expected 5 fields, got 4`,
+	}, {
+		name:     "snapshot",
+		schedule: "0 0 30 feb *",
+		err:      `schedule "0 0 30 feb \*", which never falls due, not valid`,
+	}, {
+		name:     "snapshot",
+		schedule: "@daily",
+		timeout:  -time.Second,
+		err:      "negative action timeout -1s not valid",
+	}, {
+		name:     "nonsense",
+		schedule: "@daily",
+		err:      `action "nonsense" not defined on unit "dummy/0"`,
+	}, {
+		name:     "snapshot",
+		params:   map[string]interface{}{"outfile": 5},
+		schedule: "@daily",
+		err:      `validation failed: \(root\).outfile : must be of type string, given 5`,
+	}} {
+		c.Logf("test %d: %s %q", i, test.name, test.schedule)
+		_, err := s.unit.AddActionSchedule(test.name, test.params, test.schedule, test.timeout)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	schedules, err := s.State.AllActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedules, gc.HasLen, 0)
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleDeadUnit(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.unit.AddActionSchedule("snapshot", nil, "@daily", 0)
+	c.Assert(err, gc.ErrorMatches, `cannot schedule action on unit "dummy/0": not found or not alive`)
+}
+
+func (s *ActionScheduleSuite) TestAllActionSchedules(c *gc.C) {
+	var ids []string
+	for i := 0; i < 11; i++ {
+		schedule, err := s.unit.AddActionSchedule("snapshot", nil, "@hourly", 0)
+		c.Assert(err, jc.ErrorIsNil)
+		ids = append(ids, schedule.Id())
+	}
+	schedules, err := s.State.AllActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	var found []string
+	for _, schedule := range schedules {
+		found = append(found, schedule.Id())
+	}
+	c.Check(found, jc.DeepEquals, ids)
+}
+
+func (s *ActionScheduleSuite) TestRemove(c *gc.C) {
+	schedule, err := s.unit.AddActionSchedule("snapshot", nil, "@daily", 0)
+	c.Assert(err, jc.ErrorIsNil)
+	err = schedule.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ActionSchedule(schedule.Id())
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+
+	// Removing a removed schedule is fine.
+	err = schedule.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionScheduleSuite) TestRunActionSchedule(c *gc.C) {
+	params := map[string]interface{}{"outfile": "snap.tar"}
+	schedule, err := s.unit.AddActionSchedule("snapshot", params, "0 * * * *", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The schedule is not due until 11:00.
+	action, err := s.State.RunActionSchedule(schedule.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(action, gc.IsNil)
+
+	s.clock.Advance(45 * time.Minute)
+	action, err = s.State.RunActionSchedule(schedule.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action, gc.NotNil)
+	c.Check(action.Name(), gc.Equals, "snapshot")
+	c.Check(action.Receiver(), gc.Equals, s.unit.Name())
+	c.Check(action.Parameters(), jc.DeepEquals, params)
+	c.Check(action.Timeout(), gc.Equals, time.Minute)
+	c.Check(action.Schedule(), gc.Equals, schedule.Id())
+
+	schedule, err = s.State.ActionSchedule(schedule.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.NextRun().Equal(time.Date(2016, time.June, 15, 12, 0, 0, 0, time.UTC)), jc.IsTrue)
+	c.Check(schedule.LastRun().Equal(scheduleStart.Add(45*time.Minute)), jc.IsTrue)
+	c.Check(schedule.LastAction(), gc.Equals, action.Id())
+	c.Check(schedule.LastError(), gc.Equals, "")
+
+	// Having run, the schedule is not due again until 12:00.
+	action, err = s.State.RunActionSchedule(schedule.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(action, gc.IsNil)
+	actions, err := s.unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(actions, gc.HasLen, 1)
+}
+
+func (s *ActionScheduleSuite) TestRunActionScheduleSkipsMissedRuns(c *gc.C) {
+	schedule, err := s.unit.AddActionSchedule("snapshot", nil, "0 * * * *", 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.clock.Advance(3 * time.Hour)
+	action, err := s.State.RunActionSchedule(schedule.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action, gc.NotNil)
+	schedule, err = s.State.ActionSchedule(schedule.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.NextRun().Equal(time.Date(2016, time.June, 15, 14, 0, 0, 0, time.UTC)), jc.IsTrue)
+}
+
+func (s *ActionScheduleSuite) TestRunActionScheduleRecordsError(c *gc.C) {
+	schedule, err := s.unit.AddActionSchedule("snapshot", nil, "0 * * * *", 0)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.clock.Advance(time.Hour)
+	_, err = s.State.RunActionSchedule(schedule.Id())
+	c.Assert(err, gc.ErrorMatches, `cannot run action schedule "0": unit "dummy/0" not found`)
+
+	schedule, err = s.State.ActionSchedule(schedule.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.NextRun().Equal(time.Date(2016, time.June, 15, 12, 0, 0, 0, time.UTC)), jc.IsTrue)
+	c.Check(schedule.LastAction(), gc.Equals, "")
+	c.Check(schedule.LastError(), gc.Equals, `unit "dummy/0" not found`)
+}
+
+func (s *ActionScheduleSuite) TestRunActionScheduleNotFound(c *gc.C) {
+	_, err := s.State.RunActionSchedule("42")
+	c.Assert(err, gc.ErrorMatches, `action schedule "42" not found`)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ActionScheduleSuite) TestWatchActionSchedules(c *gc.C) {
+	w := s.State.WatchActionSchedules()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange()
+	wc.AssertNoChange()
+
+	schedule, err := s.unit.AddActionSchedule("snapshot", nil, "0 * * * *", 0)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(schedule.Id())
+	wc.AssertNoChange()
+
+	err = schedule.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(schedule.Id())
+	wc.AssertNoChange()
+}
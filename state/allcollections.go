@@ -134,6 +134,10 @@ func allCollections() collectionSchema {
 			}},
 		},
 
+		// This collection holds the level of access each user has to the
+		// controller itself, as opposed to the models it hosts.
+		controllerUsersC: {global: true},
+
 		// This collection holds the last time the user connected to the API server.
 		userLastLoginC: {
 			global:    true,
@@ -163,6 +167,23 @@ func allCollections() collectionSchema {
 		// was implemented.
 		actionresultsC: {global: true},
 
+		// This collection holds the audit trail of API requests made
+		// by users, across all models. It is written directly rather
+		// than through transactions, like other logs.
+		auditLogC: {
+			global:    true,
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"time"},
+			}, {
+				Key: []string{"user", "time"},
+			}, {
+				Key: []string{"model-uuid", "time"},
+			}, {
+				Key: []string{"seq"},
+			}},
+		},
+
 		// This collection holds storage items for a macaroon bakery.
 		bakeryStorageItemsC: {
 			global:  true,
@@ -316,6 +337,11 @@ func allCollections() collectionSchema {
 			}},
 		},
 		actionNotificationsC: {},
+		actionSchedulesC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "receiver"},
+			}},
+		},
 
 		// -----
 
@@ -374,9 +400,11 @@ func allCollections() collectionSchema {
 const (
 	actionNotificationsC     = "actionnotifications"
 	actionresultsC           = "actionresults"
+	actionSchedulesC         = "actionschedules"
 	actionsC                 = "actions"
 	annotationsC             = "annotations"
 	assignUnitC              = "assignUnits"
+	auditLogC                = "auditlog"
 	bakeryStorageItemsC      = "bakeryStorageItems"
 	blockDevicesC            = "blockdevices"
 	blocksC                  = "blocks"
@@ -386,6 +414,7 @@ const (
 	constraintsC             = "constraints"
 	containerRefsC           = "containerRefs"
 	controllersC             = "controllers"
+	controllerUsersC         = "controllerusers"
 	filesystemAttachmentsC   = "filesystemAttachments"
 	filesystemsC             = "filesystems"
 	guimetadataC             = "guimetadata"
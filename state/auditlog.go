@@ -0,0 +1,262 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// AuditEntry records a single API request made by a user.
+type AuditEntry struct {
+	// Id uniquely identifies the entry. It is set by the controller
+	// when the entry is recorded.
+	Id string
+
+	// Time is when the request was received.
+	Time time.Time
+
+	// ModelUUID identifies the model the request was made against.
+	ModelUUID string
+
+	// User is the canonical name of the user who made the request.
+	User string
+
+	// Facade, Version and Method identify the API method called.
+	Facade  string
+	Version int
+	Method  string
+
+	// Args holds the JSON encoded request arguments, with any secrets
+	// removed.
+	Args string
+
+	// Error holds the error returned by the request, if it failed.
+	Error string
+
+	// Duration is how long the request took to complete.
+	Duration time.Duration
+
+	// Recorded is when the entry was written to the audit log. It is
+	// set by the controller when the entry is recorded.
+	Recorded time.Time
+
+	// Seq numbers the entries in the order they were added to the
+	// audit log. It is set by the controller when the entry is
+	// recorded.
+	Seq int64
+}
+
+// AuditFilter selects the audit entries returned by AuditEntries. Zero
+// valued fields match all entries.
+type AuditFilter struct {
+	From      time.Time
+	To        time.Time
+	User      string
+	ModelUUID string
+
+	// Limit bounds the number of entries returned, keeping the most
+	// recent ones.
+	Limit int
+}
+
+type auditEntryDoc struct {
+	Id        bson.ObjectId `bson:"_id"`
+	Time      int64         `bson:"time"`
+	ModelUUID string        `bson:"model-uuid"`
+	User      string        `bson:"user"`
+	Facade    string        `bson:"facade"`
+	Version   int           `bson:"version"`
+	Method    string        `bson:"method"`
+	Args      string        `bson:"args,omitempty"`
+	Error     string        `bson:"error,omitempty"`
+	Duration  int64         `bson:"duration"`
+	Recorded  int64         `bson:"recorded"`
+	Seq       int64         `bson:"seq"`
+}
+
+// AddAuditEntry records the audit entry in the controller. The entry
+// is not part of any transaction.
+func (st *State) AddAuditEntry(entry AuditEntry) error {
+	seq, err := st.sequence(auditLogC)
+	if err != nil {
+		return errors.Annotate(err, "cannot add audit entry")
+	}
+	auditLog, closer := st.getRawCollection(auditLogC)
+	defer closer()
+
+	doc := auditEntryDoc{
+		Id:        bson.NewObjectId(),
+		Time:      entry.Time.UnixNano(),
+		ModelUUID: entry.ModelUUID,
+		User:      entry.User,
+		Facade:    entry.Facade,
+		Version:   entry.Version,
+		Method:    entry.Method,
+		Args:      entry.Args,
+		Error:     entry.Error,
+		Duration:  int64(entry.Duration),
+		Recorded:  time.Now().UnixNano(),
+		Seq:       int64(seq),
+	}
+	if err := auditLog.Insert(&doc); err != nil {
+		return errors.Annotate(err, "cannot add audit entry")
+	}
+	return nil
+}
+
+// AuditEntries returns the audit entries matching the filter, oldest
+// first.
+func (st *State) AuditEntries(filter AuditFilter) ([]AuditEntry, error) {
+	auditLog, closer := st.getRawCollection(auditLogC)
+	defer closer()
+
+	query := bson.M{}
+	timeRange := bson.M{}
+	if !filter.From.IsZero() {
+		timeRange["$gte"] = filter.From.UnixNano()
+	}
+	if !filter.To.IsZero() {
+		timeRange["$lte"] = filter.To.UnixNano()
+	}
+	if len(timeRange) > 0 {
+		query["time"] = timeRange
+	}
+	if filter.User != "" {
+		query["user"] = filter.User
+	}
+	if filter.ModelUUID != "" {
+		query["model-uuid"] = filter.ModelUUID
+	}
+
+	var q *mgo.Query
+	if filter.Limit > 0 {
+		// Fetch the most recent entries, then put them back in order.
+		q = auditLog.Find(query).Sort("-time", "-_id").Limit(filter.Limit)
+	} else {
+		q = auditLog.Find(query).Sort("time", "_id")
+	}
+	var docs []auditEntryDoc
+	if err := q.All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read audit entries")
+	}
+	if filter.Limit > 0 {
+		for i, j := 0, len(docs)-1; i < j; i, j = i+1, j-1 {
+			docs[i], docs[j] = docs[j], docs[i]
+		}
+	}
+	return auditEntriesFromDocs(docs), nil
+}
+
+// AuditEntriesAfter returns up to limit audit entries numbered after
+// seq, in sequence order. It is used to forward the audit log
+// elsewhere. Sequence numbers start at zero.
+//
+// Entries written concurrently by different controllers may become
+// visible out of order, so the entries returned can skip numbers that
+// are filled in later.
+func (st *State) AuditEntriesAfter(seq int64, limit int) ([]AuditEntry, error) {
+	auditLog, closer := st.getRawCollection(auditLogC)
+	defer closer()
+
+	query := bson.M{"seq": bson.M{"$gt": seq}}
+	var docs []auditEntryDoc
+	if err := auditLog.Find(query).Sort("seq").Limit(limit).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read audit entries")
+	}
+	return auditEntriesFromDocs(docs), nil
+}
+
+// PruneAuditEntries removes the audit entries for requests received
+// before minTime. Only entries numbered up to maxSeq are removed, so
+// that entries not yet forwarded to a sink are kept.
+func (st *State) PruneAuditEntries(minTime time.Time, maxSeq int64) error {
+	auditLog, closer := st.getRawCollection(auditLogC)
+	defer closer()
+
+	_, err := auditLog.RemoveAll(bson.M{
+		"time": bson.M{"$lt": minTime.UnixNano()},
+		"seq":  bson.M{"$lte": maxSeq},
+	})
+	if err != nil {
+		return errors.Annotate(err, "cannot prune audit entries")
+	}
+	return nil
+}
+
+func auditEntriesFromDocs(docs []auditEntryDoc) []AuditEntry {
+	entries := make([]AuditEntry, len(docs))
+	for i, doc := range docs {
+		entries[i] = AuditEntry{
+			Id:        doc.Id.Hex(),
+			Time:      time.Unix(0, doc.Time).UTC(),
+			ModelUUID: doc.ModelUUID,
+			User:      doc.User,
+			Facade:    doc.Facade,
+			Version:   doc.Version,
+			Method:    doc.Method,
+			Args:      doc.Args,
+			Error:     doc.Error,
+			Duration:  time.Duration(doc.Duration),
+			Recorded:  time.Unix(0, doc.Recorded).UTC(),
+			Seq:       doc.Seq,
+		}
+	}
+	return entries
+}
+
+// auditForwardedDoc records the sequence number of the last audit
+// entry forwarded to a sink.
+type auditForwardedDoc struct {
+	ID   string `bson:"_id"`
+	Sink string `bson:"sink"`
+	Seq  int64  `bson:"seq"`
+}
+
+// AuditForwardPosition records and retrieves how far through the audit
+// log entries have been forwarded to a sink.
+type AuditForwardPosition struct {
+	session *mgo.Session
+	id      string
+	sink    string
+}
+
+// NewAuditForwardPosition returns an AuditForwardPosition for the
+// named sink.
+func NewAuditForwardPosition(st LoggingState, sink string) *AuditForwardPosition {
+	return &AuditForwardPosition{
+		session: st.MongoSession(),
+		id:      fmt.Sprintf("%v#%v", st.ModelUUID(), sink),
+		sink:    sink,
+	}
+}
+
+// Set records the sequence number of the last audit entry forwarded.
+func (p *AuditForwardPosition) Set(seq int64) error {
+	collection := p.session.DB(logsDB).C(forwardedC)
+	_, err := collection.UpsertId(p.id, auditForwardedDoc{
+		ID:   p.id,
+		Sink: p.sink,
+		Seq:  seq,
+	})
+	return errors.Trace(err)
+}
+
+// Get retrieves the sequence number of the last audit entry forwarded.
+// If nothing has been forwarded to the sink, ErrNeverForwarded is
+// returned.
+func (p *AuditForwardPosition) Get() (int64, error) {
+	collection := p.session.DB(logsDB).C(forwardedC)
+	var doc auditForwardedDoc
+	if err := collection.FindId(p.id).One(&doc); err == mgo.ErrNotFound {
+		return 0, errors.Trace(ErrNeverForwarded)
+	} else if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return doc.Seq, nil
+}
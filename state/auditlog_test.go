@@ -0,0 +1,178 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"math"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type AuditLogSuite struct {
+	ConnSuite
+	base time.Time
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+func (s *AuditLogSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.base = time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func (s *AuditLogSuite) addEntry(c *gc.C, offset time.Duration, user, modelUUID, method string) state.AuditEntry {
+	entry := state.AuditEntry{
+		Time:      s.base.Add(offset),
+		ModelUUID: modelUUID,
+		User:      user,
+		Facade:    "Client",
+		Version:   1,
+		Method:    method,
+		Args:      `{"some":"args"}`,
+		Duration:  time.Second,
+	}
+	err := s.State.AddAuditEntry(entry)
+	c.Assert(err, jc.ErrorIsNil)
+	return entry
+}
+
+func (s *AuditLogSuite) methods(entries []state.AuditEntry) []string {
+	var methods []string
+	for _, entry := range entries {
+		methods = append(methods, entry.Method)
+	}
+	return methods
+}
+
+func (s *AuditLogSuite) TestAddAndRead(c *gc.C) {
+	entry := s.addEntry(c, 0, "bob@local", "model-1", "FullStatus")
+	entry.Error = ""
+
+	entries, err := s.State.AuditEntries(state.AuditFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Assert(entries[0].Id, gc.Not(gc.Equals), "")
+	c.Assert(entries[0].Recorded.IsZero(), jc.IsFalse)
+	entry.Id = entries[0].Id
+	entry.Recorded = entries[0].Recorded
+	entry.Seq = entries[0].Seq
+	c.Assert(entries[0], jc.DeepEquals, entry)
+}
+
+func (s *AuditLogSuite) TestOrderedByTime(c *gc.C) {
+	s.addEntry(c, 2*time.Minute, "bob@local", "model-1", "Third")
+	s.addEntry(c, 0, "bob@local", "model-1", "First")
+	s.addEntry(c, time.Minute, "bob@local", "model-1", "Second")
+
+	entries, err := s.State.AuditEntries(state.AuditFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.methods(entries), jc.DeepEquals, []string{"First", "Second", "Third"})
+}
+
+func (s *AuditLogSuite) TestFilterTime(c *gc.C) {
+	s.addEntry(c, 0, "bob@local", "model-1", "First")
+	s.addEntry(c, time.Minute, "bob@local", "model-1", "Second")
+	s.addEntry(c, 2*time.Minute, "bob@local", "model-1", "Third")
+
+	entries, err := s.State.AuditEntries(state.AuditFilter{
+		From: s.base.Add(30 * time.Second),
+		To:   s.base.Add(time.Minute),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.methods(entries), jc.DeepEquals, []string{"Second"})
+}
+
+func (s *AuditLogSuite) TestFilterUserAndModel(c *gc.C) {
+	s.addEntry(c, 0, "bob@local", "model-1", "BobOne")
+	s.addEntry(c, time.Minute, "mary@local", "model-1", "MaryOne")
+	s.addEntry(c, 2*time.Minute, "bob@local", "model-2", "BobTwo")
+
+	entries, err := s.State.AuditEntries(state.AuditFilter{User: "bob@local"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.methods(entries), jc.DeepEquals, []string{"BobOne", "BobTwo"})
+
+	entries, err = s.State.AuditEntries(state.AuditFilter{ModelUUID: "model-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.methods(entries), jc.DeepEquals, []string{"BobOne", "MaryOne"})
+
+	entries, err = s.State.AuditEntries(state.AuditFilter{User: "bob@local", ModelUUID: "model-2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.methods(entries), jc.DeepEquals, []string{"BobTwo"})
+}
+
+func (s *AuditLogSuite) TestLimitKeepsMostRecent(c *gc.C) {
+	s.addEntry(c, 0, "bob@local", "model-1", "First")
+	s.addEntry(c, time.Minute, "bob@local", "model-1", "Second")
+	s.addEntry(c, 2*time.Minute, "bob@local", "model-1", "Third")
+
+	entries, err := s.State.AuditEntries(state.AuditFilter{Limit: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.methods(entries), jc.DeepEquals, []string{"Second", "Third"})
+}
+
+func (s *AuditLogSuite) TestEntriesAfter(c *gc.C) {
+	// Entries are returned in the order they were written, not the
+	// order the requests were made.
+	s.addEntry(c, time.Minute, "bob@local", "model-1", "First")
+	s.addEntry(c, 0, "bob@local", "model-1", "Second")
+	s.addEntry(c, 2*time.Minute, "bob@local", "model-1", "Third")
+
+	entries, err := s.State.AuditEntriesAfter(-1, 10)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.methods(entries), jc.DeepEquals, []string{"First", "Second", "Third"})
+	c.Assert(entries[1].Seq, gc.Equals, entries[0].Seq+1)
+	c.Assert(entries[2].Seq, gc.Equals, entries[1].Seq+1)
+
+	entries, err = s.State.AuditEntriesAfter(entries[0].Seq, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.methods(entries), jc.DeepEquals, []string{"Second"})
+}
+
+func (s *AuditLogSuite) TestForwardPosition(c *gc.C) {
+	position := state.NewAuditForwardPosition(s.State, "audit:sink")
+	_, err := position.Get()
+	c.Assert(errors.Cause(err), gc.Equals, state.ErrNeverForwarded)
+
+	err = position.Set(42)
+	c.Assert(err, jc.ErrorIsNil)
+	seq, err := position.Get()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(seq, gc.Equals, int64(42))
+
+	// Positions are kept separately for each sink.
+	_, err = state.NewAuditForwardPosition(s.State, "audit:other").Get()
+	c.Assert(errors.Cause(err), gc.Equals, state.ErrNeverForwarded)
+}
+
+func (s *AuditLogSuite) TestPruneAuditEntries(c *gc.C) {
+	s.addEntry(c, 0, "bob@local", "model-1", "Old")
+	s.addEntry(c, time.Hour, "bob@local", "model-1", "New")
+
+	err := s.State.PruneAuditEntries(s.base.Add(time.Minute), math.MaxInt64)
+	c.Assert(err, jc.ErrorIsNil)
+
+	entries, err := s.State.AuditEntries(state.AuditFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.methods(entries), jc.DeepEquals, []string{"New"})
+}
+
+func (s *AuditLogSuite) TestPruneAuditEntriesKeepsUnforwarded(c *gc.C) {
+	s.addEntry(c, 0, "bob@local", "model-1", "Forwarded")
+	s.addEntry(c, time.Second, "bob@local", "model-1", "Unforwarded")
+	entries, err := s.State.AuditEntries(state.AuditFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 2)
+
+	err = s.State.PruneAuditEntries(s.base.Add(time.Hour), entries[0].Seq)
+	c.Assert(err, jc.ErrorIsNil)
+
+	entries, err = s.State.AuditEntries(state.AuditFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.methods(entries), jc.DeepEquals, []string{"Unforwarded"})
+}
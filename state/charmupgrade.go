@@ -0,0 +1,255 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// CharmUpgradeStrategy describes how a service's units are moved to a
+// new charm during a rolling upgrade.
+type CharmUpgradeStrategy struct {
+	// BatchSize is the number of units upgraded at once.
+	BatchSize int `json:"batchsize"`
+
+	// Pause is how long to wait, once a batch of units has upgraded,
+	// before upgrading the next batch.
+	Pause time.Duration `json:"pause"`
+
+	// HaltOnError, if true, stops the upgrade when an upgraded unit
+	// goes into an error state.
+	HaltOnError bool `json:"haltonerror"`
+}
+
+// Validate returns an error if the strategy is not valid.
+func (s CharmUpgradeStrategy) Validate() error {
+	if s.BatchSize < 1 {
+		return errors.NotValidf("batch size %d", s.BatchSize)
+	}
+	if s.Pause < 0 {
+		return errors.NotValidf("pause %s", s.Pause)
+	}
+	return nil
+}
+
+// CharmUpgrade describes a rolling charm upgrade in progress.
+type CharmUpgrade struct {
+	// FromCharmURL is the charm that units run until they are
+	// released to upgrade.
+	FromCharmURL *charm.URL
+
+	// ToCharmURL is the charm that units are being upgraded to.
+	ToCharmURL *charm.URL
+
+	// Strategy describes how the units are upgraded.
+	Strategy CharmUpgradeStrategy
+
+	// Released holds the names of the units that have been released
+	// to upgrade to the new charm.
+	Released []string
+
+	// HaltReason, if not empty, explains why the upgrade was halted.
+	// No more units are released until the upgrade is resumed.
+	HaltReason string
+}
+
+// charmUpgradeDoc records the progress of a rolling charm upgrade. It
+// is held in the service document, so that the service's units are
+// notified when they are released to upgrade.
+type charmUpgradeDoc struct {
+	FromCharmURL             *charm.URL    `bson:"fromcharmurl"`
+	FromCharmModifiedVersion int           `bson:"fromcharmmodifiedversion"`
+	BatchSize                int           `bson:"batchsize"`
+	Pause                    time.Duration `bson:"pause"`
+	HaltOnError              bool          `bson:"haltonerror"`
+	Released                 []string      `bson:"released"`
+	HaltReason               string        `bson:"haltreason"`
+}
+
+// charmUpgradeOps returns the operations needed to start a rolling
+// upgrade from the service's current charm when its charm is changed,
+// along with the upgrade that will be in progress once they have run.
+// If strategy is nil, all units are upgraded at once, replacing any
+// rolling upgrade in progress.
+func charmUpgradeOps(doc *serviceDoc, strategy *CharmUpgradeStrategy) ([]txn.Op, *charmUpgradeDoc, error) {
+	if strategy == nil {
+		if doc.CharmUpgrade == nil {
+			return nil, nil, nil
+		}
+		return []txn.Op{{
+			C:      servicesC,
+			Id:     doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$unset", bson.D{{"charmupgrade", nil}}}},
+		}}, nil, nil
+	}
+	if doc.CharmUpgrade != nil {
+		return nil, nil, errors.Errorf("a rolling charm upgrade is already in progress")
+	}
+	upgrade := &charmUpgradeDoc{
+		FromCharmURL:             doc.CharmURL,
+		FromCharmModifiedVersion: doc.CharmModifiedVersion,
+		BatchSize:                strategy.BatchSize,
+		Pause:                    strategy.Pause,
+		HaltOnError:              strategy.HaltOnError,
+	}
+	return []txn.Op{{
+		C:      servicesC,
+		Id:     doc.DocID,
+		Assert: bson.D{{"charmupgrade", bson.D{{"$exists", false}}}},
+		Update: bson.D{{"$set", bson.D{{"charmupgrade", upgrade}}}},
+	}}, upgrade, nil
+}
+
+// CharmUpgrade returns the rolling charm upgrade in progress for the
+// service. It returns an error satisfying errors.IsNotFound if there
+// is none.
+func (s *Service) CharmUpgrade() (*CharmUpgrade, error) {
+	doc := s.doc.CharmUpgrade
+	if doc == nil {
+		return nil, errors.NotFoundf("rolling charm upgrade for service %q", s)
+	}
+	return &CharmUpgrade{
+		FromCharmURL: doc.FromCharmURL,
+		ToCharmURL:   s.doc.CharmURL,
+		Strategy: CharmUpgradeStrategy{
+			BatchSize:   doc.BatchSize,
+			Pause:       doc.Pause,
+			HaltOnError: doc.HaltOnError,
+		},
+		Released:   append([]string(nil), doc.Released...),
+		HaltReason: doc.HaltReason,
+	}, nil
+}
+
+// charmUpgradeHeldBack reports whether the named unit is being held on
+// the previous charm by a rolling upgrade.
+func (s *Service) charmUpgradeHeldBack(unitName string) bool {
+	doc := s.doc.CharmUpgrade
+	if doc == nil {
+		return false
+	}
+	for _, released := range doc.Released {
+		if released == unitName {
+			return false
+		}
+	}
+	return true
+}
+
+// UnitCharmURL returns the charm URL that the named unit should run,
+// and whether it should upgrade to that charm even if it is in an error
+// state. This is the service's charm URL, unless a rolling upgrade is
+// in progress and the unit has not yet been released to upgrade.
+func (s *Service) UnitCharmURL(unitName string) (curl *charm.URL, force bool) {
+	if s.charmUpgradeHeldBack(unitName) {
+		return s.doc.CharmUpgrade.FromCharmURL, false
+	}
+	return s.CharmURL()
+}
+
+// UnitCharmModifiedVersion returns the CharmModifiedVersion that the
+// named unit should see, in the same way as UnitCharmURL.
+func (s *Service) UnitCharmModifiedVersion(unitName string) int {
+	if s.charmUpgradeHeldBack(unitName) {
+		return s.doc.CharmUpgrade.FromCharmModifiedVersion
+	}
+	return s.CharmModifiedVersion()
+}
+
+// ReleaseCharmUpgradeUnits releases the named units to upgrade to the
+// service's charm during a rolling upgrade.
+func (s *Service) ReleaseCharmUpgradeUnits(unitNames []string) error {
+	if len(unitNames) == 0 {
+		return nil
+	}
+	curl := s.doc.CharmURL
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := s.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if s.doc.CharmUpgrade == nil || *s.doc.CharmURL != *curl {
+			return nil, errors.Errorf("rolling charm upgrade to %q is no longer in progress", curl)
+		}
+		if s.doc.CharmUpgrade.HaltReason != "" {
+			return nil, errors.Errorf("rolling charm upgrade is halted: %s", s.doc.CharmUpgrade.HaltReason)
+		}
+		return []txn.Op{{
+			C:  servicesC,
+			Id: s.doc.DocID,
+			Assert: bson.D{
+				{"charmurl", curl},
+				{"charmupgrade.haltreason", ""},
+			},
+			Update: bson.D{{"$addToSet", bson.D{
+				{"charmupgrade.released", bson.D{{"$each", unitNames}}},
+			}}},
+		}}, nil
+	}
+	if err := s.st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot release units of service %q to upgrade", s)
+	}
+	return errors.Trace(s.Refresh())
+}
+
+// HaltCharmUpgrade stops a rolling charm upgrade from releasing any more
+// units, recording the reason.
+func (s *Service) HaltCharmUpgrade(reason string) error {
+	if reason == "" {
+		return errors.NotValidf("empty halt reason")
+	}
+	err := s.updateCharmUpgrade(bson.D{{"$set", bson.D{{"charmupgrade.haltreason", reason}}}})
+	return errors.Annotatef(err, "cannot halt rolling charm upgrade of service %q", s)
+}
+
+// ResumeCharmUpgrade allows a halted rolling charm upgrade to continue.
+func (s *Service) ResumeCharmUpgrade() error {
+	err := s.updateCharmUpgrade(bson.D{{"$set", bson.D{{"charmupgrade.haltreason", ""}}}})
+	return errors.Annotatef(err, "cannot resume rolling charm upgrade of service %q", s)
+}
+
+// FinishCharmUpgrade records that a rolling charm upgrade is complete.
+func (s *Service) FinishCharmUpgrade() error {
+	err := s.updateCharmUpgrade(bson.D{{"$unset", bson.D{{"charmupgrade", nil}}}})
+	return errors.Annotatef(err, "cannot finish rolling charm upgrade of service %q", s)
+}
+
+// updateCharmUpgrade applies update to the service document, as long as
+// the rolling charm upgrade in progress has not changed charm.
+func (s *Service) updateCharmUpgrade(update bson.D) error {
+	if s.doc.CharmUpgrade == nil {
+		return errors.NotFoundf("rolling charm upgrade")
+	}
+	curl := s.doc.CharmURL
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := s.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+			if s.doc.CharmUpgrade == nil || *s.doc.CharmURL != *curl {
+				return nil, errors.NotFoundf("rolling charm upgrade")
+			}
+		}
+		return []txn.Op{{
+			C:  servicesC,
+			Id: s.doc.DocID,
+			Assert: bson.D{
+				{"charmurl", curl},
+				{"charmupgrade", bson.D{{"$exists", true}}},
+			},
+			Update: update,
+		}}, nil
+	}
+	if err := s.st.run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(s.Refresh())
+}
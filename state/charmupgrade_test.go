@@ -0,0 +1,192 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type CharmUpgradeSuite struct {
+	ConnSuite
+	charm    *state.Charm
+	newCharm *state.Charm
+	mysql    *state.Service
+}
+
+var _ = gc.Suite(&CharmUpgradeSuite{})
+
+func (s *CharmUpgradeSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.charm = s.AddTestingCharm(c, "mysql")
+	s.newCharm = s.AddMetaCharm(c, "mysql", metaBase, 2)
+	s.mysql = s.AddTestingService(c, "mysql", s.charm)
+}
+
+func (s *CharmUpgradeSuite) startRollingUpgrade(c *gc.C) {
+	err := s.mysql.SetCharm(state.SetCharmConfig{
+		Charm: s.newCharm,
+		RollingUpgrade: &state.CharmUpgradeStrategy{
+			BatchSize:   2,
+			Pause:       time.Minute,
+			HaltOnError: true,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CharmUpgradeSuite) TestNoCharmUpgrade(c *gc.C) {
+	_, err := s.mysql.CharmUpgrade()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	curl, _ := s.mysql.UnitCharmURL("mysql/0")
+	c.Assert(curl, gc.DeepEquals, s.charm.URL())
+}
+
+func (s *CharmUpgradeSuite) TestSetCharmInvalidStrategy(c *gc.C) {
+	err := s.mysql.SetCharm(state.SetCharmConfig{
+		Charm:          s.newCharm,
+		RollingUpgrade: &state.CharmUpgradeStrategy{},
+	})
+	c.Assert(err, gc.ErrorMatches, "batch size 0 not valid")
+}
+
+func (s *CharmUpgradeSuite) TestStartRollingUpgrade(c *gc.C) {
+	oldVersion := s.mysql.CharmModifiedVersion()
+	s.startRollingUpgrade(c)
+
+	for _, svc := range []*state.Service{s.mysql, s.reload(c)} {
+		upgrade, err := svc.CharmUpgrade()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(upgrade, jc.DeepEquals, &state.CharmUpgrade{
+			FromCharmURL: s.charm.URL(),
+			ToCharmURL:   s.newCharm.URL(),
+			Strategy: state.CharmUpgradeStrategy{
+				BatchSize:   2,
+				Pause:       time.Minute,
+				HaltOnError: true,
+			},
+		})
+
+		// Units are held on the old charm until released.
+		curl, force := svc.UnitCharmURL("mysql/0")
+		c.Assert(curl, gc.DeepEquals, s.charm.URL())
+		c.Assert(force, jc.IsFalse)
+		c.Assert(svc.UnitCharmModifiedVersion("mysql/0"), gc.Equals, oldVersion)
+	}
+}
+
+func (s *CharmUpgradeSuite) TestStartRollingUpgradeWhileInProgress(c *gc.C) {
+	s.startRollingUpgrade(c)
+	err := s.mysql.SetCharm(state.SetCharmConfig{
+		Charm:          s.AddMetaCharm(c, "mysql", metaBase, 3),
+		RollingUpgrade: &state.CharmUpgradeStrategy{BatchSize: 1},
+	})
+	c.Assert(err, gc.ErrorMatches, "a rolling charm upgrade is already in progress")
+}
+
+func (s *CharmUpgradeSuite) TestSetCharmReplacesRollingUpgrade(c *gc.C) {
+	s.startRollingUpgrade(c)
+	newerCharm := s.AddMetaCharm(c, "mysql", metaBase, 3)
+	err := s.mysql.SetCharm(state.SetCharmConfig{Charm: newerCharm})
+	c.Assert(err, jc.ErrorIsNil)
+
+	svc := s.reload(c)
+	_, err = svc.CharmUpgrade()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	curl, _ := svc.UnitCharmURL("mysql/0")
+	c.Assert(curl, gc.DeepEquals, newerCharm.URL())
+}
+
+func (s *CharmUpgradeSuite) TestReleaseCharmUpgradeUnits(c *gc.C) {
+	s.startRollingUpgrade(c)
+	err := s.mysql.ReleaseCharmUpgradeUnits([]string{"mysql/0", "mysql/1"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.ReleaseCharmUpgradeUnits([]string{"mysql/1"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	svc := s.reload(c)
+	upgrade, err := svc.CharmUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade.Released, jc.DeepEquals, []string{"mysql/0", "mysql/1"})
+
+	curl, _ := svc.UnitCharmURL("mysql/1")
+	c.Assert(curl, gc.DeepEquals, s.newCharm.URL())
+	c.Assert(svc.UnitCharmModifiedVersion("mysql/1"), gc.Equals, svc.CharmModifiedVersion())
+	curl, _ = svc.UnitCharmURL("mysql/2")
+	c.Assert(curl, gc.DeepEquals, s.charm.URL())
+}
+
+func (s *CharmUpgradeSuite) TestHaltAndResumeCharmUpgrade(c *gc.C) {
+	s.startRollingUpgrade(c)
+	err := s.mysql.HaltCharmUpgrade("unit mysql/0 is in error")
+	c.Assert(err, jc.ErrorIsNil)
+
+	upgrade, err := s.reload(c).CharmUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade.HaltReason, gc.Equals, "unit mysql/0 is in error")
+
+	err = s.mysql.ReleaseCharmUpgradeUnits([]string{"mysql/1"})
+	c.Assert(err, gc.ErrorMatches, `cannot release units of service "mysql" to upgrade: rolling charm upgrade is halted: unit mysql/0 is in error`)
+
+	err = s.mysql.ResumeCharmUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	upgrade, err = s.reload(c).CharmUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade.HaltReason, gc.Equals, "")
+	err = s.mysql.ReleaseCharmUpgradeUnits([]string{"mysql/1"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CharmUpgradeSuite) TestFinishCharmUpgrade(c *gc.C) {
+	s.startRollingUpgrade(c)
+	err := s.mysql.FinishCharmUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.reload(c).CharmUpgrade()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	curl, _ := s.mysql.UnitCharmURL("mysql/0")
+	c.Assert(curl, gc.DeepEquals, s.newCharm.URL())
+
+	err = s.mysql.ResumeCharmUpgrade()
+	c.Assert(err, gc.ErrorMatches, `cannot resume rolling charm upgrade of service "mysql": rolling charm upgrade not found`)
+}
+
+func (s *CharmUpgradeSuite) TestWatchCharmUpgrades(c *gc.C) {
+	w := s.State.WatchCharmUpgrades()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange()
+	wc.AssertNoChange()
+
+	// Changes to services without upgrades are not reported.
+	err := s.mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	s.startRollingUpgrade(c)
+	wc.AssertChange("mysql")
+	wc.AssertNoChange()
+
+	// Progress of an upgrade already in progress is not reported.
+	err = s.mysql.ReleaseCharmUpgradeUnits([]string{"mysql/0"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	err = s.mysql.FinishCharmUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange("mysql")
+	wc.AssertNoChange()
+}
+
+func (s *CharmUpgradeSuite) reload(c *gc.C) *state.Service {
+	svc, err := s.State.Service(s.mysql.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	return svc
+}
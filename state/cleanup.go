@@ -326,7 +326,7 @@ func (st *State) cleanupRemovedUnit(unitId string) error {
 			return err
 		}
 	}
-	return nil
+	return st.removeActionSchedulesForUnit(unitId)
 }
 
 // cleanupDyingMachine marks resources owned by the machine as dying, to ensure
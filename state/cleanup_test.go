@@ -391,6 +391,10 @@ func (s *CleanupSuite) TestCleanupActions(c *gc.C) {
 	_, err = unit.AddAction("snapshot", nil)
 	c.Assert(err, jc.ErrorIsNil)
 
+	// ...and a schedule to add more.
+	schedule, err := unit.AddActionSchedule("snapshot", nil, "@daily", 0)
+	c.Assert(err, jc.ErrorIsNil)
+
 	// make sure unit still has actions
 	actions, err := unit.PendingActions()
 	c.Assert(err, jc.ErrorIsNil)
@@ -414,6 +418,10 @@ func (s *CleanupSuite) TestCleanupActions(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(len(actions), gc.Equals, 0)
 
+	// ...and that its schedule is gone too
+	_, err = s.State.ActionSchedule(schedule.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
 	// check no cleanups
 	s.assertDoesNotNeedCleanup(c)
 }
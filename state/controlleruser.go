@@ -0,0 +1,222 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ControllerAccess represents the level of access granted to a user on
+// the controller, as opposed to any particular model hosted by it.
+type ControllerAccess string
+
+const (
+	// ControllerUndefinedAccess is not a valid access type. It is the value
+	// unmarshaled when access is not defined by the document at all.
+	ControllerUndefinedAccess ControllerAccess = ""
+
+	// ControllerLoginAccess allows a user to log in to the controller, and
+	// to use any models they have been given access to.
+	ControllerLoginAccess ControllerAccess = "login"
+
+	// ControllerAddModelAccess allows a user to add models to the
+	// controller, in addition to logging in.
+	ControllerAddModelAccess ControllerAccess = "add-model"
+
+	// ControllerSuperuserAccess allows a user full control over the
+	// controller and every model it hosts.
+	ControllerSuperuserAccess ControllerAccess = "superuser"
+)
+
+// controllerAccessLevels orders the controller access types from least to
+// most permissive.
+var controllerAccessLevels = map[ControllerAccess]int{
+	ControllerLoginAccess:     1,
+	ControllerAddModelAccess:  2,
+	ControllerSuperuserAccess: 3,
+}
+
+// Validate returns an error if the access is not one of the known
+// controller access levels.
+func (a ControllerAccess) Validate() error {
+	if _, ok := controllerAccessLevels[a]; !ok {
+		return errors.NotValidf("controller access %q", a)
+	}
+	return nil
+}
+
+// Includes returns whether the access grants at least the permissions
+// of the other access.
+func (a ControllerAccess) Includes(other ControllerAccess) bool {
+	return controllerAccessLevels[a] >= controllerAccessLevels[other]
+}
+
+// ControllerUser represents a user's access to the controller.
+// There should be no more than one ControllerUser per user.
+type ControllerUser struct {
+	st  *State
+	doc controllerUserDoc
+}
+
+type controllerUserDoc struct {
+	ID          string           `bson:"_id"`
+	UserName    string           `bson:"user"`
+	CreatedBy   string           `bson:"createdby"`
+	DateCreated time.Time        `bson:"datecreated"`
+	Access      ControllerAccess `bson:"access"`
+}
+
+// UserTag returns the tag for the controller user.
+func (u *ControllerUser) UserTag() names.UserTag {
+	return names.NewUserTag(u.doc.UserName)
+}
+
+// UserName returns the user name of the controller user.
+func (u *ControllerUser) UserName() string {
+	return u.doc.UserName
+}
+
+// CreatedBy returns the user who gave the user access to the controller.
+func (u *ControllerUser) CreatedBy() string {
+	return u.doc.CreatedBy
+}
+
+// DateCreated returns the date the controller user was created in UTC.
+func (u *ControllerUser) DateCreated() time.Time {
+	return u.doc.DateCreated.UTC()
+}
+
+// Access returns the access permission that the user has to the controller.
+func (u *ControllerUser) Access() ControllerAccess {
+	return u.doc.Access
+}
+
+// SetAccess changes the user's access permissions on the controller.
+func (u *ControllerUser) SetAccess(access ControllerAccess) error {
+	if err := access.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	op := txn.Op{
+		C:      controllerUsersC,
+		Id:     u.doc.ID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"access", access}}}},
+	}
+	if err := u.st.runTransaction([]txn.Op{op}); err != nil {
+		return errors.Trace(err)
+	}
+	u.doc.Access = access
+	return nil
+}
+
+// ControllerUser returns the controller user for the given user.
+func (st *State) ControllerUser(user names.UserTag) (*ControllerUser, error) {
+	controllerUsers, closer := st.getCollection(controllerUsersC)
+	defer closer()
+
+	controllerUser := &ControllerUser{st: st}
+	err := controllerUsers.FindId(controllerUserID(user)).One(&controllerUser.doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("controller user %q", user.Canonical())
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// DateCreated is inserted as UTC, but read out as local time. So we
+	// convert it back to UTC here.
+	controllerUser.doc.DateCreated = controllerUser.doc.DateCreated.UTC()
+	return controllerUser, nil
+}
+
+// ControllerAccess returns the level of access the given user has to the
+// controller. Users without a controller user record have no access.
+func (st *State) ControllerAccess(user names.UserTag) (ControllerAccess, error) {
+	controllerUser, err := st.ControllerUser(user)
+	if errors.IsNotFound(err) {
+		return ControllerUndefinedAccess, nil
+	} else if err != nil {
+		return ControllerUndefinedAccess, errors.Trace(err)
+	}
+	return controllerUser.Access(), nil
+}
+
+// ControllerUserSpec defines the attributes that can be set when adding a
+// new controller user.
+type ControllerUserSpec struct {
+	User      names.UserTag
+	CreatedBy names.UserTag
+	Access    ControllerAccess
+}
+
+// AddControllerUser gives a user access to the controller.
+func (st *State) AddControllerUser(spec ControllerUserSpec) (*ControllerUser, error) {
+	// Ensure local user exists in state before giving them access.
+	if spec.User.IsLocal() {
+		if _, err := st.User(spec.User); err != nil {
+			return nil, errors.Annotate(err, fmt.Sprintf("user %q does not exist locally", spec.User.Name()))
+		}
+	}
+
+	// Default to login access if not otherwise specified.
+	if spec.Access == ControllerUndefinedAccess {
+		spec.Access = ControllerLoginAccess
+	}
+	if err := spec.Access.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	op := createControllerUserOp(spec.User, spec.CreatedBy, nowToTheSecond(), spec.Access)
+	err := st.runTransaction([]txn.Op{op})
+	if err == txn.ErrAborted {
+		err = errors.AlreadyExistsf("controller user %q", spec.User.Canonical())
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return st.ControllerUser(spec.User)
+}
+
+// RemoveControllerUser removes a user's access to the controller.
+func (st *State) RemoveControllerUser(user names.UserTag) error {
+	ops := []txn.Op{{
+		C:      controllerUsersC,
+		Id:     controllerUserID(user),
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	err := st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		err = errors.NewNotFound(nil, fmt.Sprintf("controller user %q does not exist", user.Canonical()))
+	}
+	return errors.Trace(err)
+}
+
+// controllerUserID returns the document id of the controller user.
+func controllerUserID(user names.UserTag) string {
+	return strings.ToLower(user.Canonical())
+}
+
+func createControllerUserOp(user, createdBy names.UserTag, dateCreated time.Time, access ControllerAccess) txn.Op {
+	doc := &controllerUserDoc{
+		ID:          controllerUserID(user),
+		UserName:    user.Canonical(),
+		CreatedBy:   createdBy.Canonical(),
+		DateCreated: dateCreated,
+		Access:      access,
+	}
+	return txn.Op{
+		C:      controllerUsersC,
+		Id:     doc.ID,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}
+}
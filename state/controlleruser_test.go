@@ -0,0 +1,129 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ControllerUserSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ControllerUserSuite{})
+
+func (s *ControllerUserSuite) TestOwnerIsSuperuser(c *gc.C) {
+	access, err := s.State.ControllerAccess(s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.ControllerSuperuserAccess)
+}
+
+func (s *ControllerUserSuite) TestNewLocalUserHasLogin(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "validusername", NoModelUser: true})
+	controllerUser, err := s.State.ControllerUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(controllerUser.UserName(), gc.Equals, "validusername@local")
+	c.Assert(controllerUser.Access(), gc.Equals, state.ControllerLoginAccess)
+}
+
+func (s *ControllerUserSuite) TestAddControllerUser(c *gc.C) {
+	now := state.NowToTheSecond()
+	user := names.NewUserTag("bob@remote")
+	controllerUser, err := s.State.AddControllerUser(state.ControllerUserSpec{
+		User:      user,
+		CreatedBy: s.Owner,
+		Access:    state.ControllerAddModelAccess,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(controllerUser.UserTag(), gc.Equals, user)
+	c.Assert(controllerUser.CreatedBy(), gc.Equals, s.Owner.Canonical())
+	c.Assert(controllerUser.DateCreated().Before(now), jc.IsFalse)
+	c.Assert(controllerUser.Access(), gc.Equals, state.ControllerAddModelAccess)
+
+	access, err := s.State.ControllerAccess(user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.ControllerAddModelAccess)
+}
+
+func (s *ControllerUserSuite) TestAddControllerUserDefaultsToLogin(c *gc.C) {
+	controllerUser, err := s.State.AddControllerUser(state.ControllerUserSpec{
+		User:      names.NewUserTag("bob@remote"),
+		CreatedBy: s.Owner,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(controllerUser.Access(), gc.Equals, state.ControllerLoginAccess)
+}
+
+func (s *ControllerUserSuite) TestAddControllerUserMissingLocalUser(c *gc.C) {
+	_, err := s.State.AddControllerUser(state.ControllerUserSpec{
+		User:      names.NewLocalUserTag("nobody"),
+		CreatedBy: s.Owner,
+	})
+	c.Assert(err, gc.ErrorMatches, `user "nobody" does not exist locally: user "nobody" not found`)
+}
+
+func (s *ControllerUserSuite) TestAddControllerUserInvalidAccess(c *gc.C) {
+	_, err := s.State.AddControllerUser(state.ControllerUserSpec{
+		User:      names.NewUserTag("bob@remote"),
+		CreatedBy: s.Owner,
+		Access:    state.ControllerAccess("admin"),
+	})
+	c.Assert(err, gc.ErrorMatches, `controller access "admin" not valid`)
+}
+
+func (s *ControllerUserSuite) TestAddControllerUserTwice(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	_, err := s.State.AddControllerUser(state.ControllerUserSpec{
+		User:      user.UserTag(),
+		CreatedBy: s.Owner,
+	})
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *ControllerUserSuite) TestSetAccess(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	controllerUser, err := s.State.ControllerUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = controllerUser.SetAccess(state.ControllerSuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(controllerUser.Access(), gc.Equals, state.ControllerSuperuserAccess)
+
+	controllerUser, err = s.State.ControllerUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(controllerUser.Access(), gc.Equals, state.ControllerSuperuserAccess)
+
+	isAdmin, err := s.State.IsControllerAdministrator(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(isAdmin, jc.IsTrue)
+}
+
+func (s *ControllerUserSuite) TestRemoveControllerUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	err := s.State.RemoveControllerUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.ControllerUser(user.UserTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	access, err := s.State.ControllerAccess(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.ControllerUndefinedAccess)
+
+	err = s.State.RemoveControllerUser(user.UserTag())
+	c.Assert(err, gc.ErrorMatches, `controller user ".*" does not exist`)
+}
+
+func (s *ControllerUserSuite) TestAccessIncludes(c *gc.C) {
+	c.Assert(state.ControllerSuperuserAccess.Includes(state.ControllerAddModelAccess), jc.IsTrue)
+	c.Assert(state.ControllerAddModelAccess.Includes(state.ControllerAddModelAccess), jc.IsTrue)
+	c.Assert(state.ControllerLoginAccess.Includes(state.ControllerAddModelAccess), jc.IsFalse)
+	c.Assert(state.ControllerUndefinedAccess.Includes(state.ControllerLoginAccess), jc.IsFalse)
+}
@@ -208,6 +208,14 @@ type Action interface {
 	// Results returns the structured output of the action and any error.
 	Results() (map[string]interface{}, string)
 
+	// Timeout returns how long the action may run before it is stopped,
+	// or zero if it may run indefinitely.
+	Timeout() time.Duration
+
+	// Schedule returns the id of the action schedule that enqueued
+	// the action, or "" if it was not enqueued by a schedule.
+	Schedule() string
+
 	// ActionTag returns an ActionTag constructed from this action's
 	// Prefix and Sequence.
 	ActionTag() names.ActionTag
@@ -219,4 +227,9 @@ type Action interface {
 	// Finish removes action from the pending queue and captures the output
 	// and end state of the action.
 	Finish(results ActionResults) (Action, error)
+
+	// Cancel stops the action. A pending action is marked as cancelled;
+	// a running action is marked as aborting, and its receiver is
+	// notified so that it can stop the action.
+	Cancel() (Action, error)
 }
@@ -0,0 +1,20 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+// LeasesReport returns a map describing the leadership and singular
+// leases held in the state's model, as known to its lease managers.
+func (st *State) LeasesReport() map[string]interface{} {
+	return map[string]interface{}{
+		"model-uuid": st.ModelUUID(),
+		"leadership": st.workers.LeadershipManager().Report(),
+		"singular":   st.workers.SingularManager().Report(),
+	}
+}
+
+// PresenceReport returns a map describing the agents considered alive
+// in the state's model, and those whose presence is being watched.
+func (st *State) PresenceReport() map[string]interface{} {
+	return st.workers.PresenceWatcher().Report()
+}
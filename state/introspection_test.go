@@ -0,0 +1,51 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type IntrospectionSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&IntrospectionSuite{})
+
+func (s *IntrospectionSuite) TestLeasesReport(c *gc.C) {
+	err := s.State.LeadershipClaimer().ClaimLeadership("mysql", "mysql/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	report := s.State.LeasesReport()
+	c.Check(report["model-uuid"], gc.Equals, s.State.ModelUUID())
+	leadership, ok := report["leadership"].(map[string]interface{})
+	c.Assert(ok, jc.IsTrue)
+	leases, ok := leadership["leases"].(map[string]interface{})
+	c.Assert(ok, jc.IsTrue)
+	mysql, ok := leases["mysql"].(map[string]interface{})
+	c.Assert(ok, jc.IsTrue)
+	c.Check(mysql["holder"], gc.Equals, "mysql/0")
+	c.Check(report["singular"], gc.NotNil)
+}
+
+func (s *IntrospectionSuite) TestPresenceReport(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	pinger, err := machine.SetAgentPresence()
+	c.Assert(err, jc.ErrorIsNil)
+	defer pinger.Stop()
+	s.State.StartSync()
+	err = machine.WaitAgentPresence(coretesting.LongWait)
+	c.Assert(err, jc.ErrorIsNil)
+
+	report := s.State.PresenceReport()
+	c.Check(report["model-uuid"], gc.Equals, s.State.ModelUUID())
+	c.Check(report["alive"], jc.DeepEquals, []string{"m#" + machine.Id()})
+}
@@ -34,6 +34,24 @@ func (st *State) LeadershipChecker() leadership.Checker {
 	return leadershipChecker{st.workers.LeadershipManager()}
 }
 
+// ServiceLeaders returns a map from service name to the name of the unit
+// currently holding leadership of that service. Services without a current
+// leader are not included.
+func (st *State) ServiceLeaders() (map[string]string, error) {
+	client, err := st.getLeadershipLeaseClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	now := GetClock().Now()
+	leaders := make(map[string]string)
+	for serviceName, info := range client.Leases() {
+		if info.Expiry.After(now) {
+			leaders[serviceName] = info.Holder
+		}
+	}
+	return leaders, nil
+}
+
 // HackLeadership stops the state's internal leadership manager to prevent it
 // from interfering with apiserver shutdown.
 func (st *State) HackLeadership() {
@@ -207,8 +207,14 @@ type LogRecord struct {
 
 // LogTailerParams specifies the filtering a LogTailer should apply to
 // logs in order to decide which to return.
+//
+// If EndTime is set, the LogTailer stops once the matching logs
+// already recorded have been returned, as if NoTail were set.
+// MessageRegex, if set, is a regular expression that log messages
+// must match; it is evaluated by the database, as PCRE.
 type LogTailerParams struct {
 	StartTime     time.Time
+	EndTime       time.Time
 	MinLevel      loggo.Level
 	InitialLines  int
 	NoTail        bool
@@ -216,6 +222,7 @@ type LogTailerParams struct {
 	ExcludeEntity []string
 	IncludeModule []string
 	ExcludeModule []string
+	MessageRegex  string
 	Oplog         *mgo.Collection // For testing only
 	AllModels     bool
 }
@@ -301,7 +308,7 @@ func (t *logTailer) loop() error {
 		return errors.Trace(err)
 	}
 
-	if t.params.NoTail {
+	if t.params.NoTail || !t.params.EndTime.IsZero() {
 		return nil
 	}
 
@@ -394,8 +401,12 @@ func (t *logTailer) tailOplog() error {
 }
 
 func (t *logTailer) paramsToSelector(params *LogTailerParams, prefix string) bson.D {
+	timeSel := bson.M{"$gte": params.StartTime}
+	if !params.EndTime.IsZero() {
+		timeSel["$lte"] = params.EndTime
+	}
 	sel := bson.D{
-		{"t", bson.M{"$gte": params.StartTime}},
+		{"t", timeSel},
 	}
 	if !params.AllModels {
 		sel = append(sel, bson.DocElem{"e", t.modelUUID})
@@ -419,6 +430,9 @@ func (t *logTailer) paramsToSelector(params *LogTailerParams, prefix string) bso
 		sel = append(sel,
 			bson.DocElem{"m", bson.M{"$not": bson.RegEx{Pattern: makeModulePattern(params.ExcludeModule)}}})
 	}
+	if params.MessageRegex != "" {
+		sel = append(sel, bson.DocElem{"x", bson.RegEx{Pattern: params.MessageRegex}})
+	}
 	if prefix != "" {
 		for i, elem := range sel {
 			sel[i].Name = prefix + elem.Name
@@ -505,11 +519,28 @@ func logDocToRecord(doc *logDoc) *LogRecord {
 	}
 }
 
+// ModelLogLimits tightens the controller-wide log pruning limits for
+// a single model.
+type ModelLogLimits struct {
+	// MinLogTime, if set and later than the controller-wide minimum
+	// log time, is used instead of it for the model. A model cannot
+	// keep its logs for longer than the controller allows.
+	MinLogTime time.Time
+
+	// MaxLogsMB, if positive, is the most space the model's logs may
+	// use. The space used is estimated from the number of records
+	// and the average record size.
+	MaxLogsMB int
+}
+
 // PruneLogs removes old log documents in order to control the size of
-// logs collection. All logs older than minLogTime are
-// removed. Further removal is also performed if the logs collection
-// size is greater than maxLogsMB.
-func PruneLogs(st LoggingState, minLogTime time.Time, maxLogsMB int) error {
+// logs collection. All logs older than minLogTime are removed, along
+// with older logs for models with a later minimum log time in
+// modelLimits. The logs
+// of models with a size limit in modelLimits are then pruned to that
+// size. Further removal is also performed if the logs collection size
+// is greater than maxLogsMB.
+func PruneLogs(st LoggingState, minLogTime time.Time, maxLogsMB int, modelLimits map[string]ModelLogLimits) error {
 	session, logsColl := initLogsSession(st)
 	defer session.Close()
 
@@ -523,9 +554,13 @@ func PruneLogs(st LoggingState, minLogTime time.Time, maxLogsMB int) error {
 	// Remove old log entries (per model UUID to take advantage
 	// of indexes on the logs collection).
 	for _, modelUUID := range modelUUIDs {
+		modelMinLogTime := minLogTime
+		if limits := modelLimits[modelUUID]; limits.MinLogTime.After(minLogTime) {
+			modelMinLogTime = limits.MinLogTime
+		}
 		removeInfo, err := logsColl.RemoveAll(bson.M{
 			"e": modelUUID,
-			"t": bson.M{"$lt": minLogTime},
+			"t": bson.M{"$lt": modelMinLogTime},
 		})
 		if err != nil {
 			return errors.Annotate(err, "failed to prune logs by time")
@@ -533,6 +568,19 @@ func PruneLogs(st LoggingState, minLogTime time.Time, maxLogsMB int) error {
 		pruneCounts[modelUUID] = removeInfo.Removed
 	}
 
+	// Keep each model with a size limit within it.
+	for _, modelUUID := range modelUUIDs {
+		limits := modelLimits[modelUUID]
+		if limits.MaxLogsMB <= 0 {
+			continue
+		}
+		removed, err := pruneModelLogsBySize(logsColl, modelUUID, limits.MaxLogsMB)
+		if err != nil {
+			return errors.Annotate(err, "failed to prune logs by model size")
+		}
+		pruneCounts[modelUUID] += removed
+	}
+
 	// Do further pruning if the logs collection is over the maximum size.
 	for {
 		collMB, err := getCollectionMB(logsColl)
@@ -552,31 +600,11 @@ func PruneLogs(st LoggingState, minLogTime time.Time, maxLogsMB int) error {
 		}
 
 		// Remove the oldest 1% of log records for the model.
-		toRemove := int(float64(count) * 0.01)
-
-		// Find the threshold timestammp to start removing from.
-		// NOTE: this assumes that there are no more logs being added
-		// for the time range being pruned (which should be true for
-		// any realistic minimum log collection size).
-		tsQuery := logsColl.Find(bson.M{"e": modelUUID}).Sort("t")
-		tsQuery = tsQuery.Skip(toRemove)
-		tsQuery = tsQuery.Select(bson.M{"t": 1})
-		var doc bson.M
-		err = tsQuery.One(&doc)
+		removed, err := removeOldestLogs(logsColl, modelUUID, int(float64(count)*0.01))
 		if err != nil {
-			return errors.Annotate(err, "log pruning timestamp query failed")
+			return errors.Trace(err)
 		}
-		thresholdTs := doc["t"].(time.Time)
-
-		// Remove old records.
-		removeInfo, err := logsColl.RemoveAll(bson.M{
-			"e": modelUUID,
-			"t": bson.M{"$lt": thresholdTs},
-		})
-		if err != nil {
-			return errors.Annotate(err, "log pruning failed")
-		}
-		pruneCounts[modelUUID] += removeInfo.Removed
+		pruneCounts[modelUUID] += removed
 	}
 
 	for modelUUID, count := range pruneCounts {
@@ -587,6 +615,93 @@ func PruneLogs(st LoggingState, minLogTime time.Time, maxLogsMB int) error {
 	return nil
 }
 
+// pruneModelLogsBySize removes the oldest of a model's logs until
+// their estimated size is no more than maxLogsMB. It returns the
+// number of records removed.
+func pruneModelLogsBySize(logsColl *mgo.Collection, modelUUID string, maxLogsMB int) (int, error) {
+	avgSize, err := getAverageLogSize(logsColl)
+	if err != nil || avgSize == 0 {
+		return 0, errors.Trace(err)
+	}
+	count, err := getLogCountForEnv(logsColl, modelUUID)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	maxCount := int(int64(maxLogsMB) * humanize.MiByte / avgSize)
+	if count <= maxCount {
+		return 0, nil
+	}
+	return removeOldestLogs(logsColl, modelUUID, count-maxCount)
+}
+
+// removeOldestLogs removes approximately the oldest toRemove log
+// records for a model, and returns the number actually removed.
+func removeOldestLogs(logsColl *mgo.Collection, modelUUID string, toRemove int) (int, error) {
+	// Find the threshold timestammp to start removing from.
+	// NOTE: this assumes that there are no more logs being added
+	// for the time range being pruned (which should be true for
+	// any realistic minimum log collection size).
+	tsQuery := logsColl.Find(bson.M{"e": modelUUID}).Sort("t")
+	tsQuery = tsQuery.Skip(toRemove)
+	tsQuery = tsQuery.Select(bson.M{"t": 1})
+	var doc bson.M
+	err := tsQuery.One(&doc)
+	if err == mgo.ErrNotFound {
+		// Every record is to be removed.
+		removeInfo, err := logsColl.RemoveAll(bson.M{"e": modelUUID})
+		if err != nil {
+			return 0, errors.Annotate(err, "log pruning failed")
+		}
+		return removeInfo.Removed, nil
+	} else if err != nil {
+		return 0, errors.Annotate(err, "log pruning timestamp query failed")
+	}
+	thresholdTs := doc["t"].(time.Time)
+
+	// Remove old records.
+	removeInfo, err := logsColl.RemoveAll(bson.M{
+		"e": modelUUID,
+		"t": bson.M{"$lt": thresholdTs},
+	})
+	if err != nil {
+		return 0, errors.Annotate(err, "log pruning failed")
+	}
+	return removeInfo.Removed, nil
+}
+
+// LogUsage describes the logs stored for a model.
+type LogUsage struct {
+	// Records holds the number of log records stored.
+	Records int
+
+	// Bytes holds the estimated space used by the records.
+	Bytes int64
+}
+
+// ModelLogUsage returns the number of log records stored for the
+// model, and an estimate of the space they use.
+func ModelLogUsage(st LoggingState) (LogUsage, error) {
+	session := st.MongoSession().Copy()
+	defer session.Close()
+	logsColl := session.DB(logsDB).C(logsC)
+
+	count, err := getLogCountForEnv(logsColl, st.ModelUUID())
+	if err != nil {
+		return LogUsage{}, errors.Trace(err)
+	}
+	if count == 0 {
+		return LogUsage{}, nil
+	}
+	avgSize, err := getAverageLogSize(logsColl)
+	if err != nil {
+		return LogUsage{}, errors.Trace(err)
+	}
+	return LogUsage{
+		Records: count,
+		Bytes:   int64(count) * avgSize,
+	}, nil
+}
+
 // initLogsSession creates a new session suitable for logging updates,
 // returning the session and a logs mgo.Collection connected to that
 // session.
@@ -616,6 +731,28 @@ func getCollectionMB(coll *mgo.Collection) (int, error) {
 	return result["size"].(int), nil
 }
 
+// getAverageLogSize returns the average size of the documents in the
+// logs collection, in bytes.
+func getAverageLogSize(coll *mgo.Collection) (int64, error) {
+	var result bson.M
+	err := coll.Database.Run(bson.D{
+		{"collStats", coll.Name},
+	}, &result)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	switch size := result["avgObjSize"].(type) {
+	case int:
+		return int64(size), nil
+	case int64:
+		return size, nil
+	case float64:
+		return int64(size), nil
+	}
+	// The collection is empty.
+	return 0, nil
+}
+
 // getEnvsInLogs returns the unique model UUIDs that exist in
 // the logs collection. This uses the one of the indexes on the
 // collection and should be fast.
@@ -128,7 +128,7 @@ func (s *LogsSuite) TestPruneLogsByTime(c *gc.C) {
 	log(maxLogTime.Add(-(2 * time.Second)), "prune")
 
 	noPruneMB := 100
-	err := state.PruneLogs(s.State, maxLogTime, noPruneMB)
+	err := state.PruneLogs(s.State, maxLogTime, noPruneMB, nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	// After pruning there should just be 3 "keep" messages left.
@@ -162,7 +162,7 @@ func (s *LogsSuite) TestPruneLogsBySize(c *gc.C) {
 
 	// Prune logs collection back to 1 MiB.
 	tsNoPrune := time.Now().Add(-3 * 24 * time.Hour)
-	err := state.PruneLogs(s.State, tsNoPrune, 1)
+	err := state.PruneLogs(s.State, tsNoPrune, 1, nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	// Logs for first env should not be touched.
@@ -187,6 +187,84 @@ func (s *LogsSuite) TestPruneLogsBySize(c *gc.C) {
 	assertLatestTs(s2)
 }
 
+func (s *LogsSuite) TestPruneLogsModelMinLogTime(c *gc.C) {
+	now := time.Now().Truncate(time.Millisecond)
+	s.generateLogs(c, s.State, now, 10)
+	other := s.Factory.MakeModel(c, nil)
+	defer other.Close()
+	s.generateLogs(c, other, now, 10)
+
+	// The other model keeps its logs for less time than the
+	// controller-wide limit.
+	err := state.PruneLogs(s.State, now.Add(-4*time.Second), 100, map[string]state.ModelLogLimits{
+		other.ModelUUID(): {MinLogTime: now.Add(-2 * time.Second)},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.countLogs(c, s.State), gc.Equals, 5)
+	c.Assert(s.countLogs(c, other), gc.Equals, 3)
+}
+
+func (s *LogsSuite) TestPruneLogsModelMinLogTimeCannotExtend(c *gc.C) {
+	now := time.Now().Truncate(time.Millisecond)
+	other := s.Factory.MakeModel(c, nil)
+	defer other.Close()
+	s.generateLogs(c, other, now, 10)
+
+	// A model cannot keep its logs for longer than the
+	// controller-wide limit.
+	err := state.PruneLogs(s.State, now.Add(-4*time.Second), 100, map[string]state.ModelLogLimits{
+		other.ModelUUID(): {MinLogTime: now.Add(-time.Minute)},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.countLogs(c, other), gc.Equals, 5)
+}
+
+func (s *LogsSuite) TestPruneLogsModelMaxSize(c *gc.C) {
+	now := time.Now().Truncate(time.Millisecond)
+	s.generateLogs(c, s.State, now, 10000)
+	other := s.Factory.MakeModel(c, nil)
+	defer other.Close()
+	s.generateLogs(c, other, now, 100)
+
+	tsNoPrune := now.Add(-7 * 24 * time.Hour)
+	err := state.PruneLogs(s.State, tsNoPrune, 100, map[string]state.ModelLogLimits{
+		s.State.ModelUUID(): {MaxLogsMB: 1},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The chatty model is brought within its limit, while the other
+	// model's logs are untouched.
+	usage, err := state.ModelLogUsage(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage.Records, jc.LessThan, 10000)
+	c.Assert(usage.Bytes <= 1024*1024, jc.IsTrue)
+	c.Assert(s.countLogs(c, other), gc.Equals, 100)
+
+	// The latest records are kept.
+	var doc bson.M
+	err = s.logsColl.Find(bson.M{"e": s.State.ModelUUID()}).Sort("-t").One(&doc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(doc["t"].(time.Time), gc.Equals, now)
+}
+
+func (s *LogsSuite) TestModelLogUsage(c *gc.C) {
+	usage, err := state.ModelLogUsage(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, gc.Equals, state.LogUsage{})
+
+	s.generateLogs(c, s.State, time.Now(), 20)
+	other := s.Factory.MakeModel(c, nil)
+	defer other.Close()
+	s.generateLogs(c, other, time.Now(), 5)
+
+	usage, err = state.ModelLogUsage(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage.Records, gc.Equals, 20)
+	c.Assert(usage.Bytes > 0, jc.IsTrue)
+}
+
 func (s *LogsSuite) generateLogs(c *gc.C, st *state.State, endTime time.Time, count int) {
 	dbLogger := state.NewDbLogger(st, names.NewMachineTag("0"))
 	defer dbLogger.Close()
@@ -261,6 +339,33 @@ func (s *LogTailerSuite) TestTimeFiltering(c *gc.C) {
 
 }
 
+func (s *LogTailerSuite) TestEndTimeFiltering(c *gc.C) {
+	threshT := time.Now()
+	want := logTemplate{Message: "want"}
+	s.writeLogsT(c, threshT.Add(-5*time.Second), threshT.Add(-time.Second), 5, want)
+	s.writeLogsT(c, threshT.Add(time.Second), threshT.Add(5*time.Second), 5,
+		logTemplate{Message: "dont want"},
+	)
+
+	tailer, err := state.NewLogTailer(s.otherState, &state.LogTailerParams{
+		EndTime: threshT,
+		Oplog:   s.oplogColl,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+	s.assertTailer(c, tailer, 5, want)
+
+	// With an end time the tailer stops once the logs collection
+	// has been read.
+	select {
+	case _, ok := <-tailer.Logs():
+		c.Assert(ok, jc.IsFalse)
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for logs channel to close")
+	}
+	c.Assert(tailer.Err(), jc.ErrorIsNil)
+}
+
 func (s *LogTailerSuite) TestOplogTransition(c *gc.C) {
 	// Ensure that logs aren't repeated as the log tailer moves from
 	// reading from the logs collection to tailing the oplog.
@@ -647,6 +752,25 @@ func (s *LogTailerSuite) TestIncludeExcludeModule(c *gc.C) {
 	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
 }
 
+func (s *LogTailerSuite) TestMessageRegex(c *gc.C) {
+	started := logTemplate{Message: "started worker"}
+	stopped := logTemplate{Message: "stopped worker"}
+	other := logTemplate{Message: "something else"}
+	writeLogs := func() {
+		s.writeLogs(c, 2, started)
+		s.writeLogs(c, 1, other)
+		s.writeLogs(c, 1, stopped)
+	}
+	params := &state.LogTailerParams{
+		MessageRegex: "^(start|stop)ped w",
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 2, started)
+		s.assertTailer(c, tailer, 1, stopped)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
 func (s *LogTailerSuite) checkLogTailerFiltering(
 	c *gc.C,
 	st *state.State,
@@ -14,6 +14,8 @@ import (
 	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/storage/poolmanager"
 )
 
 // Export the current model for the State.
@@ -81,9 +83,27 @@ func (st *State) Export() (description.Model, error) {
 	if err := export.services(); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := export.resources(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := export.payloads(); err != nil {
+		return nil, errors.Trace(err)
+	}
 	if err := export.relations(); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := export.storage(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := export.spaces(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := export.subnets(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := export.linklayerdevices(); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	if err := export.model.Validate(); err != nil {
 		return nil, errors.Trace(err)
@@ -163,6 +183,7 @@ func (e *exporter) modelUsers() error {
 			DateCreated:    user.DateCreated(),
 			LastConnection: lastConn,
 			ReadOnly:       user.ReadOnly(),
+			Access:         string(user.Access()),
 		}
 		e.model.AddUser(arg)
 	}
@@ -441,6 +462,18 @@ func (e *exporter) addService(service *Service, refcounts map[string]int, units
 		return errors.Errorf("missing leadership settings for service %q", service.Name())
 	}
 
+	storageConstraints, err := e.storageConstraintsArgs(service.globalKey())
+	if err != nil {
+		return errors.Annotatef(err, "storage constraints for service %s", service.Name())
+	}
+
+	// Services added before endpoint bindings were introduced have no
+	// bindings document, and use the default space for all endpoints.
+	bindings, _, err := readEndpointBindings(e.st, service.globalKey())
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Annotatef(err, "endpoint bindings for service %s", service.Name())
+	}
+
 	args := description.ServiceArgs{
 		Tag:                  service.ServiceTag(),
 		Series:               service.doc.Series,
@@ -456,6 +489,11 @@ func (e *exporter) addService(service *Service, refcounts map[string]int, units
 		Leader:               leader,
 		LeadershipSettings:   leadershipSettingsDoc.Settings,
 		MetricsCredentials:   service.doc.MetricCredentials,
+		StorageConstraints:   storageConstraints,
+		EndpointBindings:     bindings,
+
+		ExposedSourceCIDRs:     service.doc.ExposedSourceCIDRs,
+		ExposedPortSourceCIDRs: service.doc.ExposedPortSourceCIDRs,
 	}
 	exService := e.model.AddService(args)
 	// Find the current service status.
@@ -535,6 +573,97 @@ func (e *exporter) addService(service *Service, refcounts map[string]int, units
 	return nil
 }
 
+// resources adds the resource metadata for the services and their units.
+// The resource blobs are not part of the model description, and are
+// sent to the target controller separately.
+func (e *exporter) resources() error {
+	persist := NewResourcePersistence(e.st.newPersistence())
+	for _, service := range e.model.Services() {
+		serviceResources, err := persist.ListResources(service.Name())
+		if err != nil {
+			return errors.Annotatef(err, "resources for service %s", service.Name())
+		}
+		for i, res := range serviceResources.Resources {
+			args := description.ResourceArgs{
+				Name:     res.Name,
+				Revision: e.resourceRevisionArgs(res),
+			}
+			// Resources that have never been polled from the charm store
+			// have an empty charm store entry.
+			if csRes := serviceResources.CharmStoreResources[i]; csRes.Name != "" {
+				csArgs := e.resourceRevisionArgs(resource.Resource{Resource: csRes})
+				args.CharmStoreRevision = &csArgs
+			}
+			service.AddResource(args)
+		}
+
+		units := make(map[string]description.Unit)
+		for _, unit := range service.Units() {
+			units[unit.Name()] = unit
+		}
+		for _, unitResources := range serviceResources.UnitResources {
+			unit, found := units[unitResources.Tag.Id()]
+			if !found {
+				return errors.Errorf("resources for unknown unit %q", unitResources.Tag.Id())
+			}
+			for _, res := range unitResources.Resources {
+				unit.AddResource(description.ResourceArgs{
+					Name:     res.Name,
+					Revision: e.resourceRevisionArgs(res),
+				})
+			}
+		}
+	}
+	return nil
+}
+
+func (e *exporter) resourceRevisionArgs(res resource.Resource) description.ResourceRevisionArgs {
+	return description.ResourceRevisionArgs{
+		Revision:       res.Revision,
+		Type:           res.Type.String(),
+		Path:           res.Path,
+		Description:    res.Description,
+		Origin:         res.Origin.String(),
+		FingerprintHex: res.Fingerprint.String(),
+		Size:           res.Size,
+		Timestamp:      res.Timestamp,
+		Username:       res.Username,
+	}
+}
+
+func (e *exporter) payloads() error {
+	envPayloads, err := e.st.EnvPayloads()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	payloads, err := envPayloads.ListAll()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	e.logger.Debugf("found %d payloads", len(payloads))
+
+	units := make(map[string]description.Unit)
+	for _, service := range e.model.Services() {
+		for _, unit := range service.Units() {
+			units[unit.Name()] = unit
+		}
+	}
+	for _, payload := range payloads {
+		unit, found := units[payload.Unit]
+		if !found {
+			return errors.Errorf("payload %q for unknown unit %q", payload.Name, payload.Unit)
+		}
+		unit.AddPayload(description.PayloadArgs{
+			Name:   payload.Name,
+			Type:   payload.Type,
+			RawID:  payload.ID,
+			State:  payload.Status,
+			Labels: payload.Labels,
+		})
+	}
+	return nil
+}
+
 func (e *exporter) relations() error {
 	rels, err := e.st.AllRelations()
 	if err != nil {
@@ -585,6 +714,346 @@ func (e *exporter) relations() error {
 	return nil
 }
 
+func (e *exporter) storageConstraintsArgs(globalKey string) (map[string]description.StorageConstraintArgs, error) {
+	cons, err := readStorageConstraints(e.st, globalKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(cons) == 0 {
+		return nil, nil
+	}
+	result := make(map[string]description.StorageConstraintArgs)
+	for key, value := range cons {
+		result[key] = description.StorageConstraintArgs{
+			Pool:  value.Pool,
+			Size:  value.Size,
+			Count: value.Count,
+		}
+	}
+	return result, nil
+}
+
+func (e *exporter) storage() error {
+	if err := e.volumes(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := e.filesystems(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := e.storageInstances(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := e.storagePools(); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func (e *exporter) volumes() error {
+	coll, closer := e.st.getCollection(volumesC)
+	defer closer()
+
+	attachments, err := e.readVolumeAttachments()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var docs []volumeDoc
+	if err := coll.Find(nil).Sort("_id").All(&docs); err != nil {
+		return errors.Annotate(err, "failed to read volumes")
+	}
+	e.logger.Debugf("read %d volume documents", len(docs))
+	for _, doc := range docs {
+		if err := e.addVolume(doc, attachments[doc.Name]); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (e *exporter) addVolume(doc volumeDoc, attachments []volumeAttachmentDoc) error {
+	args := description.VolumeArgs{
+		Tag: names.NewVolumeTag(doc.Name),
+	}
+	if doc.StorageId != "" {
+		args.Storage = names.NewStorageTag(doc.StorageId)
+	}
+	if doc.Binding != "" {
+		binding, err := names.ParseTag(doc.Binding)
+		if err != nil {
+			return errors.Annotatef(err, "binding for volume %s", doc.Name)
+		}
+		args.Binding = binding
+	}
+	if info := doc.Info; info != nil {
+		args.Provisioned = true
+		args.Size = info.Size
+		args.Pool = info.Pool
+		args.HardwareID = info.HardwareId
+		args.VolumeID = info.VolumeId
+		args.Persistent = info.Persistent
+	} else if params := doc.Params; params != nil {
+		args.Size = params.Size
+		args.Pool = params.Pool
+	}
+	exVolume := e.model.AddVolume(args)
+	globalKey := volumeGlobalKey(doc.Name)
+	statusArgs, err := e.statusArgs(globalKey)
+	if err != nil {
+		return errors.Annotatef(err, "status for volume %s", doc.Name)
+	}
+	exVolume.SetStatus(statusArgs)
+	exVolume.SetStatusHistory(e.statusHistoryArgs(globalKey))
+	for _, attachment := range attachments {
+		args := description.VolumeAttachmentArgs{
+			Machine: names.NewMachineTag(attachment.Machine),
+		}
+		if info := attachment.Info; info != nil {
+			args.Provisioned = true
+			args.ReadOnly = info.ReadOnly
+			args.DeviceName = info.DeviceName
+			args.DeviceLink = info.DeviceLink
+			args.BusAddress = info.BusAddress
+		} else if params := attachment.Params; params != nil {
+			args.ReadOnly = params.ReadOnly
+		}
+		exVolume.AddAttachment(args)
+	}
+	return nil
+}
+
+func (e *exporter) readVolumeAttachments() (map[string][]volumeAttachmentDoc, error) {
+	coll, closer := e.st.getCollection(volumeAttachmentsC)
+	defer closer()
+
+	var docs []volumeAttachmentDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "failed to read volume attachments")
+	}
+	e.logger.Debugf("read %d volume attachment documents", len(docs))
+	result := make(map[string][]volumeAttachmentDoc)
+	for _, doc := range docs {
+		result[doc.Volume] = append(result[doc.Volume], doc)
+	}
+	return result, nil
+}
+
+func (e *exporter) filesystems() error {
+	coll, closer := e.st.getCollection(filesystemsC)
+	defer closer()
+
+	attachments, err := e.readFilesystemAttachments()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var docs []filesystemDoc
+	if err := coll.Find(nil).Sort("_id").All(&docs); err != nil {
+		return errors.Annotate(err, "failed to read filesystems")
+	}
+	e.logger.Debugf("read %d filesystem documents", len(docs))
+	for _, doc := range docs {
+		if err := e.addFilesystem(doc, attachments[doc.FilesystemId]); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (e *exporter) addFilesystem(doc filesystemDoc, attachments []filesystemAttachmentDoc) error {
+	args := description.FilesystemArgs{
+		Tag: names.NewFilesystemTag(doc.FilesystemId),
+	}
+	if doc.StorageId != "" {
+		args.Storage = names.NewStorageTag(doc.StorageId)
+	}
+	if doc.VolumeId != "" {
+		args.Volume = names.NewVolumeTag(doc.VolumeId)
+	}
+	if doc.Binding != "" {
+		binding, err := names.ParseTag(doc.Binding)
+		if err != nil {
+			return errors.Annotatef(err, "binding for filesystem %s", doc.FilesystemId)
+		}
+		args.Binding = binding
+	}
+	if info := doc.Info; info != nil {
+		args.Provisioned = true
+		args.Size = info.Size
+		args.Pool = info.Pool
+		args.FilesystemID = info.FilesystemId
+	} else if params := doc.Params; params != nil {
+		args.Size = params.Size
+		args.Pool = params.Pool
+	}
+	exFilesystem := e.model.AddFilesystem(args)
+	globalKey := filesystemGlobalKey(doc.FilesystemId)
+	statusArgs, err := e.statusArgs(globalKey)
+	if err != nil {
+		return errors.Annotatef(err, "status for filesystem %s", doc.FilesystemId)
+	}
+	exFilesystem.SetStatus(statusArgs)
+	exFilesystem.SetStatusHistory(e.statusHistoryArgs(globalKey))
+	for _, attachment := range attachments {
+		args := description.FilesystemAttachmentArgs{
+			Machine: names.NewMachineTag(attachment.Machine),
+		}
+		if info := attachment.Info; info != nil {
+			args.Provisioned = true
+			args.MountPoint = info.MountPoint
+			args.ReadOnly = info.ReadOnly
+		} else if params := attachment.Params; params != nil {
+			args.MountPoint = params.Location
+			args.ReadOnly = params.ReadOnly
+		}
+		exFilesystem.AddAttachment(args)
+	}
+	return nil
+}
+
+func (e *exporter) readFilesystemAttachments() (map[string][]filesystemAttachmentDoc, error) {
+	coll, closer := e.st.getCollection(filesystemAttachmentsC)
+	defer closer()
+
+	var docs []filesystemAttachmentDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "failed to read filesystem attachments")
+	}
+	e.logger.Debugf("read %d filesystem attachment documents", len(docs))
+	result := make(map[string][]filesystemAttachmentDoc)
+	for _, doc := range docs {
+		result[doc.Filesystem] = append(result[doc.Filesystem], doc)
+	}
+	return result, nil
+}
+
+func (e *exporter) storageInstances() error {
+	coll, closer := e.st.getCollection(storageInstancesC)
+	defer closer()
+
+	attachments, err := e.readStorageAttachments()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var docs []storageInstanceDoc
+	if err := coll.Find(nil).Sort("_id").All(&docs); err != nil {
+		return errors.Annotate(err, "failed to read storage instances")
+	}
+	e.logger.Debugf("read %d storage instance documents", len(docs))
+	for _, doc := range docs {
+		owner, err := names.ParseTag(doc.Owner)
+		if err != nil {
+			return errors.Annotatef(err, "owner for storage %s", doc.Id)
+		}
+		e.model.AddStorage(description.StorageArgs{
+			Tag:         names.NewStorageTag(doc.Id),
+			Kind:        doc.Kind.String(),
+			Owner:       owner,
+			Name:        doc.StorageName,
+			Attachments: attachments[doc.Id],
+		})
+	}
+	return nil
+}
+
+func (e *exporter) readStorageAttachments() (map[string][]names.UnitTag, error) {
+	coll, closer := e.st.getCollection(storageAttachmentsC)
+	defer closer()
+
+	var docs []storageAttachmentDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "failed to read storage attachments")
+	}
+	e.logger.Debugf("read %d storage attachment documents", len(docs))
+	result := make(map[string][]names.UnitTag)
+	for _, doc := range docs {
+		unit := names.NewUnitTag(doc.Unit)
+		result[doc.StorageInstance] = append(result[doc.StorageInstance], unit)
+	}
+	return result, nil
+}
+
+func (e *exporter) storagePools() error {
+	pm := poolmanager.New(NewStateSettings(e.st))
+	poolConfigs, err := pm.List()
+	if err != nil {
+		return errors.Annotate(err, "listing pools")
+	}
+	for _, cfg := range poolConfigs {
+		e.model.AddStoragePool(description.StoragePoolArgs{
+			Name:       cfg.Name(),
+			Provider:   string(cfg.Provider()),
+			Attributes: cfg.Attrs(),
+		})
+	}
+	return nil
+}
+
+func (e *exporter) spaces() error {
+	spaces, err := e.st.AllSpaces()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	e.logger.Debugf("read %d spaces", len(spaces))
+
+	for _, space := range spaces {
+		e.model.AddSpace(description.SpaceArgs{
+			Name:       space.Name(),
+			Public:     space.doc.IsPublic,
+			ProviderID: string(space.ProviderId()),
+		})
+	}
+	return nil
+}
+
+func (e *exporter) subnets() error {
+	subnets, err := e.st.AllSubnets()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	e.logger.Debugf("read %d subnets", len(subnets))
+
+	for _, subnet := range subnets {
+		e.model.AddSubnet(description.SubnetArgs{
+			CIDR:              subnet.CIDR(),
+			ProviderID:        string(subnet.ProviderId()),
+			VLANTag:           subnet.VLANTag(),
+			AvailabilityZone:  subnet.AvailabilityZone(),
+			SpaceName:         subnet.SpaceName(),
+			AllocatableIPHigh: subnet.AllocatableIPHigh(),
+			AllocatableIPLow:  subnet.AllocatableIPLow(),
+		})
+	}
+	return nil
+}
+
+func (e *exporter) linklayerdevices() error {
+	coll, closer := e.st.getCollection(linkLayerDevicesC)
+	defer closer()
+
+	var docs []linkLayerDeviceDoc
+	if err := coll.Find(nil).Sort("_id").All(&docs); err != nil {
+		return errors.Annotate(err, "failed to read link-layer devices")
+	}
+	e.logger.Debugf("read %d link-layer device documents", len(docs))
+
+	for _, doc := range docs {
+		e.model.AddLinkLayerDevice(description.LinkLayerDeviceArgs{
+			Name:        doc.Name,
+			MTU:         doc.MTU,
+			ProviderID:  doc.ProviderID,
+			MachineID:   doc.MachineID,
+			Type:        string(doc.Type),
+			MACAddress:  doc.MACAddress,
+			IsAutoStart: doc.IsAutoStart,
+			IsUp:        doc.IsUp,
+			ParentName:  doc.ParentName,
+		})
+	}
+	return nil
+}
+
 func (e *exporter) readAllRelationScopes() (set.Strings, error) {
 	relationScopes, closer := e.st.getCollection(relationScopesC)
 	defer closer()
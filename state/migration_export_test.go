@@ -4,6 +4,8 @@
 package state_test
 
 import (
+	"bytes"
+	"io/ioutil"
 	"math/rand"
 	"time"
 
@@ -11,9 +13,13 @@ import (
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/payload"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/testing/factory"
@@ -173,6 +179,7 @@ func (s *MigrationExportSuite) TestModelUsers(c *gc.C) {
 	c.Assert(exportedAdmin.DateCreated(), gc.Equals, owner.DateCreated())
 	c.Assert(exportedAdmin.LastConnection(), gc.Equals, lastConnection)
 	c.Assert(exportedAdmin.ReadOnly(), jc.IsFalse)
+	c.Assert(exportedAdmin.Access(), gc.Equals, "admin")
 
 	c.Assert(exportedBob.Name(), gc.Equals, bobTag)
 	c.Assert(exportedBob.DisplayName(), gc.Equals, "")
@@ -428,9 +435,224 @@ func (s *MigrationExportSuite) TestRelations(c *gc.C) {
 	checkEndpoint(exEps[1], wordpress_0.Name(), wpEp, wordpressSettings)
 }
 
+func (s *MigrationExportSuite) TestMachineStorage(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Volumes: []state.MachineVolumeParams{{
+			Volume: state.VolumeParams{Pool: "loop", Size: 1024},
+		}},
+		Filesystems: []state.MachineFilesystemParams{{
+			Filesystem: state.FilesystemParams{Pool: "rootfs", Size: 2048},
+		}},
+	})
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	volumes := model.Volumes()
+	c.Assert(volumes, gc.HasLen, 1)
+	volume := volumes[0]
+	c.Check(volume.Tag(), gc.Equals, names.NewVolumeTag("0/0"))
+	c.Check(volume.Provisioned(), jc.IsFalse)
+	c.Check(volume.Size(), gc.Equals, uint64(1024))
+	c.Check(volume.Pool(), gc.Equals, "loop")
+	volAttachments := volume.Attachments()
+	c.Assert(volAttachments, gc.HasLen, 1)
+	c.Check(volAttachments[0].Machine(), gc.Equals, machine.MachineTag())
+
+	filesystems := model.Filesystems()
+	c.Assert(filesystems, gc.HasLen, 1)
+	filesystem := filesystems[0]
+	c.Check(filesystem.Tag(), gc.Equals, names.NewFilesystemTag("0/0"))
+	c.Check(filesystem.Provisioned(), jc.IsFalse)
+	c.Check(filesystem.Size(), gc.Equals, uint64(2048))
+	c.Check(filesystem.Pool(), gc.Equals, "rootfs")
+	fsAttachments := filesystem.Attachments()
+	c.Assert(fsAttachments, gc.HasLen, 1)
+	c.Check(fsAttachments[0].Machine(), gc.Equals, machine.MachineTag())
+}
+
+func (s *MigrationExportSuite) TestSpaces(c *gc.C) {
+	_, err := s.State.AddSpace("one", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	spaces := model.Spaces()
+	c.Assert(spaces, gc.HasLen, 1)
+	space := spaces[0]
+	c.Assert(space.Name(), gc.Equals, "one")
+	c.Assert(space.ProviderID(), gc.Equals, "")
+	c.Assert(space.Public(), jc.IsTrue)
+}
+
+func (s *MigrationExportSuite) TestSubnets(c *gc.C) {
+	_, err := s.State.AddSpace("bar", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSubnet(state.SubnetInfo{
+		CIDR:             "10.0.0.0/24",
+		ProviderId:       network.Id("foo"),
+		VLANTag:          64,
+		AvailabilityZone: "bar",
+		SpaceName:        "bar",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	subnets := model.Subnets()
+	c.Assert(subnets, gc.HasLen, 1)
+	subnet := subnets[0]
+	c.Assert(subnet.CIDR(), gc.Equals, "10.0.0.0/24")
+	c.Assert(subnet.ProviderID(), gc.Equals, "foo")
+	c.Assert(subnet.VLANTag(), gc.Equals, 64)
+	c.Assert(subnet.AvailabilityZone(), gc.Equals, "bar")
+	c.Assert(subnet.SpaceName(), gc.Equals, "bar")
+}
+
+func (s *MigrationExportSuite) TestLinkLayerDevices(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	err := machine.SetLinkLayerDevices(state.LinkLayerDeviceArgs{
+		Name:       "eth0",
+		Type:       state.EthernetDevice,
+		MACAddress: "aa:bb:cc:dd:ee:f0",
+		IsUp:       true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetLinkLayerDevices(state.LinkLayerDeviceArgs{
+		Name:       "eth0.100",
+		MTU:        1500,
+		Type:       state.VLAN_8021QDevice,
+		MACAddress: "aa:bb:cc:dd:ee:f0",
+		ParentName: "eth0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	devices := model.LinkLayerDevices()
+	c.Assert(devices, gc.HasLen, 2)
+	eth0, vlan := devices[0], devices[1]
+	c.Assert(eth0.Name(), gc.Equals, "eth0")
+	c.Assert(eth0.MachineID(), gc.Equals, machine.Id())
+	c.Assert(eth0.Type(), gc.Equals, "ethernet")
+	c.Assert(eth0.IsUp(), jc.IsTrue)
+	c.Assert(vlan.Name(), gc.Equals, "eth0.100")
+	c.Assert(vlan.MTU(), gc.Equals, uint(1500))
+	c.Assert(vlan.Type(), gc.Equals, "802.1q")
+	c.Assert(vlan.ParentName(), gc.Equals, "eth0")
+}
+
+func (s *MigrationExportSuite) TestEndpointBindings(c *gc.C) {
+	_, err := s.State.AddSpace("one", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	state.AddTestingServiceWithBindings(
+		c, s.State, "mysql", state.AddTestingCharm(c, s.State, "mysql"), s.Owner,
+		map[string]string{"server": "one"},
+	)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	services := model.Services()
+	c.Assert(services, gc.HasLen, 1)
+	c.Assert(services[0].EndpointBindings(), jc.DeepEquals, map[string]string{
+		"server": "one",
+	})
+}
+
 type goodToken struct{}
 
 // Check implements leadership.Token
 func (*goodToken) Check(interface{}) error {
 	return nil
 }
+
+func (s *MigrationExportSuite) TestResources(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	service, err := unit.Service()
+	c.Assert(err, jc.ErrorIsNil)
+
+	st, err := s.State.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+	data := "spamspamspam"
+	res := newResource(c, "spam", data)
+	_, err = st.SetResource(service.Name(), res.Username, res.Resource, bytes.NewBufferString(data))
+	c.Assert(err, jc.ErrorIsNil)
+	err = st.SetCharmStoreResources(service.Name(), []charmresource.Resource{res.Resource}, time.Now())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Reading the resource through the uniter records the unit's revision.
+	_, reader, err := st.OpenResourceForUniter(unit, "spam")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = ioutil.ReadAll(reader)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reader.Close(), jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	services := model.Services()
+	c.Assert(services, gc.HasLen, 1)
+	resources := services[0].Resources()
+	c.Assert(resources, gc.HasLen, 1)
+	exported := resources[0]
+	c.Check(exported.Name(), gc.Equals, "spam")
+	revision := exported.Revision()
+	c.Check(revision.Revision(), gc.Equals, res.Revision)
+	c.Check(revision.Type(), gc.Equals, "file")
+	c.Check(revision.Path(), gc.Equals, res.Path)
+	c.Check(revision.Origin(), gc.Equals, res.Origin.String())
+	c.Check(revision.FingerprintHex(), gc.Equals, res.Fingerprint.String())
+	c.Check(revision.Size(), gc.Equals, res.Size)
+	c.Check(revision.Username(), gc.Equals, res.Username)
+	c.Check(revision.Timestamp().IsZero(), jc.IsFalse)
+	csRevision := exported.CharmStoreRevision()
+	c.Assert(csRevision, gc.NotNil)
+	c.Check(csRevision.FingerprintHex(), gc.Equals, res.Fingerprint.String())
+
+	units := services[0].Units()
+	c.Assert(units, gc.HasLen, 1)
+	unitResources := units[0].Resources()
+	c.Assert(unitResources, gc.HasLen, 1)
+	c.Check(unitResources[0].Name(), gc.Equals, "spam")
+	c.Check(unitResources[0].Revision().FingerprintHex(), gc.Equals, res.Fingerprint.String())
+}
+
+func (s *MigrationExportSuite) TestPayloads(c *gc.C) {
+	ch := s.AddMetaCharm(c, "dummy", payloadsMetaYAML, 2)
+	service := s.Factory.MakeService(c, &factory.ServiceParams{Charm: ch})
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Service: service})
+
+	up, err := s.State.UnitPayloads(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = up.Track(payload.Payload{
+		PayloadClass: charm.PayloadClass{
+			Name: "payloadA",
+			Type: "docker",
+		},
+		Status: payload.StateRunning,
+		ID:     "xyz",
+		Labels: []string{"ham"},
+		Unit:   unit.Name(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	services := model.Services()
+	c.Assert(services, gc.HasLen, 1)
+	units := services[0].Units()
+	c.Assert(units, gc.HasLen, 1)
+	payloads := units[0].Payloads()
+	c.Assert(payloads, gc.HasLen, 1)
+	exported := payloads[0]
+	c.Check(exported.Name(), gc.Equals, "payloadA")
+	c.Check(exported.Type(), gc.Equals, "docker")
+	c.Check(exported.RawID(), gc.Equals, "xyz")
+	c.Check(exported.State(), gc.Equals, payload.StateRunning)
+	c.Check(exported.Labels(), jc.DeepEquals, []string{"ham"})
+}
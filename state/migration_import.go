@@ -8,8 +8,11 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/names"
+	"github.com/juju/utils/set"
 	"github.com/juju/version"
 	"gopkg.in/juju/charm.v6-unstable"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
@@ -17,7 +20,12 @@ import (
 	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/payload"
+	"github.com/juju/juju/resource"
 	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/poolmanager"
 	"github.com/juju/juju/tools"
 )
 
@@ -59,6 +67,11 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 	logger.Debugf("model created %s/%s", dbModel.Owner().Canonical(), dbModel.Name())
 	defer func() {
 		if err != nil {
+			// Remove whatever was written so far, so a failed import
+			// does not block a later attempt with the same model.
+			if removeErr := newSt.RemoveImportingModelDocs(); removeErr != nil {
+				logger.Errorf("cannot remove partially imported model: %v", removeErr)
+			}
 			newSt.Close()
 		}
 	}()
@@ -85,15 +98,33 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 	if err := restore.modelUsers(); err != nil {
 		return nil, nil, errors.Annotate(err, "modelUsers")
 	}
+	if err := restore.spaces(); err != nil {
+		return nil, nil, errors.Annotate(err, "spaces")
+	}
+	if err := restore.subnets(); err != nil {
+		return nil, nil, errors.Annotate(err, "subnets")
+	}
 	if err := restore.machines(); err != nil {
 		return nil, nil, errors.Annotate(err, "machines")
 	}
+	if err := restore.linklayerdevices(); err != nil {
+		return nil, nil, errors.Annotate(err, "link-layer devices")
+	}
 	if err := restore.services(); err != nil {
 		return nil, nil, errors.Annotate(err, "services")
 	}
+	if err := restore.resources(); err != nil {
+		return nil, nil, errors.Annotate(err, "resources")
+	}
+	if err := restore.payloads(); err != nil {
+		return nil, nil, errors.Annotate(err, "payloads")
+	}
 	if err := restore.relations(); err != nil {
 		return nil, nil, errors.Annotate(err, "relations")
 	}
+	if err := restore.storage(); err != nil {
+		return nil, nil, errors.Annotate(err, "storage")
+	}
 
 	// NOTE: at the end of the import make sure that the mode of the model
 	// is set to "imported" not "active" (or whatever we call it). This way
@@ -186,9 +217,17 @@ func (i *importer) modelUsers() error {
 	modelUUID := i.dbModel.UUID()
 	var ops []txn.Op
 	for _, user := range users {
-		access := ModelAdminAccess
-		if user.ReadOnly() {
-			access = ModelReadAccess
+		// Models exported before the access level was recorded only
+		// distinguish between read-only and admin users.
+		access := ModelAccess(user.Access())
+		if access == ModelUndefinedAccess {
+			access = ModelAdminAccess
+			if user.ReadOnly() {
+				access = ModelReadAccess
+			}
+		}
+		if err := access.Validate(); err != nil {
+			return errors.Annotatef(err, "user %q", user.Name().Canonical())
 		}
 		ops = append(ops, createModelUserOp(
 			modelUUID,
@@ -538,14 +577,26 @@ func (i *importer) service(s description.Service) error {
 	// TODO: update never set malarky... maybe...
 
 	ops := addServiceOps(i.st, addServiceOpsArgs{
-		serviceDoc:  sdoc,
-		statusDoc:   statusDoc,
-		constraints: i.constraints(s.Constraints()),
-		// storage          TODO,
+		serviceDoc:         sdoc,
+		statusDoc:          statusDoc,
+		constraints:        i.constraints(s.Constraints()),
+		storage:            i.storageConstraints(s.StorageConstraints()),
 		settings:           s.Settings(),
 		settingsRefCount:   s.SettingsRefCount(),
 		leadershipSettings: s.LeadershipSettings(),
 	})
+	if bindings := s.EndpointBindings(); len(bindings) > 0 {
+		// The bindings were validated against the charm when the service
+		// was deployed in the source model, so they are inserted as is.
+		ops = append(ops, txn.Op{
+			C:      endpointBindingsC,
+			Id:     serviceGlobalKey(s.Name()),
+			Assert: txn.DocMissing,
+			Insert: endpointBindingsDoc{
+				Bindings: bindings,
+			},
+		})
+	}
 
 	if err := i.st.runTransaction(ops); err != nil {
 		return errors.Trace(err)
@@ -669,6 +720,9 @@ func (i *importer) makeServiceDoc(s description.Service) (*serviceDoc, error) {
 		Exposed:              s.Exposed(),
 		MinUnits:             s.MinUnits(),
 		MetricCredentials:    s.MetricsCredentials(),
+
+		ExposedSourceCIDRs:     s.ExposedSourceCIDRs(),
+		ExposedPortSourceCIDRs: s.ExposedPortSourceCIDRs(),
 	}, nil
 }
 
@@ -711,7 +765,6 @@ func (i *importer) makeUnitDoc(s description.Service, u description.Unit) (*unit
 		CharmURL:     charmUrl,
 		Principal:    u.Principal().Id(),
 		Subordinates: subordinates,
-		// StorageAttachmentCount int `bson:"storageattachmentcount"`
 		MachineId:    u.Machine().Id(),
 		Tools:        i.makeTools(u.Tools()),
 		Life:         Alive,
@@ -719,6 +772,136 @@ func (i *importer) makeUnitDoc(s description.Service, u description.Unit) (*unit
 	}, nil
 }
 
+// resources imports the resource metadata for the services and their
+// units. The blobs for the resources are uploaded separately, and until
+// then the resources cannot be opened.
+func (i *importer) resources() error {
+	i.logger.Debugf("importing resources")
+	persist := NewResourcePersistence(i.st.newPersistence())
+	for _, s := range i.model.Services() {
+		for _, r := range s.Resources() {
+			res, err := i.makeResource(s.Name(), r.Name(), r.Revision())
+			if err != nil {
+				return errors.Annotatef(err, "resource %q of service %q", r.Name(), s.Name())
+			}
+			if err := persist.SetResource(res); err != nil {
+				return errors.Annotatef(err, "resource %q of service %q", r.Name(), s.Name())
+			}
+			if csRevision := r.CharmStoreRevision(); csRevision != nil {
+				csRes, err := i.makeCharmResource(r.Name(), csRevision)
+				if err != nil {
+					return errors.Annotatef(err, "charm store resource %q of service %q", r.Name(), s.Name())
+				}
+				// The time the resource was last polled is not
+				// migrated, so the charm store is treated as having
+				// just been polled.
+				if err := persist.SetCharmStoreResource(res.ID, s.Name(), csRes, time.Now().UTC()); err != nil {
+					return errors.Annotatef(err, "charm store resource %q of service %q", r.Name(), s.Name())
+				}
+			}
+		}
+		for _, u := range s.Units() {
+			for _, r := range u.Resources() {
+				res, err := i.makeResource(s.Name(), r.Name(), r.Revision())
+				if err != nil {
+					return errors.Annotatef(err, "resource %q of unit %q", r.Name(), u.Name())
+				}
+				if err := persist.SetUnitResource(u.Name(), res); err != nil {
+					return errors.Annotatef(err, "resource %q of unit %q", r.Name(), u.Name())
+				}
+			}
+		}
+	}
+	i.logger.Debugf("importing resources succeeded")
+	return nil
+}
+
+func (i *importer) makeResource(serviceID, name string, revision description.ResourceRevision) (resource.Resource, error) {
+	chRes, err := i.makeCharmResource(name, revision)
+	if err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	return resource.Resource{
+		Resource: chRes,
+		// The ID matches the one generated by resource/state.
+		ID:        serviceID + "/" + name,
+		ServiceID: serviceID,
+		Username:  revision.Username(),
+		Timestamp: revision.Timestamp(),
+	}, nil
+}
+
+func (i *importer) makeCharmResource(name string, revision description.ResourceRevision) (charmresource.Resource, error) {
+	resType, err := charmresource.ParseType(revision.Type())
+	if err != nil {
+		return charmresource.Resource{}, errors.Trace(err)
+	}
+	origin, err := charmresource.ParseOrigin(revision.Origin())
+	if err != nil {
+		return charmresource.Resource{}, errors.Trace(err)
+	}
+	// Placeholder resources have no data, and so have no fingerprint.
+	var fingerprint charmresource.Fingerprint
+	if hex := revision.FingerprintHex(); hex != "" {
+		fingerprint, err = charmresource.ParseFingerprint(hex)
+		if err != nil {
+			return charmresource.Resource{}, errors.Trace(err)
+		}
+	}
+	return charmresource.Resource{
+		Meta: charmresource.Meta{
+			Name:        name,
+			Type:        resType,
+			Path:        revision.Path(),
+			Description: revision.Description(),
+		},
+		Origin:      origin,
+		Revision:    revision.Revision(),
+		Fingerprint: fingerprint,
+		Size:        revision.Size(),
+	}, nil
+}
+
+func (i *importer) payloads() error {
+	i.logger.Debugf("importing payloads")
+	for _, s := range i.model.Services() {
+		units := make(map[string]*Unit)
+		for _, unit := range i.serviceUnits[s.Name()] {
+			units[unit.Name()] = unit
+		}
+		for _, u := range s.Units() {
+			if len(u.Payloads()) == 0 {
+				continue
+			}
+			unit, found := units[u.Name()]
+			if !found {
+				return errors.Errorf("missing unit %q", u.Name())
+			}
+			unitPayloads, err := i.st.UnitPayloads(unit)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			for _, p := range u.Payloads() {
+				err := unitPayloads.Track(payload.Payload{
+					PayloadClass: charm.PayloadClass{
+						Name: p.Name(),
+						Type: p.Type(),
+					},
+					ID:     p.RawID(),
+					Status: p.State(),
+					Labels: p.Labels(),
+					Unit:   u.Name(),
+				})
+				if err != nil {
+					return errors.Annotatef(err, "payload %q of unit %q", p.Name(), u.Name())
+				}
+			}
+		}
+	}
+	i.logger.Debugf("importing payloads succeeded")
+	return nil
+}
+
 func (i *importer) relations() error {
 	i.logger.Debugf("importing relations")
 	for _, r := range i.model.Relations() {
@@ -800,6 +983,426 @@ func (i *importer) makeRelationDoc(rel description.Relation) *relationDoc {
 	return doc
 }
 
+func (i *importer) spaces() error {
+	i.logger.Debugf("importing spaces")
+	for _, s := range i.model.Spaces() {
+		// The subnets are added to the spaces when they are imported.
+		_, err := i.st.AddSpace(s.Name(), network.Id(s.ProviderID()), nil, s.Public())
+		if err != nil {
+			i.logger.Errorf("error importing space %s: %s", s.Name(), err)
+			return errors.Annotate(err, s.Name())
+		}
+	}
+	i.logger.Debugf("importing spaces succeeded")
+	return nil
+}
+
+func (i *importer) subnets() error {
+	i.logger.Debugf("importing subnets")
+	for _, subnet := range i.model.Subnets() {
+		_, err := i.st.AddSubnet(SubnetInfo{
+			CIDR:              subnet.CIDR(),
+			ProviderId:        network.Id(subnet.ProviderID()),
+			VLANTag:           subnet.VLANTag(),
+			AvailabilityZone:  subnet.AvailabilityZone(),
+			SpaceName:         subnet.SpaceName(),
+			AllocatableIPHigh: subnet.AllocatableIPHigh(),
+			AllocatableIPLow:  subnet.AllocatableIPLow(),
+		})
+		if err != nil {
+			i.logger.Errorf("error importing subnet %s: %s", subnet.CIDR(), err)
+			return errors.Annotate(err, subnet.CIDR())
+		}
+	}
+	i.logger.Debugf("importing subnets succeeded")
+	return nil
+}
+
+func (i *importer) linklayerdevices() error {
+	i.logger.Debugf("importing link-layer devices")
+	// A device can only be added once its parent exists, and the parent
+	// of a container's device may live on the host machine, so keep
+	// going over the remaining devices until all of them are added.
+	remaining := i.model.LinkLayerDevices()
+	added := set.NewStrings()
+	for len(remaining) > 0 {
+		var pending []description.LinkLayerDevice
+		for _, device := range remaining {
+			parentKey := linkLayerDeviceParentGlobalKey(device.MachineID(), device.ParentName())
+			if parentKey != "" && !added.Contains(parentKey) {
+				pending = append(pending, device)
+				continue
+			}
+			if err := i.linklayerdevice(device); err != nil {
+				i.logger.Errorf("error importing link-layer device %s: %s", device.Name(), err)
+				return errors.Annotate(err, device.Name())
+			}
+			added.Add(linkLayerDeviceGlobalKey(device.MachineID(), device.Name()))
+		}
+		if len(pending) == len(remaining) {
+			return errors.Errorf("link-layer device %q on machine %q has unknown parent %q",
+				pending[0].Name(), pending[0].MachineID(), pending[0].ParentName())
+		}
+		remaining = pending
+	}
+	i.logger.Debugf("importing link-layer devices succeeded")
+	return nil
+}
+
+// linkLayerDeviceParentGlobalKey returns the global key of the parent of a
+// device on the given machine, or an empty string if there is no parent.
+func linkLayerDeviceParentGlobalKey(machineID, parentName string) string {
+	if parentName == "" {
+		return ""
+	}
+	if hostMachineID, hostDeviceName, err := parseLinkLayerDeviceParentNameAsGlobalKey(parentName); err == nil && hostDeviceName != "" {
+		return linkLayerDeviceGlobalKey(hostMachineID, hostDeviceName)
+	}
+	return linkLayerDeviceGlobalKey(machineID, parentName)
+}
+
+func (i *importer) linklayerdevice(device description.LinkLayerDevice) error {
+	machine, err := i.st.Machine(device.MachineID())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return machine.SetLinkLayerDevices(LinkLayerDeviceArgs{
+		Name:        device.Name(),
+		MTU:         device.MTU(),
+		ProviderID:  network.Id(device.ProviderID()),
+		Type:        LinkLayerDeviceType(device.Type()),
+		MACAddress:  device.MACAddress(),
+		IsAutoStart: device.IsAutoStart(),
+		IsUp:        device.IsUp(),
+		ParentName:  device.ParentName(),
+	})
+}
+
+func (i *importer) storageConstraints(cons map[string]description.StorageConstraint) map[string]StorageConstraints {
+	if len(cons) == 0 {
+		return nil
+	}
+	result := make(map[string]StorageConstraints)
+	for key, value := range cons {
+		result[key] = StorageConstraints{
+			Pool:  value.Pool(),
+			Size:  value.Size(),
+			Count: value.Count(),
+		}
+	}
+	return result
+}
+
+func (i *importer) storage() error {
+	if err := i.storagePools(); err != nil {
+		return errors.Annotate(err, "storage pools")
+	}
+	if err := i.storageInstances(); err != nil {
+		return errors.Annotate(err, "storage instances")
+	}
+	if err := i.volumes(); err != nil {
+		return errors.Annotate(err, "volumes")
+	}
+	if err := i.filesystems(); err != nil {
+		return errors.Annotate(err, "filesystems")
+	}
+	return nil
+}
+
+func (i *importer) storagePools() error {
+	pm := poolmanager.New(NewStateSettings(i.st))
+	for _, pool := range i.model.StoragePools() {
+		_, err := pm.Create(pool.Name(), storage.ProviderType(pool.Provider()), pool.Attributes())
+		if err != nil {
+			return errors.Annotatef(err, "creating pool %q", pool.Name())
+		}
+	}
+	return nil
+}
+
+func (i *importer) storageInstances() error {
+	i.logger.Debugf("importing storage instances")
+	for _, s := range i.model.Storages() {
+		if err := i.storageInstance(s); err != nil {
+			i.logger.Errorf("error importing storage %s: %s", s.Tag().Id(), err)
+			return errors.Annotate(err, s.Tag().Id())
+		}
+	}
+	i.logger.Debugf("importing storage instances succeeded")
+	return nil
+}
+
+func (i *importer) storageInstance(s description.Storage) error {
+	owner, err := s.Owner()
+	if err != nil {
+		return errors.Annotate(err, "storage owner")
+	}
+	curl, err := i.storageCharmURL(owner)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	attachments := s.Attachments()
+	doc := &storageInstanceDoc{
+		Id:              s.Tag().Id(),
+		Kind:            parseStorageKind(s.Kind()),
+		Life:            Alive,
+		Owner:           owner.String(),
+		StorageName:     s.Name(),
+		AttachmentCount: len(attachments),
+		CharmURL:        curl,
+	}
+	ops := []txn.Op{{
+		C:      storageInstancesC,
+		Id:     s.Tag().Id(),
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}}
+	for _, unit := range attachments {
+		ops = append(ops, createStorageAttachmentOp(s.Tag(), unit))
+		ops = append(ops, txn.Op{
+			C:      unitsC,
+			Id:     unit.Id(),
+			Assert: txn.DocExists,
+			Update: bson.D{{"$inc", bson.D{{"storageattachmentcount", 1}}}},
+		})
+	}
+
+	if err := i.st.runTransaction(ops); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// storageCharmURL returns the charm URL of the service that owns, either
+// directly or through one of its units, the storage instance.
+func (i *importer) storageCharmURL(owner names.Tag) (*charm.URL, error) {
+	var serviceName string
+	switch owner := owner.(type) {
+	case names.ServiceTag:
+		serviceName = owner.Id()
+	case names.UnitTag:
+		name, err := names.UnitService(owner.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		serviceName = name
+	default:
+		return nil, errors.NotValidf("storage owner %q", owner)
+	}
+	for _, s := range i.model.Services() {
+		if s.Name() == serviceName {
+			return charm.ParseURL(s.CharmURL())
+		}
+	}
+	return nil, errors.NotFoundf("service %q", serviceName)
+}
+
+func (i *importer) volumes() error {
+	i.logger.Debugf("importing volumes")
+	for _, volume := range i.model.Volumes() {
+		if err := i.volume(volume); err != nil {
+			i.logger.Errorf("error importing volume %s: %s", volume.Tag().Id(), err)
+			return errors.Annotate(err, volume.Tag().Id())
+		}
+	}
+	i.logger.Debugf("importing volumes succeeded")
+	return nil
+}
+
+func (i *importer) volume(volume description.Volume) error {
+	attachments := volume.Attachments()
+	tag := volume.Tag()
+	var binding string
+	bindingTag, err := volume.Binding()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if bindingTag != nil {
+		binding = bindingTag.String()
+	}
+	status := volume.Status()
+	if status == nil {
+		return errors.NotValidf("missing status")
+	}
+
+	doc := &volumeDoc{
+		Name:            tag.Id(),
+		StorageId:       volume.Storage().Id(),
+		Life:            Alive,
+		Binding:         binding,
+		AttachmentCount: len(attachments),
+	}
+	if volume.Provisioned() {
+		doc.Info = &VolumeInfo{
+			HardwareId: volume.HardwareID(),
+			Size:       volume.Size(),
+			Pool:       volume.Pool(),
+			VolumeId:   volume.VolumeID(),
+			Persistent: volume.Persistent(),
+		}
+	} else {
+		doc.Params = &VolumeParams{
+			Size: volume.Size(),
+			Pool: volume.Pool(),
+		}
+	}
+	ops := []txn.Op{
+		{
+			C:      volumesC,
+			Id:     tag.Id(),
+			Assert: txn.DocMissing,
+			Insert: doc,
+		},
+		createStatusOp(i.st, volumeGlobalKey(tag.Id()), i.makeStatusDoc(status)),
+	}
+	for _, attachment := range attachments {
+		ops = append(ops, i.volumeAttachmentOps(tag.Id(), attachment)...)
+	}
+
+	if err := i.st.runTransaction(ops); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := i.importStatusHistory(volumeGlobalKey(tag.Id()), volume.StatusHistory()); err != nil {
+		return errors.Annotate(err, "status history")
+	}
+	return nil
+}
+
+func (i *importer) volumeAttachmentOps(volumeId string, attachment description.VolumeAttachment) []txn.Op {
+	machineId := attachment.Machine().Id()
+	doc := &volumeAttachmentDoc{
+		Volume:  volumeId,
+		Machine: machineId,
+		Life:    Alive,
+	}
+	if attachment.Provisioned() {
+		doc.Info = &VolumeAttachmentInfo{
+			DeviceName: attachment.DeviceName(),
+			DeviceLink: attachment.DeviceLink(),
+			BusAddress: attachment.BusAddress(),
+			ReadOnly:   attachment.ReadOnly(),
+		}
+	} else {
+		doc.Params = &VolumeAttachmentParams{
+			ReadOnly: attachment.ReadOnly(),
+		}
+	}
+	return []txn.Op{{
+		C:      volumeAttachmentsC,
+		Id:     volumeAttachmentId(machineId, volumeId),
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}, {
+		C:      machinesC,
+		Id:     machineId,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$addToSet", bson.D{{"volumes", volumeId}}}},
+	}}
+}
+
+func (i *importer) filesystems() error {
+	i.logger.Debugf("importing filesystems")
+	for _, fs := range i.model.Filesystems() {
+		if err := i.filesystem(fs); err != nil {
+			i.logger.Errorf("error importing filesystem %s: %s", fs.Tag().Id(), err)
+			return errors.Annotate(err, fs.Tag().Id())
+		}
+	}
+	i.logger.Debugf("importing filesystems succeeded")
+	return nil
+}
+
+func (i *importer) filesystem(fs description.Filesystem) error {
+	attachments := fs.Attachments()
+	tag := fs.Tag()
+	var binding string
+	bindingTag, err := fs.Binding()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if bindingTag != nil {
+		binding = bindingTag.String()
+	}
+	status := fs.Status()
+	if status == nil {
+		return errors.NotValidf("missing status")
+	}
+
+	doc := &filesystemDoc{
+		FilesystemId:    tag.Id(),
+		StorageId:       fs.Storage().Id(),
+		VolumeId:        fs.Volume().Id(),
+		Life:            Alive,
+		Binding:         binding,
+		AttachmentCount: len(attachments),
+	}
+	if fs.Provisioned() {
+		doc.Info = &FilesystemInfo{
+			Size:         fs.Size(),
+			Pool:         fs.Pool(),
+			FilesystemId: fs.FilesystemID(),
+		}
+	} else {
+		doc.Params = &FilesystemParams{
+			Size: fs.Size(),
+			Pool: fs.Pool(),
+		}
+	}
+	ops := []txn.Op{
+		{
+			C:      filesystemsC,
+			Id:     tag.Id(),
+			Assert: txn.DocMissing,
+			Insert: doc,
+		},
+		createStatusOp(i.st, filesystemGlobalKey(tag.Id()), i.makeStatusDoc(status)),
+	}
+	for _, attachment := range attachments {
+		ops = append(ops, i.filesystemAttachmentOps(tag.Id(), attachment)...)
+	}
+
+	if err := i.st.runTransaction(ops); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := i.importStatusHistory(filesystemGlobalKey(tag.Id()), fs.StatusHistory()); err != nil {
+		return errors.Annotate(err, "status history")
+	}
+	return nil
+}
+
+func (i *importer) filesystemAttachmentOps(fsId string, attachment description.FilesystemAttachment) []txn.Op {
+	machineId := attachment.Machine().Id()
+	doc := &filesystemAttachmentDoc{
+		Filesystem: fsId,
+		Machine:    machineId,
+		Life:       Alive,
+	}
+	if attachment.Provisioned() {
+		doc.Info = &FilesystemAttachmentInfo{
+			MountPoint: attachment.MountPoint(),
+			ReadOnly:   attachment.ReadOnly(),
+		}
+	} else {
+		doc.Params = &FilesystemAttachmentParams{
+			Location: attachment.MountPoint(),
+			ReadOnly: attachment.ReadOnly(),
+		}
+	}
+	return []txn.Op{{
+		C:      filesystemAttachmentsC,
+		Id:     filesystemAttachmentId(machineId, fsId),
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}, {
+		C:      machinesC,
+		Id:     machineId,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$addToSet", bson.D{{"filesystems", fsId}}}},
+	}}
+}
+
 func (i *importer) importStatusHistory(globalKey string, history []description.Status) error {
 	docs := make([]interface{}, len(history))
 	for i, statusVal := range history {
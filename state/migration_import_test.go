@@ -4,6 +4,8 @@
 package state_test
 
 import (
+	"bytes"
+	"io/ioutil"
 	"time"
 
 	"github.com/juju/errors"
@@ -12,10 +14,13 @@ import (
 	"github.com/juju/utils"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/payload"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/testing/factory"
@@ -133,11 +138,7 @@ func (s *MigrationImportSuite) TestNewModel(c *gc.C) {
 	c.Assert(blocks[0].Message(), gc.Equals, "locked down")
 }
 
-func (s *MigrationImportSuite) newModelUser(c *gc.C, name string, readOnly bool, lastConnection time.Time) *state.ModelUser {
-	access := state.ModelAdminAccess
-	if readOnly {
-		access = state.ModelReadAccess
-	}
+func (s *MigrationImportSuite) newModelUser(c *gc.C, name string, access state.ModelAccess, lastConnection time.Time) *state.ModelUser {
 	user, err := s.State.AddModelUser(state.ModelUserSpec{
 		User:      names.NewUserTag(name),
 		CreatedBy: s.Owner,
@@ -157,6 +158,7 @@ func (s *MigrationImportSuite) AssertUserEqual(c *gc.C, newUser, oldUser *state.
 	c.Assert(newUser.CreatedBy(), gc.Equals, oldUser.CreatedBy())
 	c.Assert(newUser.DateCreated(), gc.Equals, oldUser.DateCreated())
 	c.Assert(newUser.ReadOnly(), gc.Equals, oldUser.ReadOnly())
+	c.Assert(newUser.Access(), gc.Equals, oldUser.Access())
 
 	connTime, err := oldUser.LastConnection()
 	if state.IsNeverConnectedError(err) {
@@ -172,22 +174,23 @@ func (s *MigrationImportSuite) AssertUserEqual(c *gc.C, newUser, oldUser *state.
 }
 
 func (s *MigrationImportSuite) TestModelUsers(c *gc.C) {
-	// To be sure with this test, we create three env users, and remove
+	// To be sure with this test, we create four env users, and remove
 	// the owner.
 	err := s.State.RemoveModelUser(s.Owner)
 	c.Assert(err, jc.ErrorIsNil)
 
 	lastConnection := state.NowToTheSecond()
 
-	bravo := s.newModelUser(c, "bravo@external", false, lastConnection)
-	charlie := s.newModelUser(c, "charlie@external", true, lastConnection)
-	delta := s.newModelUser(c, "delta@external", true, time.Time{})
+	bravo := s.newModelUser(c, "bravo@external", state.ModelAdminAccess, lastConnection)
+	charlie := s.newModelUser(c, "charlie@external", state.ModelReadAccess, lastConnection)
+	delta := s.newModelUser(c, "delta@external", state.ModelReadAccess, time.Time{})
+	echo := s.newModelUser(c, "echo@external", state.ModelWriteAccess, lastConnection)
 
 	newModel, newSt := s.importModel(c)
 	defer newSt.Close()
 
 	// Check the import values of the users.
-	for _, user := range []*state.ModelUser{bravo, charlie, delta} {
+	for _, user := range []*state.ModelUser{bravo, charlie, delta, echo} {
 		newUser, err := newSt.ModelUser(user.UserTag())
 		c.Assert(err, jc.ErrorIsNil)
 		s.AssertUserEqual(c, newUser, user)
@@ -196,7 +199,7 @@ func (s *MigrationImportSuite) TestModelUsers(c *gc.C) {
 	// Also make sure that there aren't any more.
 	allUsers, err := newModel.Users()
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(allUsers, gc.HasLen, 3)
+	c.Assert(allUsers, gc.HasLen, 4)
 }
 
 func (s *MigrationImportSuite) AssertMachineEqual(c *gc.C, newMachine, oldMachine *state.Machine) {
@@ -466,6 +469,215 @@ func (s *MigrationImportSuite) TestUnitsOpenPorts(c *gc.C) {
 	})
 }
 
+func (s *MigrationImportSuite) TestMachineStorage(c *gc.C) {
+	s.Factory.MakeMachine(c, &factory.MachineParams{
+		Volumes: []state.MachineVolumeParams{{
+			Volume: state.VolumeParams{Pool: "loop", Size: 1024},
+		}},
+		Filesystems: []state.MachineFilesystemParams{{
+			Filesystem: state.FilesystemParams{Pool: "rootfs", Size: 2048},
+		}},
+	})
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	volumes, err := newSt.AllVolumes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumes, gc.HasLen, 1)
+	params, ok := volumes[0].Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(params.Pool, gc.Equals, "loop")
+	c.Check(params.Size, gc.Equals, uint64(1024))
+	volAttachments, err := newSt.VolumeAttachments(volumes[0].VolumeTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volAttachments, gc.HasLen, 1)
+
+	filesystems, err := newSt.AllFilesystems()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(filesystems, gc.HasLen, 1)
+	fsParams, ok := filesystems[0].Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(fsParams.Pool, gc.Equals, "rootfs")
+	c.Check(fsParams.Size, gc.Equals, uint64(2048))
+	fsAttachments, err := newSt.FilesystemAttachments(filesystems[0].FilesystemTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fsAttachments, gc.HasLen, 1)
+}
+
+func (s *MigrationImportSuite) TestSpaces(c *gc.C) {
+	space, err := s.State.AddSpace("one", network.Id("provider"), nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	imported, err := newSt.Space(space.Name())
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(imported.Name(), gc.Equals, space.Name())
+	c.Assert(imported.ProviderId(), gc.Equals, space.ProviderId())
+}
+
+func (s *MigrationImportSuite) TestSubnets(c *gc.C) {
+	_, err := s.State.AddSpace("bam", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	original, err := s.State.AddSubnet(state.SubnetInfo{
+		CIDR:             "10.0.0.0/24",
+		ProviderId:       network.Id("foo"),
+		VLANTag:          64,
+		AvailabilityZone: "bar",
+		SpaceName:        "bam",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	subnet, err := newSt.Subnet(original.CIDR())
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(subnet.CIDR(), gc.Equals, "10.0.0.0/24")
+	c.Assert(subnet.ProviderId(), gc.Equals, network.Id("foo"))
+	c.Assert(subnet.VLANTag(), gc.Equals, 64)
+	c.Assert(subnet.AvailabilityZone(), gc.Equals, "bar")
+	c.Assert(subnet.SpaceName(), gc.Equals, "bam")
+}
+
+func (s *MigrationImportSuite) TestLinkLayerDevices(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	err := machine.SetLinkLayerDevices(state.LinkLayerDeviceArgs{
+		Name:       "eth0",
+		Type:       state.EthernetDevice,
+		MACAddress: "aa:bb:cc:dd:ee:f0",
+		IsUp:       true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetLinkLayerDevices(state.LinkLayerDeviceArgs{
+		Name:       "eth0.100",
+		MTU:        1500,
+		Type:       state.VLAN_8021QDevice,
+		MACAddress: "aa:bb:cc:dd:ee:f0",
+		ParentName: "eth0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	imported, err := newSt.Machine(machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	devices, err := imported.AllLinkLayerDevices()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(devices, gc.HasLen, 2)
+
+	vlan, err := imported.LinkLayerDevice("eth0.100")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(vlan.MTU(), gc.Equals, uint(1500))
+	c.Assert(vlan.Type(), gc.Equals, state.VLAN_8021QDevice)
+	parent, err := vlan.ParentDevice()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(parent.Name(), gc.Equals, "eth0")
+	c.Assert(parent.IsUp(), jc.IsTrue)
+}
+
+func (s *MigrationImportSuite) TestEndpointBindings(c *gc.C) {
+	_, err := s.State.AddSpace("one", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	state.AddTestingServiceWithBindings(
+		c, s.State, "mysql", state.AddTestingCharm(c, s.State, "mysql"), s.Owner,
+		map[string]string{"server": "one"},
+	)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	imported, err := newSt.Service("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	bindings, err := imported.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bindings, jc.DeepEquals, map[string]string{"server": "one"})
+}
+
+func (s *MigrationImportSuite) TestResources(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	service, err := unit.Service()
+	c.Assert(err, jc.ErrorIsNil)
+
+	st, err := s.State.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+	data := "spamspamspam"
+	res := newResource(c, "spam", data)
+	_, err = st.SetResource(service.Name(), res.Username, res.Resource, bytes.NewBufferString(data))
+	c.Assert(err, jc.ErrorIsNil)
+	err = st.SetCharmStoreResources(service.Name(), []charmresource.Resource{res.Resource}, time.Now())
+	c.Assert(err, jc.ErrorIsNil)
+	_, reader, err := st.OpenResourceForUniter(unit, "spam")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = ioutil.ReadAll(reader)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reader.Close(), jc.ErrorIsNil)
+
+	expected, err := st.ListResources(service.Name())
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	newResources, err := newSt.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+	imported, err := newResources.ListResources(service.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported.Resources, jc.DeepEquals, expected.Resources)
+	c.Assert(imported.CharmStoreResources, jc.DeepEquals, expected.CharmStoreResources)
+	c.Assert(imported.UnitResources, gc.HasLen, 1)
+	c.Assert(imported.UnitResources[0].Tag, gc.Equals, unit.UnitTag())
+	c.Assert(imported.UnitResources[0].Resources, jc.DeepEquals, expected.Resources)
+}
+
+func (s *MigrationImportSuite) TestPayloads(c *gc.C) {
+	ch := s.AddMetaCharm(c, "dummy", payloadsMetaYAML, 2)
+	service := s.Factory.MakeService(c, &factory.ServiceParams{Charm: ch})
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Service: service})
+
+	up, err := s.State.UnitPayloads(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = up.Track(payload.Payload{
+		PayloadClass: charm.PayloadClass{
+			Name: "payloadA",
+			Type: "docker",
+		},
+		Status: payload.StateRunning,
+		ID:     "xyz",
+		Labels: []string{"ham"},
+		Unit:   unit.Name(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	envPayloads, err := newSt.EnvPayloads()
+	c.Assert(err, jc.ErrorIsNil)
+	payloads, err := envPayloads.ListAll()
+	c.Assert(err, jc.ErrorIsNil)
+	machineID, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(payloads, jc.DeepEquals, []payload.FullPayloadInfo{{
+		Payload: payload.Payload{
+			PayloadClass: charm.PayloadClass{
+				Name: "payloadA",
+				Type: "docker",
+			},
+			ID:     "xyz",
+			Status: payload.StateRunning,
+			Labels: []string{"ham"},
+			Unit:   unit.Name(),
+		},
+		Machine: machineID,
+	}})
+}
+
 func (s *MigrationImportSuite) TestDestroyEmptyModel(c *gc.C) {
 	newModel, newSt := s.importModel(c)
 	defer newSt.Close()
@@ -517,3 +729,40 @@ func (m *mockModel) Config() map[string]interface{} {
 	c["name"] = m.name
 	return c
 }
+
+func (s *MigrationImportSuite) TestImportFailureRemovesModel(c *gc.C) {
+	s.Factory.MakeService(c, nil)
+	out, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	uuid := utils.MustNewUUID().String()
+	in := &noServiceStatusModel{newModel(out, uuid, "new")}
+
+	_, _, err = s.State.Import(in)
+	c.Assert(err, gc.ErrorMatches, `services: .*: missing status not valid`)
+
+	_, err = s.State.GetModel(names.NewModelTag(uuid))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+// noServiceStatusModel returns services without a status, which makes
+// the import fail after the model itself has been created.
+type noServiceStatusModel struct {
+	description.Model
+}
+
+func (m *noServiceStatusModel) Services() []description.Service {
+	var result []description.Service
+	for _, service := range m.Model.Services() {
+		result = append(result, noStatusService{service})
+	}
+	return result
+}
+
+type noStatusService struct {
+	description.Service
+}
+
+func (noStatusService) Status() description.Status {
+	return nil
+}
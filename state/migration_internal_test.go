@@ -38,6 +38,10 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		servicesC,
 		unitsC,
 		meterStatusC, // red / green status for metrics of units
+		"payloads",
+		// The resource blobs are streamed to the target controller
+		// separately from the model description.
+		resourcesC,
 
 		// settings reference counts are only used for services
 		settingsrefsC,
@@ -45,6 +49,25 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// relation
 		relationsC,
 		relationScopesC,
+
+		// storage
+		filesystemsC,
+		filesystemAttachmentsC,
+		storageInstancesC,
+		storageAttachmentsC,
+		storageConstraintsC,
+		volumesC,
+		volumeAttachmentsC,
+
+		// network
+		endpointBindingsC,
+		linkLayerDevicesC,
+		subnetsC,
+		spacesC,
+		// The provider id and device reference documents are created
+		// as the spaces, subnets and link-layer devices are imported.
+		providerIDsC,
+		linkLayerDevicesRefsC,
 	)
 
 	ignoredCollections := set.NewStrings(
@@ -60,10 +83,14 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// Users aren't migrated.
 		usersC,
 		userLastLoginC,
+		// Controller access is controller global, not migrated.
+		controllerUsersC,
 		// userenvnameC is just to provide a unique key constraint.
 		usermodelnameC,
 		// Metrics aren't migrated.
 		metricsC,
+		// The audit log stays with the controller that recorded it.
+		auditLogC,
 		// leaseC is deprecated in favour of leasesC.
 		leaseC,
 		// Backup and restore information is not migrated.
@@ -112,6 +139,10 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// The SSH host keys for each machine will be reported as each
 		// machine agent starts up.
 		sshHostKeysC,
+
+		// Block devices are published by the machine agents, and will be
+		// reported again as each machine agent starts up.
+		blockDevicesC,
 	)
 
 	// THIS SET WILL BE REMOVED WHEN MIGRATIONS ARE COMPLETE
@@ -124,32 +155,15 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 
 		// service / unit
 		charmsC,
-		"payloads",
-		"resources",
-		endpointBindingsC,
-
-		// storage
-		blockDevicesC,
-		filesystemsC,
-		filesystemAttachmentsC,
-		storageInstancesC,
-		storageAttachmentsC,
-		storageConstraintsC,
-		volumesC,
-		volumeAttachmentsC,
 
 		// network
 		ipAddressesC,
-		providerIDsC,
-		linkLayerDevicesC,
-		linkLayerDevicesRefsC,
-		subnetsC,
-		spacesC,
 
 		// actions
 		actionsC,
 		actionNotificationsC,
 		actionresultsC,
+		actionSchedulesC,
 
 		// uncategorised
 		metricsManagerC, // should really be copied across
@@ -249,12 +263,15 @@ func (s *MigrationSuite) TestMachineDocFields(c *gc.C) {
 		// Ignored at this stage, could be an issue if mongo 3.0 isn't
 		// available.
 		"StopMongoUntilVersion",
+
+		// Volumes and Filesystems are populated from the volume and
+		// filesystem attachments.
+		"Volumes",
+		"Filesystems",
 	)
 	todo := set.NewStrings(
-		"Volumes",
 		"NoVote",
 		"Clean",
-		"Filesystems",
 		"HasVote",
 	)
 	s.AssertExportedFields(c, machineDoc{}, fields.Union(todo))
@@ -300,6 +317,9 @@ func (s *MigrationSuite) TestServiceDocFields(c *gc.C) {
 		// RelationCount is handled by the number of times the service name
 		// appears in relation endpoints.
 		"RelationCount",
+		// CharmUpgrade is not migrated; any units held back by a
+		// rolling upgrade move to the service's charm.
+		"CharmUpgrade",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -310,6 +330,8 @@ func (s *MigrationSuite) TestServiceDocFields(c *gc.C) {
 		"CharmModifiedVersion",
 		"ForceCharm",
 		"Exposed",
+		"ExposedSourceCIDRs",
+		"ExposedPortSourceCIDRs",
 		"MinUnits",
 		"MetricCredentials",
 	)
@@ -355,12 +377,11 @@ func (s *MigrationSuite) TestUnitDocFields(c *gc.C) {
 		"Ports",
 		"PublicAddress",
 		"PrivateAddress",
-	)
-	todo := set.NewStrings(
+		// StorageAttachmentCount is recreated from the storage attachments.
 		"StorageAttachmentCount",
 	)
 
-	s.AssertExportedFields(c, unitDoc{}, fields.Union(todo))
+	s.AssertExportedFields(c, unitDoc{}, fields)
 }
 
 func (s *MigrationSuite) TestPortsDocFields(c *gc.C) {
@@ -514,6 +535,182 @@ func (s *MigrationSuite) TestHistoricalStatusDocFields(c *gc.C) {
 	s.AssertExportedFields(c, historicalStatusDoc{}, fields)
 }
 
+func (s *MigrationSuite) TestStorageInstanceDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocID is the model + storage id.
+		"DocID",
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+		"Id",
+		"Kind",
+		"Owner",
+		"StorageName",
+		// Life isn't migrated as we only migrate live things.
+		"Life",
+		// AttachmentCount is recreated from the attachments.
+		"AttachmentCount",
+		// CharmURL comes from the owning service.
+		"CharmURL",
+	)
+	s.AssertExportedFields(c, storageInstanceDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestStorageAttachmentDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		"DocID",
+		"ModelUUID",
+		"Unit",
+		"StorageInstance",
+		// Life isn't migrated as we only migrate live things.
+		"Life",
+	)
+	s.AssertExportedFields(c, storageAttachmentDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestStorageConstraintsDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		"DocID",
+		"ModelUUID",
+		"Constraints",
+	)
+	s.AssertExportedFields(c, storageConstraintsDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestVolumeDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		"DocID",
+		"ModelUUID",
+		"Name",
+		"StorageId",
+		"Binding",
+		"Info",
+		"Params",
+		// Life isn't migrated as we only migrate live things.
+		"Life",
+		// AttachmentCount is recreated from the attachments.
+		"AttachmentCount",
+	)
+	s.AssertExportedFields(c, volumeDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestVolumeAttachmentDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		"DocID",
+		"ModelUUID",
+		"Volume",
+		"Machine",
+		"Info",
+		"Params",
+		// Life isn't migrated as we only migrate live things.
+		"Life",
+	)
+	s.AssertExportedFields(c, volumeAttachmentDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestFilesystemDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		"DocID",
+		"ModelUUID",
+		"FilesystemId",
+		"StorageId",
+		"VolumeId",
+		"Binding",
+		"Info",
+		"Params",
+		// Life isn't migrated as we only migrate live things.
+		"Life",
+		// AttachmentCount is recreated from the attachments.
+		"AttachmentCount",
+	)
+	s.AssertExportedFields(c, filesystemDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestFilesystemAttachmentDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		"DocID",
+		"ModelUUID",
+		"Filesystem",
+		"Machine",
+		"Info",
+		"Params",
+		// Life isn't migrated as we only migrate live things.
+		"Life",
+	)
+	s.AssertExportedFields(c, filesystemAttachmentDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestSpaceDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocID is the name of the space, and the model uuid is
+		// set when the space is added.
+		"DocID",
+		"ModelUUID",
+		// Life isn't migrated as we only migrate live things.
+		"Life",
+
+		"Name",
+		"IsPublic",
+		"ProviderId",
+	)
+	s.AssertExportedFields(c, spaceDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestSubnetDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocID is the CIDR of the subnet, and the model uuid is
+		// set when the subnet is added.
+		"DocID",
+		"ModelUUID",
+		// Life isn't migrated as we only migrate live things.
+		"Life",
+
+		"ProviderId",
+		"CIDR",
+		"AllocatableIPHigh",
+		"AllocatableIPLow",
+		"VLANTag",
+		"AvailabilityZone",
+		"SpaceName",
+		// IsPublic is not set anywhere yet, so it isn't migrated.
+		"IsPublic",
+	)
+	s.AssertExportedFields(c, subnetDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestLinkLayerDeviceDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocID and ModelUUID are recreated from the machine and
+		// device name in the new model.
+		"DocID",
+		"ModelUUID",
+
+		"Name",
+		"MTU",
+		"ProviderID",
+		"MachineID",
+		"Type",
+		"MACAddress",
+		"IsAutoStart",
+		"IsUp",
+		"ParentName",
+	)
+	s.AssertExportedFields(c, linkLayerDeviceDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestEndpointBindingsDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocID is the service global key.
+		"DocID",
+		"EnvUUID",
+		// TxnRevno is managed by the transaction runner.
+		"TxnRevno",
+
+		"Bindings",
+	)
+	s.AssertExportedFields(c, endpointBindingsDoc{}, fields)
+}
+
 func (s *MigrationSuite) AssertExportedFields(c *gc.C, doc interface{}, fields set.Strings) {
 	expected := getExportedFields(doc)
 	unknown := expected.Difference(fields)
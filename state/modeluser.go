@@ -46,10 +46,25 @@ const (
 	// being able to make any changes.
 	ModelReadAccess ModelAccess = "read"
 
+	// ModelWriteAccess allows a user to make changes to a model's services,
+	// units, relations and configuration, but not to manage the model's
+	// users or to destroy the model.
+	ModelWriteAccess ModelAccess = "write"
+
 	// ModelAdminAccess allows a user full control over the model.
 	ModelAdminAccess ModelAccess = "admin"
 )
 
+// Validate returns an error if the access is not one of the known model
+// access levels.
+func (a ModelAccess) Validate() error {
+	switch a {
+	case ModelReadAccess, ModelWriteAccess, ModelAdminAccess:
+		return nil
+	}
+	return errors.NotValidf("model access %q", a)
+}
+
 // modelUserLastConnectionDoc is updated by the apiserver whenever the user
 // connects over the API. This update is not done using mgo.txn so the values
 // could well change underneath a normal transaction and as such, it should
@@ -104,6 +119,11 @@ func (e *ModelUser) ReadOnly() bool {
 	return e.doc.Access == ModelUndefinedAccess || e.doc.Access == ModelReadAccess
 }
 
+// IsAdmin returns whether or not the user has admin access to the model.
+func (e *ModelUser) IsAdmin() bool {
+	return e.doc.Access == ModelAdminAccess
+}
+
 // Access returns the access permission that the user has to the model.
 func (e *ModelUser) Access() ModelAccess {
 	return e.doc.Access
@@ -111,10 +131,8 @@ func (e *ModelUser) Access() ModelAccess {
 
 // SetAccess changes the user's access permissions on the model.
 func (e *ModelUser) SetAccess(access ModelAccess) error {
-	switch access {
-	case ModelReadAccess, ModelAdminAccess:
-	default:
-		return errors.Errorf("invalid model access %q", access)
+	if err := access.Validate(); err != nil {
+		return errors.Trace(err)
 	}
 	op := txn.Op{
 		C:      modelUsersC,
@@ -237,6 +255,9 @@ func (st *State) AddModelUser(spec ModelUserSpec) (*ModelUser, error) {
 	if spec.Access == ModelUndefinedAccess {
 		spec.Access = ModelReadAccess
 	}
+	if err := spec.Access.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	modelUUID := st.ModelUUID()
 	op := createModelUserOp(modelUUID, spec.User, spec.CreatedBy, spec.DisplayName, nowToTheSecond(), spec.Access)
@@ -341,6 +362,10 @@ func (st *State) ModelsForUser(user names.UserTag) ([]*UserModel, error) {
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		if env.MigrationMode() == MigrationModeImporting {
+			// Models are not usable until their import completes.
+			continue
+		}
 
 		result = append(result, &UserModel{Model: env, User: user})
 	}
@@ -348,9 +373,18 @@ func (st *State) ModelsForUser(user names.UserTag) ([]*UserModel, error) {
 	return result, nil
 }
 
-// IsControllerAdministrator returns true if the user specified has access to the
-// controller model (the system model).
+// IsControllerAdministrator returns true if the user specified has superuser
+// access to the controller, or admin access to the controller model (the
+// system model).
 func (st *State) IsControllerAdministrator(user names.UserTag) (bool, error) {
+	access, err := st.ControllerAccess(user)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if access == ControllerSuperuserAccess {
+		return true, nil
+	}
+
 	ssinfo, err := st.ControllerInfo()
 	if err != nil {
 		return false, errors.Annotate(err, "could not get controller info")
@@ -102,6 +102,39 @@ func (s *ModelUserSuite) TestSetAccessModelUser(c *gc.C) {
 	c.Assert(modelUser.Access(), gc.Equals, state.ModelReadAccess)
 }
 
+func (s *ModelUserSuite) TestAddWriteModelUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "validusername", NoModelUser: true})
+	createdBy := s.Factory.MakeUser(c, &factory.UserParams{Name: "createdby"})
+	modelUser, err := s.State.AddModelUser(state.ModelUserSpec{
+		User: user.UserTag(), CreatedBy: createdBy.UserTag(), Access: state.ModelWriteAccess})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.ReadOnly(), jc.IsFalse)
+	c.Assert(modelUser.IsAdmin(), jc.IsFalse)
+	c.Assert(modelUser.Access(), gc.Equals, state.ModelWriteAccess)
+
+	err = modelUser.SetAccess(state.ModelAdminAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	modelUser, err = s.State.ModelUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.IsAdmin(), jc.IsTrue)
+	c.Assert(modelUser.Access(), gc.Equals, state.ModelAdminAccess)
+}
+
+func (s *ModelUserSuite) TestAddModelUserInvalidAccess(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "validusername", NoModelUser: true})
+	createdBy := s.Factory.MakeUser(c, &factory.UserParams{Name: "createdby"})
+	_, err := s.State.AddModelUser(state.ModelUserSpec{
+		User: user.UserTag(), CreatedBy: createdBy.UserTag(), Access: state.ModelAccess("superpowers")})
+	c.Assert(err, gc.ErrorMatches, `model access "superpowers" not valid`)
+}
+
+func (s *ModelUserSuite) TestSetAccessInvalid(c *gc.C) {
+	modelUser := s.Factory.MakeModelUser(c, nil)
+	err := modelUser.SetAccess(state.ModelAccess("superpowers"))
+	c.Assert(err, gc.ErrorMatches, `model access "superpowers" not valid`)
+}
+
 func (s *ModelUserSuite) TestCaseUserNameVsId(c *gc.C) {
 	model, err := s.State.Model()
 	c.Assert(err, jc.ErrorIsNil)
@@ -306,6 +339,27 @@ func (s *ModelUserSuite) TestModelsForUserEnvOwner(c *gc.C) {
 	s.checkSameModel(c, models[0].Model, model)
 }
 
+func (s *ModelUserSuite) TestModelsForUserSkipsImportingModels(c *gc.C) {
+	owner := names.NewUserTag("external@remote")
+	uuid, err := utils.NewUUID()
+	c.Assert(err, jc.ErrorIsNil)
+	cfg := testing.CustomModelConfig(c, testing.Attrs{
+		"name": "importing",
+		"uuid": uuid.String(),
+	})
+	_, st, err := s.State.NewModel(state.ModelArgs{
+		Config:        cfg,
+		Owner:         owner,
+		MigrationMode: state.MigrationModeImporting,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+
+	models, err := s.State.ModelsForUser(owner)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(models, gc.HasLen, 0)
+}
+
 func (s *ModelUserSuite) checkSameModel(c *gc.C, env1, env2 *state.Model) {
 	c.Check(env1.Name(), gc.Equals, env2.Name())
 	c.Check(env1.UUID(), gc.Equals, env2.UUID())
@@ -143,6 +143,7 @@ func Initialize(owner names.UserTag, info *mongo.MongoInfo, cfg *config.Config,
 	}
 	ops := []txn.Op{
 		createInitialUserOp(st, owner, info.Password, salt),
+		createControllerUserOp(owner, owner, nowToTheSecond(), ControllerSuperuserAccess),
 		txn.Op{
 			C:      controllersC,
 			Id:     modelGlobalKey,
@@ -9,6 +9,7 @@ package presence
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
@@ -204,6 +205,10 @@ type reqAlive struct {
 	result chan bool
 }
 
+type reqReport struct {
+	result chan map[string]interface{}
+}
+
 func (w *Watcher) sendReq(req interface{}) {
 	select {
 	case w.request <- req:
@@ -255,6 +260,20 @@ func (w *Watcher) Alive(key string) (bool, error) {
 	return alive, nil
 }
 
+// Report returns a map describing the keys the watcher currently
+// considers alive, and the keys being watched. It returns nil if the
+// watcher is dying.
+func (w *Watcher) Report() map[string]interface{} {
+	result := make(chan map[string]interface{}, 1)
+	w.sendReq(reqReport{result})
+	select {
+	case report := <-result:
+		return report
+	case <-w.tomb.Dying():
+		return nil
+	}
+}
+
 // period is the length of each time slot in seconds.
 // It's not a time.Duration because the code is more convenient like
 // this and also because sub-second timings don't work as the slot
@@ -347,6 +366,24 @@ func (w *Watcher) handle(req interface{}) {
 	case reqAlive:
 		_, alive := w.beingSeq[r.key]
 		r.result <- alive
+	case reqReport:
+		alive := make([]string, 0, len(w.beingSeq))
+		for key := range w.beingSeq {
+			alive = append(alive, key)
+		}
+		sort.Strings(alive)
+		watched := make([]string, 0, len(w.watches))
+		for key, watches := range w.watches {
+			if len(watches) > 0 {
+				watched = append(watched, key)
+			}
+		}
+		sort.Strings(watched)
+		r.result <- map[string]interface{}{
+			"model-uuid": w.modelUUID,
+			"alive":      alive,
+			"watched":    watched,
+		}
 	default:
 		panic(fmt.Errorf("unknown request: %T", req))
 	}
@@ -120,6 +120,28 @@ func (s *PresenceSuite) TestAliveError(c *gc.C) {
 	c.Assert(alive, jc.IsFalse)
 }
 
+func (s *PresenceSuite) TestReport(c *gc.C) {
+	w := presence.NewWatcher(s.presence, s.modelTag)
+	pa := presence.NewPinger(s.presence, s.modelTag, "a")
+	defer w.Stop()
+	defer pa.Stop()
+
+	c.Assert(pa.Start(), gc.IsNil)
+	ch := make(chan presence.Change)
+	w.Watch("b", ch)
+	assertChange(c, ch, presence.Change{"b", false})
+	w.Sync()
+
+	c.Assert(w.Report(), jc.DeepEquals, map[string]interface{}{
+		"model-uuid": s.modelTag.Id(),
+		"alive":      []string{"a"},
+		"watched":    []string{"b"},
+	})
+
+	c.Assert(w.Stop(), gc.IsNil)
+	c.Assert(w.Report(), gc.IsNil)
+}
+
 func (s *PresenceSuite) TestWorkflow(c *gc.C) {
 	w := presence.NewWatcher(s.presence, s.modelTag)
 	pa := presence.NewPinger(s.presence, s.modelTag, "a")
@@ -23,6 +23,7 @@ import (
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
 )
 
@@ -52,6 +53,18 @@ type serviceDoc struct {
 	OwnerTag             string     `bson:"ownertag"`
 	TxnRevno             int64      `bson:"txn-revno"`
 	MetricCredentials    []byte     `bson:"metric-credentials"`
+
+	// ExposedSourceCIDRs holds the networks from which the opened
+	// ports of the exposed service may be reached; empty means
+	// anywhere.
+	ExposedSourceCIDRs []string `bson:"exposed-source-cidrs,omitempty"`
+	// ExposedPortSourceCIDRs overrides ExposedSourceCIDRs for
+	// individual port ranges, keyed on the port range string.
+	ExposedPortSourceCIDRs map[string][]string `bson:"exposed-port-source-cidrs,omitempty"`
+
+	// CharmUpgrade is set while a rolling charm upgrade is in
+	// progress.
+	CharmUpgrade *charmUpgradeDoc `bson:"charmupgrade,omitempty"`
 }
 
 func newService(st *State, doc *serviceDoc) *Service {
@@ -287,26 +300,140 @@ func (s *Service) SetExposed() error {
 	return s.setExposed(true)
 }
 
-// ClearExposed removes the exposed flag from the service.
+// ClearExposed removes the exposed flag from the service, along with
+// any source CIDRs set for it.
 // See SetExposed and IsExposed.
 func (s *Service) ClearExposed() error {
 	return s.setExposed(false)
 }
 
 func (s *Service) setExposed(exposed bool) (err error) {
+	update := bson.D{{"$set", bson.D{{"exposed", exposed}}}}
+	if !exposed {
+		update = append(update, bson.DocElem{"$unset", bson.D{
+			{"exposed-source-cidrs", nil},
+			{"exposed-port-source-cidrs", nil},
+		}})
+	}
 	ops := []txn.Op{{
 		C:      servicesC,
 		Id:     s.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"exposed", exposed}}}},
+		Update: update,
 	}}
 	if err := s.st.runTransaction(ops); err != nil {
 		return fmt.Errorf("cannot set exposed flag for service %q to %v: %v", s, exposed, onAbort(err, errNotAlive))
 	}
 	s.doc.Exposed = exposed
+	if !exposed {
+		s.doc.ExposedSourceCIDRs = nil
+		s.doc.ExposedPortSourceCIDRs = nil
+	}
+	return nil
+}
+
+// ExposeSourceCIDRs returns the networks from which the opened ports of
+// the service may be reached while it is exposed.
+func (s *Service) ExposeSourceCIDRs() (network.ExposeSourceCIDRs, error) {
+	sources := network.ExposeSourceCIDRs{
+		Default: s.doc.ExposedSourceCIDRs,
+	}
+	for key, sourceCIDRs := range s.doc.ExposedPortSourceCIDRs {
+		portRange, err := network.ParsePortRange(key)
+		if err != nil {
+			return network.ExposeSourceCIDRs{}, errors.Trace(err)
+		}
+		if sources.Ports == nil {
+			sources.Ports = make(map[network.PortRange][]string)
+		}
+		sources.Ports[portRange] = sourceCIDRs
+	}
+	return sources, nil
+}
+
+// SetExposeSourceCIDRs sets the networks from which the opened ports of
+// the service may be reached while it is exposed, for the port ranges
+// without source CIDRs of their own. An empty slice means anywhere.
+func (s *Service) SetExposeSourceCIDRs(sourceCIDRs []string) error {
+	sourceCIDRs, err := normaliseSourceCIDRs(sourceCIDRs)
+	if err != nil {
+		return errors.Annotatef(err, "cannot set source CIDRs for service %q", s)
+	}
+	var update bson.D
+	if len(sourceCIDRs) == 0 {
+		update = bson.D{{"$unset", bson.D{{"exposed-source-cidrs", nil}}}}
+	} else {
+		update = bson.D{{"$set", bson.D{{"exposed-source-cidrs", sourceCIDRs}}}}
+	}
+	ops := []txn.Op{{
+		C:      servicesC,
+		Id:     s.doc.DocID,
+		Assert: isAliveDoc,
+		Update: update,
+	}}
+	if err := s.st.runTransaction(ops); err != nil {
+		return errors.Errorf("cannot set source CIDRs for service %q: %v", s, onAbort(err, errNotAlive))
+	}
+	s.doc.ExposedSourceCIDRs = sourceCIDRs
+	return nil
+}
+
+// SetExposePortSourceCIDRs sets the networks from which the given port
+// range of the service may be reached while it is exposed, overriding
+// the source CIDRs set with SetExposeSourceCIDRs. An empty slice removes
+// the override.
+func (s *Service) SetExposePortSourceCIDRs(portRange network.PortRange, sourceCIDRs []string) error {
+	if err := portRange.Validate(); err != nil {
+		return errors.Annotatef(err, "cannot set source CIDRs for service %q", s)
+	}
+	sourceCIDRs, err := normaliseSourceCIDRs(sourceCIDRs)
+	if err != nil {
+		return errors.Annotatef(err, "cannot set source CIDRs for service %q", s)
+	}
+	field := "exposed-port-source-cidrs." + portRange.String()
+	var update bson.D
+	if sourceCIDRs == nil {
+		update = bson.D{{"$unset", bson.D{{field, nil}}}}
+	} else {
+		update = bson.D{{"$set", bson.D{{field, sourceCIDRs}}}}
+	}
+	ops := []txn.Op{{
+		C:      servicesC,
+		Id:     s.doc.DocID,
+		Assert: isAliveDoc,
+		Update: update,
+	}}
+	if err := s.st.runTransaction(ops); err != nil {
+		return errors.Errorf("cannot set source CIDRs for service %q: %v", s, onAbort(err, errNotAlive))
+	}
+	if sourceCIDRs == nil {
+		delete(s.doc.ExposedPortSourceCIDRs, portRange.String())
+	} else {
+		if s.doc.ExposedPortSourceCIDRs == nil {
+			s.doc.ExposedPortSourceCIDRs = make(map[string][]string)
+		}
+		s.doc.ExposedPortSourceCIDRs[portRange.String()] = sourceCIDRs
+	}
 	return nil
 }
 
+// normaliseSourceCIDRs validates and sorts the given source CIDRs. It
+// returns an empty slice if they include 0.0.0.0/0, and nil if there
+// are none.
+func normaliseSourceCIDRs(sourceCIDRs []string) ([]string, error) {
+	if len(sourceCIDRs) == 0 {
+		return nil, nil
+	}
+	rule, err := network.NewIngressRule(network.PortRange{}, sourceCIDRs...)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if rule.IsOpen() {
+		return []string{}, nil
+	}
+	return rule.SourceCIDRs, nil
+}
+
 // Charm returns the service's charm and whether units should upgrade to that
 // charm even if they are in an error state.
 func (s *Service) Charm() (ch *Charm, force bool, err error) {
@@ -680,10 +807,16 @@ type SetCharmConfig struct {
 	// ResourceIDs is a map of resource names to resource IDs to activate during
 	// the upgrade.
 	ResourceIDs map[string]string `json:"resourceids"`
+	// RollingUpgrade, if set, causes the service's existing units to be
+	// upgraded to the new charm in batches, as described, rather than
+	// all at once.
+	RollingUpgrade *CharmUpgradeStrategy `json:"rollingupgrade"`
 }
 
 // SetCharm changes the charm for the service. New units will be started with
-// this charm, and existing units will be upgraded to use it.
+// this charm, and existing units will be upgraded to use it; if a rolling
+// upgrade is requested, they are upgraded in batches as they are released by
+// ReleaseCharmUpgradeUnits.
 // If forceUnits is true, units will be upgraded even if they are in an error state.
 // If forceSeries is true, the charm will be used even if it's the service's series
 // is not supported by the charm.
@@ -740,12 +873,21 @@ func (s *Service) SetCharm(cfg SetCharmConfig) error {
 		}
 	}
 
+	if cfg.RollingUpgrade != nil {
+		if err := cfg.RollingUpgrade.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	services, closer := s.st.getCollection(servicesC)
 	defer closer()
 
 	// this value holds the *previous* charm modified version, before this
 	// transaction commits.
 	var charmModifiedVersion int
+	// charmUpgrade holds the rolling upgrade in progress once this
+	// transaction commits.
+	var charmUpgrade *charmUpgradeDoc
 	channel := string(cfg.Channel)
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		if attempt > 0 {
@@ -806,6 +948,7 @@ func (s *Service) SetCharm(cfg SetCharmConfig) error {
 					{"forcecharm", cfg.ForceUnits},
 				}}},
 			}}...)
+			charmUpgrade = doc.CharmUpgrade
 		} else {
 			// Change the charm URL.
 			chng, err := s.changeCharmOps(cfg.Charm, channel, cfg.ForceUnits, cfg.ResourceIDs)
@@ -813,6 +956,13 @@ func (s *Service) SetCharm(cfg SetCharmConfig) error {
 				return nil, errors.Trace(err)
 			}
 			ops = append(ops, chng...)
+
+			upgradeOps, upgrade, err := charmUpgradeOps(&doc, cfg.RollingUpgrade)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, upgradeOps...)
+			charmUpgrade = upgrade
 		}
 
 		return ops, nil
@@ -823,6 +973,7 @@ func (s *Service) SetCharm(cfg SetCharmConfig) error {
 		s.doc.Channel = channel
 		s.doc.ForceCharm = cfg.ForceUnits
 		s.doc.CharmModifiedVersion = charmModifiedVersion + 1
+		s.doc.CharmUpgrade = charmUpgrade
 	}
 	return err
 }
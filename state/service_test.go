@@ -21,6 +21,7 @@ import (
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/testing"
 	"github.com/juju/juju/status"
@@ -1346,6 +1347,65 @@ func (s *ServiceSuite) TestServiceExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ServiceSuite) TestServiceExposeSourceCIDRs(c *gc.C) {
+	admin := network.MustParsePortRange("8443/tcp")
+	web := network.MustParsePortRange("80/tcp")
+	err := s.mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetExposeSourceCIDRs([]string{"192.168.0.0/16", "10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetExposePortSourceCIDRs(admin, []string{"10.1.0.0/16"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetExposePortSourceCIDRs(web, []string{"0.0.0.0/0"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := network.ExposeSourceCIDRs{
+		Default: []string{"10.0.0.0/8", "192.168.0.0/16"},
+		Ports: map[network.PortRange][]string{
+			admin: {"10.1.0.0/16"},
+			web:   {},
+		},
+	}
+	sources, err := s.mysql.ExposeSourceCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sources, jc.DeepEquals, expected)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	sources, err = s.mysql.ExposeSourceCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sources.Equals(expected), jc.IsTrue)
+	rule, err := sources.IngressRule(web)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule.IsOpen(), jc.IsTrue)
+
+	// Removing an override falls back to the service's source CIDRs.
+	err = s.mysql.SetExposePortSourceCIDRs(admin, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	sources, err = s.mysql.ExposeSourceCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	rule, err = sources.IngressRule(admin)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule.SourceCIDRs, jc.DeepEquals, []string{"10.0.0.0/8", "192.168.0.0/16"})
+
+	// Unexposing the service forgets its source CIDRs.
+	err = s.mysql.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	sources, err = s.mysql.ExposeSourceCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sources, jc.DeepEquals, network.ExposeSourceCIDRs{})
+}
+
+func (s *ServiceSuite) TestServiceExposeSourceCIDRsInvalid(c *gc.C) {
+	err := s.mysql.SetExposeSourceCIDRs([]string{"10.0.0.0"})
+	c.Assert(err, gc.ErrorMatches, `cannot set source CIDRs for service "mysql": source CIDR "10.0.0.0" not valid`)
+	err = s.mysql.SetExposePortSourceCIDRs(network.PortRange{FromPort: 80, ToPort: 70, Protocol: "tcp"}, []string{"10.0.0.0/8"})
+	c.Assert(err, gc.ErrorMatches, `cannot set source CIDRs for service "mysql": invalid port range 80-70/tcp`)
+}
+
 func (s *ServiceSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	unitZero, err := s.mysql.AddUnit()
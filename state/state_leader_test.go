@@ -121,6 +121,26 @@ func (s *LeadershipSuite) TestCheck(c *gc.C) {
 	c.Check(ops2, gc.IsNil)
 }
 
+func (s *LeadershipSuite) TestServiceLeaders(c *gc.C) {
+	err := s.claimer.ClaimLeadership("service", "service/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.claimer.ClaimLeadership("other", "other/2", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	leaders, err := s.State.ServiceLeaders()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(leaders, jc.DeepEquals, map[string]string{
+		"service": "service/0",
+		"other":   "other/2",
+	})
+
+	// Expired leases are not reported.
+	s.expire(c, "service")
+	leaders, err = s.State.ServiceLeaders()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(leaders, gc.HasLen, 0)
+}
+
 func (s *LeadershipSuite) TestHackLeadershipUnblocksClaimer(c *gc.C) {
 	err := s.claimer.ClaimLeadership("blah", "blah/0", time.Minute)
 	c.Assert(err, jc.ErrorIsNil)
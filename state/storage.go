@@ -76,6 +76,31 @@ const (
 	StorageKindFilesystem
 )
 
+// String returns a human readable string representing the type.
+func (k StorageKind) String() string {
+	switch k {
+	case StorageKindBlock:
+		return "block"
+	case StorageKindFilesystem:
+		return "filesystem"
+	default:
+		return "unknown"
+	}
+}
+
+// parseStorageKind is used by the migration code to go from the
+// string representation back to the enum.
+func parseStorageKind(value string) StorageKind {
+	switch value {
+	case "block":
+		return StorageKindBlock
+	case "filesystem":
+		return StorageKindFilesystem
+	default:
+		return StorageKindUnknown
+	}
+}
+
 type storageInstance struct {
 	st  *State
 	doc storageInstanceDoc
@@ -4,6 +4,8 @@
 package state
 
 import (
+	"sync/atomic"
+
 	"github.com/juju/errors"
 	jujutxn "github.com/juju/txn"
 	"gopkg.in/mgo.v2/bson"
@@ -63,6 +65,18 @@ func (st *State) MaybePruneTransactions() error {
 	return runner.MaybePruneTransactions(2.0)
 }
 
+// txnRuns and txnRetries count the transactions run through every
+// multiModelRunner in the process, and the attempts at them that were
+// retried because an earlier attempt's assertions failed.
+var txnRuns, txnRetries int64
+
+// TransactionStats returns the number of transactions run by all
+// States in the process since it started, and the number of retried
+// attempts at them.
+func TransactionStats() (runs, retries int64) {
+	return atomic.LoadInt64(&txnRuns), atomic.LoadInt64(&txnRetries)
+}
+
 type multiModelRunner struct {
 	rawRunner jujutxn.Runner
 	schema    collectionSchema
@@ -73,6 +87,7 @@ type multiModelRunner struct {
 // that affect multi-model collections will be modified to
 // ensure correct interaction with these collections.
 func (r *multiModelRunner) RunTransaction(ops []txn.Op) error {
+	atomic.AddInt64(&txnRuns, 1)
 	newOps, err := r.updateOps(ops)
 	if err != nil {
 		return errors.Trace(err)
@@ -86,6 +101,11 @@ func (r *multiModelRunner) RunTransaction(ops []txn.Op) error {
 // these collections.
 func (r *multiModelRunner) Run(transactions jujutxn.TransactionSource) error {
 	return r.rawRunner.Run(func(attempt int) ([]txn.Op, error) {
+		if attempt == 0 {
+			atomic.AddInt64(&txnRuns, 1)
+		} else {
+			atomic.AddInt64(&txnRetries, 1)
+		}
 		ops, err := transactions(attempt)
 		if err != nil {
 			// Don't use Trace here as jujutxn doens't use juju/errors
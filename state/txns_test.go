@@ -440,6 +440,25 @@ func (s *MultiModelRunnerSuite) TestRunWithError(c *gc.C) {
 	c.Check(s.testRunner.seenOps, gc.IsNil)
 }
 
+func (s *MultiModelRunnerSuite) TestTransactionStats(c *gc.C) {
+	runs0, retries0 := TransactionStats()
+
+	err := s.multiModelRunner.RunTransaction([]txn.Op{{C: machinesC, Id: "1"}})
+	c.Assert(err, jc.ErrorIsNil)
+	runs, retries := TransactionStats()
+	c.Check(runs-runs0, gc.Equals, int64(1))
+	c.Check(retries-retries0, gc.Equals, int64(0))
+
+	// The recording runner reports every attempt as a retry.
+	err = s.multiModelRunner.Run(func(attempt int) ([]txn.Op, error) {
+		return []txn.Op{{C: machinesC, Id: "1"}}, nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	runs, retries = TransactionStats()
+	c.Check(runs-runs0, gc.Equals, int64(1))
+	c.Check(retries-retries0, gc.Equals, int64(1))
+}
+
 func (s *MultiModelRunnerSuite) TestResumeTransactions(c *gc.C) {
 	err := s.multiModelRunner.ResumeTransactions()
 	c.Check(err, jc.ErrorIsNil)
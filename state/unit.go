@@ -2069,8 +2069,47 @@ type ActionSpecsByName map[string]charm.ActionSpec
 // this Unit, and returns its ID.  Note that the use of spec.InsertDefaults
 // mutates payload.
 func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, error) {
+	return u.AddActionWithTimeout(name, payload, 0)
+}
+
+// AddActionWithTimeout adds a new Action as AddAction does, which will
+// be stopped and marked as failed if it runs for longer than timeout.
+// If timeout is zero, the default timeout from the action's definition
+// in the charm is used, if it has one.
+func (u *Unit) AddActionWithTimeout(name string, payload map[string]interface{}, timeout time.Duration) (Action, error) {
+	return u.addAction(name, payload, timeout, "")
+}
+
+// addAction adds a new Action as AddActionWithTimeout does, recording
+// the id of the action schedule that enqueued it, if any.
+func (u *Unit) addAction(name string, payload map[string]interface{}, timeout time.Duration, schedule string) (Action, error) {
+	spec, err := u.actionSpec(name)
+	if err != nil {
+		return nil, err
+	}
+	// Reject bad payloads before attempting to insert defaults.
+	err = spec.ValidateParams(payload)
+	if err != nil {
+		return nil, err
+	}
+	payloadWithDefaults, err := spec.InsertDefaults(payload)
+	if err != nil {
+		return nil, err
+	}
+	if timeout == 0 {
+		timeout, err = defaultActionTimeout(spec)
+		if err != nil {
+			return nil, errors.Annotatef(err, "action %q", name)
+		}
+	}
+	return u.st.enqueueAction(u.Tag(), name, payloadWithDefaults, timeout, schedule)
+}
+
+// actionSpec returns the spec of the named action, which may be
+// predefined by juju or defined by the unit's charm.
+func (u *Unit) actionSpec(name string) (charm.ActionSpec, error) {
 	if len(name) == 0 {
-		return nil, errors.New("no action name given")
+		return charm.ActionSpec{}, errors.New("no action name given")
 	}
 
 	// If the action is predefined inside juju, get spec from map
@@ -2078,23 +2117,33 @@ func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, e
 	if !ok {
 		specs, err := u.ActionSpecs()
 		if err != nil {
-			return nil, err
+			return charm.ActionSpec{}, err
 		}
 		spec, ok = specs[name]
 		if !ok {
-			return nil, errors.Errorf("action %q not defined on unit %q", name, u.Name())
+			return charm.ActionSpec{}, errors.Errorf("action %q not defined on unit %q", name, u.Name())
 		}
 	}
-	// Reject bad payloads before attempting to insert defaults.
-	err := spec.ValidateParams(payload)
-	if err != nil {
-		return nil, err
+	return spec, nil
+}
+
+// defaultActionTimeout returns the timeout declared by an action's
+// top-level "timeout" key in actions.yaml, which the charm package
+// passes through into the action's schema.
+func defaultActionTimeout(spec charm.ActionSpec) (time.Duration, error) {
+	value, ok := spec.Params["timeout"]
+	if !ok {
+		return 0, nil
 	}
-	payloadWithDefaults, err := spec.InsertDefaults(payload)
-	if err != nil {
-		return nil, err
+	str, ok := value.(string)
+	if !ok {
+		return 0, errors.NotValidf("timeout %v", value)
+	}
+	timeout, err := time.ParseDuration(str)
+	if err != nil || timeout < 0 {
+		return 0, errors.NotValidf("timeout %q", str)
 	}
-	return u.st.EnqueueAction(u.Tag(), name, payloadWithDefaults)
+	return timeout, nil
 }
 
 // ActionSpecs gets the ActionSpec map for the Unit's charm.
@@ -249,3 +249,39 @@ func addDefaultBindingsToServices(st *State) error {
 func AddDefaultEndpointBindingsToServices(st *State) error {
 	return runForAllEnvStates(st, addDefaultBindingsToServices)
 }
+
+// AddControllerUsers gives every existing local user a controller user
+// record, so that they keep the access they had before controller
+// permissions were introduced: the controller model owner becomes a
+// superuser, and every other user may log in and add models.
+func AddControllerUsers(st *State) error {
+	controllerModel, err := st.ControllerModel()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	owner := controllerModel.Owner()
+	users, err := st.AllUsers(true)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var ops []txn.Op
+	for _, user := range users {
+		_, err := st.ControllerUser(user.UserTag())
+		if err == nil {
+			continue
+		}
+		if !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+		access := ControllerAddModelAccess
+		if user.UserTag().Canonical() == owner.Canonical() {
+			access = ControllerSuperuserAccess
+		}
+		createdBy := names.NewUserTag(user.CreatedBy())
+		ops = append(ops, createControllerUserOp(user.UserTag(), createdBy, user.DateCreated(), access))
+	}
+	if len(ops) > 0 {
+		return errors.Trace(st.runTransaction(ops))
+	}
+	return nil
+}
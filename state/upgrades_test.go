@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/mgo.v2"
@@ -586,3 +587,26 @@ func (s *upgradesSuite) TestAddDefaultEndpointBindingsToServices(c *gc.C) {
 func (s *upgradesSuite) TestAddDefaultEndpointBindingsToServicesIdempotent(c *gc.C) {
 	s.testAddDefaultEndpointBindingsToServices(c, true)
 }
+
+func (s *upgradesSuite) TestAddControllerUsers(c *gc.C) {
+	_, err := s.state.AddUser("bob", "Bob", "password", s.owner.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	bob := names.NewLocalUserTag("bob")
+	// Existing users had no controller user records before the upgrade.
+	controllerUsers, closer := s.state.getRawCollection(controllerUsersC)
+	defer closer()
+	_, err = controllerUsers.RemoveAll(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	for i := 0; i < 2; i++ {
+		err = AddControllerUsers(s.state)
+		c.Assert(err, jc.ErrorIsNil)
+
+		access, err := s.state.ControllerAccess(s.owner)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(access, gc.Equals, ControllerSuperuserAccess)
+		access, err = s.state.ControllerAccess(bob)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(access, gc.Equals, ControllerAddModelAccess)
+	}
+}
@@ -85,12 +85,16 @@ func (st *State) addUser(name, displayName, password, creator string, secretKey
 		user.doc.PasswordSalt = salt
 	}
 
+	// Every local user may log in to the controller; any further
+	// controller access must be granted explicitly.
 	ops := []txn.Op{{
 		C:      usersC,
 		Id:     nameToLower,
 		Assert: txn.DocMissing,
 		Insert: &user.doc,
-	}}
+	}, createControllerUserOp(
+		user.UserTag(), names.NewUserTag(creator), user.doc.DateCreated, ControllerLoginAccess,
+	)}
 	err := st.runTransaction(ops)
 	if err == txn.ErrAborted {
 		err = errors.AlreadyExistsf("user")
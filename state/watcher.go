@@ -683,6 +683,111 @@ func (w *minUnitsWatcher) Changes() <-chan []string {
 	return w.out
 }
 
+// charmUpgradesWatcher notifies about rolling charm upgrades of the
+// model's services. The first event returned by the watcher is the set of
+// services with upgrades in progress. Subsequent events are generated
+// when a service's upgrade starts or finishes.
+type charmUpgradesWatcher struct {
+	commonWatcher
+	known set.Strings
+	out   chan []string
+}
+
+var _ Watcher = (*charmUpgradesWatcher)(nil)
+
+func newCharmUpgradesWatcher(st *State) StringsWatcher {
+	w := &charmUpgradesWatcher{
+		commonWatcher: newCommonWatcher(st),
+		known:         make(set.Strings),
+		out:           make(chan []string),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// WatchCharmUpgrades returns a StringsWatcher that notifies of the
+// services whose rolling charm upgrades start or finish.
+func (st *State) WatchCharmUpgrades() StringsWatcher {
+	return newCharmUpgradesWatcher(st)
+}
+
+func (w *charmUpgradesWatcher) initial() (set.Strings, error) {
+	serviceNames := make(set.Strings)
+	var doc serviceDoc
+	services, closer := w.st.getCollection(servicesC)
+	defer closer()
+
+	query := bson.D{{"charmupgrade", bson.D{{"$exists", true}}}}
+	iter := services.Find(query).Select(bson.D{{"name", 1}}).Iter()
+	for iter.Next(&doc) {
+		w.known.Add(doc.Name)
+		serviceNames.Add(doc.Name)
+	}
+	return serviceNames, iter.Close()
+}
+
+func (w *charmUpgradesWatcher) merge(serviceNames set.Strings, change watcher.Change) error {
+	serviceName := w.st.localID(change.Id.(string))
+	upgrading := false
+	if change.Revno != -1 {
+		var doc serviceDoc
+		services, closer := w.st.getCollection(servicesC)
+		defer closer()
+		err := services.FindId(change.Id).Select(bson.D{{"charmupgrade", 1}}).One(&doc)
+		if err == nil {
+			upgrading = doc.CharmUpgrade != nil
+		} else if err != mgo.ErrNotFound {
+			return err
+		}
+	}
+	if upgrading != w.known.Contains(serviceName) {
+		if upgrading {
+			w.known.Add(serviceName)
+		} else {
+			w.known.Remove(serviceName)
+		}
+		serviceNames.Add(serviceName)
+	}
+	return nil
+}
+
+func (w *charmUpgradesWatcher) loop() (err error) {
+	ch := make(chan watcher.Change)
+	w.watcher.WatchCollectionWithFilter(servicesC, ch, isLocalID(w.st))
+	defer w.watcher.UnwatchCollection(servicesC, ch)
+	serviceNames, err := w.initial()
+	if err != nil {
+		return err
+	}
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case change := <-ch:
+			if err = w.merge(serviceNames, change); err != nil {
+				return err
+			}
+			if !serviceNames.IsEmpty() {
+				out = w.out
+			}
+		case out <- serviceNames.SortedValues():
+			out = nil
+			serviceNames = set.NewStrings()
+		}
+	}
+}
+
+func (w *charmUpgradesWatcher) Changes() <-chan []string {
+	return w.out
+}
+
 // scopeInfo holds a RelationScopeWatcher's last-delivered state, and any
 // known but undelivered changes thereto.
 type scopeInfo struct {
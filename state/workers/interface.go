@@ -90,6 +90,9 @@ type PresenceWatcher interface {
 	Alive(key string) (bool, error)
 	Watch(key string, ch chan<- presence.Change)
 	Unwatch(key string, ch chan<- presence.Change)
+
+	// Report describes the watcher's view of presence.
+	Report() map[string]interface{}
 }
 
 // PresenceWorker includes the presence.Watcher's worker.Worker methods,
@@ -104,6 +107,9 @@ type PresenceWorker interface {
 type LeaseManager interface {
 	lease.Claimer
 	lease.Checker
+
+	// Report describes the leases known to the manager.
+	Report() map[string]interface{}
 }
 
 // LeaseWorker includes the lease.Manager's worker.Worker methods,
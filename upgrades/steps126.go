@@ -73,5 +73,12 @@ func stateStepsFor126() []Step {
 				return state.AddDefaultEndpointBindingsToServices(context.State())
 			},
 		},
+		&upgradeStep{
+			description: "add controller users for existing users",
+			targets:     []Target{DatabaseMaster},
+			run: func(context Context) error {
+				return state.AddControllerUsers(context.State())
+			},
+		},
 	}
 }
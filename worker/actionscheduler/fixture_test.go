@@ -0,0 +1,148 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/actionscheduler"
+	"github.com/juju/juju/worker/workertest"
+)
+
+// retryDelay is the RetryDelay used by the fixture's workers.
+const retryDelay = 10 * time.Second
+
+// fixture is used to test the operation of an actionscheduler worker.
+type fixture struct {
+	clock  *coretesting.Clock
+	facade *stubFacade
+}
+
+func newFixture(nextRuns map[string]time.Time) *fixture {
+	return &fixture{
+		clock:  coretesting.NewClock(time.Date(2016, time.June, 15, 10, 30, 0, 0, time.UTC)),
+		facade: newStubFacade(nextRuns),
+	}
+}
+
+// Run creates an actionscheduler worker and passes it to the supplied
+// test func, stopping it again when the func returns.
+func (fix *fixture) Run(c *gc.C, test func(worker.Worker)) {
+	w, err := actionscheduler.New(actionscheduler.Config{
+		Facade:     fix.facade,
+		Clock:      fix.clock,
+		RetryDelay: retryDelay,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer worker.Stop(w)
+	test(w)
+}
+
+// Change delivers a change to the worker's watcher.
+func (fix *fixture) Change(c *gc.C, ids ...string) {
+	select {
+	case fix.facade.changes <- ids:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending change")
+	}
+}
+
+// WaitAlarm waits for the worker to start waiting on the clock.
+func (fix *fixture) WaitAlarm(c *gc.C) {
+	select {
+	case <-fix.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for alarm")
+	}
+}
+
+// AssertRun checks that the worker runs the given schedules.
+func (fix *fixture) AssertRun(c *gc.C, expect ...string) {
+	select {
+	case ids := <-fix.facade.runs:
+		sort.Strings(ids)
+		c.Check(ids, jc.DeepEquals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for run")
+	}
+}
+
+// AssertNoRun checks that the worker does not run any schedules.
+func (fix *fixture) AssertNoRun(c *gc.C) {
+	select {
+	case ids := <-fix.facade.runs:
+		c.Fatalf("unexpected run: %v", ids)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+// stubFacade implements actionscheduler.Facade.
+type stubFacade struct {
+	mu       sync.Mutex
+	nextRuns map[string]time.Time
+	runsErr  error
+	runErr   error
+
+	changes chan []string
+	runs    chan []string
+}
+
+func newStubFacade(nextRuns map[string]time.Time) *stubFacade {
+	return &stubFacade{
+		nextRuns: nextRuns,
+		changes:  make(chan []string),
+		runs:     make(chan []string, 10),
+	}
+}
+
+// SetNextRuns changes the next run times the facade reports.
+func (facade *stubFacade) SetNextRuns(nextRuns map[string]time.Time) {
+	facade.mu.Lock()
+	defer facade.mu.Unlock()
+	facade.nextRuns = nextRuns
+}
+
+// Watch is part of the actionscheduler.Facade interface.
+func (facade *stubFacade) Watch() (watcher.StringsWatcher, error) {
+	if facade.changes == nil {
+		return nil, errors.New("zap ouch")
+	}
+	return &stubWatcher{
+		Worker:  workertest.NewErrorWorker(nil),
+		changes: facade.changes,
+	}, nil
+}
+
+// NextRuns is part of the actionscheduler.Facade interface.
+func (facade *stubFacade) NextRuns() (map[string]time.Time, error) {
+	facade.mu.Lock()
+	defer facade.mu.Unlock()
+	return facade.nextRuns, facade.runsErr
+}
+
+// Run is part of the actionscheduler.Facade interface.
+func (facade *stubFacade) Run(ids []string) error {
+	facade.runs <- ids
+	return facade.runErr
+}
+
+// stubWatcher implements watcher.StringsWatcher.
+type stubWatcher struct {
+	worker.Worker
+	changes chan []string
+}
+
+// Changes is part of the watcher.StringsWatcher interface.
+func (w *stubWatcher) Changes() watcher.StringsChannel {
+	return w.changes
+}
@@ -0,0 +1,172 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package actionscheduler provides a worker that enqueues scheduled
+// actions as their schedules fall due.
+//
+// The controller decides whether a schedule is due, and advances it
+// as it enqueues the action, so several workers may run against the
+// same model without enqueueing an action twice. A run missed while
+// no worker is running is enqueued once, when a worker next starts;
+// any further runs that were missed are skipped.
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.actionscheduler")
+
+// Facade defines the capabilities required by the worker.
+type Facade interface {
+
+	// Watch returns a StringsWatcher reporting ids of action
+	// schedules whose next run time may have changed.
+	Watch() (watcher.StringsWatcher, error)
+
+	// NextRuns returns the time at which each action schedule next
+	// falls due, keyed on schedule id.
+	NextRuns() (map[string]time.Time, error)
+
+	// Run enqueues the actions of the identified schedules, if
+	// they are due.
+	Run(ids []string) error
+}
+
+// Config defines a worker's dependencies.
+type Config struct {
+	Facade Facade
+	Clock  clock.Clock
+
+	// RetryDelay is how long the worker waits before checking
+	// schedules again after running some, in case the controller's
+	// clock disagreed and it did not consider them due.
+	RetryDelay time.Duration
+}
+
+// Validate returns an error if the config can't be expected
+// to run a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.RetryDelay <= 0 {
+		return errors.NotValidf("non-positive RetryDelay")
+	}
+	return nil
+}
+
+// New returns a worker that enqueues scheduled actions as they fall
+// due.
+func New(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config: config,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Worker enqueues scheduled actions as they fall due.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill implements worker.Worker.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait implements worker.Worker.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	watch, err := w.config.Facade.Watch()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(watch); err != nil {
+		return errors.Trace(err)
+	}
+
+	var timer <-chan time.Time
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watch.Changes():
+			if !ok {
+				return errors.New("action schedule watcher closed")
+			}
+		case <-timer:
+		}
+
+		wait, err := w.runDue()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		timer = nil
+		if wait > 0 {
+			timer = w.config.Clock.After(wait)
+		}
+	}
+}
+
+// runDue runs any schedules that are due, and returns how long to wait
+// before the next one falls due, or zero if none ever will.
+func (w *Worker) runDue() (time.Duration, error) {
+	nextRuns, err := w.config.Facade.NextRuns()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	now := w.config.Clock.Now()
+	var due []string
+	var wait time.Duration
+	for id, nextRun := range nextRuns {
+		if nextRun.IsZero() {
+			continue
+		}
+		if !nextRun.After(now) {
+			due = append(due, id)
+			continue
+		}
+		if until := nextRun.Sub(now); wait == 0 || until < wait {
+			wait = until
+		}
+	}
+	if len(due) == 0 {
+		return wait, nil
+	}
+
+	logger.Debugf("running action schedules %v", due)
+	if err := w.config.Facade.Run(due); err != nil {
+		// The failure is recorded against the schedule, and the
+		// schedule has moved on; there's nothing more to do.
+		logger.Warningf("cannot run action schedule: %v", err)
+	}
+	if wait == 0 || w.config.RetryDelay < wait {
+		wait = w.config.RetryDelay
+	}
+	return wait, nil
+}
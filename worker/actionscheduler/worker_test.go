@@ -0,0 +1,157 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/actionscheduler"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	valid := actionscheduler.Config{
+		Facade:     &stubFacade{},
+		Clock:      coretesting.NewClock(time.Now()),
+		RetryDelay: time.Second,
+	}
+	for i, test := range []struct {
+		modify func(*actionscheduler.Config)
+		err    string
+	}{{
+		func(config *actionscheduler.Config) { config.Facade = nil },
+		"nil Facade not valid",
+	}, {
+		func(config *actionscheduler.Config) { config.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(config *actionscheduler.Config) { config.RetryDelay = 0 },
+		"non-positive RetryDelay not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := valid
+		test.modify(&config)
+		err := config.Validate()
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+
+		w, err := actionscheduler.New(config)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(w, gc.IsNil)
+	}
+}
+
+func (s *WorkerSuite) TestWatchError(c *gc.C) {
+	fix := newFixture(nil)
+	fix.facade.changes = nil
+	fix.Run(c, func(w worker.Worker) {
+		err := workertest.CheckKilled(c, w)
+		c.Check(err, gc.ErrorMatches, "zap ouch")
+	})
+}
+
+func (s *WorkerSuite) TestNextRunsError(c *gc.C) {
+	fix := newFixture(nil)
+	fix.facade.runsErr = errors.New("pew squish")
+	fix.Run(c, func(w worker.Worker) {
+		fix.Change(c)
+		err := workertest.CheckKilled(c, w)
+		c.Check(err, gc.ErrorMatches, "pew squish")
+	})
+}
+
+func (s *WorkerSuite) TestRunsDueSchedules(c *gc.C) {
+	now := time.Date(2016, time.June, 15, 10, 30, 0, 0, time.UTC)
+	fix := newFixture(map[string]time.Time{
+		"1": now.Add(-time.Minute),
+		"2": now,
+		"3": now.Add(time.Hour),
+		"4": time.Time{},
+	})
+	fix.Run(c, func(w worker.Worker) {
+		fix.Change(c, "1", "2", "3", "4")
+		fix.AssertRun(c, "1", "2")
+		fix.AssertNoRun(c)
+		workertest.CheckAlive(c, w)
+	})
+}
+
+func (s *WorkerSuite) TestRunsWhenDue(c *gc.C) {
+	now := time.Date(2016, time.June, 15, 10, 30, 0, 0, time.UTC)
+	fix := newFixture(map[string]time.Time{
+		"1": now.Add(time.Hour),
+		"2": now.Add(2 * time.Hour),
+	})
+	fix.Run(c, func(w worker.Worker) {
+		fix.Change(c, "1", "2")
+		fix.WaitAlarm(c)
+		fix.AssertNoRun(c)
+
+		fix.clock.Advance(time.Hour)
+		fix.AssertRun(c, "1")
+	})
+}
+
+func (s *WorkerSuite) TestRetriesAfterRun(c *gc.C) {
+	now := time.Date(2016, time.June, 15, 10, 30, 0, 0, time.UTC)
+	fix := newFixture(map[string]time.Time{
+		"1": now,
+	})
+	fix.Run(c, func(w worker.Worker) {
+		// The controller doesn't agree that the schedule was due, so
+		// doesn't advance it; the worker tries again shortly.
+		fix.Change(c, "1")
+		fix.AssertRun(c, "1")
+		fix.WaitAlarm(c)
+		fix.clock.Advance(retryDelay)
+		fix.AssertRun(c, "1")
+	})
+}
+
+func (s *WorkerSuite) TestRunErrorNotFatal(c *gc.C) {
+	now := time.Date(2016, time.June, 15, 10, 30, 0, 0, time.UTC)
+	fix := newFixture(map[string]time.Time{
+		"1": now,
+	})
+	fix.facade.runErr = errors.New("unit not found")
+	fix.Run(c, func(w worker.Worker) {
+		fix.Change(c, "1")
+		fix.AssertRun(c, "1")
+		fix.WaitAlarm(c)
+		workertest.CheckAlive(c, w)
+	})
+}
+
+func (s *WorkerSuite) TestRescheduleOnChange(c *gc.C) {
+	now := time.Date(2016, time.June, 15, 10, 30, 0, 0, time.UTC)
+	fix := newFixture(map[string]time.Time{
+		"1": now.Add(time.Hour),
+	})
+	fix.Run(c, func(w worker.Worker) {
+		fix.Change(c, "1")
+		fix.WaitAlarm(c)
+
+		fix.facade.SetNextRuns(map[string]time.Time{
+			"1": now.Add(time.Hour),
+			"2": now.Add(time.Minute),
+		})
+		fix.Change(c, "2")
+		fix.WaitAlarm(c)
+		fix.clock.Advance(time.Minute)
+		fix.AssertRun(c, "2")
+	})
+}
@@ -0,0 +1,91 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditforwarder
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/syslogsink"
+)
+
+// Sink is somewhere audit entries can be sent.
+type Sink interface {
+	// Send delivers the entries to the sink, in order. If it returns
+	// an error, some of the entries may still have been delivered.
+	Send(entries []state.AuditEntry) error
+
+	// Close releases any resources held by the sink.
+	Close() error
+}
+
+// SinkConfig holds the settings needed to open a Sink.
+type SinkConfig struct {
+	// URL identifies the sink. Supported schemes are syslog+tcp and
+	// syslog+tls, for an RFC5424 syslog server, and http and https,
+	// for a webhook.
+	URL string
+
+	// CACert holds the certificate of the CA that signed the sink's
+	// certificate, in PEM format. If it is empty, the system's trusted
+	// CAs are used.
+	CACert string
+}
+
+// sendTimeout bounds the time taken to send a batch of entries to a
+// webhook.
+const sendTimeout = 30 * time.Second
+
+// NewSink returns the Sink described by config.
+func NewSink(config SinkConfig) (Sink, error) {
+	u, err := url.Parse(config.URL)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing audit sink URL")
+	}
+	switch u.Scheme {
+	case "syslog+tcp", "syslog+tls":
+		sink, err := syslogsink.New(u, config.CACert)
+		if err != nil {
+			return nil, errors.Annotate(err, "audit sink")
+		}
+		return newSyslogSink(sink), nil
+	case "http", "https":
+		tlsConfig, err := syslogsink.TLSConfig(config.CACert)
+		if err != nil {
+			return nil, errors.Annotate(err, "audit sink")
+		}
+		return newWebhookSink(config.URL, tlsConfig), nil
+	}
+	return nil, errors.NotSupportedf("audit sink URL scheme %q", u.Scheme)
+}
+
+// auditRecord is the form in which audit entries are sent to sinks.
+type auditRecord struct {
+	Time      time.Time `json:"time"`
+	ModelUUID string    `json:"model-uuid,omitempty"`
+	User      string    `json:"user"`
+	Facade    string    `json:"facade"`
+	Version   int       `json:"version"`
+	Method    string    `json:"method"`
+	Args      string    `json:"args,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	Duration  float64   `json:"duration-seconds"`
+}
+
+func newAuditRecord(entry state.AuditEntry) auditRecord {
+	return auditRecord{
+		Time:      entry.Time.UTC(),
+		ModelUUID: entry.ModelUUID,
+		User:      entry.User,
+		Facade:    entry.Facade,
+		Version:   entry.Version,
+		Method:    entry.Method,
+		Args:      entry.Args,
+		Error:     entry.Error,
+		Duration:  entry.Duration.Seconds(),
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditforwarder
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/syslogsink"
+)
+
+const (
+	// syslogFacility is the "log audit" facility.
+	syslogFacility = 13
+
+	syslogSeverityWarning = 4
+	syslogSeverityInfo    = 6
+)
+
+// syslogSink sends audit entries to a syslog server as RFC5424
+// messages.
+type syslogSink struct {
+	*syslogsink.Sink
+	hostname string
+}
+
+func newSyslogSink(sink *syslogsink.Sink) *syslogSink {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+	return &syslogSink{
+		Sink:     sink,
+		hostname: hostname,
+	}
+}
+
+// Send is part of the Sink interface.
+func (s *syslogSink) Send(entries []state.AuditEntry) error {
+	messages := make([]string, len(entries))
+	for i, entry := range entries {
+		msg, err := s.format(entry)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		messages[i] = msg
+	}
+	return s.Sink.Send(messages)
+}
+
+// format returns the RFC5424 message for the entry. The entry's
+// details are sent as JSON in the message body, which is simpler for
+// collectors to parse than structured data elements.
+func (s *syslogSink) format(entry state.AuditEntry) (string, error) {
+	body, err := json.Marshal(newAuditRecord(entry))
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	severity := syslogSeverityInfo
+	if entry.Error != "" {
+		severity = syslogSeverityWarning
+	}
+	// <PRI>VERSION TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA MSG
+	return fmt.Sprintf("<%d>1 %s %s juju - audit - %s",
+		syslogFacility*8+severity,
+		entry.Time.UTC().Format(syslogsink.TimeFormat),
+		s.hostname,
+		body,
+	), nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditforwarder
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/state"
+)
+
+// webhookSink sends each batch of audit entries to a URL as a JSON
+// POST request body of the form {"entries": [...]}.
+type webhookSink struct {
+	url    string
+	client *http.Client
+}
+
+func newWebhookSink(url string, tlsConfig *tls.Config) *webhookSink {
+	return &webhookSink{
+		url: url,
+		client: &http.Client{
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: tlsConfig,
+			},
+			Timeout: sendTimeout,
+		},
+	}
+}
+
+type webhookBody struct {
+	Entries []auditRecord `json:"entries"`
+}
+
+// Send is part of the Sink interface.
+func (s *webhookSink) Send(entries []state.AuditEntry) error {
+	body := webhookBody{
+		Entries: make([]auditRecord, len(entries)),
+	}
+	for i, entry := range entries {
+		body.Entries[i] = newAuditRecord(entry)
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	// Drain the body so that the connection can be reused.
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// Close is part of the Sink interface.
+func (s *webhookSink) Close() error {
+	if transport, ok := s.client.Transport.(*http.Transport); ok {
+		transport.CloseIdleConnections()
+	}
+	return nil
+}
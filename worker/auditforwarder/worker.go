@@ -0,0 +1,299 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package auditforwarder provides a worker that forwards the
+// controller's audit log to an external syslog server or webhook, so
+// that it can be collected alongside other security events.
+//
+// The worker records how far through the audit log it has got for
+// each sink, so entries are not lost when the controller restarts or
+// the sink is unavailable. Entries may be sent more than once if the
+// controller stops between sending a batch and recording that it was
+// sent.
+package auditforwarder
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.auditforwarder")
+
+// Backend describes the controller state used by the worker.
+type Backend interface {
+	// ModelConfig returns the controller model's configuration, which
+	// holds the audit sink settings.
+	ModelConfig() (*config.Config, error)
+
+	// AuditEntriesAfter returns up to limit audit entries numbered
+	// after seq, in sequence order.
+	AuditEntriesAfter(seq int64, limit int) ([]state.AuditEntry, error)
+}
+
+// LastSent records the sequence number of the most recent audit entry
+// sent to a sink. It is implemented by *state.AuditForwardPosition.
+type LastSent interface {
+	Get() (int64, error)
+	Set(int64) error
+}
+
+// Config defines the operation of a Worker.
+type Config struct {
+	Backend     Backend
+	NewLastSent func(sink string) LastSent
+	NewSink     func(SinkConfig) (Sink, error)
+	Clock       clock.Clock
+
+	// PollInterval is how often the audit log is checked for new
+	// entries once everything recorded has been sent.
+	PollInterval time.Duration
+
+	// SettleDelay is how long a gap in the entry numbering is waited
+	// on before the entries after it are sent. Entries written by
+	// different controllers may become visible slightly out of order,
+	// so a gap is normally an entry still being written; one that
+	// outlasts the delay is taken to be an entry that was never
+	// written.
+	SettleDelay time.Duration
+
+	// BatchSize is the maximum number of entries sent to the sink
+	// at once.
+	BatchSize int
+
+	// MinRetryDelay and MaxRetryDelay bound the time waited before
+	// retrying after a failure. The delay doubles after each
+	// consecutive failure.
+	MinRetryDelay time.Duration
+	MaxRetryDelay time.Duration
+}
+
+// Validate returns an error if config cannot drive a Worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.NewLastSent == nil {
+		return errors.NotValidf("nil NewLastSent")
+	}
+	if config.NewSink == nil {
+		return errors.NotValidf("nil NewSink")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.PollInterval <= 0 {
+		return errors.NotValidf("non-positive PollInterval")
+	}
+	if config.SettleDelay < 0 {
+		return errors.NotValidf("negative SettleDelay")
+	}
+	if config.BatchSize <= 0 {
+		return errors.NotValidf("non-positive BatchSize")
+	}
+	if config.MinRetryDelay <= 0 {
+		return errors.NotValidf("non-positive MinRetryDelay")
+	}
+	if config.MaxRetryDelay < config.MinRetryDelay {
+		return errors.NotValidf("MaxRetryDelay less than MinRetryDelay")
+	}
+	return nil
+}
+
+// SinkName returns the name under which the position of the audit
+// sink with the given URL is recorded.
+func SinkName(url string) string {
+	return "audit:" + url
+}
+
+// DefaultConfig returns a Config with the default timings and batch
+// size, for the given backend.
+func DefaultConfig(backend Backend, newLastSent func(string) LastSent) Config {
+	return Config{
+		Backend:       backend,
+		NewLastSent:   newLastSent,
+		NewSink:       NewSink,
+		Clock:         clock.WallClock,
+		PollInterval:  5 * time.Second,
+		SettleDelay:   2 * time.Second,
+		BatchSize:     500,
+		MinRetryDelay: time.Second,
+		MaxRetryDelay: 5 * time.Minute,
+	}
+}
+
+// New returns a Worker backed by config, or an error.
+func New(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config: config,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Worker forwards the controller's audit log to the sink set in the
+// controller model's configuration.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	sinkConfig SinkConfig
+	sink       Sink
+	lastSent   LastSent
+
+	// gapSeq and gapSeen record the first missing sequence number
+	// found, and when it was found.
+	gapSeq  int64
+	gapSeen time.Time
+}
+
+// Kill implements worker.Worker.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait implements worker.Worker.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	defer w.closeSink()
+
+	var wait, retryDelay time.Duration
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(wait):
+		}
+
+		more, err := w.forward()
+		if err != nil {
+			retryDelay *= 2
+			if retryDelay < w.config.MinRetryDelay {
+				retryDelay = w.config.MinRetryDelay
+			} else if retryDelay > w.config.MaxRetryDelay {
+				retryDelay = w.config.MaxRetryDelay
+			}
+			logger.Errorf("cannot forward audit log to %s (retrying in %s): %v", w.sinkConfig.URL, retryDelay, err)
+			wait = retryDelay
+			continue
+		}
+		retryDelay = 0
+		if more {
+			wait = 0
+		} else {
+			wait = w.config.PollInterval
+		}
+	}
+}
+
+// forward sends the next batch of audit entries to the configured sink,
+// if there is one. It returns true if more entries may be waiting.
+func (w *Worker) forward() (bool, error) {
+	modelConfig, err := w.config.Backend.ModelConfig()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	caCert, _ := modelConfig.AuditSinkCACert()
+	sinkConfig := SinkConfig{
+		URL:    modelConfig.AuditSinkURL(),
+		CACert: caCert,
+	}
+	if sinkConfig != w.sinkConfig {
+		w.closeSink()
+		w.sinkConfig = sinkConfig
+		w.lastSent = nil
+		if sinkConfig.URL != "" {
+			logger.Infof("forwarding audit log to %s", sinkConfig.URL)
+			w.lastSent = w.config.NewLastSent(SinkName(sinkConfig.URL))
+		}
+	}
+	if w.lastSent == nil {
+		return false, nil
+	}
+
+	after, err := w.lastSent.Get()
+	neverForwarded := errors.Cause(err) == state.ErrNeverForwarded
+	if neverForwarded {
+		after = -1
+	} else if err != nil {
+		return false, errors.Annotate(err, "cannot read audit log position")
+	}
+	entries, err := w.config.Backend.AuditEntriesAfter(after, w.config.BatchSize)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if neverForwarded && len(entries) > 0 {
+		// Start from the oldest entry still in the audit log.
+		after = entries[0].Seq - 1
+	}
+	entries = w.contiguous(after, entries)
+	if len(entries) == 0 {
+		return false, nil
+	}
+
+	if w.sink == nil {
+		sink, err := w.config.NewSink(sinkConfig)
+		if err != nil {
+			return false, errors.Annotate(err, "cannot open audit sink")
+		}
+		w.sink = sink
+	}
+	if err := w.sink.Send(entries); err != nil {
+		// Start again with a fresh connection next time.
+		w.closeSink()
+		return false, errors.Annotate(err, "cannot send audit entries")
+	}
+	if err := w.lastSent.Set(entries[len(entries)-1].Seq); err != nil {
+		return false, errors.Annotate(err, "cannot record audit log position")
+	}
+	return len(entries) == w.config.BatchSize, nil
+}
+
+// contiguous returns the leading entries that follow on from seq
+// without a gap in their numbering, unless the gap has been waited on
+// for the settle delay.
+func (w *Worker) contiguous(seq int64, entries []state.AuditEntry) []state.AuditEntry {
+	now := w.config.Clock.Now()
+	for i, entry := range entries {
+		if missing := seq + 1; entry.Seq != missing {
+			if w.gapSeq != missing || w.gapSeen.IsZero() {
+				w.gapSeq = missing
+				w.gapSeen = now
+			}
+			if now.Sub(w.gapSeen) < w.config.SettleDelay {
+				return entries[:i]
+			}
+			logger.Warningf("audit entries %d to %d were never written, skipping them", missing, entry.Seq-1)
+		}
+		seq = entry.Seq
+	}
+	return entries
+}
+
+func (w *Worker) closeSink() {
+	if w.sink == nil {
+		return
+	}
+	if err := w.sink.Close(); err != nil {
+		logger.Warningf("closing audit sink: %v", err)
+	}
+	w.sink = nil
+}
@@ -0,0 +1,345 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditforwarder_test
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/auditforwarder"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type workerSuite struct {
+	coretesting.BaseSuite
+	clock    *coretesting.Clock
+	backend  *fakeBackend
+	lastSent *fakeLastSent
+	sinks    chan auditforwarder.SinkConfig
+	sink     *fakeSink
+	config   auditforwarder.Config
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+const sinkURL = "syslog+tcp://siem.example.com:514"
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	now := time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC)
+	s.clock = coretesting.NewClock(now)
+	s.backend = &fakeBackend{
+		c:       c,
+		sinkURL: sinkURL,
+	}
+	for i := 0; i < 5; i++ {
+		s.backend.addEntry(int64(i), fmt.Sprintf("Method%d", i), now.Add(time.Duration(i-10)*time.Second))
+	}
+	s.lastSent = &fakeLastSent{positions: make(map[string]int64)}
+	s.sinks = make(chan auditforwarder.SinkConfig, 10)
+	s.sink = &fakeSink{attempts: make(chan []state.AuditEntry, 10)}
+	s.config = auditforwarder.Config{
+		Backend:     s.backend,
+		NewLastSent: s.lastSent.forSink,
+		NewSink: func(config auditforwarder.SinkConfig) (auditforwarder.Sink, error) {
+			s.sinks <- config
+			return s.sink, nil
+		},
+		Clock:         s.clock,
+		PollInterval:  time.Minute,
+		SettleDelay:   time.Second,
+		BatchSize:     2,
+		MinRetryDelay: 10 * time.Second,
+		MaxRetryDelay: time.Minute,
+	}
+}
+
+func (s *workerSuite) startWorker(c *gc.C) {
+	w, err := auditforwarder.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+}
+
+// nextAttempt returns the entries passed to the next call to the
+// sink's Send method, advancing the clock whenever the worker waits.
+func (s *workerSuite) nextAttempt(c *gc.C) []state.AuditEntry {
+	timeout := time.After(coretesting.LongWait)
+	for {
+		select {
+		case entries := <-s.sink.attempts:
+			return entries
+		case <-s.clock.Alarms():
+			s.clock.Advance(time.Minute)
+		case <-timeout:
+			c.Fatalf("timed out waiting for entries to be sent")
+		}
+	}
+}
+
+func methods(entries []state.AuditEntry) []string {
+	var methods []string
+	for _, entry := range entries {
+		methods = append(methods, entry.Method)
+	}
+	return methods
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		change func(*auditforwarder.Config)
+		err    string
+	}{{
+		change: func(config *auditforwarder.Config) { config.Backend = nil },
+		err:    "nil Backend not valid",
+	}, {
+		change: func(config *auditforwarder.Config) { config.NewLastSent = nil },
+		err:    "nil NewLastSent not valid",
+	}, {
+		change: func(config *auditforwarder.Config) { config.NewSink = nil },
+		err:    "nil NewSink not valid",
+	}, {
+		change: func(config *auditforwarder.Config) { config.Clock = nil },
+		err:    "nil Clock not valid",
+	}, {
+		change: func(config *auditforwarder.Config) { config.BatchSize = 0 },
+		err:    "non-positive BatchSize not valid",
+	}, {
+		change: func(config *auditforwarder.Config) { config.MaxRetryDelay = time.Second },
+		err:    "MaxRetryDelay less than MinRetryDelay not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := s.config
+		test.change(&config)
+		err := config.Validate()
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *workerSuite) TestForwardsInBatches(c *gc.C) {
+	s.startWorker(c)
+
+	c.Assert(methods(s.nextAttempt(c)), jc.DeepEquals, []string{"Method0", "Method1"})
+	c.Assert(methods(s.nextAttempt(c)), jc.DeepEquals, []string{"Method2", "Method3"})
+	c.Assert(methods(s.nextAttempt(c)), jc.DeepEquals, []string{"Method4"})
+	c.Assert(<-s.sinks, jc.DeepEquals, auditforwarder.SinkConfig{URL: sinkURL})
+
+	// The position is recorded after each batch is sent.
+	s.lastSent.waitFor(c, "audit:"+sinkURL, 4)
+}
+
+func (s *workerSuite) TestResumesFromLastSent(c *gc.C) {
+	s.lastSent.positions["audit:"+sinkURL] = 2
+	s.startWorker(c)
+
+	c.Assert(methods(s.nextAttempt(c)), jc.DeepEquals, []string{"Method3", "Method4"})
+}
+
+func (s *workerSuite) TestForwardsNewEntries(c *gc.C) {
+	s.config.BatchSize = 10
+	s.startWorker(c)
+	c.Assert(s.nextAttempt(c), gc.HasLen, 5)
+	s.lastSent.waitFor(c, "audit:"+sinkURL, 4)
+
+	s.backend.addEntry(5, "Later", s.clock.Now())
+	c.Assert(methods(s.nextAttempt(c)), jc.DeepEquals, []string{"Later"})
+}
+
+func (s *workerSuite) TestStartsFromOldestEntry(c *gc.C) {
+	// Earlier entries have been pruned.
+	s.backend.entries = s.backend.entries[3:]
+	s.startWorker(c)
+
+	c.Assert(methods(s.nextAttempt(c)), jc.DeepEquals, []string{"Method3", "Method4"})
+}
+
+func (s *workerSuite) TestWaitsForGapToFill(c *gc.C) {
+	s.config.BatchSize = 10
+	s.backend.entries = nil
+	s.backend.addEntry(0, "First", s.clock.Now())
+	s.backend.addEntry(2, "Third", s.clock.Now())
+	s.startWorker(c)
+
+	c.Assert(methods(s.nextAttempt(c)), jc.DeepEquals, []string{"First"})
+	s.lastSent.waitFor(c, "audit:"+sinkURL, 0)
+
+	// The entry written by another controller arrives late, and is
+	// still sent in order.
+	s.backend.addEntry(1, "Second", s.clock.Now())
+	c.Assert(methods(s.nextAttempt(c)), jc.DeepEquals, []string{"Second", "Third"})
+}
+
+func (s *workerSuite) TestSkipsGapThatNeverFills(c *gc.C) {
+	s.config.BatchSize = 10
+	s.backend.entries = nil
+	s.backend.addEntry(0, "First", s.clock.Now())
+	s.backend.addEntry(2, "Third", s.clock.Now())
+	s.startWorker(c)
+
+	c.Assert(methods(s.nextAttempt(c)), jc.DeepEquals, []string{"First"})
+	c.Assert(methods(s.nextAttempt(c)), jc.DeepEquals, []string{"Third"})
+	s.lastSent.waitFor(c, "audit:"+sinkURL, 2)
+}
+
+func (s *workerSuite) TestRetriesAfterSendFailure(c *gc.C) {
+	s.sink.failures = 1
+	s.startWorker(c)
+
+	c.Assert(methods(s.nextAttempt(c)), jc.DeepEquals, []string{"Method0", "Method1"})
+	// The failed batch is sent again, on a fresh sink.
+	c.Assert(methods(s.nextAttempt(c)), jc.DeepEquals, []string{"Method0", "Method1"})
+	c.Assert(s.sink.closeCount(), gc.Equals, 1)
+	c.Assert(s.sinks, gc.HasLen, 2)
+	c.Assert(methods(s.nextAttempt(c)), jc.DeepEquals, []string{"Method2", "Method3"})
+}
+
+func (s *workerSuite) TestNoSinkConfigured(c *gc.C) {
+	s.backend.sinkURL = ""
+	s.startWorker(c)
+
+	// Let the worker poll the configuration a few times.
+	for i := 0; i < 3; i++ {
+		select {
+		case <-s.clock.Alarms():
+			s.clock.Advance(time.Minute)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for worker to poll")
+		}
+	}
+	c.Assert(s.sinks, gc.HasLen, 0)
+	c.Assert(s.sink.attempts, gc.HasLen, 0)
+}
+
+type fakeBackend struct {
+	c       *gc.C
+	mu      sync.Mutex
+	sinkURL string
+	entries []state.AuditEntry
+}
+
+func (b *fakeBackend) addEntry(seq int64, method string, recorded time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.entries = append(b.entries, state.AuditEntry{
+		Time:     recorded,
+		User:     "bob@local",
+		Facade:   "Client",
+		Version:  1,
+		Method:   method,
+		Recorded: recorded,
+		Seq:      seq,
+	})
+	sort.Sort(bySeq(b.entries))
+}
+
+type bySeq []state.AuditEntry
+
+func (s bySeq) Len() int           { return len(s) }
+func (s bySeq) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s bySeq) Less(i, j int) bool { return s[i].Seq < s[j].Seq }
+
+func (b *fakeBackend) ModelConfig() (*config.Config, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	attrs := coretesting.Attrs{}
+	if b.sinkURL != "" {
+		attrs[config.AuditSinkURL] = b.sinkURL
+	}
+	return coretesting.CustomModelConfig(b.c, attrs), nil
+}
+
+func (b *fakeBackend) AuditEntriesAfter(seq int64, limit int) ([]state.AuditEntry, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var result []state.AuditEntry
+	for _, entry := range b.entries {
+		if entry.Seq > seq && len(result) < limit {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+type fakeLastSent struct {
+	mu        sync.Mutex
+	positions map[string]int64
+}
+
+func (f *fakeLastSent) forSink(sink string) auditforwarder.LastSent {
+	return &sinkLastSent{f, sink}
+}
+
+func (f *fakeLastSent) waitFor(c *gc.C, sink string, expect int64) {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		f.mu.Lock()
+		seq, ok := f.positions[sink]
+		f.mu.Unlock()
+		if ok && seq == expect {
+			return
+		}
+	}
+	c.Fatalf("last sent position for %q never reached %d", sink, expect)
+}
+
+type sinkLastSent struct {
+	*fakeLastSent
+	sink string
+}
+
+func (l *sinkLastSent) Get() (int64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	seq, ok := l.positions[l.sink]
+	if !ok {
+		return 0, errors.Trace(state.ErrNeverForwarded)
+	}
+	return seq, nil
+}
+
+func (l *sinkLastSent) Set(seq int64) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.positions[l.sink] = seq
+	return nil
+}
+
+type fakeSink struct {
+	mu       sync.Mutex
+	failures int
+	closed   int
+	attempts chan []state.AuditEntry
+}
+
+func (s *fakeSink) Send(entries []state.AuditEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attempts <- entries
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func (s *fakeSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed++
+	return nil
+}
+
+func (s *fakeSink) closeCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
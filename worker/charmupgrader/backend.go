@@ -0,0 +1,78 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmupgrader
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+// Backend describes the model state used by the worker.
+type Backend interface {
+	// WatchCharmUpgrades returns a watcher that notifies of the
+	// services whose rolling charm upgrades start or finish.
+	WatchCharmUpgrades() state.StringsWatcher
+
+	// Service returns the named service.
+	Service(name string) (Service, error)
+}
+
+// Service describes a service whose units may be upgraded.
+type Service interface {
+	CharmUpgrade() (*state.CharmUpgrade, error)
+	AllUnits() ([]Unit, error)
+	ReleaseCharmUpgradeUnits(unitNames []string) error
+	HaltCharmUpgrade(reason string) error
+	FinishCharmUpgrade() error
+}
+
+// Unit describes a unit being upgraded.
+type Unit interface {
+	Name() string
+	CharmURL() (*charm.URL, bool)
+	Status() (status.StatusInfo, error)
+}
+
+// NewStateBackend returns a Backend backed by st.
+func NewStateBackend(st *state.State) Backend {
+	return stateBackend{st}
+}
+
+type stateBackend struct {
+	st *state.State
+}
+
+// WatchCharmUpgrades is part of the Backend interface.
+func (b stateBackend) WatchCharmUpgrades() state.StringsWatcher {
+	return b.st.WatchCharmUpgrades()
+}
+
+// Service is part of the Backend interface.
+func (b stateBackend) Service(name string) (Service, error) {
+	service, err := b.st.Service(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return stateService{service}, nil
+}
+
+type stateService struct {
+	*state.Service
+}
+
+// AllUnits is part of the Service interface.
+func (s stateService) AllUnits() ([]Unit, error) {
+	units, err := s.Service.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Unit, len(units))
+	for i, unit := range units {
+		result[i] = unit
+	}
+	return result, nil
+}
@@ -0,0 +1,240 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmupgrader provides a worker that drives a model's rolling
+// charm upgrades.
+//
+// When a service's charm is changed with a rolling upgrade strategy,
+// its units are held on the previous charm until released. The worker
+// releases them one batch at a time: once every unit in a batch runs
+// the new charm, it waits for the strategy's pause and then releases
+// the next batch. If the strategy asks for it, the upgrade is halted
+// as soon as a released unit goes into an error state, until it is
+// resumed.
+package charmupgrader
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/core/unitsort"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.charmupgrader")
+
+// Config defines the operation of a Worker.
+type Config struct {
+	Backend Backend
+	Clock   clock.Clock
+
+	// PollInterval is how often the units of services with rolling
+	// upgrades in progress are checked for progress.
+	PollInterval time.Duration
+}
+
+// Validate returns an error if config cannot drive a Worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.PollInterval <= 0 {
+		return errors.NotValidf("non-positive PollInterval")
+	}
+	return nil
+}
+
+// DefaultConfig returns a Config with the default poll interval.
+func DefaultConfig(backend Backend) Config {
+	return Config{
+		Backend:      backend,
+		Clock:        clock.WallClock,
+		PollInterval: 10 * time.Second,
+	}
+}
+
+// New returns a Worker backed by config, or an error.
+func New(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config:    config,
+		services:  make(set.Strings),
+		batchDone: make(map[string]time.Time),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Worker releases the units of services with rolling charm upgrades in
+// progress, one batch at a time.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// services holds the names of the services with rolling upgrades
+	// in progress. Their units' progress is not watched, so they are
+	// polled until their upgrades finish.
+	services set.Strings
+
+	// batchDone holds, for each service, the time at which the most
+	// recently released batch of units was seen to have upgraded.
+	batchDone map[string]time.Time
+}
+
+// Kill implements worker.Worker.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait implements worker.Worker.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	watcher := w.config.Backend.WatchCharmUpgrades()
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var poll <-chan time.Time
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case names, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("charm upgrades watcher closed")
+			}
+			for _, name := range names {
+				w.services.Add(name)
+				if err := w.checkService(name); err != nil {
+					return errors.Trace(err)
+				}
+			}
+		case <-poll:
+			poll = nil
+			for _, name := range w.services.SortedValues() {
+				if err := w.checkService(name); err != nil {
+					return errors.Trace(err)
+				}
+			}
+		}
+		if poll == nil && len(w.services) > 0 {
+			poll = w.config.Clock.After(w.config.PollInterval)
+		}
+	}
+}
+
+// checkService releases the next batch of the named service's units to
+// upgrade, halts the upgrade or records that it is complete, as
+// appropriate.
+func (w *Worker) checkService(name string) error {
+	service, err := w.config.Backend.Service(name)
+	if errors.IsNotFound(err) {
+		w.services.Remove(name)
+		delete(w.batchDone, name)
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	upgrade, err := service.CharmUpgrade()
+	if errors.IsNotFound(err) {
+		w.services.Remove(name)
+		delete(w.batchDone, name)
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if upgrade.HaltReason != "" {
+		return nil
+	}
+
+	units, err := service.AllUnits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	released := set.NewStrings(upgrade.Released...)
+	var pending []string
+	batchUpgraded := true
+	for _, unit := range units {
+		if !released.Contains(unit.Name()) {
+			pending = append(pending, unit.Name())
+			continue
+		}
+		unitStatus, err := unit.Status()
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if unitStatus.Status == status.StatusError {
+			if upgrade.Strategy.HaltOnError {
+				reason := fmt.Sprintf("unit %s is in error", unit.Name())
+				logger.Warningf("halting rolling upgrade of service %q: %s", name, reason)
+				return errors.Trace(service.HaltCharmUpgrade(reason))
+			}
+			// The unit will not upgrade until its error is
+			// resolved; do not hold up the rest of the service.
+			continue
+		}
+		if curl, _ := unit.CharmURL(); curl == nil || *curl != *upgrade.ToCharmURL {
+			batchUpgraded = false
+		}
+	}
+	if !batchUpgraded {
+		delete(w.batchDone, name)
+		return nil
+	}
+	if len(pending) == 0 {
+		logger.Infof("rolling upgrade of service %q to %q complete", name, upgrade.ToCharmURL)
+		if err := service.FinishCharmUpgrade(); err != nil {
+			return errors.Trace(err)
+		}
+		w.services.Remove(name)
+		delete(w.batchDone, name)
+		return nil
+	}
+
+	// Wait before releasing the next batch, unless this is the first.
+	if len(upgrade.Released) > 0 {
+		now := w.config.Clock.Now()
+		doneAt, ok := w.batchDone[name]
+		if !ok {
+			doneAt = now
+			w.batchDone[name] = now
+		}
+		if now.Before(doneAt.Add(upgrade.Strategy.Pause)) {
+			return nil
+		}
+	}
+
+	unitsort.Sort(pending)
+	if len(pending) > upgrade.Strategy.BatchSize {
+		pending = pending[:upgrade.Strategy.BatchSize]
+	}
+	logger.Infof("releasing units %s of service %q to upgrade to %q", strings.Join(pending, ", "), name, upgrade.ToCharmURL)
+	if err := service.ReleaseCharmUpgradeUnits(pending); err != nil {
+		return errors.Trace(err)
+	}
+	delete(w.batchDone, name)
+	return nil
+}
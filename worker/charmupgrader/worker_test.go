@@ -0,0 +1,360 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmupgrader_test
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/charmupgrader"
+	"github.com/juju/juju/worker/workertest"
+)
+
+var (
+	oldCharmURL = charm.MustParseURL("cs:trusty/mysql-1")
+	newCharmURL = charm.MustParseURL("cs:trusty/mysql-2")
+)
+
+type workerSuite struct {
+	coretesting.BaseSuite
+	clock   *coretesting.Clock
+	backend *fakeBackend
+	service *fakeService
+	config  charmupgrader.Config
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = coretesting.NewClock(time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.service = &fakeService{
+		upgrade: &state.CharmUpgrade{
+			FromCharmURL: oldCharmURL,
+			ToCharmURL:   newCharmURL,
+			Strategy: state.CharmUpgradeStrategy{
+				BatchSize:   2,
+				Pause:       time.Minute,
+				HaltOnError: true,
+			},
+		},
+		calls: make(chan string, 10),
+	}
+	for i := 0; i < 5; i++ {
+		s.service.units = append(s.service.units, &fakeUnit{
+			name:     fmt.Sprintf("mysql/%d", i),
+			charmURL: oldCharmURL,
+			status:   status.StatusActive,
+		})
+	}
+	s.backend = &fakeBackend{
+		services: map[string]*fakeService{"mysql": s.service},
+		changes:  make(chan []string, 2),
+	}
+	s.backend.changes <- []string{"mysql"}
+	s.config = charmupgrader.Config{
+		Backend:      s.backend,
+		Clock:        s.clock,
+		PollInterval: 10 * time.Second,
+	}
+}
+
+func (s *workerSuite) startWorker(c *gc.C) {
+	w, err := charmupgrader.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+}
+
+func (s *workerSuite) nextCall(c *gc.C) string {
+	select {
+	case call := <-s.service.calls:
+		return call
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for call")
+	}
+	panic("unreachable")
+}
+
+func (s *workerSuite) assertNoCall(c *gc.C) {
+	select {
+	case call := <-s.service.calls:
+		c.Fatalf("unexpected call %q", call)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+// waitPoll waits for the worker to start waiting to poll, then advances
+// the clock by d.
+func (s *workerSuite) waitPoll(c *gc.C, d time.Duration) {
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for poll")
+	}
+	s.clock.Advance(d)
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	s.config.PollInterval = 0
+	_, err := charmupgrader.New(s.config)
+	c.Assert(err, gc.ErrorMatches, "non-positive PollInterval not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *workerSuite) TestReleasesFirstBatch(c *gc.C) {
+	s.startWorker(c)
+	c.Assert(s.nextCall(c), gc.Equals, "release mysql/0 mysql/1")
+}
+
+func (s *workerSuite) TestWaitsForBatchToUpgrade(c *gc.C) {
+	s.service.setReleased("mysql/0", "mysql/1")
+	s.service.units[0].setCharmURL(newCharmURL)
+	s.startWorker(c)
+	s.waitPoll(c, time.Hour)
+	s.assertNoCall(c)
+}
+
+func (s *workerSuite) TestPausesBetweenBatches(c *gc.C) {
+	s.service.setReleased("mysql/0", "mysql/1")
+	s.service.units[0].setCharmURL(newCharmURL)
+	s.service.units[1].setCharmURL(newCharmURL)
+	s.startWorker(c)
+
+	s.waitPoll(c, 50*time.Second)
+	s.assertNoCall(c)
+	s.waitPoll(c, 10*time.Second)
+	c.Assert(s.nextCall(c), gc.Equals, "release mysql/2 mysql/3")
+}
+
+func (s *workerSuite) TestSkipsErrorsWithoutHalting(c *gc.C) {
+	s.service.upgrade.Strategy.HaltOnError = false
+	s.service.upgrade.Strategy.Pause = 0
+	s.service.setReleased("mysql/0", "mysql/1")
+	s.service.units[0].setCharmURL(newCharmURL)
+	s.service.units[1].setStatus(status.StatusError)
+	s.startWorker(c)
+	c.Assert(s.nextCall(c), gc.Equals, "release mysql/2 mysql/3")
+}
+
+func (s *workerSuite) TestHaltsOnError(c *gc.C) {
+	s.service.setReleased("mysql/0", "mysql/1")
+	s.service.units[0].setCharmURL(newCharmURL)
+	s.service.units[1].setStatus(status.StatusError)
+	s.startWorker(c)
+	c.Assert(s.nextCall(c), gc.Equals, "halt unit mysql/1 is in error")
+
+	// No more units are released while the upgrade is halted.
+	s.service.units[1].setStatus(status.StatusActive)
+	s.service.units[1].setCharmURL(newCharmURL)
+	s.waitPoll(c, time.Hour)
+	s.assertNoCall(c)
+}
+
+func (s *workerSuite) TestFinishesUpgrade(c *gc.C) {
+	var names []string
+	for _, unit := range s.service.units {
+		unit.setCharmURL(newCharmURL)
+		names = append(names, unit.name)
+	}
+	s.service.setReleased(names...)
+	s.startWorker(c)
+	c.Assert(s.nextCall(c), gc.Equals, "finish")
+}
+
+func (s *workerSuite) TestIgnoresServicesWithoutUpgrade(c *gc.C) {
+	s.service.upgrade = nil
+	s.backend.changes <- []string{"wordpress"}
+	s.startWorker(c)
+	s.assertNoCall(c)
+}
+
+func (s *workerSuite) TestNoticesUpgradeStartedLater(c *gc.C) {
+	upgrade := s.service.upgrade
+	s.service.upgrade = nil
+	s.startWorker(c)
+	s.assertNoCall(c)
+
+	s.service.mu.Lock()
+	s.service.upgrade = upgrade
+	s.service.mu.Unlock()
+	s.backend.changes <- []string{"mysql"}
+	c.Assert(s.nextCall(c), gc.Equals, "release mysql/0 mysql/1")
+}
+
+func (s *workerSuite) TestDoesNotPollWithoutUpgrades(c *gc.C) {
+	s.service.upgrade = nil
+	s.startWorker(c)
+
+	select {
+	case <-s.clock.Alarms():
+		c.Fatalf("worker polled with no upgrades in progress")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *workerSuite) TestStopsPollingFinishedUpgrade(c *gc.C) {
+	var names []string
+	for _, unit := range s.service.units {
+		unit.setCharmURL(newCharmURL)
+		names = append(names, unit.name)
+	}
+	s.service.setReleased(names...)
+	s.startWorker(c)
+	c.Assert(s.nextCall(c), gc.Equals, "finish")
+
+	select {
+	case <-s.clock.Alarms():
+		c.Fatalf("worker kept polling after the upgrade finished")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type fakeBackend struct {
+	services map[string]*fakeService
+	changes  chan []string
+}
+
+func (b *fakeBackend) WatchCharmUpgrades() state.StringsWatcher {
+	return &fakeWatcher{
+		changes: b.changes,
+		done:    make(chan struct{}),
+	}
+}
+
+func (b *fakeBackend) Service(name string) (charmupgrader.Service, error) {
+	service, ok := b.services[name]
+	if !ok {
+		return nil, errors.NotFoundf("service %q", name)
+	}
+	return service, nil
+}
+
+type fakeService struct {
+	mu      sync.Mutex
+	upgrade *state.CharmUpgrade
+	units   []*fakeUnit
+	calls   chan string
+}
+
+func (s *fakeService) setReleased(names ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.upgrade.Released = names
+}
+
+func (s *fakeService) CharmUpgrade() (*state.CharmUpgrade, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.upgrade == nil {
+		return nil, errors.NotFoundf("rolling charm upgrade")
+	}
+	upgrade := *s.upgrade
+	upgrade.Released = append([]string(nil), s.upgrade.Released...)
+	return &upgrade, nil
+}
+
+func (s *fakeService) AllUnits() ([]charmupgrader.Unit, error) {
+	units := make([]charmupgrader.Unit, len(s.units))
+	for i, unit := range s.units {
+		units[i] = unit
+	}
+	return units, nil
+}
+
+func (s *fakeService) ReleaseCharmUpgradeUnits(names []string) error {
+	s.mu.Lock()
+	s.upgrade.Released = append(s.upgrade.Released, names...)
+	s.mu.Unlock()
+	s.calls <- "release " + strings.Join(names, " ")
+	return nil
+}
+
+func (s *fakeService) HaltCharmUpgrade(reason string) error {
+	s.mu.Lock()
+	s.upgrade.HaltReason = reason
+	s.mu.Unlock()
+	s.calls <- "halt " + reason
+	return nil
+}
+
+func (s *fakeService) FinishCharmUpgrade() error {
+	s.mu.Lock()
+	s.upgrade = nil
+	s.mu.Unlock()
+	s.calls <- "finish"
+	return nil
+}
+
+type fakeUnit struct {
+	mu       sync.Mutex
+	name     string
+	charmURL *charm.URL
+	status   status.Status
+}
+
+func (u *fakeUnit) setCharmURL(curl *charm.URL) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.charmURL = curl
+}
+
+func (u *fakeUnit) setStatus(s status.Status) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.status = s
+}
+
+func (u *fakeUnit) Name() string {
+	return u.name
+}
+
+func (u *fakeUnit) CharmURL() (*charm.URL, bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.charmURL, false
+}
+
+func (u *fakeUnit) Status() (status.StatusInfo, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return status.StatusInfo{Status: u.status}, nil
+}
+
+type fakeWatcher struct {
+	changes chan []string
+	once    sync.Once
+	done    chan struct{}
+}
+
+func (w *fakeWatcher) Changes() <-chan []string {
+	return w.changes
+}
+
+func (w *fakeWatcher) Kill() {
+	w.once.Do(func() { close(w.done) })
+}
+
+func (w *fakeWatcher) Wait() error {
+	<-w.done
+	return nil
+}
+
+func (w *fakeWatcher) Stop() error {
+	w.Kill()
+	return w.Wait()
+}
+
+func (w *fakeWatcher) Err() error {
+	return nil
+}
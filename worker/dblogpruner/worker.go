@@ -4,15 +4,20 @@
 package dblogpruner
 
 import (
+	"math"
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"launchpad.net/tomb"
 
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/auditforwarder"
 )
 
+var logger = loggo.GetLogger("juju.worker.dblogpruner")
+
 // LogPruneParams specifies how logs should be pruned.
 type LogPruneParams struct {
 	MaxLogAge       time.Duration
@@ -35,8 +40,12 @@ func NewLogPruneParams() *LogPruneParams {
 }
 
 // New returns a worker which periodically wakes up to remove old log
-// entries stored in MongoDB. This worker is intended to run just
-// once, on the MongoDB master.
+// entries stored in MongoDB. Models may tighten the age and size
+// limits with the max-log-age and max-log-size model config settings,
+// but never keep logs for longer than the controller allows.
+// Old entries are also removed from the controller's audit log, as
+// set by the controller model's audit-log-max-age setting.
+// This worker is intended to run just once, on the MongoDB master.
 func New(st *state.State, params *LogPruneParams) worker.Worker {
 	w := &pruneWorker{
 		st:     st,
@@ -58,11 +67,79 @@ func (w *pruneWorker) loop(stopCh <-chan struct{}) error {
 			return tomb.ErrDying
 		case <-time.After(p.PruneInterval):
 			// TODO(fwereade): 2016-03-17 lp:1558657
-			minLogTime := time.Now().Add(-p.MaxLogAge)
-			err := state.PruneLogs(w.st, minLogTime, p.MaxCollectionMB)
+			now := time.Now()
+			modelLimits, err := w.modelLogLimits(now)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			minLogTime := now.Add(-p.MaxLogAge)
+			err = state.PruneLogs(w.st, minLogTime, p.MaxCollectionMB, modelLimits)
 			if err != nil {
 				return errors.Trace(err)
 			}
+			if err := w.pruneAuditLog(now); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// pruneAuditLog removes audit entries older than the controller
+// model's audit-log-max-age. Entries that have not yet been forwarded
+// to the audit sink are kept whatever their age.
+func (w *pruneWorker) pruneAuditLog(now time.Time) error {
+	model, err := w.st.ControllerModel()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cfg, err := model.Config()
+	if err != nil {
+		return errors.Annotate(err, "cannot read controller model config")
+	}
+	maxAge := cfg.AuditLogMaxAge()
+	if maxAge == 0 {
+		return nil
+	}
+	maxSeq := int64(math.MaxInt64)
+	if sinkURL := cfg.AuditSinkURL(); sinkURL != "" {
+		position := state.NewAuditForwardPosition(w.st, auditforwarder.SinkName(sinkURL))
+		seq, err := position.Get()
+		if errors.Cause(err) == state.ErrNeverForwarded {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		maxSeq = seq
+	}
+	return errors.Trace(w.st.PruneAuditEntries(now.Add(-maxAge), maxSeq))
+}
+
+// modelLogLimits returns the log limits set in the configuration of
+// each model, keyed by model UUID. Models whose configuration cannot
+// be read are logged and left to the controller-wide limits, so that
+// one broken model does not stop pruning for every other.
+func (w *pruneWorker) modelLogLimits(now time.Time) (map[string]state.ModelLogLimits, error) {
+	models, err := w.st.AllModels()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot list models")
+	}
+	limits := make(map[string]state.ModelLogLimits)
+	for _, m := range models {
+		cfg, err := m.Config()
+		if err != nil {
+			logger.Warningf("cannot read config for model %q: %v", m.UUID(), err)
+			continue
+		}
+		var modelLimits state.ModelLogLimits
+		if maxAge, ok := cfg.MaxLogAge(); ok {
+			modelLimits.MinLogTime = now.Add(-maxAge)
+		}
+		if maxSizeMB, ok := cfg.MaxLogSizeMB(); ok {
+			modelLimits.MaxLogsMB = int(maxSizeMB)
+		}
+		if modelLimits != (state.ModelLogLimits{}) {
+			limits[m.UUID()] = modelLimits
 		}
 	}
+	return limits, nil
 }
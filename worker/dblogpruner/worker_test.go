@@ -18,6 +18,7 @@ import (
 	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/testing"
 	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/auditforwarder"
 	"github.com/juju/juju/worker/dblogpruner"
 )
 
@@ -106,6 +107,65 @@ func (s *suite) TestPrunesLogsBySize(c *gc.C) {
 	c.Fatal("pruning didn't happen as expected")
 }
 
+func (s *suite) TestPrunesLogsByModelMaxLogAge(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"max-log-age": "1h",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := time.Now()
+	s.addLogs(c, now, "keep", 5)
+	s.addLogs(c, now.Add(-2*time.Hour), "prune", 5)
+
+	noPruneAge := 999 * time.Hour
+	noPruneMB := int(1e9)
+	s.StartWorker(c, noPruneAge, noPruneMB)
+
+	for attempt := testing.LongAttempt.Start(); attempt.Next(); {
+		pruneRemaining, err := s.logsColl.Find(bson.M{"x": "prune"}).Count()
+		c.Assert(err, jc.ErrorIsNil)
+		if pruneRemaining == 0 {
+			keepCount, err := s.logsColl.Find(bson.M{"x": "keep"}).Count()
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(keepCount, gc.Equals, 5)
+			return
+		}
+	}
+	c.Fatal("pruning didn't happen as expected")
+}
+
+func (s *suite) TestPrunesLogsWithBrokenModelConfig(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	settings := s.State.MongoSession().DB("juju").C("settings")
+	err := settings.RemoveId(st.ModelUUID() + ":e")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateModelConfig(map[string]interface{}{
+		"max-log-age": "1h",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := time.Now()
+	s.addLogs(c, now, "keep", 5)
+	s.addLogs(c, now.Add(-2*time.Hour), "prune", 5)
+
+	noPruneAge := 999 * time.Hour
+	noPruneMB := int(1e9)
+	s.StartWorker(c, noPruneAge, noPruneMB)
+
+	for attempt := testing.LongAttempt.Start(); attempt.Next(); {
+		pruneRemaining, err := s.logsColl.Find(bson.M{"x": "prune"}).Count()
+		c.Assert(err, jc.ErrorIsNil)
+		if pruneRemaining == 0 {
+			keepCount, err := s.logsColl.Find(bson.M{"x": "keep"}).Count()
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(keepCount, gc.Equals, 5)
+			return
+		}
+	}
+	c.Fatal("pruning didn't happen as expected")
+}
+
 func (s *suite) addLogs(c *gc.C, t0 time.Time, text string, count int) {
 	dbLogger := state.NewDbLogger(s.State, names.NewMachineTag("0"))
 	defer dbLogger.Close()
@@ -115,3 +175,97 @@ func (s *suite) addLogs(c *gc.C, t0 time.Time, text string, count int) {
 		dbLogger.Log(t, "some.module", "foo.go:42", loggo.INFO, text)
 	}
 }
+
+func (s *suite) addAuditEntries(c *gc.C, now time.Time) {
+	for _, entry := range []state.AuditEntry{{
+		Time:   now.Add(-3 * time.Hour),
+		User:   "bob@local",
+		Method: "Oldest",
+	}, {
+		Time:   now.Add(-2 * time.Hour),
+		User:   "bob@local",
+		Method: "Old",
+	}, {
+		Time:   now,
+		User:   "bob@local",
+		Method: "New",
+	}} {
+		err := s.State.AddAuditEntry(entry)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *suite) startAuditPruner(c *gc.C) {
+	s.pruner = dblogpruner.New(s.State, &dblogpruner.LogPruneParams{
+		MaxLogAge:       999 * time.Hour,
+		MaxCollectionMB: int(1e9),
+		PruneInterval:   time.Millisecond,
+	})
+	s.AddCleanup(func(*gc.C) {
+		s.pruner.Kill()
+		c.Assert(s.pruner.Wait(), jc.ErrorIsNil)
+	})
+}
+
+func (s *suite) auditMethods(c *gc.C) []string {
+	entries, err := s.State.AuditEntries(state.AuditFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	var methods []string
+	for _, entry := range entries {
+		methods = append(methods, entry.Method)
+	}
+	return methods
+}
+
+func (s *suite) TestPrunesOldAuditEntries(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"audit-log-max-age": "1h",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.addAuditEntries(c, time.Now())
+	s.startAuditPruner(c)
+
+	for attempt := testing.LongAttempt.Start(); attempt.Next(); {
+		methods := s.auditMethods(c)
+		if len(methods) == 1 {
+			c.Assert(methods, jc.DeepEquals, []string{"New"})
+			return
+		}
+	}
+	c.Fatal("pruning didn't happen as expected")
+}
+
+func (s *suite) TestKeepsAuditEntriesByDefault(c *gc.C) {
+	s.addAuditEntries(c, time.Now())
+	s.startAuditPruner(c)
+
+	time.Sleep(testing.ShortWait)
+	c.Assert(s.auditMethods(c), jc.DeepEquals, []string{"Oldest", "Old", "New"})
+}
+
+func (s *suite) TestKeepsUnforwardedAuditEntries(c *gc.C) {
+	const sinkURL = "syslog+tcp://siem.example.com:514"
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"audit-log-max-age": "1h",
+		"audit-sink-url":    sinkURL,
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.addAuditEntries(c, time.Now())
+	entries, err := s.State.AuditEntries(state.AuditFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only the oldest entry has reached the sink.
+	position := state.NewAuditForwardPosition(s.State, auditforwarder.SinkName(sinkURL))
+	err = position.Set(entries[0].Seq)
+	c.Assert(err, jc.ErrorIsNil)
+	s.startAuditPruner(c)
+
+	for attempt := testing.LongAttempt.Start(); attempt.Next(); {
+		methods := s.auditMethods(c)
+		if len(methods) == 2 {
+			c.Assert(methods, jc.DeepEquals, []string{"Old", "New"})
+			return
+		}
+	}
+	c.Fatal("pruning didn't happen as expected")
+}
@@ -39,7 +39,7 @@ type Firewaller struct {
 	serviceds       map[names.ServiceTag]*serviceData
 	exposedChange   chan *exposedChange
 	globalMode      bool
-	globalPortRef   map[network.PortRange]int
+	globalRuleRef   map[string]int
 	machinePorts    map[names.MachineTag]machineRanges
 }
 
@@ -89,7 +89,7 @@ func (fw *Firewaller) setUp() error {
 	case config.FwInstance:
 	case config.FwGlobal:
 		fw.globalMode = true
-		fw.globalPortRef = make(map[network.PortRange]int)
+		fw.globalRuleRef = make(map[string]int)
 	case config.FwNone:
 		logger.Infof("stopping firewaller (not required)")
 		fw.Kill()
@@ -182,6 +182,7 @@ func (fw *Firewaller) loop() error {
 			}
 		case change := <-fw.exposedChange:
 			change.serviced.exposed = change.exposed
+			change.serviced.sources = change.sources
 			unitds := []*unitData{}
 			for _, unitd := range change.serviced.unitds {
 				unitds = append(unitds, unitd)
@@ -200,7 +201,7 @@ func (fw *Firewaller) startMachine(tag names.MachineTag) error {
 		fw:           fw,
 		tag:          tag,
 		unitds:       make(map[names.UnitTag]*unitData),
-		openedPorts:  make([]network.PortRange, 0),
+		openedRules:  make([]network.IngressRule, 0),
 		definedPorts: make(map[network.PortRange]names.UnitTag),
 	}
 	m, err := machined.machine()
@@ -311,16 +312,21 @@ func (fw *Firewaller) startService(service *firewaller.Service) error {
 	if err != nil {
 		return err
 	}
+	sources, err := service.ExposeSourceCIDRs()
+	if err != nil {
+		return err
+	}
 	serviced := &serviceData{
 		fw:      fw,
 		service: service,
 		exposed: exposed,
+		sources: sources,
 		unitds:  make(map[names.UnitTag]*unitData),
 	}
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &serviced.catacomb,
 		Work: func() error {
-			return serviced.watchLoop(exposed)
+			return serviced.watchLoop(exposed, sources)
 		},
 	})
 	if err != nil {
@@ -337,43 +343,49 @@ func (fw *Firewaller) startService(service *firewaller.Service) error {
 // units and services with the opened and closed ports globally and
 // opens and closes the appropriate ports for the whole environment.
 func (fw *Firewaller) reconcileGlobal() error {
-	initialPortRanges, err := fw.environ.Ports()
-	if err != nil {
-		return err
+	var initialRules []network.IngressRule
+	if rulesFirewaller, ok := fw.environ.(environs.IngressRulesFirewaller); ok {
+		var err error
+		initialRules, err = rulesFirewaller.IngressRules()
+		if err != nil {
+			return err
+		}
+	} else {
+		initialPortRanges, err := fw.environ.Ports()
+		if err != nil {
+			return err
+		}
+		initialRules = network.OpenIngressRules(initialPortRanges)
 	}
-	collector := make(map[network.PortRange]bool)
+	initialRules = network.SplitIngressRulesBySource(initialRules)
+	collector := make(map[string]network.IngressRule)
 	for _, machined := range fw.machineds {
-		for portRange, unitTag := range machined.definedPorts {
-			unitd, known := machined.unitds[unitTag]
-			if !known {
-				delete(machined.unitds, unitTag)
-				continue
-			}
-			if unitd.serviced.exposed {
-				collector[portRange] = true
-			}
+		rules, err := machined.wantedRules()
+		if err != nil {
+			return err
+		}
+		for _, rule := range rules {
+			collector[rule.String()] = rule
 		}
 	}
-	wantedPorts := []network.PortRange{}
-	for port := range collector {
-		wantedPorts = append(wantedPorts, port)
+	wantedRules := []network.IngressRule{}
+	for _, rule := range collector {
+		wantedRules = append(wantedRules, rule)
 	}
 	// Check which ports to open or to close.
-	toOpen := diffRanges(wantedPorts, initialPortRanges)
-	toClose := diffRanges(initialPortRanges, wantedPorts)
+	toOpen := diffRules(wantedRules, initialRules)
+	toClose := diffRules(initialRules, wantedRules)
 	if len(toOpen) > 0 {
 		logger.Infof("opening global ports %v", toOpen)
-		if err := fw.environ.OpenPorts(toOpen); err != nil {
+		if err := fw.openGlobalRules(toOpen); err != nil {
 			return err
 		}
-		network.SortPortRanges(toOpen)
 	}
 	if len(toClose) > 0 {
 		logger.Infof("closing global ports %v", toClose)
-		if err := fw.environ.ClosePorts(toClose); err != nil {
+		if err := fw.closeGlobalRules(toClose); err != nil {
 			return err
 		}
-		network.SortPortRanges(toClose)
 	}
 	return nil
 }
@@ -409,31 +421,39 @@ func (fw *Firewaller) reconcileInstances() error {
 			return err
 		}
 		machineId := machined.tag.Id()
-		initialPortRanges, err := instances[0].Ports(machineId)
-		if err != nil {
-			return err
+		var initialRules []network.IngressRule
+		if rulesFirewaller, ok := instances[0].(instance.IngressRulesFirewaller); ok {
+			initialRules, err = rulesFirewaller.IngressRules(machineId)
+			if err != nil {
+				return err
+			}
+		} else {
+			initialPortRanges, err := instances[0].Ports(machineId)
+			if err != nil {
+				return err
+			}
+			initialRules = network.OpenIngressRules(initialPortRanges)
 		}
+		initialRules = network.SplitIngressRulesBySource(initialRules)
 
 		// Check which ports to open or to close.
-		toOpen := diffRanges(machined.openedPorts, initialPortRanges)
-		toClose := diffRanges(initialPortRanges, machined.openedPorts)
+		toOpen := diffRules(machined.openedRules, initialRules)
+		toClose := diffRules(initialRules, machined.openedRules)
 		if len(toOpen) > 0 {
 			logger.Infof("opening instance port ranges %v for %q",
 				toOpen, machined.tag)
-			if err := instances[0].OpenPorts(machineId, toOpen); err != nil {
+			if err := openInstanceRules(instances[0], machineId, toOpen); err != nil {
 				// TODO(mue) Add local retry logic.
 				return err
 			}
-			network.SortPortRanges(toOpen)
 		}
 		if len(toClose) > 0 {
 			logger.Infof("closing instance port ranges %v for %q",
 				toClose, machined.tag)
-			if err := instances[0].ClosePorts(machineId, toClose); err != nil {
+			if err := closeInstanceRules(instances[0], machineId, toClose); err != nil {
 				// TODO(mue) Add local retry logic.
 				return err
 			}
-			network.SortPortRanges(toClose)
 		}
 	}
 	return nil
@@ -555,20 +575,13 @@ func (fw *Firewaller) flushUnits(unitds []*unitData) error {
 // flushMachine opens and closes ports for the passed machine.
 func (fw *Firewaller) flushMachine(machined *machineData) error {
 	// Gather ports to open and close.
-	want := []network.PortRange{}
-	for portRange, unitTag := range machined.definedPorts {
-		unitd, known := machined.unitds[unitTag]
-		if !known {
-			delete(machined.unitds, unitTag)
-			continue
-		}
-		if unitd.serviced.exposed {
-			want = append(want, portRange)
-		}
+	want, err := machined.wantedRules()
+	if err != nil {
+		return err
 	}
-	toOpen := diffRanges(want, machined.openedPorts)
-	toClose := diffRanges(machined.openedPorts, want)
-	machined.openedPorts = want
+	toOpen := diffRules(want, machined.openedRules)
+	toClose := diffRules(machined.openedRules, want)
+	machined.openedRules = want
 	if fw.globalMode {
 		return fw.flushGlobalPorts(toOpen, toClose)
 	}
@@ -577,45 +590,90 @@ func (fw *Firewaller) flushMachine(machined *machineData) error {
 
 // flushGlobalPorts opens and closes global ports in the environment.
 // It keeps a reference count for ports so that only 0-to-1 and 1-to-0 events
-// modify the environment.
-func (fw *Firewaller) flushGlobalPorts(rawOpen, rawClose []network.PortRange) error {
+// modify the environment. Rules hold a single source CIDR each, so services
+// sharing a port range and some of their sources keep those sources open
+// until none of them needs it.
+func (fw *Firewaller) flushGlobalPorts(rawOpen, rawClose []network.IngressRule) error {
 	// Filter which ports are really to open or close.
-	var toOpen, toClose []network.PortRange
-	for _, portRange := range rawOpen {
-		if fw.globalPortRef[portRange] == 0 {
-			toOpen = append(toOpen, portRange)
+	var toOpen, toClose []network.IngressRule
+	for _, rule := range rawOpen {
+		key := rule.String()
+		if fw.globalRuleRef[key] == 0 {
+			toOpen = append(toOpen, rule)
 		}
-		fw.globalPortRef[portRange]++
-	}
-	for _, portRange := range rawClose {
-		fw.globalPortRef[portRange]--
-		if fw.globalPortRef[portRange] == 0 {
-			toClose = append(toClose, portRange)
-			delete(fw.globalPortRef, portRange)
+		fw.globalRuleRef[key]++
+	}
+	for _, rule := range rawClose {
+		key := rule.String()
+		fw.globalRuleRef[key]--
+		if fw.globalRuleRef[key] == 0 {
+			toClose = append(toClose, rule)
+			delete(fw.globalRuleRef, key)
 		}
 	}
 	// Open and close the ports.
 	if len(toOpen) > 0 {
-		if err := fw.environ.OpenPorts(toOpen); err != nil {
+		if err := fw.openGlobalRules(toOpen); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toOpen)
 		logger.Infof("opened port ranges %v in environment", toOpen)
 	}
 	if len(toClose) > 0 {
-		if err := fw.environ.ClosePorts(toClose); err != nil {
+		if err := fw.closeGlobalRules(toClose); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toClose)
 		logger.Infof("closed port ranges %v in environment", toClose)
 	}
 	return nil
 }
 
+// openGlobalRules opens the given ingress rules for the whole
+// environment. Rules restricted to source CIDRs are only opened if the
+// environment supports them; they are never opened to everyone.
+func (fw *Firewaller) openGlobalRules(rules []network.IngressRule) error {
+	network.SortIngressRules(rules)
+	openPorts, restricted := splitIngressRules(rules)
+	if len(openPorts) > 0 {
+		if err := fw.environ.OpenPorts(openPorts); err != nil {
+			return err
+		}
+	}
+	if len(restricted) == 0 {
+		return nil
+	}
+	rulesFirewaller, ok := fw.environ.(environs.IngressRulesFirewaller)
+	if !ok {
+		logger.Errorf("cannot open port ranges %v: model does not support source CIDRs", restricted)
+		return nil
+	}
+	return rulesFirewaller.OpenIngressRules(restricted)
+}
+
+// closeGlobalRules closes the given ingress rules for the whole
+// environment.
+func (fw *Firewaller) closeGlobalRules(rules []network.IngressRule) error {
+	network.SortIngressRules(rules)
+	openPorts, restricted := splitIngressRules(rules)
+	if len(openPorts) > 0 {
+		if err := fw.environ.ClosePorts(openPorts); err != nil {
+			return err
+		}
+	}
+	if len(restricted) == 0 {
+		return nil
+	}
+	// Restricted rules were never opened if the environment does not
+	// support them, so there is nothing to close.
+	if rulesFirewaller, ok := fw.environ.(environs.IngressRulesFirewaller); ok {
+		return rulesFirewaller.CloseIngressRules(restricted)
+	}
+	return nil
+}
+
 // flushInstancePorts opens and closes ports global on the machine.
-func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose []network.PortRange) error {
+func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose []network.IngressRule) error {
 	// If there's nothing to do, do nothing.
 	// This is important because when a machine is first created,
 	// it will have no instance id but also no open ports -
@@ -641,24 +699,64 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 	}
 	// Open and close the ports.
 	if len(toOpen) > 0 {
-		if err := instances[0].OpenPorts(machineId, toOpen); err != nil {
+		if err := openInstanceRules(instances[0], machineId, toOpen); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toOpen)
 		logger.Infof("opened port ranges %v on %q", toOpen, machined.tag)
 	}
 	if len(toClose) > 0 {
-		if err := instances[0].ClosePorts(machineId, toClose); err != nil {
+		if err := closeInstanceRules(instances[0], machineId, toClose); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toClose)
 		logger.Infof("closed port ranges %v on %q", toClose, machined.tag)
 	}
 	return nil
 }
 
+// openInstanceRules opens the given ingress rules on the instance.
+// Rules restricted to source CIDRs are only opened if the instance
+// supports them; they are never opened to everyone.
+func openInstanceRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	network.SortIngressRules(rules)
+	openPorts, restricted := splitIngressRules(rules)
+	if len(openPorts) > 0 {
+		if err := inst.OpenPorts(machineId, openPorts); err != nil {
+			return err
+		}
+	}
+	if len(restricted) == 0 {
+		return nil
+	}
+	rulesFirewaller, ok := inst.(instance.IngressRulesFirewaller)
+	if !ok {
+		logger.Errorf("cannot open port ranges %v on machine %s: instance does not support source CIDRs", restricted, machineId)
+		return nil
+	}
+	return rulesFirewaller.OpenIngressRules(machineId, restricted)
+}
+
+// closeInstanceRules closes the given ingress rules on the instance.
+func closeInstanceRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	network.SortIngressRules(rules)
+	openPorts, restricted := splitIngressRules(rules)
+	if len(openPorts) > 0 {
+		if err := inst.ClosePorts(machineId, openPorts); err != nil {
+			return err
+		}
+	}
+	if len(restricted) == 0 {
+		return nil
+	}
+	// Restricted rules were never opened if the instance does not
+	// support them, so there is nothing to close.
+	if rulesFirewaller, ok := inst.(instance.IngressRulesFirewaller); ok {
+		return rulesFirewaller.CloseIngressRules(machineId, restricted)
+	}
+	return nil
+}
+
 // machineLifeChanged starts watching new machines when the firewaller
 // is starting, or when new machines come to life, and stops watching
 // machines that are dying.
@@ -753,7 +851,7 @@ type machineData struct {
 	fw          *Firewaller
 	tag         names.MachineTag
 	unitds      map[names.UnitTag]*unitData
-	openedPorts []network.PortRange
+	openedRules []network.IngressRule
 	// ports defined by units on this machine
 	definedPorts map[network.PortRange]names.UnitTag
 }
@@ -762,6 +860,28 @@ func (md *machineData) machine() (*firewaller.Machine, error) {
 	return md.fw.st.Machine(md.tag)
 }
 
+// wantedRules returns the ingress rules for the ports defined by the
+// units of exposed services on the machine, with one source CIDR each.
+func (md *machineData) wantedRules() ([]network.IngressRule, error) {
+	want := []network.IngressRule{}
+	for portRange, unitTag := range md.definedPorts {
+		unitd, known := md.unitds[unitTag]
+		if !known {
+			delete(md.unitds, unitTag)
+			continue
+		}
+		if !unitd.serviced.exposed {
+			continue
+		}
+		rule, err := unitd.serviced.sources.IngressRule(portRange)
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot get source CIDRs for %q", unitd.serviced.service.Tag())
+		}
+		want = append(want, rule)
+	}
+	return network.SplitIngressRulesBySource(want), nil
+}
+
 // watchLoop watches the machine for units added or removed.
 func (md *machineData) watchLoop(unitw watcher.StringsWatcher) error {
 	if err := md.catacomb.Add(unitw); err != nil {
@@ -803,10 +923,12 @@ type unitData struct {
 	machined *machineData
 }
 
-// exposedChange contains the changed exposed flag for one specific service.
+// exposedChange contains the changed exposed flag and source CIDRs for
+// one specific service.
 type exposedChange struct {
 	serviced *serviceData
 	exposed  bool
+	sources  network.ExposeSourceCIDRs
 }
 
 // serviceData holds service details and watches exposure changes.
@@ -815,11 +937,13 @@ type serviceData struct {
 	fw       *Firewaller
 	service  *firewaller.Service
 	exposed  bool
+	sources  network.ExposeSourceCIDRs
 	unitds   map[names.UnitTag]*unitData
 }
 
-// watchLoop watches the service's exposed flag for changes.
-func (sd *serviceData) watchLoop(exposed bool) error {
+// watchLoop watches the service's exposed flag and source CIDRs for
+// changes.
+func (sd *serviceData) watchLoop(exposed bool, sources network.ExposeSourceCIDRs) error {
 	serviceWatcher, err := sd.service.Watch()
 	if err != nil {
 		return errors.Trace(err)
@@ -845,13 +969,18 @@ func (sd *serviceData) watchLoop(exposed bool) error {
 			if err != nil {
 				return errors.Trace(err)
 			}
-			if change == exposed {
+			changedSources, err := sd.service.ExposeSourceCIDRs()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if change == exposed && changedSources.Equals(sources) {
 				continue
 			}
 
 			exposed = change
+			sources = changedSources
 			select {
-			case sd.fw.exposedChange <- &exposedChange{sd, change}:
+			case sd.fw.exposedChange <- &exposedChange{sd, change, changedSources}:
 			case <-sd.catacomb.Dying():
 				return sd.catacomb.ErrDying()
 			}
@@ -869,12 +998,12 @@ func (sd *serviceData) Wait() error {
 	return sd.catacomb.Wait()
 }
 
-// diffRanges returns all the port rangess that exist in A but not B.
-func diffRanges(A, B []network.PortRange) (missing []network.PortRange) {
+// diffRules returns all the ingress rules that exist in A but not B.
+func diffRules(A, B []network.IngressRule) (missing []network.IngressRule) {
 next:
 	for _, a := range A {
 		for _, b := range B {
-			if a == b {
+			if a.Equals(b) {
 				continue next
 			}
 		}
@@ -883,6 +1012,19 @@ next:
 	return
 }
 
+// splitIngressRules separates the port ranges of the rules open to
+// traffic from anywhere from the rules restricted to source CIDRs.
+func splitIngressRules(rules []network.IngressRule) (openPorts []network.PortRange, restricted []network.IngressRule) {
+	for _, rule := range rules {
+		if rule.IsOpen() {
+			openPorts = append(openPorts, rule.PortRange)
+		} else {
+			restricted = append(restricted, rule)
+		}
+	}
+	return openPorts, restricted
+}
+
 // parsePortsKey parses a ports document global key coming from the ports
 // watcher (e.g. "42:0.1.2.0/24") and returns the machine and subnet tags from
 // its components (in the last example "machine-42" and "subnet-0.1.2.0/24").
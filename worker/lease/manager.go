@@ -28,10 +28,11 @@ func NewManager(config ManagerConfig) (*Manager, error) {
 		return nil, errors.Trace(err)
 	}
 	manager := &Manager{
-		config: config,
-		claims: make(chan claim),
-		checks: make(chan check),
-		blocks: make(chan block),
+		config:  config,
+		claims:  make(chan claim),
+		checks:  make(chan check),
+		blocks:  make(chan block),
+		reports: make(chan chan map[string]interface{}),
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &manager.catacomb,
@@ -58,6 +59,9 @@ type Manager struct {
 
 	// blocks is used to deliver expiry block requests to the loop.
 	blocks chan block
+
+	// reports is used to deliver report requests to the loop.
+	reports chan chan map[string]interface{}
 }
 
 // Kill is part of the worker.Worker interface.
@@ -101,6 +105,42 @@ func (manager *Manager) choose(blocks blocks) error {
 	case block := <-manager.blocks:
 		blocks.add(block)
 		return nil
+	case report := <-manager.reports:
+		report <- manager.report(blocks)
+		return nil
+	}
+}
+
+// Report returns a map describing the leases known to the manager, and
+// the leases whose expiry is awaited. It returns nil if the manager has
+// stopped.
+func (manager *Manager) Report() map[string]interface{} {
+	report := make(chan map[string]interface{}, 1)
+	select {
+	case <-manager.catacomb.Dying():
+		return nil
+	case manager.reports <- report:
+		return <-report
+	}
+}
+
+// report implements Report on the loop goroutine.
+func (manager *Manager) report(blocks blocks) map[string]interface{} {
+	leases := make(map[string]interface{})
+	for name, info := range manager.config.Client.Leases() {
+		leases[name] = map[string]interface{}{
+			"holder": info.Holder,
+			"expiry": info.Expiry,
+		}
+	}
+	blocked := make([]string, 0, len(blocks))
+	for name := range blocks {
+		blocked = append(blocked, name)
+	}
+	sort.Strings(blocked)
+	return map[string]interface{}{
+		"leases":  leases,
+		"blocked": blocked,
 	}
 }
 
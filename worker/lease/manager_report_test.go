@@ -0,0 +1,57 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	corelease "github.com/juju/juju/core/lease"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/lease"
+)
+
+type ReportSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ReportSuite{})
+
+func (s *ReportSuite) TestReport(c *gc.C) {
+	fix := &Fixture{
+		leases: map[string]corelease.Info{
+			"redis": corelease.Info{
+				Holder: "redis/0",
+				Expiry: offset(time.Second),
+			},
+		},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *coretesting.Clock) {
+		blockTest := newBlockTest(manager, "redis")
+		blockTest.assertBlocked(c)
+
+		c.Check(manager.Report(), jc.DeepEquals, map[string]interface{}{
+			"leases": map[string]interface{}{
+				"redis": map[string]interface{}{
+					"holder": "redis/0",
+					"expiry": offset(time.Second),
+				},
+			},
+			"blocked": []string{"redis"},
+		})
+	})
+}
+
+func (s *ReportSuite) TestReportStopped(c *gc.C) {
+	fix := &Fixture{}
+	fix.RunTest(c, func(manager *lease.Manager, _ *coretesting.Clock) {
+		manager.Kill()
+		err := manager.Wait()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(manager.Report(), gc.IsNil)
+	})
+}
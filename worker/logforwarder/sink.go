@@ -0,0 +1,52 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder
+
+import (
+	"net/url"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/syslogsink"
+)
+
+// Sink is somewhere log records can be sent.
+type Sink interface {
+	// Send delivers the records to the sink, in order. If it returns
+	// an error, some of the records may still have been delivered.
+	Send(records []*state.LogRecord) error
+
+	// Close releases any resources held by the sink.
+	Close() error
+}
+
+// SinkConfig holds the settings needed to open a Sink.
+type SinkConfig struct {
+	// URL identifies the sink. Supported schemes are syslog+tcp and
+	// syslog+tls, for an RFC5424 syslog server.
+	URL string
+
+	// CACert holds the certificate of the CA that signed the sink's
+	// certificate, in PEM format. If it is empty, the system's trusted
+	// CAs are used.
+	CACert string
+}
+
+// NewSink returns the Sink described by config.
+func NewSink(config SinkConfig) (Sink, error) {
+	u, err := url.Parse(config.URL)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing log forward URL")
+	}
+	switch u.Scheme {
+	case "syslog+tcp", "syslog+tls":
+		sink, err := syslogsink.New(u, config.CACert)
+		if err != nil {
+			return nil, errors.Annotate(err, "log forward")
+		}
+		return syslogSink{sink}, nil
+	}
+	return nil, errors.NotSupportedf("log forward URL scheme %q", u.Scheme)
+}
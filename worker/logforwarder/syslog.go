@@ -0,0 +1,79 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/syslogsink"
+)
+
+const (
+	// syslogFacility is the "user-level messages" facility.
+	syslogFacility = 1
+
+	// syslogSDID identifies the structured data element holding a
+	// record's juju-specific fields. 28978 is Canonical's private
+	// enterprise number.
+	syslogSDID = "juju@28978"
+)
+
+// syslogSeverity returns the syslog severity corresponding to level.
+func syslogSeverity(level loggo.Level) int {
+	switch level {
+	case loggo.CRITICAL:
+		return 2
+	case loggo.ERROR:
+		return 3
+	case loggo.WARNING:
+		return 4
+	case loggo.INFO:
+		return 6
+	}
+	return 7
+}
+
+// sdParamEscaper escapes the characters that may not appear unescaped
+// in an RFC5424 structured data parameter value.
+var sdParamEscaper = strings.NewReplacer(`"`, `\"`, `\`, `\\`, `]`, `\]`)
+
+// syslogSink sends log records to a syslog server as RFC5424
+// messages.
+type syslogSink struct {
+	*syslogsink.Sink
+}
+
+// Send is part of the Sink interface.
+func (s syslogSink) Send(records []*state.LogRecord) error {
+	messages := make([]string, len(records))
+	for i, rec := range records {
+		messages[i] = formatSyslog(rec)
+	}
+	return s.Sink.Send(messages)
+}
+
+// formatSyslog returns the RFC5424 message for the record. The entity
+// that logged the record is sent as the hostname, and the model, module
+// and source location as structured data.
+func formatSyslog(rec *state.LogRecord) string {
+	hostname := rec.Entity
+	if hostname == "" {
+		hostname = "-"
+	}
+	// <PRI>VERSION TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA MSG
+	return fmt.Sprintf(`<%d>1 %s %s juju - - [%s model-uuid="%s" module="%s" location="%s"] %s`,
+		syslogFacility*8+syslogSeverity(rec.Level),
+		rec.Time.UTC().Format(syslogsink.TimeFormat),
+		hostname,
+		syslogSDID,
+		sdParamEscaper.Replace(rec.ModelUUID),
+		sdParamEscaper.Replace(rec.Module),
+		sdParamEscaper.Replace(rec.Location),
+		rec.Message,
+	)
+}
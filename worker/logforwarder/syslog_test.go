@@ -0,0 +1,93 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder_test
+
+import (
+	"io/ioutil"
+	"net"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+type syslogSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&syslogSuite{})
+
+func (s *syslogSuite) TestSend(c *gc.C) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	defer listener.Close()
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			received <- err.Error()
+			return
+		}
+		defer conn.Close()
+		data, _ := ioutil.ReadAll(conn)
+		received <- string(data)
+	}()
+
+	sink, err := logforwarder.NewSink(logforwarder.SinkConfig{
+		URL: "syslog+tcp://" + listener.Addr().String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = sink.Send([]*state.LogRecord{{
+		Time:      time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC),
+		Entity:    "unit-mysql-0",
+		Module:    "unit.mysql/0.juju-log",
+		Location:  "hooks.go:12",
+		Level:     loggo.ERROR,
+		Message:   "hook failed",
+		ModelUUID: "deadbeef",
+	}, {
+		Time:     time.Date(2016, 6, 1, 12, 0, 1, 0, time.UTC),
+		Entity:   "machine-0",
+		Module:   `odd"module]`,
+		Level:    loggo.DEBUG,
+		Message:  "x",
+		Location: "-",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	err = sink.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := "" +
+		`156 <11>1 2016-06-01T12:00:00.000000Z unit-mysql-0 juju - - ` +
+		`[juju@28978 model-uuid="deadbeef" module="unit.mysql/0.juju-log" location="hooks.go:12"] hook failed` +
+		`117 <15>1 2016-06-01T12:00:01.000000Z machine-0 juju - - ` +
+		`[juju@28978 model-uuid="" module="odd\"module\]" location="-"] x`
+	select {
+	case data := <-received:
+		c.Assert(data, gc.Equals, expected)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for messages")
+	}
+}
+
+func (s *syslogSuite) TestNewSinkUnsupportedScheme(c *gc.C) {
+	_, err := logforwarder.NewSink(logforwarder.SinkConfig{
+		URL: "https://logs.example.com/",
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *syslogSuite) TestNewSinkBadCACert(c *gc.C) {
+	_, err := logforwarder.NewSink(logforwarder.SinkConfig{
+		URL:    "syslog+tls://logs.example.com:6514",
+		CACert: "not a cert",
+	})
+	c.Assert(err, gc.ErrorMatches, "log forward: CA certificate is not valid PEM")
+}
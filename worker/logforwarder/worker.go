@@ -0,0 +1,330 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package logforwarder provides a worker that forwards a model's logs,
+// as recorded by the controller's logsink, to an external RFC5424
+// syslog server.
+//
+// The worker records how far through the logs it has got for each
+// sink, so records are not lost when the controller restarts or the
+// sink is unavailable. Records may be sent more than once if the
+// controller stops between sending a batch and recording that it was
+// sent, or if they were logged in the same millisecond as the last
+// record sent.
+package logforwarder
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.logforwarder")
+
+// Backend describes the model state used by the worker.
+type Backend interface {
+	// ModelConfig returns the model's configuration, which holds
+	// the log forwarding settings.
+	ModelConfig() (*config.Config, error)
+
+	// WatchForModelConfigChanges returns a watcher that notifies
+	// when the model's configuration changes.
+	WatchForModelConfigChanges() state.NotifyWatcher
+}
+
+// LastSent records the time at which the most recent log record sent
+// to a sink was logged. It is implemented by *state.DbLoggerLastSent.
+type LastSent interface {
+	Get() (time.Time, error)
+	Set(time.Time) error
+}
+
+// Config defines the operation of a Worker.
+type Config struct {
+	Backend      Backend
+	NewLogTailer func(*state.LogTailerParams) (state.LogTailer, error)
+	NewLastSent  func(sink string) LastSent
+	NewSink      func(SinkConfig) (Sink, error)
+	Clock        clock.Clock
+
+	// BatchSize is the maximum number of records sent to the sink
+	// at once.
+	BatchSize int
+
+	// FlushInterval is the longest a record is held back while
+	// waiting for a batch to fill.
+	FlushInterval time.Duration
+
+	// MinRetryDelay and MaxRetryDelay bound the time waited before
+	// retrying after a failure. The delay doubles after each
+	// consecutive failure.
+	MinRetryDelay time.Duration
+	MaxRetryDelay time.Duration
+}
+
+// Validate returns an error if config cannot drive a Worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.NewLogTailer == nil {
+		return errors.NotValidf("nil NewLogTailer")
+	}
+	if config.NewLastSent == nil {
+		return errors.NotValidf("nil NewLastSent")
+	}
+	if config.NewSink == nil {
+		return errors.NotValidf("nil NewSink")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.BatchSize <= 0 {
+		return errors.NotValidf("non-positive BatchSize")
+	}
+	if config.FlushInterval <= 0 {
+		return errors.NotValidf("non-positive FlushInterval")
+	}
+	if config.MinRetryDelay <= 0 {
+		return errors.NotValidf("non-positive MinRetryDelay")
+	}
+	if config.MaxRetryDelay < config.MinRetryDelay {
+		return errors.NotValidf("MaxRetryDelay less than MinRetryDelay")
+	}
+	return nil
+}
+
+// DefaultConfig returns a Config with the default timings and batch
+// size, for the given model.
+func DefaultConfig(
+	backend Backend,
+	newLogTailer func(*state.LogTailerParams) (state.LogTailer, error),
+	newLastSent func(string) LastSent,
+) Config {
+	return Config{
+		Backend:       backend,
+		NewLogTailer:  newLogTailer,
+		NewLastSent:   newLastSent,
+		NewSink:       NewSink,
+		Clock:         clock.WallClock,
+		BatchSize:     500,
+		FlushInterval: time.Second,
+		MinRetryDelay: time.Second,
+		MaxRetryDelay: 5 * time.Minute,
+	}
+}
+
+// New returns a Worker backed by config, or an error.
+func New(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config: config,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Worker forwards a model's logs to the sink set in the model's
+// configuration.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	sinkConfig SinkConfig
+	sink       Sink
+	lastSent   LastSent
+	tailer     state.LogTailer
+
+	// batch holds the records read from the tailer but not yet sent.
+	batch []*state.LogRecord
+
+	// flush and retry fire when the batch should next be sent.
+	flush      <-chan time.Time
+	retry      <-chan time.Time
+	retryDelay time.Duration
+}
+
+// Kill implements worker.Worker.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait implements worker.Worker.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	defer w.stopForwarding()
+
+	watcher := w.config.Backend.WatchForModelConfigChanges()
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	for {
+		// Stop reading records while the batch is full or waiting
+		// to be retried; the tailer will wait for us.
+		var logs <-chan *state.LogRecord
+		if w.tailer != nil && w.retry == nil && len(w.batch) < w.config.BatchSize {
+			logs = w.tailer.Logs()
+		}
+
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("model config watcher closed")
+			}
+			if err := w.configure(); err != nil {
+				return errors.Trace(err)
+			}
+		case rec, ok := <-logs:
+			if !ok {
+				if err := w.tailer.Err(); err != nil {
+					return errors.Annotate(err, "log tailer stopped")
+				}
+				return errors.New("log tailer stopped")
+			}
+			w.batch = append(w.batch, rec)
+			if len(w.batch) == w.config.BatchSize {
+				if err := w.send(); err != nil {
+					return errors.Trace(err)
+				}
+			} else if w.flush == nil {
+				w.flush = w.config.Clock.After(w.config.FlushInterval)
+			}
+		case <-w.flush:
+			if err := w.send(); err != nil {
+				return errors.Trace(err)
+			}
+		case <-w.retry:
+			w.retry = nil
+			if err := w.send(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// configure starts forwarding to the sink set in the model's
+// configuration, if it has changed.
+func (w *Worker) configure() error {
+	modelConfig, err := w.config.Backend.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	caCert, _ := modelConfig.LogForwardCACert()
+	sinkConfig := SinkConfig{
+		URL:    modelConfig.LogForwardURL(),
+		CACert: caCert,
+	}
+	if sinkConfig == w.sinkConfig {
+		return nil
+	}
+	w.stopForwarding()
+	w.sinkConfig = sinkConfig
+	if sinkConfig.URL == "" {
+		return nil
+	}
+
+	logger.Infof("forwarding logs to %s", sinkConfig.URL)
+	w.lastSent = w.config.NewLastSent("log:" + sinkConfig.URL)
+	start, err := w.lastSent.Get()
+	if errors.Cause(err) == state.ErrNeverForwarded {
+		start = time.Time{}
+	} else if err != nil {
+		return errors.Annotate(err, "cannot read log forwarding position")
+	}
+	tailer, err := w.config.NewLogTailer(&state.LogTailerParams{
+		StartTime: start,
+	})
+	if err != nil {
+		return errors.Annotate(err, "cannot tail logs")
+	}
+	w.tailer = tailer
+	return nil
+}
+
+// send sends the batched records to the sink. If that fails, it
+// arranges for them to be sent again later.
+func (w *Worker) send() error {
+	w.flush = nil
+	if len(w.batch) == 0 {
+		return nil
+	}
+	if err := w.sendBatch(); err != nil {
+		// Start again with a fresh connection next time.
+		w.closeSink()
+		w.retryDelay *= 2
+		if w.retryDelay < w.config.MinRetryDelay {
+			w.retryDelay = w.config.MinRetryDelay
+		} else if w.retryDelay > w.config.MaxRetryDelay {
+			w.retryDelay = w.config.MaxRetryDelay
+		}
+		logger.Errorf("cannot forward logs to %s (retrying in %s): %v", w.sinkConfig.URL, w.retryDelay, err)
+		w.retry = w.config.Clock.After(w.retryDelay)
+		return nil
+	}
+	w.retryDelay = 0
+	last := w.batch[len(w.batch)-1].Time
+	w.batch = nil
+	if err := w.lastSent.Set(last); err != nil {
+		return errors.Annotate(err, "cannot record log forwarding position")
+	}
+	return nil
+}
+
+func (w *Worker) sendBatch() error {
+	if w.sink == nil {
+		sink, err := w.config.NewSink(w.sinkConfig)
+		if err != nil {
+			return errors.Annotate(err, "cannot open log sink")
+		}
+		w.sink = sink
+	}
+	return errors.Annotate(w.sink.Send(w.batch), "cannot send log records")
+}
+
+// stopForwarding stops the tailer and closes the sink, discarding any
+// records not yet sent. They will be read again if forwarding to the
+// same sink resumes.
+func (w *Worker) stopForwarding() {
+	if w.tailer != nil {
+		if err := w.tailer.Stop(); err != nil {
+			logger.Warningf("stopping log tailer: %v", err)
+		}
+		w.tailer = nil
+	}
+	w.closeSink()
+	w.lastSent = nil
+	w.batch = nil
+	w.flush = nil
+	w.retry = nil
+	w.retryDelay = 0
+}
+
+func (w *Worker) closeSink() {
+	if w.sink == nil {
+		return
+	}
+	if err := w.sink.Close(); err != nil {
+		logger.Warningf("closing log sink: %v", err)
+	}
+	w.sink = nil
+}
@@ -0,0 +1,443 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder_test
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/logforwarder"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type workerSuite struct {
+	coretesting.BaseSuite
+	clock    *coretesting.Clock
+	backend  *fakeBackend
+	tailers  chan *fakeTailer
+	lastSent *fakeLastSent
+	sinks    chan logforwarder.SinkConfig
+	sink     *fakeSink
+	config   logforwarder.Config
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+const sinkURL = "syslog+tls://logs.example.com:6514"
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = coretesting.NewClock(time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.backend = &fakeBackend{
+		c:       c,
+		sinkURL: sinkURL,
+		changes: make(chan struct{}, 1),
+	}
+	s.backend.changes <- struct{}{}
+	s.tailers = make(chan *fakeTailer, 10)
+	s.lastSent = &fakeLastSent{times: make(map[string]time.Time)}
+	s.sinks = make(chan logforwarder.SinkConfig, 10)
+	s.sink = &fakeSink{attempts: make(chan []*state.LogRecord, 10)}
+	s.config = logforwarder.Config{
+		Backend: s.backend,
+		NewLogTailer: func(params *state.LogTailerParams) (state.LogTailer, error) {
+			tailer := newFakeTailer(params)
+			s.tailers <- tailer
+			return tailer, nil
+		},
+		NewLastSent: s.lastSent.forSink,
+		NewSink: func(config logforwarder.SinkConfig) (logforwarder.Sink, error) {
+			s.sinks <- config
+			return s.sink, nil
+		},
+		Clock:         s.clock,
+		BatchSize:     2,
+		FlushInterval: time.Second,
+		MinRetryDelay: 10 * time.Second,
+		MaxRetryDelay: time.Minute,
+	}
+}
+
+func (s *workerSuite) startWorker(c *gc.C) {
+	w, err := logforwarder.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+}
+
+func (s *workerSuite) nextTailer(c *gc.C) *fakeTailer {
+	select {
+	case tailer := <-s.tailers:
+		return tailer
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for log tailer")
+	}
+	panic("unreachable")
+}
+
+// nextAttempt returns the records passed to the next call to the
+// sink's Send method, advancing the clock whenever the worker waits.
+func (s *workerSuite) nextAttempt(c *gc.C) []string {
+	timeout := time.After(coretesting.LongWait)
+	for {
+		select {
+		case records := <-s.sink.attempts:
+			var messages []string
+			for _, rec := range records {
+				messages = append(messages, rec.Message)
+			}
+			return messages
+		case <-s.clock.Alarms():
+			s.clock.Advance(time.Minute)
+		case <-timeout:
+			c.Fatalf("timed out waiting for records to be sent")
+		}
+	}
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		change func(*logforwarder.Config)
+		err    string
+	}{{
+		change: func(config *logforwarder.Config) { config.Backend = nil },
+		err:    "nil Backend not valid",
+	}, {
+		change: func(config *logforwarder.Config) { config.NewLogTailer = nil },
+		err:    "nil NewLogTailer not valid",
+	}, {
+		change: func(config *logforwarder.Config) { config.NewLastSent = nil },
+		err:    "nil NewLastSent not valid",
+	}, {
+		change: func(config *logforwarder.Config) { config.NewSink = nil },
+		err:    "nil NewSink not valid",
+	}, {
+		change: func(config *logforwarder.Config) { config.Clock = nil },
+		err:    "nil Clock not valid",
+	}, {
+		change: func(config *logforwarder.Config) { config.BatchSize = 0 },
+		err:    "non-positive BatchSize not valid",
+	}, {
+		change: func(config *logforwarder.Config) { config.FlushInterval = 0 },
+		err:    "non-positive FlushInterval not valid",
+	}, {
+		change: func(config *logforwarder.Config) { config.MaxRetryDelay = time.Second },
+		err:    "MaxRetryDelay less than MinRetryDelay not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := s.config
+		test.change(&config)
+		err := config.Validate()
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *workerSuite) TestForwardsInBatches(c *gc.C) {
+	s.startWorker(c)
+	tailer := s.nextTailer(c)
+	c.Assert(tailer.params.StartTime.IsZero(), jc.IsTrue)
+
+	last := tailer.send(c, "one", "two", "three")
+	c.Assert(s.nextAttempt(c), jc.DeepEquals, []string{"one", "two"})
+	// The last record is sent once the flush interval has passed.
+	c.Assert(s.nextAttempt(c), jc.DeepEquals, []string{"three"})
+	c.Assert(<-s.sinks, jc.DeepEquals, logforwarder.SinkConfig{URL: sinkURL})
+
+	// The position is recorded after each batch is sent.
+	s.lastSent.waitFor(c, "log:"+sinkURL, last)
+}
+
+func (s *workerSuite) TestResumesFromLastSent(c *gc.C) {
+	lastSent := time.Date(2016, 5, 31, 0, 0, 0, 0, time.UTC)
+	s.lastSent.times["log:"+sinkURL] = lastSent
+	s.startWorker(c)
+
+	tailer := s.nextTailer(c)
+	c.Assert(tailer.params.StartTime, gc.Equals, lastSent)
+}
+
+func (s *workerSuite) TestRetriesAfterSendFailure(c *gc.C) {
+	s.sink.failures = 1
+	s.startWorker(c)
+	tailer := s.nextTailer(c)
+
+	tailer.send(c, "one", "two")
+	c.Assert(s.nextAttempt(c), jc.DeepEquals, []string{"one", "two"})
+	// The failed batch is sent again, on a fresh sink.
+	c.Assert(s.nextAttempt(c), jc.DeepEquals, []string{"one", "two"})
+	c.Assert(s.sink.closeCount(), gc.Equals, 1)
+	c.Assert(s.sinks, gc.HasLen, 2)
+
+	tailer.send(c, "three", "four")
+	c.Assert(s.nextAttempt(c), jc.DeepEquals, []string{"three", "four"})
+}
+
+func (s *workerSuite) TestNoSinkConfigured(c *gc.C) {
+	s.backend.setSinkURL("")
+	s.startWorker(c)
+
+	// Wait for the configuration to be read.
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.backend.changes) == 0 {
+			break
+		}
+	}
+	select {
+	case <-s.tailers:
+		c.Fatalf("unexpected log tailer")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *workerSuite) TestSinkChanged(c *gc.C) {
+	s.startWorker(c)
+	tailer := s.nextTailer(c)
+	tailer.send(c, "one", "two")
+	c.Assert(s.nextAttempt(c), jc.DeepEquals, []string{"one", "two"})
+
+	const newSinkURL = "syslog+tls://other.example.com:6514"
+	s.backend.setSinkURL(newSinkURL)
+	s.backend.changes <- struct{}{}
+
+	// The old tailer is stopped, and forwarding to the new sink
+	// starts from the beginning.
+	newTailer := s.nextTailer(c)
+	c.Assert(newTailer.params.StartTime.IsZero(), jc.IsTrue)
+	tailer.waitStopped(c)
+	c.Assert(s.sink.closeCount(), gc.Equals, 1)
+
+	newTailer.send(c, "three", "four")
+	c.Assert(s.nextAttempt(c), jc.DeepEquals, []string{"three", "four"})
+	c.Assert(<-s.sinks, jc.DeepEquals, logforwarder.SinkConfig{URL: sinkURL})
+	c.Assert(<-s.sinks, jc.DeepEquals, logforwarder.SinkConfig{URL: newSinkURL})
+}
+
+func (s *workerSuite) TestTailerFailure(c *gc.C) {
+	w, err := logforwarder.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	tailer := s.nextTailer(c)
+	tailer.kill(errors.New("oplog gone"))
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "log tailer stopped: oplog gone")
+}
+
+type fakeBackend struct {
+	c       *gc.C
+	mu      sync.Mutex
+	sinkURL string
+	changes chan struct{}
+}
+
+func (b *fakeBackend) setSinkURL(sinkURL string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sinkURL = sinkURL
+}
+
+func (b *fakeBackend) ModelConfig() (*config.Config, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	attrs := coretesting.Attrs{}
+	if b.sinkURL != "" {
+		attrs[config.LogForwardURL] = b.sinkURL
+	}
+	return coretesting.CustomModelConfig(b.c, attrs), nil
+}
+
+func (b *fakeBackend) WatchForModelConfigChanges() state.NotifyWatcher {
+	return &fakeWatcher{
+		changes: b.changes,
+		done:    make(chan struct{}),
+	}
+}
+
+type fakeWatcher struct {
+	changes chan struct{}
+	once    sync.Once
+	done    chan struct{}
+}
+
+func (w *fakeWatcher) Changes() <-chan struct{} {
+	return w.changes
+}
+
+func (w *fakeWatcher) Kill() {
+	w.once.Do(func() { close(w.done) })
+}
+
+func (w *fakeWatcher) Wait() error {
+	<-w.done
+	return nil
+}
+
+func (w *fakeWatcher) Stop() error {
+	w.Kill()
+	return w.Wait()
+}
+
+func (w *fakeWatcher) Err() error {
+	return nil
+}
+
+type fakeTailer struct {
+	params  *state.LogTailerParams
+	logs    chan *state.LogRecord
+	mu      sync.Mutex
+	err     error
+	once    sync.Once
+	dying   chan struct{}
+	stopped chan struct{}
+}
+
+func newFakeTailer(params *state.LogTailerParams) *fakeTailer {
+	return &fakeTailer{
+		params:  params,
+		logs:    make(chan *state.LogRecord),
+		dying:   make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+}
+
+// send delivers records with the given messages to the worker, and
+// returns the time of the last one.
+func (t *fakeTailer) send(c *gc.C, messages ...string) time.Time {
+	var last time.Time
+	for i, message := range messages {
+		last = time.Date(2016, 6, 1, 11, 0, i, 0, time.UTC)
+		rec := &state.LogRecord{
+			Time:    last,
+			Entity:  "machine-0",
+			Module:  "juju.test",
+			Message: message,
+		}
+		select {
+		case t.logs <- rec:
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out sending %q", message)
+		}
+	}
+	return last
+}
+
+func (t *fakeTailer) kill(err error) {
+	t.mu.Lock()
+	t.err = err
+	t.mu.Unlock()
+	t.once.Do(func() {
+		close(t.dying)
+		close(t.logs)
+	})
+}
+
+func (t *fakeTailer) waitStopped(c *gc.C) {
+	select {
+	case <-t.stopped:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("log tailer not stopped")
+	}
+}
+
+func (t *fakeTailer) Logs() <-chan *state.LogRecord {
+	return t.logs
+}
+
+func (t *fakeTailer) Dying() <-chan struct{} {
+	return t.dying
+}
+
+func (t *fakeTailer) Stop() error {
+	t.kill(nil)
+	close(t.stopped)
+	return nil
+}
+
+func (t *fakeTailer) Err() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.err
+}
+
+type fakeLastSent struct {
+	mu    sync.Mutex
+	times map[string]time.Time
+}
+
+func (f *fakeLastSent) forSink(sink string) logforwarder.LastSent {
+	return &sinkLastSent{f, sink}
+}
+
+func (f *fakeLastSent) waitFor(c *gc.C, sink string, expect time.Time) {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		f.mu.Lock()
+		t := f.times[sink]
+		f.mu.Unlock()
+		if t.Equal(expect) {
+			return
+		}
+	}
+	c.Fatalf("last sent time for %q never reached %v", sink, expect)
+}
+
+type sinkLastSent struct {
+	*fakeLastSent
+	sink string
+}
+
+func (l *sinkLastSent) Get() (time.Time, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	t, ok := l.times[l.sink]
+	if !ok {
+		return time.Time{}, errors.Trace(state.ErrNeverForwarded)
+	}
+	return t, nil
+}
+
+func (l *sinkLastSent) Set(t time.Time) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.times[l.sink] = t
+	return nil
+}
+
+type fakeSink struct {
+	mu       sync.Mutex
+	failures int
+	closed   int
+	attempts chan []*state.LogRecord
+}
+
+func (s *fakeSink) Send(records []*state.LogRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attempts <- records
+	if s.failures > 0 {
+		s.failures--
+		return fmt.Errorf("connection refused")
+	}
+	return nil
+}
+
+func (s *fakeSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed++
+	return nil
+}
+
+func (s *fakeSink) closeCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
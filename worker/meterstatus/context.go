@@ -64,6 +64,11 @@ func (ctx *limitedContext) UnitName() string {
 // SetProcess implements runner.Context.
 func (ctx *limitedContext) SetProcess(process context.HookProcess) {}
 
+// AbortAction implements runner.Context.
+func (ctx *limitedContext) AbortAction(string, string, time.Duration, <-chan struct{}) error {
+	return jujuc.ErrRestrictedContext
+}
+
 // ActionData implements runner.Context.
 func (ctx *limitedContext) ActionData() (*context.ActionData, error) {
 	return nil, jujuc.ErrRestrictedContext
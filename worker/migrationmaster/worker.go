@@ -159,6 +159,16 @@ func (w *Worker) run() error {
 
 		logger.Infof("setting migration phase to %s", phase)
 		if err := w.config.Facade.SetPhase(phase); err != nil {
+			// The migration may have been aborted by a user while
+			// the phase was being worked on. If so, reverse what has
+			// been done so far.
+			if aborted, checkErr := w.wasAborted(); checkErr != nil {
+				return errors.Trace(checkErr)
+			} else if aborted {
+				logger.Infof("migration was aborted")
+				phase = migration.ABORT
+				continue
+			}
 			return errors.Annotate(err, "failed to set phase")
 		}
 
@@ -173,6 +183,16 @@ func (w *Worker) run() error {
 	}
 }
 
+// wasAborted reports whether the migration phase has been set to ABORT
+// by something other than this worker.
+func (w *Worker) wasAborted() (bool, error) {
+	status, err := w.config.Facade.GetMigrationStatus()
+	if err != nil {
+		return false, errors.Annotate(err, "retrieving migration status")
+	}
+	return status.Phase == migration.ABORT, nil
+}
+
 func (w *Worker) killed() bool {
 	select {
 	case <-w.catacomb.Dying():
@@ -271,9 +291,15 @@ func (w *Worker) doABORT(targetInfo migration.TargetInfo, modelUUID string) (mig
 		// efforts attempt.
 		logger.Errorf("failed to reverse model import: %v", err)
 	}
+	// Once ABORTDONE is set the migration is no longer active, and
+	// control of the model returns to this controller.
 	return migration.ABORTDONE, nil
 }
 
+// removeImportedModel removes any partially imported copy of the
+// model from the target controller. It is safe to call more than
+// once: a model that has already been removed, or was never
+// imported, is not an error.
 func removeImportedModel(targetInfo migration.TargetInfo, modelUUID string) error {
 	conn, err := openAPIConn(targetInfo)
 	if err != nil {
@@ -283,6 +309,9 @@ func removeImportedModel(targetInfo migration.TargetInfo, modelUUID string) erro
 
 	targetClient := migrationtarget.NewClient(conn)
 	err = targetClient.Abort(modelUUID)
+	if params.IsCodeNotFound(err) {
+		return nil
+	}
 	return errors.Trace(err)
 }
 
@@ -320,6 +349,11 @@ func (w *Worker) waitForActiveMigration() (migrationmaster.MigrationStatus, erro
 		if !status.Phase.IsTerminal() {
 			return status, nil
 		}
+		// The latest migration ended without the model moving (it
+		// was aborted), so the model is usable again here.
+		if err := w.config.Guard.Unlock(); err != nil {
+			return empty, errors.Trace(err)
+		}
 	}
 }
 
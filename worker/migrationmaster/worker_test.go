@@ -168,7 +168,72 @@ func (s *Suite) TestPreviouslyAbortedMigration(c *gc.C) {
 	workertest.CheckAlive(c, worker)
 	workertest.CleanKill(c, worker)
 
-	// No reliable way to test stub calls in this case unfortunately.
+	// The model is usable again once the migration has been aborted.
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Unlock", nil},
+	})
+}
+
+func (s *Suite) TestExternalAbort(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.abortAtPhase = migration.VALIDATION
+	worker, err := migrationmaster.New(migrationmaster.Config{
+		Facade: masterClient,
+		Guard:  newStubGuard(s.stub),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrDoneForNow)
+
+	// The abort is noticed when the next phase is set, and the
+	// imported model is removed from the target.
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		{"masterClient.SetPhase", []interface{}{migration.READONLY}},
+		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
+		{"masterClient.SetPhase", []interface{}{migration.IMPORT}},
+		{"masterClient.Export", nil},
+		apiOpenCall,
+		importCall,
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.VALIDATION}},
+		{"masterClient.GetMigrationStatus", nil},
+		apiOpenCall,
+		abortCall,
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.ABORTDONE}},
+	})
+}
+
+func (s *Suite) TestAbortRetryModelAlreadyRemoved(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.status.Phase = migration.ABORT
+	s.connection.abortErr = &params.Error{Code: params.CodeNotFound}
+	worker, err := migrationmaster.New(migrationmaster.Config{
+		Facade: masterClient,
+		Guard:  newStubGuard(s.stub),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrDoneForNow)
+
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		apiOpenCall,
+		abortCall,
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.ABORTDONE}},
+	})
 }
 
 func (s *Suite) TestPreviouslyCompletedMigration(c *gc.C) {
@@ -419,6 +484,7 @@ type stubMasterClient struct {
 	status         masterapi.MigrationStatus
 	statusErr      error
 	exportErr      error
+	abortAtPhase   migration.Phase
 }
 
 func (c *stubMasterClient) Watch() (watcher.NotifyWatcher, error) {
@@ -448,6 +514,11 @@ func (c *stubMasterClient) Export() ([]byte, error) {
 
 func (c *stubMasterClient) SetPhase(phase migration.Phase) error {
 	c.stub.AddCall("masterClient.SetPhase", phase)
+	if phase == c.abortAtPhase {
+		// Simulate the migration being aborted by a user.
+		c.status.Phase = migration.ABORT
+		return errors.New("phase already changed")
+	}
 	return nil
 }
 
@@ -471,6 +542,7 @@ type stubConnection struct {
 	api.Connection
 	stub      *jujutesting.Stub
 	importErr error
+	abortErr  error
 }
 
 func (c *stubConnection) BestFacadeVersion(string) int {
@@ -486,6 +558,8 @@ func (c *stubConnection) APICall(objType string, version int, id, request string
 			return c.importErr
 		case "Activate":
 			return nil
+		case "Abort":
+			return c.abortErr
 		}
 	}
 	return errors.New("unexpected API call")
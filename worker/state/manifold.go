@@ -11,6 +11,7 @@ import (
 	"launchpad.net/tomb"
 
 	coreagent "github.com/juju/juju/agent"
+	"github.com/juju/juju/introspection"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/dependency"
@@ -116,6 +117,18 @@ func (w *stateWorker) loop() error {
 	}
 	defer w.stTracker.Done()
 
+	// Make the controller model's leases and presence available on
+	// the agent's introspection socket while the state is in use.
+	modelUUID := st.ModelUUID()
+	unregisterLeases := introspection.Register(
+		introspection.KindLeases, modelUUID, introspection.ReporterFunc(st.LeasesReport),
+	)
+	defer unregisterLeases()
+	unregisterPresence := introspection.Register(
+		introspection.KindPresence, modelUUID, introspection.ReporterFunc(st.PresenceReport),
+	)
+	defer unregisterPresence()
+
 	for {
 		select {
 		case <-w.tomb.Dying():
@@ -12,6 +12,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	coreagent "github.com/juju/juju/agent"
+	"github.com/juju/juju/introspection"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 	coretesting "github.com/juju/juju/testing"
@@ -111,6 +112,23 @@ func (s *ManifoldSuite) TestStartSuccess(c *gc.C) {
 	checkStop(c, w)
 }
 
+func (s *ManifoldSuite) TestIntrospectionReports(c *gc.C) {
+	w := s.mustStartManifold(c)
+	checkNotExiting(c, w)
+
+	uuid := s.State.ModelUUID()
+	leases := introspection.DefaultRegistry.Reporters(introspection.KindLeases)
+	c.Assert(leases[uuid], gc.NotNil)
+	c.Check(leases[uuid].Report()["model-uuid"], gc.Equals, uuid)
+	presence := introspection.DefaultRegistry.Reporters(introspection.KindPresence)
+	c.Assert(presence[uuid], gc.NotNil)
+	c.Check(presence[uuid].Report()["model-uuid"], gc.Equals, uuid)
+
+	checkStop(c, w)
+	c.Check(introspection.DefaultRegistry.Reporters(introspection.KindLeases)[uuid], gc.IsNil)
+	c.Check(introspection.DefaultRegistry.Reporters(introspection.KindPresence)[uuid], gc.IsNil)
+}
+
 func (s *ManifoldSuite) TestStatePinging(c *gc.C) {
 	w := s.mustStartManifold(c)
 	checkNotExiting(c, w)
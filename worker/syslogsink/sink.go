@@ -0,0 +1,119 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package syslogsink sends messages to a syslog server over a stream
+// connection. Messages are framed with octet counting, as described in
+// RFC6587; formatting them, normally as RFC5424 messages, is left to
+// the caller.
+package syslogsink
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+)
+
+// SendTimeout bounds the time taken to connect to a syslog server and
+// to send a batch of messages to it.
+const SendTimeout = 30 * time.Second
+
+// TimeFormat is the RFC5424 timestamp format, with microsecond
+// precision.
+const TimeFormat = "2006-01-02T15:04:05.000000Z07:00"
+
+// Sink sends messages to a syslog server. The connection is opened
+// when the first messages are sent, and reopened after a failure.
+type Sink struct {
+	addr      string
+	tlsConfig *tls.Config
+	conn      net.Conn
+}
+
+// New returns a Sink for the syslog server identified by u, which must
+// have the syslog+tcp or syslog+tls scheme. For syslog+tls, caCert
+// holds the certificate of the CA that signed the server's certificate,
+// in PEM format; if it is empty, the system's trusted CAs are used.
+func New(u *url.URL, caCert string) (*Sink, error) {
+	switch u.Scheme {
+	case "syslog+tcp":
+		return &Sink{addr: u.Host}, nil
+	case "syslog+tls":
+		tlsConfig, err := TLSConfig(caCert)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return &Sink{addr: u.Host, tlsConfig: tlsConfig}, nil
+	}
+	return nil, errors.NotSupportedf("syslog URL scheme %q", u.Scheme)
+}
+
+// TLSConfig returns the TLS configuration used to connect to a server
+// whose certificate is signed by the CA with the PEM encoded caCert, or
+// by one of the system's trusted CAs if caCert is empty.
+func TLSConfig(caCert string) (*tls.Config, error) {
+	tlsConfig := utils.SecureTLSConfig()
+	if caCert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(caCert)) {
+			return nil, errors.New("CA certificate is not valid PEM")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return tlsConfig, nil
+}
+
+// Send sends the messages to the server, in order. If it returns an
+// error, some of the messages may still have been delivered.
+func (s *Sink) Send(messages []string) error {
+	var buf bytes.Buffer
+	for _, msg := range messages {
+		fmt.Fprintf(&buf, "%d %s", len(msg), msg)
+	}
+
+	if s.conn == nil {
+		conn, err := s.dial()
+		if err != nil {
+			return errors.Annotatef(err, "connecting to %s", s.addr)
+		}
+		s.conn = conn
+	}
+	s.conn.SetWriteDeadline(time.Now().Add(SendTimeout))
+	if _, err := s.conn.Write(buf.Bytes()); err != nil {
+		s.Close()
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func (s *Sink) dial() (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: SendTimeout}
+	if s.tlsConfig == nil {
+		return dialer.Dial("tcp", s.addr)
+	}
+	tlsConfig := *s.tlsConfig
+	if tlsConfig.ServerName == "" {
+		host, _, err := net.SplitHostPort(s.addr)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		tlsConfig.ServerName = host
+	}
+	return tls.DialWithDialer(dialer, "tcp", s.addr, &tlsConfig)
+}
+
+// Close closes the connection to the server, if it is open.
+func (s *Sink) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return errors.Trace(err)
+}
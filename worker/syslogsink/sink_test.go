@@ -0,0 +1,84 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package syslogsink_test
+
+import (
+	"io/ioutil"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/syslogsink"
+)
+
+type sinkSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&sinkSuite{})
+
+func (s *sinkSuite) TestSendFramesMessages(c *gc.C) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	defer listener.Close()
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			received <- err.Error()
+			return
+		}
+		defer conn.Close()
+		data, _ := ioutil.ReadAll(conn)
+		received <- string(data)
+	}()
+
+	sink, err := syslogsink.New(&url.URL{Scheme: "syslog+tcp", Host: listener.Addr().String()}, "")
+	c.Assert(err, jc.ErrorIsNil)
+	err = sink.Send([]string{"<14>1 first", "<14>1 second message"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = sink.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case data := <-received:
+		c.Assert(data, gc.Equals, "11 <14>1 first20 <14>1 second message")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for messages")
+	}
+}
+
+func (s *sinkSuite) TestSendConnectionRefused(c *gc.C) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	addr := listener.Addr().String()
+	listener.Close()
+
+	sink, err := syslogsink.New(&url.URL{Scheme: "syslog+tcp", Host: addr}, "")
+	c.Assert(err, jc.ErrorIsNil)
+	err = sink.Send([]string{"message"})
+	c.Assert(err, gc.ErrorMatches, "connecting to "+addr+": .*")
+}
+
+func (s *sinkSuite) TestNewUnsupportedScheme(c *gc.C) {
+	_, err := syslogsink.New(&url.URL{Scheme: "https", Host: "logs.example.com"}, "")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `syslog URL scheme "https" not supported`)
+}
+
+func (s *sinkSuite) TestNewBadCACert(c *gc.C) {
+	_, err := syslogsink.New(&url.URL{Scheme: "syslog+tls", Host: "logs.example.com:6514"}, "not a cert")
+	c.Assert(err, gc.ErrorMatches, "CA certificate is not valid PEM")
+}
+
+func (s *sinkSuite) TestTLSConfigWithCACert(c *gc.C) {
+	tlsConfig, err := syslogsink.TLSConfig(coretesting.CACert)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tlsConfig.RootCAs, gc.NotNil)
+}
@@ -8,11 +8,13 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/names"
+	"github.com/juju/utils/set"
 	corecharm "gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/status"
+	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/uniter/charm"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner"
@@ -104,6 +106,45 @@ func (opc *operationCallbacks) FailAction(actionId, message string) error {
 	return err
 }
 
+// WatchActionAborted is part of the operation.Callbacks interface.
+func (opc *operationCallbacks) WatchActionAborted(actionId string, stop <-chan struct{}) (<-chan struct{}, error) {
+	if !names.IsValidAction(actionId) {
+		return nil, errors.Errorf("invalid action id %q", actionId)
+	}
+	tag := names.NewActionTag(actionId)
+	w, err := opc.u.unit.WatchActionNotifications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	aborted := make(chan struct{})
+	go func() {
+		defer worker.Stop(w)
+		for {
+			select {
+			case <-stop:
+				return
+			case ids, ok := <-w.Changes():
+				if !ok {
+					return
+				}
+				if !set.NewStrings(ids...).Contains(actionId) {
+					continue
+				}
+				status, err := opc.u.st.ActionStatus(tag)
+				if err != nil {
+					logger.Errorf("cannot get status of action %q: %v", actionId, err)
+					return
+				}
+				if status == params.ActionAborting {
+					close(aborted)
+					return
+				}
+			}
+		}
+	}()
+	return aborted, nil
+}
+
 // GetArchiveInfo is part of the operation.Callbacks interface.
 func (opc *operationCallbacks) GetArchiveInfo(charmURL *corecharm.URL) (charm.BundleInfo, error) {
 	ch, err := opc.u.st.Charm(charmURL)
@@ -145,6 +145,11 @@ type Callbacks interface {
 	// RunActions operations.
 	FailAction(actionId, message string) error
 
+	// WatchActionAborted returns a channel that is closed if the supplied
+	// action is cancelled while it is running. The watch ends when stop
+	// is closed. It's only used by RunAction operations.
+	WatchActionAborted(actionId string, stop <-chan struct{}) (<-chan struct{}, error)
+
 	// GetArchiveInfo is used to find out how to download a charm archive. It's
 	// only used by Deploy operations.
 	GetArchiveInfo(charmURL *corecharm.URL) (charm.BundleInfo, error)
@@ -5,12 +5,18 @@ package operation
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/uniter/runner"
 )
 
+// abortGracePeriod is how long a cancelled action's process is given to
+// stop after being asked to terminate, before it is killed.
+var abortGracePeriod = 30 * time.Second
+
 type runAction struct {
 	actionId string
 
@@ -72,7 +78,35 @@ func (ra *runAction) Execute(state State) (*State, error) {
 		return nil, err
 	}
 
-	err := ra.runner.RunAction(ra.name)
+	stop := make(chan struct{})
+	defer close(stop)
+	aborted, err := ra.callbacks.WatchActionAborted(ra.actionId, stop)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot watch action %q", ra.name)
+	}
+
+	// If the action is cancelled while it runs, stop its process; the
+	// runner then records the action as cancelled.
+	done := make(chan struct{})
+	abortFinished := make(chan struct{})
+	go func() {
+		defer close(abortFinished)
+		select {
+		case <-aborted:
+			logger.Infof("action %s cancelled while running", ra.actionId)
+			err := ra.runner.Context().AbortAction(
+				params.ActionCancelled, "action cancelled while running", abortGracePeriod, done,
+			)
+			if err != nil {
+				logger.Errorf("cannot stop action %s: %v", ra.actionId, err)
+			}
+		case <-done:
+		}
+	}()
+
+	err = ra.runner.RunAction(ra.name)
+	close(done)
+	<-abortFinished
 	if err != nil {
 		// This indicates an actual error -- an action merely failing should
 		// be handled inside the Runner, and returned as nil.
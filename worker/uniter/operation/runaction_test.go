@@ -10,6 +10,7 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/runner"
@@ -227,6 +228,54 @@ func (s *RunActionSuite) TestExecuteSuccess(c *gc.C) {
 	}
 }
 
+func (s *RunActionSuite) TestExecuteAborted(c *gc.C) {
+	runnerFactory := NewRunActionRunnerFactory(nil)
+	callbacks := &RunActionCallbacks{aborted: make(chan struct{})}
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: runnerFactory,
+		Callbacks:     callbacks,
+	})
+	op, err := factory.NewAction(someActionId)
+	c.Assert(err, jc.ErrorIsNil)
+	midState, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The action runs until its context is asked to abort it.
+	rnr := runnerFactory.MockNewActionRunner.runner
+	ctx := rnr.context.(*MockContext)
+	ctx.stopped = make(chan struct{})
+	rnr.MockRunAction.block = ctx.stopped
+	close(callbacks.aborted)
+
+	newState, err := op.Execute(*midState)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState, jc.DeepEquals, &operation.State{
+		Kind:     operation.RunAction,
+		Step:     operation.Done,
+		ActionId: &someActionId,
+	})
+	ctx.CheckCallNames(c, "Prepare", "AbortAction")
+	ctx.CheckCall(c, 1, "AbortAction", params.ActionCancelled, "action cancelled while running")
+}
+
+func (s *RunActionSuite) TestExecuteWatchError(c *gc.C) {
+	runnerFactory := NewRunActionRunnerFactory(nil)
+	callbacks := &RunActionCallbacks{watchErr: errors.New("blam")}
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: runnerFactory,
+		Callbacks:     callbacks,
+	})
+	op, err := factory.NewAction(someActionId)
+	c.Assert(err, jc.ErrorIsNil)
+	midState, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Execute(*midState)
+	c.Assert(newState, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, `cannot watch action "some-action-name": blam`)
+	c.Assert(runnerFactory.MockNewActionRunner.runner.MockRunAction.gotName, gc.IsNil)
+}
+
 func (s *RunActionSuite) TestCommit(c *gc.C) {
 	var stateChangeTests = []struct {
 		description string
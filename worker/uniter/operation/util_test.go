@@ -4,6 +4,8 @@
 package operation_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	utilexec "github.com/juju/utils/exec"
@@ -124,6 +126,8 @@ type RunActionCallbacks struct {
 	operation.Callbacks
 	*MockFailAction
 	executingMessage string
+	aborted          chan struct{}
+	watchErr         error
 }
 
 func (cb *RunActionCallbacks) FailAction(actionId, message string) error {
@@ -135,6 +139,10 @@ func (cb *RunActionCallbacks) SetExecutingStatus(message string) error {
 	return nil
 }
 
+func (cb *RunActionCallbacks) WatchActionAborted(actionId string, stop <-chan struct{}) (<-chan struct{}, error) {
+	return cb.aborted, cb.watchErr
+}
+
 type RunCommandsCallbacks struct {
 	operation.Callbacks
 	executingMessage string
@@ -290,6 +298,7 @@ type MockContext struct {
 	actionData      *context.ActionData
 	setStatusCalled bool
 	status          jujuc.StatusInfo
+	stopped         chan struct{}
 }
 
 func (mock *MockContext) ActionData() (*context.ActionData, error) {
@@ -326,13 +335,25 @@ func (mock *MockContext) Prepare() error {
 	return mock.NextErr()
 }
 
+func (mock *MockContext) AbortAction(status, message string, grace time.Duration, done <-chan struct{}) error {
+	mock.MethodCall(mock, "AbortAction", status, message)
+	if mock.stopped != nil {
+		close(mock.stopped)
+	}
+	return mock.NextErr()
+}
+
 type MockRunAction struct {
 	gotName *string
 	err     error
+	block   <-chan struct{}
 }
 
 func (mock *MockRunAction) Call(actionName string) error {
 	mock.gotName = &actionName
+	if mock.block != nil {
+		<-mock.block
+	}
 	return mock.err
 }
 
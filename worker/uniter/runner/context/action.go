@@ -4,6 +4,8 @@
 package context
 
 import (
+	"time"
+
 	"github.com/juju/names"
 )
 
@@ -15,6 +17,16 @@ type ActionData struct {
 	Failed         bool
 	ResultsMessage string
 	ResultsMap     map[string]interface{}
+
+	// Timeout is how long the action may run before it is stopped and
+	// marked as failed; zero means it may run indefinitely.
+	Timeout time.Duration
+
+	// AbortStatus is set when the action is stopped before finishing,
+	// to the status it should be recorded with; AbortMessage then
+	// describes why.
+	AbortStatus  string
+	AbortMessage string
 }
 
 // NewActionData builds a suitable ActionData struct with no nil members.
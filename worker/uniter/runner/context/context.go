@@ -92,6 +92,12 @@ type HookProcess interface {
 	Kill() error
 }
 
+// terminator is implemented by hook processes that can be asked to
+// stop before being killed outright.
+type terminator interface {
+	Terminate() error
+}
+
 // HookContext is the implementation of jujuc.Context.
 type HookContext struct {
 	unit *uniter.Unit
@@ -747,6 +753,16 @@ func (ctx *HookContext) finalizeAction(err, unhandledErr error) error {
 		status = params.ActionFailed
 	}
 
+	// An aborted action is recorded as the abort requested, whatever
+	// the process exited with, keeping any results it set before it
+	// was stopped.
+	mutex.Lock()
+	if ctx.actionData.AbortStatus != "" {
+		status = ctx.actionData.AbortStatus
+		message = ctx.actionData.AbortMessage
+	}
+	mutex.Unlock()
+
 	callErr := ctx.state.ActionFinish(tag, status, results, message)
 	if callErr != nil {
 		unhandledErr = errors.Wrap(unhandledErr, callErr)
@@ -787,6 +803,49 @@ func (ctx *HookContext) killCharmHook() error {
 	}
 }
 
+// AbortAction stops the running action, which will be recorded with
+// the given status and message. If grace is non-zero, the action's
+// process is asked to terminate, and is killed if it has not finished
+// by the time the grace period expires; otherwise it is killed at
+// once. done should be closed once the process has finished.
+func (ctx *HookContext) AbortAction(status, message string, grace time.Duration, done <-chan struct{}) error {
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	mutex.Lock()
+	ctx.actionData.AbortStatus = status
+	ctx.actionData.AbortMessage = message
+	mutex.Unlock()
+
+	// The abort may arrive before the process has been started.
+	proc := ctx.GetProcess()
+	for proc == nil {
+		select {
+		case <-done:
+			return nil
+		case <-ctx.clock.After(100 * time.Millisecond):
+		}
+		proc = ctx.GetProcess()
+	}
+
+	t, ok := proc.(terminator)
+	if !ok || grace == 0 {
+		return ctx.killCharmHook()
+	}
+	logger.Infof("terminating action process %v", proc.Pid())
+	if err := t.Terminate(); err != nil {
+		logger.Infof("terminate returned: %s", err)
+		return ctx.killCharmHook()
+	}
+	select {
+	case <-done:
+		return nil
+	case <-ctx.clock.After(grace):
+	}
+	logger.Infof("action process %v still running after %v", proc.Pid(), grace)
+	return ctx.killCharmHook()
+}
+
 // NetworkConfig returns the network config for the given bindingName.
 func (ctx *HookContext) NetworkConfig(bindingName string) ([]params.NetworkConfig, error) {
 	return ctx.unit.NetworkConfig(bindingName)
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
@@ -216,6 +217,8 @@ func (s *InterfaceSuite) TestNonActionCallsToActionMethodsFail(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "not running an action")
 	err = ctx.UpdateActionResults([]string{"1", "2", "3"}, "value")
 	c.Check(err, gc.ErrorMatches, "not running an action")
+	err = ctx.AbortAction(params.ActionCancelled, "foo", time.Second, nil)
+	c.Check(err, gc.ErrorMatches, "not running an action")
 }
 
 // TestUpdateActionResults demonstrates that UpdateActionResults functions
@@ -369,6 +372,88 @@ func (s *InterfaceSuite) TestRequestRebootNowNoProcess(c *gc.C) {
 	c.Assert(priority, gc.Equals, jujuc.RebootNow)
 }
 
+func (s *InterfaceSuite) TestAbortActionTerminates(c *gc.C) {
+	ctx := context.GetStubActionContextWithClock(nil, s.clock)
+	done := make(chan struct{})
+	p := &mockTerminatingProcess{
+		mockProcess: mockProcess{func() error {
+			c.Fatalf("process should not be killed")
+			return nil
+		}},
+		terminate: func() error {
+			close(done)
+			return nil
+		},
+	}
+	ctx.SetProcess(p)
+
+	err := ctx.AbortAction(params.ActionCancelled, "stop it", time.Minute, done)
+	c.Assert(err, jc.ErrorIsNil)
+	actionData, err := ctx.ActionData()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(actionData.AbortStatus, gc.Equals, params.ActionCancelled)
+	c.Check(actionData.AbortMessage, gc.Equals, "stop it")
+}
+
+func (s *InterfaceSuite) TestAbortActionKillsAfterGracePeriod(c *gc.C) {
+	ctx := context.GetStubActionContextWithClock(nil, s.clock)
+	var terminated, killed bool
+	p := &mockTerminatingProcess{
+		mockProcess: mockProcess{func() error {
+			killed = true
+			return errors.New("process is already dead")
+		}},
+		terminate: func() error {
+			terminated = true
+			return nil
+		},
+	}
+	ctx.SetProcess(p)
+
+	result := make(chan error, 1)
+	go func() {
+		result <- ctx.AbortAction(params.ActionCancelled, "stop it", time.Minute, make(chan struct{}))
+	}()
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for grace period to start")
+	}
+	s.clock.Advance(time.Minute)
+	select {
+	case err := <-result:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for action to be aborted")
+	}
+	c.Check(terminated, jc.IsTrue)
+	c.Check(killed, jc.IsTrue)
+}
+
+func (s *InterfaceSuite) TestAbortActionWithoutGracePeriodKills(c *gc.C) {
+	ctx := context.GetStubActionContextWithClock(nil, s.clock)
+	var killed bool
+	p := &mockTerminatingProcess{
+		mockProcess: mockProcess{func() error {
+			killed = true
+			return errors.New("process is already dead")
+		}},
+		terminate: func() error {
+			c.Fatalf("process should not be terminated")
+			return nil
+		},
+	}
+	ctx.SetProcess(p)
+
+	err := ctx.AbortAction(params.ActionFailed, "action timed out after 1m0s", 0, make(chan struct{}))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(killed, jc.IsTrue)
+	actionData, err := ctx.ActionData()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(actionData.AbortStatus, gc.Equals, params.ActionFailed)
+	c.Check(actionData.AbortMessage, gc.Equals, "action timed out after 1m0s")
+}
+
 func (s *InterfaceSuite) TestStorageAddConstraints(c *gc.C) {
 	expected := map[string][]params.StorageConstraints{
 		"data": []params.StorageConstraints{
@@ -439,3 +524,12 @@ func (p *mockProcess) Kill() error {
 func (p *mockProcess) Pid() int {
 	return 123
 }
+
+type mockTerminatingProcess struct {
+	mockProcess
+	terminate func() error
+}
+
+func (p *mockTerminatingProcess) Terminate() error {
+	return p.terminate()
+}
@@ -124,6 +124,12 @@ func GetStubActionContext(in map[string]interface{}) *HookContext {
 	}
 }
 
+func GetStubActionContextWithClock(in map[string]interface{}, clock clock.Clock) *HookContext {
+	ctx := GetStubActionContext(in)
+	ctx.clock = clock
+	return ctx
+}
+
 type LeadershipContextFunc func(LeadershipSettingsAccessor, leadership.Tracker) LeadershipContext
 
 func PatchNewLeadershipContext(f LeadershipContextFunc) func() {
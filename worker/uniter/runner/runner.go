@@ -9,6 +9,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"syscall"
 	"time"
 	"unicode/utf8"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/juju/utils/clock"
 	utilexec "github.com/juju/utils/exec"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/actions"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/debug"
@@ -50,6 +52,7 @@ type Context interface {
 	HookVars(paths context.Paths) ([]string, error)
 	ActionData() (*context.ActionData, error)
 	SetProcess(process context.HookProcess)
+	AbortAction(status, message string, grace time.Duration, done <-chan struct{}) error
 	HasExecutionSetUnitStatus() bool
 	ResetExecutionSetUnitStatus()
 
@@ -189,15 +192,46 @@ func (runner *runner) updateActionResults(results *utilexec.ExecResponse) error
 
 // RunAction exists to satisfy the Runner interface.
 func (runner *runner) RunAction(actionName string) error {
-	if _, err := runner.context.ActionData(); err != nil {
+	data, err := runner.context.ActionData()
+	if err != nil {
 		return errors.Trace(err)
 	}
+	if data.Timeout > 0 {
+		done := make(chan struct{})
+		stopped := runner.enforceTimeout(data.Timeout, done, clock.WallClock)
+		defer func() {
+			close(done)
+			<-stopped
+		}()
+	}
 	if actionName == actions.JujuRunActionName {
 		return runner.runJujuRunAction()
 	}
 	return runner.runCharmHookWithLocation(actionName, "actions")
 }
 
+// enforceTimeout kills the running action and marks it as failed if it
+// has not finished within the timeout. done should be closed when the
+// action finishes; the returned channel is closed once enforceTimeout
+// has nothing left to do.
+func (runner *runner) enforceTimeout(timeout time.Duration, done <-chan struct{}, clock clock.Clock) <-chan struct{} {
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		select {
+		case <-done:
+			return
+		case <-clock.After(timeout):
+		}
+		message := fmt.Sprintf("action timed out after %v", timeout)
+		logger.Infof("%s; killing it", message)
+		if err := runner.context.AbortAction(params.ActionFailed, message, 0, done); err != nil {
+			logger.Errorf("cannot stop timed out action: %v", err)
+		}
+	}()
+	return stopped
+}
+
 // RunHook exists to satisfy the Runner interface.
 func (runner *runner) RunHook(hookName string) error {
 	return runner.runCharmHookWithLocation(hookName, "hooks")
@@ -292,3 +326,9 @@ type hookProcess struct {
 func (p hookProcess) Pid() int {
 	return p.Process.Pid
 }
+
+// Terminate asks the process to stop. It fails on platforms that
+// cannot deliver SIGTERM, in which case the process should be killed.
+func (p hookProcess) Terminate() error {
+	return p.Process.Signal(syscall.SIGTERM)
+}
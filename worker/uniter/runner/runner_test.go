@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
@@ -19,6 +20,7 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
@@ -155,6 +157,10 @@ type MockContext struct {
 	flushBadge      string
 	flushFailure    error
 	flushResult     error
+	mu              sync.Mutex
+	process         context.HookProcess
+	abortStatus     string
+	abortMessage    string
 }
 
 func (ctx *MockContext) UnitName() string {
@@ -173,7 +179,18 @@ func (ctx *MockContext) ActionData() (*context.ActionData, error) {
 }
 
 func (ctx *MockContext) SetProcess(process context.HookProcess) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
 	ctx.expectPid = process.Pid()
+	ctx.process = process
+}
+
+func (ctx *MockContext) AbortAction(status, message string, grace time.Duration, done <-chan struct{}) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	ctx.abortStatus = status
+	ctx.abortMessage = message
+	return ctx.process.Kill()
 }
 
 func (ctx *MockContext) Prepare() error {
@@ -336,6 +353,38 @@ func (s *RunMockContextSuite) TestRunActionCancelled(c *gc.C) {
 	c.Assert(ctx.actionResults["Stderr"], gc.Equals, nil)
 }
 
+func (s *RunMockContextSuite) TestRunActionTimeout(c *gc.C) {
+	ctx := &MockContext{
+		actionData: &context.ActionData{Timeout: 100 * time.Millisecond},
+		actionParams: map[string]interface{}{
+			"command": "sleep 10",
+		},
+		actionResults: map[string]interface{}{},
+	}
+	t0 := time.Now()
+	err := runner.NewRunner(ctx, s.paths).RunAction("juju-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(time.Now().Sub(t0) < 5*time.Second, jc.IsTrue)
+	c.Assert(ctx.flushBadge, gc.Equals, "juju-run")
+	c.Assert(ctx.abortStatus, gc.Equals, params.ActionFailed)
+	c.Assert(ctx.abortMessage, gc.Equals, "action timed out after 100ms")
+}
+
+func (s *RunMockContextSuite) TestRunActionWithinTimeout(c *gc.C) {
+	ctx := &MockContext{
+		actionData: &context.ActionData{Timeout: time.Minute},
+		actionParams: map[string]interface{}{
+			"command": "echo 1",
+		},
+		actionResults: map[string]interface{}{},
+	}
+	err := runner.NewRunner(ctx, s.paths).RunAction("juju-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.IsNil)
+	c.Assert(ctx.abortStatus, gc.Equals, "")
+	c.Assert(ctx.actionResults["Code"], gc.Equals, "0")
+}
+
 func (s *RunMockContextSuite) TestRunCommandsFlushSuccess(c *gc.C) {
 	expectErr := errors.New("pew pew pew")
 	ctx := &MockContext{